@@ -0,0 +1,29 @@
+package assets
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestEntrypoints_ChownHomePersistSubdirs checks every provider entrypoint
+// that gets home.persist_subdirs volumes hands them to addt in the root
+// phase, before dropping privileges
+func TestEntrypoints_ChownHomePersistSubdirs(t *testing.T) {
+	entrypoints := map[string][]byte{
+		"docker":   DockerEntrypoint,
+		"podman":   PodmanEntrypoint,
+		"orbstack": OrbStackEntrypoint,
+	}
+	for name, script := range entrypoints {
+		s := string(script)
+		chown := strings.Index(s, `for sub in $(echo "${ADDT_HOME_PERSIST_SUBDIRS:-}"`)
+		drop := strings.Index(s, `exec gosu addt "$0" "$@"`)
+		if chown < 0 {
+			t.Errorf("%s entrypoint doesn't chown the ADDT_HOME_PERSIST_SUBDIRS volumes", name)
+			continue
+		}
+		if drop < 0 || chown > drop {
+			t.Errorf("%s entrypoint chowns the home volumes after dropping privileges", name)
+		}
+	}
+}
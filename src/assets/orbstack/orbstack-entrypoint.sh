@@ -16,6 +16,21 @@ debug_log() {
     fi
 }
 
+# Split off --entrypoint-arg values: the first ADDT_ENTRYPOINT_ARGS args are
+# for this script, the rest go to the agent. Unset so the re-exec as addt
+# does not split again.
+if [ "${ADDT_ENTRYPOINT_ARGS:-0}" -gt 0 ] 2>/dev/null; then
+    entrypoint_argc=$(( ADDT_ENTRYPOINT_ARGS < $# ? ADDT_ENTRYPOINT_ARGS : $# ))
+    for arg in "${@:1:$entrypoint_argc}"; do
+        case "$arg" in
+            --debug) export ADDT_LOG_LEVEL=DEBUG ;;
+            *) echo "Warning: unknown entrypoint arg: $arg" >&2 ;;
+        esac
+    done
+    shift "$entrypoint_argc"
+fi
+unset ADDT_ENTRYPOINT_ARGS
+
 # Initialize debug log file
 if [ "${ADDT_LOG_LEVEL:-INFO}" = "DEBUG" ]; then
     echo "[$(date '+%Y-%m-%d %H:%M:%S')] Entrypoint script started" > "$DEBUG_LOG_FILE"
@@ -32,8 +47,14 @@ if [ "$(id -u)" = "0" ]; then
 
     # Initialize firewall if enabled
     if [ "${ADDT_FIREWALL_ENABLED}" = "true" ] && [ -f /usr/local/bin/init-firewall.sh ]; then
-        debug_log "Firewall enabled, initializing (as root)"
-        /usr/local/bin/init-firewall.sh
+        if [ "${ADDT_FIREWALL_ASYNC}" = "true" ]; then
+            debug_log "Firewall enabled, initializing in the background (as root)"
+            echo "Warning: firewall initializing in the background; early connections may not be filtered" >&2
+            /usr/local/bin/init-firewall.sh >/tmp/addt-firewall-init.log 2>&1 &
+        else
+            debug_log "Firewall enabled, initializing (as root)"
+            /usr/local/bin/init-firewall.sh
+        fi
     fi
 
     # Start Docker daemon if in DinD mode
@@ -69,6 +90,28 @@ if [ "$(id -u)" = "0" ]; then
         debug_log "Fixed secrets file ownership for addt user"
     fi
 
+    # Mounted history files are created on the host; make sure addt owns them
+    # when the image UID doesn't match the host user that created them
+    for hist in /home/addt/.bash_history /home/addt/.zsh_history; do
+        if [ -f "$hist" ] && [ "$(stat -c %u "$hist")" != "$(id -u addt)" ]; then
+            chown "$(id -u addt):$(id -g addt)" "$hist" 2>/dev/null || true
+            debug_log "Fixed history file ownership: $hist"
+        fi
+    done
+
+    # Named volumes for home.persist_subdirs start out root-owned, as do the
+    # parent directories the runtime creates for them inside the home
+    for sub in $(echo "${ADDT_HOME_PERSIST_SUBDIRS:-}" | tr ',' ' '); do
+        dir="/home/addt/$sub"
+        while [ "$dir" != "/home/addt" ]; do
+            if [ -d "$dir" ] && [ "$(stat -c %u "$dir")" != "$(id -u addt)" ]; then
+                chown "$(id -u addt):$(id -g addt)" "$dir" 2>/dev/null || true
+                debug_log "Fixed home volume ownership: $dir"
+            fi
+            dir=$(dirname "$dir")
+        done
+    done
+
     # Re-exec this script as addt user
     debug_log "Dropping privileges: exec gosu addt $0 $*"
     exec gosu addt "$0" "$@"
@@ -144,7 +187,8 @@ fi
 # This approach keeps secrets out of environment variables entirely
 if [ -f /run/secrets/.secrets ]; then
     debug_log "Loading secrets from /run/secrets/.secrets"
-    # Parse JSON and export directly to environment
+    # Parse JSON and export directly to environment, noting each name
+    SECRET_NAMES=""
     eval "$(node -e '
         const fs = require("fs");
         const data = fs.readFileSync("/run/secrets/.secrets", "utf8");
@@ -153,6 +197,7 @@ if [ -f /run/secrets/.secrets ]; then
             // Escape single quotes in value for shell safety
             const escaped = value.replace(/'"'"'/g, "'"'"'\\'"'"''"'"'");
             console.log(`export ${key}='"'"'${escaped}'"'"'`);
+            console.log(`SECRET_NAMES="$SECRET_NAMES ${key}"`);
         }
     ')"
 
@@ -164,6 +209,54 @@ if [ -f /run/secrets/.secrets ]; then
     fi
     rm -f /run/secrets/.secrets
     debug_log "Secrets loaded, scrubbed, and file removed"
+
+    # security.secret_consumers: keep the secrets out of the environment so
+    # setup scripts and other children don't inherit them; they are exported
+    # again just before exec, and only for a named consumer
+    if [ -n "$ADDT_SECRET_CONSUMERS" ]; then
+        for name in $SECRET_NAMES; do
+            export -n "$name"
+        done
+        debug_log "Secrets held back for consumers: $ADDT_SECRET_CONSUMERS"
+    fi
+fi
+
+# Docker CLI config delivered via secrets (docker.forward_config with
+# isolate_secrets): keep it on the tmpfs and point DOCKER_CONFIG at it
+if [ -n "$ADDT_DOCKER_CONFIG_JSON" ]; then
+    mkdir -p /run/secrets/docker
+    (umask 077; printf '%s' "$ADDT_DOCKER_CONFIG_JSON" > /run/secrets/docker/config.json)
+    export DOCKER_CONFIG=/run/secrets/docker
+    unset ADDT_DOCKER_CONFIG_JSON
+    debug_log "Docker config written to tmpfs, DOCKER_CONFIG=$DOCKER_CONFIG"
+fi
+
+# Sensitive forward_files delivered via secrets (isolate_secrets): write
+# each file read-only at its container path instead of bind-mounting it
+if [ -n "$ADDT_FORWARD_FILES_JSON" ]; then
+    node -e '
+        const fs = require("fs"), path = require("path");
+        const files = JSON.parse(process.env.ADDT_FORWARD_FILES_JSON);
+        for (const [target, content] of Object.entries(files)) {
+            try {
+                fs.mkdirSync(path.dirname(target), { recursive: true });
+                fs.rmSync(target, { force: true });
+                fs.writeFileSync(target, content, { mode: 0o400 });
+            } catch (err) {
+                console.error(`Warning: could not write forwarded file ${target}: ${err.message}`);
+            }
+        }
+    '
+    unset ADDT_FORWARD_FILES_JSON
+    debug_log "Forwarded files written from ADDT_FORWARD_FILES_JSON"
+fi
+
+# Gitconfig delivered by value (git.config_copy): the host file isn't mounted,
+# so agent edits to ~/.gitconfig stay inside the container
+if [ -n "$ADDT_GITCONFIG" ]; then
+    printf '%s' "$ADDT_GITCONFIG" > "$HOME/.gitconfig"
+    unset ADDT_GITCONFIG
+    debug_log "Wrote .gitconfig from ADDT_GITCONFIG"
 fi
 
 # Note: DinD and firewall initialization are handled in the root phase above.
@@ -320,7 +413,10 @@ fi
 # Build system prompt for port mappings (exported for args.sh to use)
 export ADDT_SYSTEM_PROMPT=""
 
-if [ -n "$ADDT_PORT_MAP" ]; then
+if [ -n "$ADDT_PORT_PROMPT" ]; then
+    # Rendered on the host from ports.prompt_template
+    ADDT_SYSTEM_PROMPT="$ADDT_PORT_PROMPT"
+elif [ -n "$ADDT_PORT_MAP" ]; then
     # Parse port mappings (format: "3000:30000,8080:30001")
     ADDT_SYSTEM_PROMPT="# Port Mapping Information
 
@@ -353,25 +449,48 @@ export NPM_CONFIG_CACHE="$NPM_CONFIG_PREFIX/.cache"
 # Ensure ~/.local/bin, npm-global/bin and ~/go/bin are in PATH
 export PATH="$HOME/.local/bin:$NPM_CONFIG_PREFIX/bin:$HOME/go/bin:$PATH"
 
-# Neutralize git hooks if enabled (prevents malicious .git/hooks/* execution)
-# Creates a wrapper that forces core.hooksPath=/dev/null via GIT_CONFIG_COUNT
-# Inspired by: https://github.com/IngmarKrusch/claude-docker
-if [ "$ADDT_GIT_DISABLE_HOOKS" = "true" ]; then
-    debug_log "Git hooks neutralization enabled, creating wrapper"
+# Git wrapper, created when hooks are neutralized or .gitconfig is written back:
+# - ADDT_GIT_DISABLE_HOOKS forces core.hooksPath=/dev/null via GIT_CONFIG_COUNT
+#   (prevents malicious .git/hooks/* execution)
+#   Inspired by: https://github.com/IngmarKrusch/claude-docker
+# - ADDT_GITCONFIG_WRITEBACK (git.config_readonly=false) copies ~/.gitconfig
+#   back into the writable host mount after each git command, since git can't
+#   replace the bind-mounted file itself
+if [ "$ADDT_GITCONFIG_WRITEBACK" = "true" ] && [ ! -w "$HOME/.gitconfig.host" ]; then
+    debug_log "Host .gitconfig is not writable, skipping write-back"
+    unset ADDT_GITCONFIG_WRITEBACK
+fi
+if [ "$ADDT_GIT_DISABLE_HOOKS" = "true" ] || [ "$ADDT_GITCONFIG_WRITEBACK" = "true" ]; then
+    debug_log "Creating git wrapper (hooks=$ADDT_GIT_DISABLE_HOOKS, writeback=$ADDT_GITCONFIG_WRITEBACK)"
     REAL_GIT=$(command -v git 2>/dev/null || echo "/usr/bin/git")
     mkdir -p "$HOME/.local/bin"
-    cat > "$HOME/.local/bin/git" <<WRAPPER
-#!/bin/sh
-export GIT_CONFIG_COUNT=\${GIT_CONFIG_COUNT:-0}
-n=\$GIT_CONFIG_COUNT
-export GIT_CONFIG_KEY_\$n=core.hooksPath
-export GIT_CONFIG_VALUE_\$n=/dev/null
-export GIT_CONFIG_COUNT=\$((n + 1))
-exec "$REAL_GIT" "\$@"
-WRAPPER
+    {
+        echo '#!/bin/sh'
+        if [ "$ADDT_GIT_DISABLE_HOOKS" = "true" ]; then
+            cat <<'HOOKS'
+export GIT_CONFIG_COUNT=${GIT_CONFIG_COUNT:-0}
+n=$GIT_CONFIG_COUNT
+export GIT_CONFIG_KEY_$n=core.hooksPath
+export GIT_CONFIG_VALUE_$n=/dev/null
+export GIT_CONFIG_COUNT=$((n + 1))
+HOOKS
+        fi
+        if [ "$ADDT_GITCONFIG_WRITEBACK" = "true" ]; then
+            cat <<WRITEBACK
+"$REAL_GIT" "\$@"
+rc=\$?
+if [ -f "\$HOME/.gitconfig" ]; then
+    cat "\$HOME/.gitconfig" > "\$HOME/.gitconfig.host"
+fi
+exit \$rc
+WRITEBACK
+        else
+            echo "exec \"$REAL_GIT\" \"\$@\""
+        fi
+    } > "$HOME/.local/bin/git"
     chmod +x "$HOME/.local/bin/git"
     debug_log "Git wrapper created at $HOME/.local/bin/git (real git: $REAL_GIT)"
-    unset ADDT_GIT_DISABLE_HOOKS
+    unset ADDT_GIT_DISABLE_HOOKS ADDT_GITCONFIG_WRITEBACK
 fi
 
 # Determine which command to run (entrypoint can be array: ["bash", "-i"])
@@ -448,6 +567,30 @@ else
     FINAL_ARGS=("${ADDT_CMD_ARGS[@]}" "$@")
 fi
 
+# On a TTY the pty tracks the live window size (the runtime forwards SIGWINCH).
+# Drop the COLUMNS/LINES snapshot so curses/readline apps reflow on resize.
+if [ -t 1 ]; then
+    unset COLUMNS LINES
+fi
+
+# Hand held-back secrets to the command only if it is a named consumer
+if [ -n "$ADDT_SECRET_CONSUMERS" ] && [ -n "$SECRET_NAMES" ]; then
+    case ",$ADDT_SECRET_CONSUMERS," in
+        *",$(basename "$ADDT_CMD"),"*)
+            for name in $SECRET_NAMES; do
+                export "$name"
+            done
+            debug_log "Secrets exported for consumer $(basename "$ADDT_CMD")"
+            ;;
+        *)
+            for name in $SECRET_NAMES; do
+                unset "$name"
+            done
+            echo "Warning: $(basename "$ADDT_CMD") is not in security.secret_consumers; secrets withheld" >&2
+            ;;
+    esac
+fi
+
 # Execute with optional time limit
 debug_log "Executing: $ADDT_CMD ${FINAL_ARGS[*]}"
 if [ -n "$ADDT_TIME_LIMIT_SECONDS" ] && [ "$ADDT_TIME_LIMIT_SECONDS" -gt 0 ]; then
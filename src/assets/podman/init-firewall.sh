@@ -167,6 +167,12 @@ if [ "$USE_NFTABLES" = true ]; then
         nft add rule inet addt_filter output ip daddr "$ip" accept 2>/dev/null || true
     done
 
+    # firewall.log_blocked: remember destinations that reach the final rules
+    if [ "${ADDT_FIREWALL_LOG_BLOCKED}" = "true" ]; then
+        nft add set inet addt_filter blocked "{ type ipv4_addr; flags dynamic; }" 2>/dev/null || true
+        nft add rule inet addt_filter output update @blocked "{ ip daddr }" 2>/dev/null || true
+    fi
+
     # Log and handle based on mode
     if [ "${ADDT_FIREWALL_MODE}" = "strict" ] || [ "${ADDT_FIREWALL_MODE}" = "enabled" ]; then
         nft add rule inet addt_filter output log prefix \"ADDT-FIREWALL-BLOCKED: \" level warn
@@ -221,6 +227,11 @@ elif [ "$USE_IPTABLES" = true ]; then
         done
     fi
 
+    # firewall.log_blocked: remember destinations that reach the final rules
+    if [ "${ADDT_FIREWALL_LOG_BLOCKED}" = "true" ]; then
+        iptables -A OUTPUT -m recent --name addt_blocked --rdest --set 2>/dev/null || true
+    fi
+
     # Log and drop/accept based on mode
     if [ "${ADDT_FIREWALL_MODE}" = "strict" ] || [ "${ADDT_FIREWALL_MODE}" = "enabled" ]; then
         iptables -A OUTPUT -j LOG --log-prefix "ADDT-FIREWALL-BLOCKED: " --log-level 4
@@ -238,6 +249,35 @@ elif [ "$USE_IPTABLES" = true ]; then
     fi
 fi
 
+# firewall.log_blocked: append each new blocked destination to a log in the
+# mounted firewall config dir, which "addt firewall log" reads on the host
+if [ "${ADDT_FIREWALL_LOG_BLOCKED}" = "true" ]; then
+    BLOCKED_LOG="$(dirname "$ALLOWED_DOMAINS_FILE")/blocked/${ADDT_FIREWALL_LOG_NAME:-$(hostname)}.log"
+    VERDICT=blocked
+    if [ "${ADDT_FIREWALL_MODE}" = "permissive" ]; then
+        VERDICT=would-block
+    fi
+    mkdir -p "$(dirname "$BLOCKED_LOG")"
+    touch "$BLOCKED_LOG"
+    (
+        SEEN=" "
+        while true; do
+            if [ "$USE_NFTABLES" = true ]; then
+                BLOCKED=$(nft list set inet addt_filter blocked 2>/dev/null | grep -oE '[0-9]+\.[0-9]+\.[0-9]+\.[0-9]+' || true)
+            else
+                BLOCKED=$(grep -oE 'src=[0-9.]+' /proc/net/xt_recent/addt_blocked 2>/dev/null | cut -d= -f2 || true)
+            fi
+            for ip in $BLOCKED; do
+                case "$SEEN" in *" $ip "*) continue ;; esac
+                SEEN="$SEEN$ip "
+                echo "$(date -u +%Y-%m-%dT%H:%M:%SZ) $VERDICT $ip" >> "$BLOCKED_LOG"
+            done
+            sleep 2
+        done
+    ) </dev/null >/dev/null 2>&1 &
+    echo "Firewall: Logging blocked connections to $BLOCKED_LOG"
+fi
+
 # Show summary
 IP_COUNT=$(echo "$ALLOWED_IPS" | wc -w)
 echo "Firewall: Initialized with $IP_COUNT whitelisted IPs"
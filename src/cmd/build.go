@@ -5,9 +5,58 @@ import (
 	"os"
 	"strings"
 
+	"github.com/jedi4ever/addt/config"
 	"github.com/jedi4ever/addt/provider"
 )
 
+// handleBuildSubcommand creates the provider for "addt build" and builds.
+// An extension name as first argument overrides the configured extensions.
+func handleBuildSubcommand(cfg *config.Config, subArgs []string) {
+	// Check for --force and --rebuild-base flags
+	forceNoCache := false
+	rebuildBase := false
+	var filteredArgs []string
+	for _, arg := range subArgs {
+		if arg == "--force" {
+			forceNoCache = true
+		} else if arg == "--rebuild-base" {
+			rebuildBase = true
+		} else {
+			filteredArgs = append(filteredArgs, arg)
+		}
+	}
+	subArgs = filteredArgs
+
+	// Check if extension is passed as first arg (addt build claude)
+	if len(subArgs) > 0 && !strings.HasPrefix(subArgs[0], "-") {
+		cfg.Extensions = subArgs[0]
+		subArgs = subArgs[1:]
+	}
+	// Check if extension is specified
+	if cfg.Extensions == "" {
+		printBuildNoExtension()
+		os.Exit(1)
+	}
+	providerCfg := &provider.Config{
+		AddtVersion:        cfg.AddtVersion,
+		ExtensionVersions:  cfg.ExtensionVersions,
+		NodeVersion:        cfg.NodeVersion,
+		GoVersion:          cfg.GoVersion,
+		UvVersion:          cfg.UvVersion,
+		Provider:           cfg.Provider,
+		Extensions:         cfg.Extensions,
+		NoCache:            forceNoCache,
+		DockerBuildTimeout: cfg.DockerBuildTimeout,
+		DockerPullPolicy:   cfg.DockerPullPolicy,
+	}
+	prov, err := NewProvider(cfg.Provider, providerCfg)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	HandleBuildCommand(prov, providerCfg, subArgs, forceNoCache, rebuildBase)
+}
+
 // HandleBuildCommand handles the build command
 func HandleBuildCommand(prov provider.Provider, cfg *provider.Config, args []string, noCache bool, rebuildBase bool) {
 	// Parse --build-arg flags
@@ -78,3 +127,22 @@ func printBuildHelp() {
 	fmt.Println("  addt build --build-arg ADDT_EXTENSIONS=claude,codex")
 	fmt.Println("  addt build --build-arg CLAUDE_VERSION=1.0.5")
 }
+
+// printBuildNoExtension prints usage when "addt build" has no extension to build
+func printBuildNoExtension() {
+	fmt.Println("Error: No extension specified")
+	fmt.Println()
+	fmt.Println("Usage: addt build <extension> [--force] [--rebuild-base]")
+	fmt.Println("       ADDT_EXTENSIONS=claude addt build")
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  --force         Rebuild without using Docker cache")
+	fmt.Println("  --rebuild-base  Rebuild the base image before building extension image")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  addt build claude")
+	fmt.Println("  addt build claude --force")
+	fmt.Println("  addt build claude --rebuild-base")
+	fmt.Println("  addt build claude --force --rebuild-base")
+	fmt.Println("  addt build claude,codex")
+}
@@ -22,12 +22,14 @@ func (m *mockProvider) Exists(name string) bool                            { ret
 func (m *mockProvider) IsRunning(name string) bool                         { return false }
 func (m *mockProvider) Start(name string) error                            { return nil }
 func (m *mockProvider) Stop(name string) error                             { return nil }
+func (m *mockProvider) Restart(name string) error                          { return nil }
 func (m *mockProvider) Remove(name string) error                           { return nil }
 func (m *mockProvider) List() ([]provider.Environment, error)              { return nil, nil }
 func (m *mockProvider) GeneratePersistentName() string                     { return "test-persistent" }
 func (m *mockProvider) GenerateEphemeralName() string                      { return "test-ephemeral" }
 func (m *mockProvider) GetStatus(cfg *provider.Config, name string) string { return "test" }
 func (m *mockProvider) GetName() string                                    { return "mock" }
+func (m *mockProvider) Stats(name string) (provider.Stats, error)          { return provider.Stats{}, nil }
 func (m *mockProvider) GetExtensionEnvVars(imageName string) []string      { return nil }
 
 func (m *mockProvider) DetermineImageName() string {
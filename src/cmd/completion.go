@@ -3,7 +3,6 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"strings"
 
 	cfgcmd "github.com/jedi4ever/addt/cmd/config"
 	extcmd "github.com/jedi4ever/addt/cmd/extensions"
@@ -76,342 +75,16 @@ func getConfigKeyNames() []string {
 	return names
 }
 
+// getExtensionKeyNames returns the static extension config keys for completion
+func getExtensionKeyNames() []string {
+	var names []string
+	for _, k := range cfgcmd.GetExtensionKeys() {
+		names = append(names, k.Key)
+	}
+	return names
+}
+
 // getProfileNames returns available profile names for completion
 func getProfileNames() []string {
 	return profilecmd.GetProfileNames()
 }
-
-func bashCompletion() string {
-	extensions := strings.Join(getExtensionNames(), " ")
-	configKeys := strings.Join(getConfigKeyNames(), " ")
-	profileNames := strings.Join(getProfileNames(), " ")
-
-	return fmt.Sprintf(`# addt bash completion
-_addt_completions() {
-    local cur prev words cword
-    if declare -F _init_completion >/dev/null 2>&1; then
-        _init_completion || return
-    else
-        COMPREPLY=()
-        cur="${COMP_WORDS[COMP_CWORD]}"
-        prev="${COMP_WORDS[COMP_CWORD-1]}"
-        words=("${COMP_WORDS[@]}")
-        cword=$COMP_CWORD
-    fi
-
-    local commands="run update build shell containers config profile extensions firewall completion doctor version cli"
-    local config_cmds="list get set unset audit extension path"
-    local profile_cmds="list show apply"
-    local profile_names="%s"
-    local containers_cmds="list clean"
-    local firewall_cmds="global project"
-    local firewall_actions="list allow deny remove"
-    local extensions_cmds="list info new"
-    local extensions="%s"
-    local config_keys="%s"
-
-    case "${cword}" in
-        1)
-            COMPREPLY=($(compgen -W "${commands}" -- "${cur}"))
-            ;;
-        2)
-            case "${prev}" in
-                run|update|build|shell)
-                    COMPREPLY=($(compgen -W "${extensions}" -- "${cur}"))
-                    ;;
-                config)
-                    COMPREPLY=($(compgen -W "${config_cmds}" -- "${cur}"))
-                    ;;
-                profile)
-                    COMPREPLY=($(compgen -W "${profile_cmds}" -- "${cur}"))
-                    ;;
-                containers)
-                    COMPREPLY=($(compgen -W "${containers_cmds}" -- "${cur}"))
-                    ;;
-                firewall)
-                    COMPREPLY=($(compgen -W "${firewall_cmds}" -- "${cur}"))
-                    ;;
-                extensions)
-                    COMPREPLY=($(compgen -W "${extensions_cmds}" -- "${cur}"))
-                    ;;
-                completion)
-                    COMPREPLY=($(compgen -W "bash zsh fish" -- "${cur}"))
-                    ;;
-            esac
-            ;;
-        3)
-            case "${words[1]}" in
-                config)
-                    case "${prev}" in
-                        get|set|unset)
-                            COMPREPLY=($(compgen -W "${config_keys}" -- "${cur}"))
-                            ;;
-                        extension)
-                            COMPREPLY=($(compgen -W "${extensions}" -- "${cur}"))
-                            ;;
-                    esac
-                    ;;
-                profile)
-                    case "${prev}" in
-                        show|apply)
-                            COMPREPLY=($(compgen -W "${profile_names}" -- "${cur}"))
-                            ;;
-                    esac
-                    ;;
-                firewall)
-                    COMPREPLY=($(compgen -W "${firewall_actions}" -- "${cur}"))
-                    ;;
-                extensions)
-                    case "${prev}" in
-                        info)
-                            COMPREPLY=($(compgen -W "${extensions}" -- "${cur}"))
-                            ;;
-                    esac
-                    ;;
-            esac
-            ;;
-    esac
-}
-
-complete -F _addt_completions addt
-`, profileNames, extensions, configKeys)
-}
-
-func zshCompletion() string {
-	extensions := strings.Join(getExtensionNames(), " ")
-	configKeys := strings.Join(getConfigKeyNames(), " ")
-	profileNames := strings.Join(getProfileNames(), " ")
-
-	return fmt.Sprintf(`#compdef addt
-
-_addt() {
-    local -a commands extensions config_cmds profile_cmds profile_names containers_cmds firewall_cmds firewall_actions extensions_cmds config_keys
-
-    commands=(
-        'run:Run an agent in a container'
-        'update:Update extension to latest or specific version'
-        'build:Build container image for an agent'
-        'shell:Open a shell in a container'
-        'containers:Manage containers'
-        'config:Manage configuration'
-        'profile:Apply configuration presets'
-        'extensions:Manage extensions'
-        'firewall:Manage firewall rules'
-        'completion:Generate shell completions'
-        'doctor:Check system health'
-        'version:Show version information'
-        'cli:CLI management commands'
-    )
-
-    extensions=(%s)
-
-    config_cmds=(
-        'list:List configuration values'
-        'get:Get a configuration value'
-        'set:Set a configuration value'
-        'unset:Remove a configuration value'
-        'audit:Security audit of effective configuration'
-        'extension:Manage extension configuration'
-        'path:Show config file paths'
-    )
-
-    profile_cmds=(
-        'list:List available profiles'
-        'show:Show profile settings'
-        'apply:Apply a profile'
-    )
-
-    profile_names=(%s)
-
-    containers_cmds=(
-        'list:List containers'
-        'clean:Remove all addt containers'
-    )
-
-    firewall_cmds=(
-        'global:Manage global firewall rules'
-        'project:Manage project firewall rules'
-    )
-
-    firewall_actions=(
-        'list:List firewall rules'
-        'allow:Allow a domain'
-        'deny:Deny a domain'
-        'remove:Remove a rule'
-    )
-
-    extensions_cmds=(
-        'list:List available extensions'
-        'info:Show extension details'
-        'new:Create a new extension'
-    )
-
-    config_keys=(%s)
-
-    _arguments -C \
-        '1: :->command' \
-        '2: :->subcommand' \
-        '3: :->arg3' \
-        '*::arg:->args'
-
-    case "$state" in
-        command)
-            _describe -t commands 'addt commands' commands
-            ;;
-        subcommand)
-            case "$words[2]" in
-                run|update|build|shell)
-                    _describe -t extensions 'extensions' extensions
-                    ;;
-                config)
-                    _describe -t config_cmds 'config commands' config_cmds
-                    ;;
-                profile)
-                    _describe -t profile_cmds 'profile commands' profile_cmds
-                    ;;
-                containers)
-                    _describe -t containers_cmds 'container commands' containers_cmds
-                    ;;
-                firewall)
-                    _describe -t firewall_cmds 'firewall commands' firewall_cmds
-                    ;;
-                extensions)
-                    _describe -t extensions_cmds 'extension commands' extensions_cmds
-                    ;;
-                completion)
-                    _values 'shell' 'bash' 'zsh' 'fish'
-                    ;;
-            esac
-            ;;
-        arg3)
-            case "$words[2]" in
-                config)
-                    case "$words[3]" in
-                        get|set|unset)
-                            _describe -t config_keys 'config keys' config_keys
-                            ;;
-                        extension)
-                            _describe -t extensions 'extensions' extensions
-                            ;;
-                    esac
-                    ;;
-                profile)
-                    case "$words[3]" in
-                        show|apply)
-                            _describe -t profile_names 'profiles' profile_names
-                            ;;
-                    esac
-                    ;;
-                firewall)
-                    _describe -t firewall_actions 'firewall actions' firewall_actions
-                    ;;
-                extensions)
-                    case "$words[3]" in
-                        info)
-                            _describe -t extensions 'extensions' extensions
-                            ;;
-                    esac
-                    ;;
-            esac
-            ;;
-    esac
-}
-
-_addt "$@"
-`, extensions, profileNames, configKeys)
-}
-
-func fishCompletion() string {
-	extensions := getExtensionNames()
-
-	var sb strings.Builder
-	sb.WriteString("# addt fish completion\n\n")
-
-	// Disable file completion by default
-	sb.WriteString("complete -c addt -f\n\n")
-
-	// Main commands
-	sb.WriteString("# Main commands\n")
-	sb.WriteString("complete -c addt -n '__fish_use_subcommand' -a 'run' -d 'Run an agent in a container'\n")
-	sb.WriteString("complete -c addt -n '__fish_use_subcommand' -a 'update' -d 'Update extension to latest or specific version'\n")
-	sb.WriteString("complete -c addt -n '__fish_use_subcommand' -a 'build' -d 'Build container image for an agent'\n")
-	sb.WriteString("complete -c addt -n '__fish_use_subcommand' -a 'shell' -d 'Open a shell in a container'\n")
-	sb.WriteString("complete -c addt -n '__fish_use_subcommand' -a 'containers' -d 'Manage containers'\n")
-	sb.WriteString("complete -c addt -n '__fish_use_subcommand' -a 'config' -d 'Manage configuration'\n")
-	sb.WriteString("complete -c addt -n '__fish_use_subcommand' -a 'profile' -d 'Apply configuration presets'\n")
-	sb.WriteString("complete -c addt -n '__fish_use_subcommand' -a 'extensions' -d 'Manage extensions'\n")
-	sb.WriteString("complete -c addt -n '__fish_use_subcommand' -a 'firewall' -d 'Manage firewall rules'\n")
-	sb.WriteString("complete -c addt -n '__fish_use_subcommand' -a 'completion' -d 'Generate shell completions'\n")
-	sb.WriteString("complete -c addt -n '__fish_use_subcommand' -a 'doctor' -d 'Check system health'\n")
-	sb.WriteString("complete -c addt -n '__fish_use_subcommand' -a 'version' -d 'Show version information'\n")
-	sb.WriteString("complete -c addt -n '__fish_use_subcommand' -a 'cli' -d 'CLI management commands'\n")
-	sb.WriteString("\n")
-
-	// Extensions for run/build/shell
-	sb.WriteString("# Extensions\n")
-	for _, ext := range extensions {
-		sb.WriteString(fmt.Sprintf("complete -c addt -n '__fish_seen_subcommand_from run update build shell' -a '%s'\n", ext))
-	}
-	sb.WriteString("\n")
-
-	// Config subcommands
-	sb.WriteString("# Config subcommands\n")
-	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from config' -a 'list' -d 'List configuration values'\n")
-	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from config' -a 'get' -d 'Get a configuration value'\n")
-	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from config' -a 'set' -d 'Set a configuration value'\n")
-	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from config' -a 'unset' -d 'Remove a configuration value'\n")
-	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from config' -a 'extension' -d 'Manage extension configuration'\n")
-	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from config' -a 'audit' -d 'Security audit of effective configuration'\n")
-	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from config' -a 'path' -d 'Show config file paths'\n")
-	sb.WriteString("\n")
-
-	// Config keys for get/set/unset
-	sb.WriteString("# Config keys\n")
-	configKeys := getConfigKeyNames()
-	for _, key := range configKeys {
-		sb.WriteString(fmt.Sprintf("complete -c addt -n '__fish_seen_subcommand_from config; and __fish_seen_subcommand_from get set unset' -a '%s'\n", key))
-	}
-	sb.WriteString("\n")
-
-	// Profile subcommands
-	sb.WriteString("# Profile subcommands\n")
-	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from profile' -a 'list' -d 'List available profiles'\n")
-	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from profile' -a 'show' -d 'Show profile settings'\n")
-	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from profile' -a 'apply' -d 'Apply a profile'\n")
-	sb.WriteString("\n")
-
-	// Profile names for show/apply
-	sb.WriteString("# Profile names\n")
-	profileNames := getProfileNames()
-	for _, name := range profileNames {
-		sb.WriteString(fmt.Sprintf("complete -c addt -n '__fish_seen_subcommand_from profile; and __fish_seen_subcommand_from show apply' -a '%s'\n", name))
-	}
-	sb.WriteString("\n")
-
-	// Containers subcommands
-	sb.WriteString("# Containers subcommands\n")
-	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from containers' -a 'list' -d 'List containers'\n")
-	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from containers' -a 'clean' -d 'Remove all addt containers'\n")
-	sb.WriteString("\n")
-
-	// Firewall subcommands
-	sb.WriteString("# Firewall subcommands\n")
-	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from firewall' -a 'global' -d 'Manage global firewall rules'\n")
-	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from firewall' -a 'project' -d 'Manage project firewall rules'\n")
-	sb.WriteString("\n")
-
-	// Extensions subcommands
-	sb.WriteString("# Extensions subcommands\n")
-	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from extensions' -a 'list' -d 'List available extensions'\n")
-	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from extensions' -a 'info' -d 'Show extension details'\n")
-	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from extensions' -a 'new' -d 'Create a new extension'\n")
-	sb.WriteString("\n")
-
-	// Completion subcommands
-	sb.WriteString("# Completion shells\n")
-	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from completion' -a 'bash' -d 'Generate bash completion'\n")
-	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from completion' -a 'zsh' -d 'Generate zsh completion'\n")
-	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from completion' -a 'fish' -d 'Generate fish completion'\n")
-
-	return sb.String()
-}
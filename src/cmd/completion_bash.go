@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+)
+
+func bashCompletion() string {
+	extensions := strings.Join(getExtensionNames(), " ")
+	configKeys := strings.Join(getConfigKeyNames(), " ")
+	profileNames := strings.Join(getProfileNames(), " ")
+	runFlags := strings.Join(runFlagNames(), " ")
+	extensionKeys := strings.Join(getExtensionKeyNames(), " ")
+
+	return fmt.Sprintf(`# addt bash completion
+_addt_completions() {
+    local cur prev words cword
+    if declare -F _init_completion >/dev/null 2>&1; then
+        _init_completion || return
+    else
+        COMPREPLY=()
+        cur="${COMP_WORDS[COMP_CWORD]}"
+        prev="${COMP_WORDS[COMP_CWORD-1]}"
+        words=("${COMP_WORDS[@]}")
+        cword=$COMP_CWORD
+    fi
+
+    local commands="run update build shell containers stop restart stats load-image config profile security secrets trust untrust extensions firewall completion doctor version cli"
+    local config_cmds="list get set unset add remove audit export diff extension path"
+    local profile_cmds="list show apply"
+    local profile_names="%s"
+    local security_cmds="explain"
+    local containers_cmds="list clean"
+    local firewall_cmds="global project log"
+    local firewall_actions="list allow deny remove"
+    local extensions_cmds="list info new validate"
+    local extensions="%s"
+    local config_keys="%s"
+    local run_flags="%s"
+    local extension_cmds="list get set unset firewall"
+    local extension_keys="%s"
+
+    case "${cword}" in
+        1)
+            COMPREPLY=($(compgen -W "${commands}" -- "${cur}"))
+            ;;
+        2)
+            case "${prev}" in
+                run)
+                    COMPREPLY=($(compgen -W "${extensions} ${run_flags}" -- "${cur}"))
+                    ;;
+                update|build|shell)
+                    COMPREPLY=($(compgen -W "${extensions}" -- "${cur}"))
+                    ;;
+                stop)
+                    COMPREPLY=($(compgen -W "${extensions} --all" -- "${cur}"))
+                    ;;
+                restart)
+                    COMPREPLY=($(compgen -W "${extensions}" -- "${cur}"))
+                    ;;
+                secrets)
+                    COMPREPLY=($(compgen -W "check" -- "${cur}"))
+                    ;;
+                stats)
+                    COMPREPLY=($(compgen -W "${extensions} --json --watch" -- "${cur}"))
+                    ;;
+                load-image)
+                    COMPREPLY=($(compgen -f -- "${cur}"))
+                    ;;
+                doctor)
+                    COMPREPLY=($(compgen -W "--fix --yes" -- "${cur}"))
+                    ;;
+                config)
+                    COMPREPLY=($(compgen -W "${config_cmds}" -- "${cur}"))
+                    ;;
+                profile)
+                    COMPREPLY=($(compgen -W "${profile_cmds}" -- "${cur}"))
+                    ;;
+                security)
+                    COMPREPLY=($(compgen -W "${security_cmds}" -- "${cur}"))
+                    ;;
+                containers)
+                    COMPREPLY=($(compgen -W "${containers_cmds}" -- "${cur}"))
+                    ;;
+                firewall)
+                    COMPREPLY=($(compgen -W "${firewall_cmds}" -- "${cur}"))
+                    ;;
+                extensions)
+                    COMPREPLY=($(compgen -W "${extensions_cmds}" -- "${cur}"))
+                    ;;
+                completion)
+                    COMPREPLY=($(compgen -W "bash zsh fish" -- "${cur}"))
+                    ;;
+            esac
+            ;;
+        3)
+            case "${words[1]}" in
+                config)
+                    case "${prev}" in
+                        get|set|add|remove)
+                            COMPREPLY=($(compgen -W "${config_keys}" -- "${cur}"))
+                            ;;
+                        unset)
+                            COMPREPLY=($(compgen -W "${config_keys} --all" -- "${cur}"))
+                            ;;
+                        list)
+                            COMPREPLY=($(compgen -W "--json -g --project" -- "${cur}"))
+                            ;;
+                        extension)
+                            COMPREPLY=($(compgen -W "${extensions}" -- "${cur}"))
+                            ;;
+                    esac
+                    ;;
+                profile)
+                    case "${prev}" in
+                        show|apply)
+                            COMPREPLY=($(compgen -W "${profile_names}" -- "${cur}"))
+                            ;;
+                    esac
+                    ;;
+                firewall)
+                    COMPREPLY=($(compgen -W "${firewall_actions}" -- "${cur}"))
+                    ;;
+                extensions)
+                    case "${prev}" in
+                        info)
+                            COMPREPLY=($(compgen -W "${extensions}" -- "${cur}"))
+                            ;;
+                        list)
+                            COMPREPLY=($(compgen -W "--installed --json" -- "${cur}"))
+                            ;;
+                    esac
+                    ;;
+            esac
+            ;;
+        4)
+            if [[ "${words[1]}" == "config" && "${words[2]}" == "extension" ]]; then
+                COMPREPLY=($(compgen -W "${extension_cmds}" -- "${cur}"))
+            fi
+            ;;
+        5)
+            if [[ "${words[1]}" == "config" && "${words[2]}" == "extension" ]]; then
+                case "${prev}" in
+                    get|set|unset)
+                        COMPREPLY=($(compgen -W "${extension_keys}" -- "${cur}"))
+                        ;;
+                esac
+            fi
+            ;;
+    esac
+}
+
+complete -F _addt_completions addt
+`, profileNames, extensions, configKeys, runFlags, extensionKeys)
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// fishConfigCompletions writes the fish completions for "addt config":
+// subcommands, config keys and extension keys
+func fishConfigCompletions(sb *strings.Builder) {
+	// Config subcommands
+	sb.WriteString("# Config subcommands\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from config' -a 'list' -d 'List effective configuration values'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from config; and __fish_seen_subcommand_from list' -l json -d 'Output as JSON'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from config' -a 'get' -d 'Get the effective value and its source'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from config; and __fish_seen_subcommand_from list get' -l project -d 'Read only the project config file'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from config' -a 'set' -d 'Set a configuration value'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from config' -a 'unset' -d 'Remove a configuration value'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from config; and __fish_seen_subcommand_from unset' -l all -d 'Remove every value, keeping the file'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from config; and __fish_seen_subcommand_from unset' -l dry-run -d 'List what --all would remove'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from config' -a 'add' -d 'Append an entry to a list value'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from config' -a 'remove' -d 'Remove an entry from a list value'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from config' -a 'extension' -d 'Manage extension configuration'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from config' -a 'audit' -d 'Security audit of effective configuration'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from config' -a 'export' -d 'Print the effective configuration as YAML'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from config; and __fish_seen_subcommand_from export' -l out -r -d 'Write to a file'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from config; and __fish_seen_subcommand_from export' -l show-secrets -d 'Do not redact sensitive values'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from config; and __fish_seen_subcommand_from export' -l shell -d 'Print export statements instead of YAML'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from config' -a 'diff' -d 'Compare global and project configuration'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from config; and __fish_seen_subcommand_from diff' -l all -d 'Also show unchanged keys'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from config' -a 'path' -d 'Show config file paths'\n")
+	sb.WriteString("\n")
+
+	// Config keys for get/set/unset/add/remove
+	sb.WriteString("# Config keys\n")
+	configKeys := getConfigKeyNames()
+	for _, key := range configKeys {
+		sb.WriteString(fmt.Sprintf("complete -c addt -n '__fish_seen_subcommand_from config; and __fish_seen_subcommand_from get set unset add remove; and not __fish_seen_subcommand_from extension' -a '%s'\n", key))
+	}
+	sb.WriteString("\n")
+
+	// Extension keys for config extension <name> get/set/unset
+	sb.WriteString("# Extension keys\n")
+	for _, key := range getExtensionKeyNames() {
+		sb.WriteString(fmt.Sprintf("complete -c addt -n '__fish_seen_subcommand_from config; and __fish_seen_subcommand_from extension; and __fish_seen_subcommand_from get set unset' -a '%s'\n", key))
+	}
+	sb.WriteString("\n")
+}
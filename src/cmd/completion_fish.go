@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+)
+
+func fishCompletion() string {
+	extensions := getExtensionNames()
+
+	var sb strings.Builder
+	sb.WriteString("# addt fish completion\n\n")
+
+	// Disable file completion by default
+	sb.WriteString("complete -c addt -f\n\n")
+
+	// Main commands
+	sb.WriteString("# Main commands\n")
+	sb.WriteString("complete -c addt -n '__fish_use_subcommand' -a 'run' -d 'Run an agent in a container'\n")
+	sb.WriteString("complete -c addt -n '__fish_use_subcommand' -a 'update' -d 'Update extension to latest or specific version'\n")
+	sb.WriteString("complete -c addt -n '__fish_use_subcommand' -a 'build' -d 'Build container image for an agent'\n")
+	sb.WriteString("complete -c addt -n '__fish_use_subcommand' -a 'shell' -d 'Open a shell in a container'\n")
+	sb.WriteString("complete -c addt -n '__fish_use_subcommand' -a 'containers' -d 'Manage containers'\n")
+	sb.WriteString("complete -c addt -n '__fish_use_subcommand' -a 'stop' -d 'Stop persistent containers'\n")
+	sb.WriteString("complete -c addt -n '__fish_use_subcommand' -a 'restart' -d 'Restart a persistent container'\n")
+	sb.WriteString("complete -c addt -n '__fish_use_subcommand' -a 'stats' -d 'Show container resource usage'\n")
+	sb.WriteString("complete -c addt -n '__fish_use_subcommand' -a 'load-image' -d 'Load an image saved with run --save-image'\n")
+	sb.WriteString("complete -c addt -n '__fish_use_subcommand' -a 'config' -d 'Manage configuration'\n")
+	sb.WriteString("complete -c addt -n '__fish_use_subcommand' -a 'profile' -d 'Apply configuration presets'\n")
+	sb.WriteString("complete -c addt -n '__fish_use_subcommand' -a 'security' -d 'Inspect security settings'\n")
+	sb.WriteString("complete -c addt -n '__fish_use_subcommand' -a 'secrets' -d 'Show which env vars are treated as secrets'\n")
+	sb.WriteString("complete -c addt -n '__fish_use_subcommand' -a 'trust' -d 'Trust a workdir for agents'\n")
+	sb.WriteString("complete -c addt -n '__fish_use_subcommand' -a 'untrust' -d 'Never auto-trust a workdir'\n")
+	sb.WriteString("complete -c addt -n '__fish_use_subcommand' -a 'extensions' -d 'Manage extensions'\n")
+	sb.WriteString("complete -c addt -n '__fish_use_subcommand' -a 'firewall' -d 'Manage firewall rules'\n")
+	sb.WriteString("complete -c addt -n '__fish_use_subcommand' -a 'completion' -d 'Generate shell completions'\n")
+	sb.WriteString("complete -c addt -n '__fish_use_subcommand' -a 'doctor' -d 'Check system health'\n")
+	sb.WriteString("complete -c addt -n '__fish_use_subcommand' -a 'version' -d 'Show version information'\n")
+	sb.WriteString("complete -c addt -n '__fish_use_subcommand' -a 'cli' -d 'CLI management commands'\n")
+	sb.WriteString("\n")
+
+	// Extensions for run/build/shell
+	sb.WriteString("# Extensions\n")
+	for _, ext := range extensions {
+		sb.WriteString(fmt.Sprintf("complete -c addt -n '__fish_seen_subcommand_from run update build shell stop restart stats' -a '%s'\n", ext))
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from stop' -l all -d 'Stop all persistent containers'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from stats' -l json -d 'Print samples as JSON'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from stats' -l watch -d 'Keep sampling every 2s'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from load-image' -F\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from doctor' -l fix -d 'Apply safe fixes before checking'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from doctor' -s y -l yes -d 'Apply fixes without asking'\n\n")
+
+	fishRunFlags(&sb)
+	fishConfigCompletions(&sb)
+
+	// Profile subcommands
+	sb.WriteString("# Profile subcommands\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from profile' -a 'list' -d 'List available profiles'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from profile' -a 'show' -d 'Show profile settings'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from profile' -a 'apply' -d 'Apply a profile'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from security' -a 'explain' -d 'Show the effective security posture'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from secrets' -a 'check' -d 'Show how each env var is classified'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from security; and __fish_seen_subcommand_from explain' -l json -d 'Output as JSON'\n")
+	sb.WriteString("\n")
+
+	// Profile names for show/apply
+	sb.WriteString("# Profile names\n")
+	profileNames := getProfileNames()
+	for _, name := range profileNames {
+		sb.WriteString(fmt.Sprintf("complete -c addt -n '__fish_seen_subcommand_from profile; and __fish_seen_subcommand_from show apply' -a '%s'\n", name))
+	}
+	sb.WriteString("\n")
+
+	// Containers subcommands
+	sb.WriteString("# Containers subcommands\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from containers' -a 'list' -d 'List containers'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from containers' -a 'clean' -d 'Remove all addt containers'\n")
+	sb.WriteString("\n")
+
+	// Firewall subcommands
+	sb.WriteString("# Firewall subcommands\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from firewall' -a 'global' -d 'Manage global firewall rules'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from firewall' -a 'project' -d 'Manage project firewall rules'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from firewall' -a 'log' -d 'Show connections the firewall blocked'\n")
+	sb.WriteString("\n")
+
+	// Extensions subcommands
+	sb.WriteString("# Extensions subcommands\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from extensions' -a 'list' -d 'List available extensions'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from extensions; and __fish_seen_subcommand_from list' -l installed -d 'Only the selected extensions'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from extensions; and __fish_seen_subcommand_from list' -l json -d 'Print JSON'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from extensions' -a 'info' -d 'Show extension details'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from extensions' -a 'new' -d 'Create a new extension'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from extensions' -a 'validate' -d 'Lint an extension config.yaml'\n")
+	sb.WriteString("\n")
+
+	// Completion subcommands
+	sb.WriteString("# Completion shells\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from completion' -a 'bash' -d 'Generate bash completion'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from completion' -a 'zsh' -d 'Generate zsh completion'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from completion' -a 'fish' -d 'Generate fish completion'\n")
+
+	return sb.String()
+}
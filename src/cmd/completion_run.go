@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// fishRunFlags writes the fish completions for the "addt run" flags
+func fishRunFlags(sb *strings.Builder) {
+	sb.WriteString("# Run flags\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from run' -l save-config -d 'Save flag settings to .addt.yaml'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from run' -l rebuild -d 'Rebuild the extension image before running'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from run' -l rebuild-base -d 'Rebuild the base and extension images before running'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from run' -l lock -d 'Write resolved extension versions to .addt.lock'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from run' -l frozen -d 'Fail unless versions match .addt.lock'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from run' -l explain-config -d 'Show which layer set each config value'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from run' -l strict-config -d 'Warn about unknown config keys'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from run' -l print-only-env -d 'Print the redacted run environment and exit'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from run' -l dump-spec -d 'Print the resolved run spec as JSON and exit'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from run' -l print-firewall-rules -d 'Print the merged firewall rules and exit'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from run' -l add-cap -d 'Add a capability for this run'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from run' -l drop-cap -d 'Drop a capability for this run'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from run' -l provider -x -a 'docker rancher podman orbstack applecontainer daytona' -d 'Provider for this run'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from run' -l timeout -x -d 'Host-side deadline for the run'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from run' -l mount-extra-ssh-dir -x -a '(__fish_complete_directories)' -d 'Forward another SSH key directory'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from run' -l mount-home -x -d 'Keep a home subdir in a per-workdir volume'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from run' -l env-file -r -d 'Load another env file (repeatable)'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from run' -l mount -x -d 'Docker-style mount spec (repeatable)'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from run' -l entrypoint-arg -x -d 'Pass an arg to the built-in entrypoint (repeatable)'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from run' -l mount-workdir-at -x -d 'Mount the working directory at this container path'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from run' -l no-automount-config -d 'Skip extension config mounts for this run'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from run' -l stdout-file -r -d 'Write container stdout to a file'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from run' -l save-image -r -d 'Export the built image to a tarball'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from run' -l stderr-file -r -d 'Write container stderr to a file'\n")
+	sb.WriteString("complete -c addt -n '__fish_seen_subcommand_from run' -l record -d 'Record the session output to the log dir'\n")
+	for _, def := range runFlagDefs {
+		sb.WriteString(fmt.Sprintf("complete -c addt -n '__fish_seen_subcommand_from run' -l %s -d '%s'\n", strings.TrimPrefix(def.Flag, "--"), def.Description))
+	}
+	sb.WriteString("\n")
+}
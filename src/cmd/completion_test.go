@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCompletion_ExtensionKeys(t *testing.T) {
+	keys := getExtensionKeyNames()
+	for _, want := range []string{"version", "config.readonly", "workdir.autotrust", "auth.autologin", "auth.method"} {
+		found := false
+		for _, k := range keys {
+			if k == want {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("getExtensionKeyNames() missing %q, got %v", want, keys)
+		}
+	}
+
+	scripts := map[string]string{
+		"bash": bashCompletion(),
+		"zsh":  zshCompletion(),
+		"fish": fishCompletion(),
+	}
+	for shell, script := range scripts {
+		for _, k := range keys {
+			if !strings.Contains(script, k) {
+				t.Errorf("%s completion does not offer extension key %q", shell, k)
+			}
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+)
+
+func zshCompletion() string {
+	extensions := strings.Join(getExtensionNames(), " ")
+	configKeys := strings.Join(getConfigKeyNames(), " ")
+	profileNames := strings.Join(getProfileNames(), " ")
+	runFlags := strings.Join(runFlagNames(), " ")
+	extensionKeys := strings.Join(getExtensionKeyNames(), " ")
+
+	return fmt.Sprintf(`#compdef addt
+
+_addt() {
+    local -a commands extensions config_cmds profile_cmds profile_names security_cmds containers_cmds firewall_cmds firewall_actions extensions_cmds config_keys run_flags extension_keys
+
+    commands=(
+        'run:Run an agent in a container'
+        'update:Update extension to latest or specific version'
+        'build:Build container image for an agent'
+        'shell:Open a shell in a container'
+        'containers:Manage containers'
+        'stop:Stop persistent containers'
+        'restart:Restart a persistent container'
+        'stats:Show container resource usage'
+        'load-image:Load an image saved with run --save-image'
+        'config:Manage configuration'
+        'profile:Apply configuration presets'
+        'security:Inspect security settings'
+        'secrets:Show which env vars are treated as secrets'
+        'trust:Trust a workdir for agents'
+        'untrust:Never auto-trust a workdir'
+        'extensions:Manage extensions'
+        'firewall:Manage firewall rules'
+        'completion:Generate shell completions'
+        'doctor:Check system health'
+        'version:Show version information'
+        'cli:CLI management commands'
+    )
+
+    extensions=(%s)
+
+    config_cmds=(
+        'list:List effective configuration values'
+        'get:Get the effective value and its source'
+        'set:Set a configuration value'
+        'unset:Remove a configuration value'
+        'add:Append an entry to a list value'
+        'remove:Remove an entry from a list value'
+        'audit:Security audit of effective configuration'
+        'export:Print the effective configuration as YAML'
+        'diff:Compare global and project configuration'
+        'extension:Manage extension configuration'
+        'path:Show config file paths'
+    )
+
+    profile_cmds=(
+        'list:List available profiles'
+        'show:Show profile settings'
+        'apply:Apply a profile'
+    )
+
+    profile_names=(%s)
+
+    security_cmds=(
+        'explain:Show the effective security posture'
+    )
+
+    containers_cmds=(
+        'list:List containers'
+        'clean:Remove all addt containers'
+    )
+
+    firewall_cmds=(
+        'global:Manage global firewall rules'
+        'project:Manage project firewall rules'
+        'log:Show connections the firewall blocked'
+    )
+
+    firewall_actions=(
+        'list:List firewall rules'
+        'allow:Allow a domain'
+        'deny:Deny a domain'
+        'remove:Remove a rule'
+    )
+
+    extensions_cmds=(
+        'list:List available extensions'
+        'info:Show extension details'
+        'new:Create a new extension'
+        'validate:Lint an extension config.yaml'
+    )
+
+    config_keys=(%s)
+
+    run_flags=(%s)
+    extension_keys=(%s)
+
+    _arguments -C \
+        '1: :->command' \
+        '2: :->subcommand' \
+        '3: :->arg3' \
+        '*::arg:->args'
+
+    case "$state" in
+        command)
+            _describe -t commands 'addt commands' commands
+            ;;
+        subcommand)
+            case "$words[2]" in
+                run)
+                    _describe -t extensions 'extensions' extensions
+                    _describe -t run_flags 'run flags' run_flags
+                    ;;
+                update|build|shell)
+                    _describe -t extensions 'extensions' extensions
+                    ;;
+                stop)
+                    _describe -t extensions 'extensions' extensions
+                    compadd -- --all
+                    ;;
+                restart)
+                    _describe -t extensions 'extensions' extensions
+                    ;;
+                secrets)
+                    compadd -- check
+                    ;;
+                stats)
+                    _describe -t extensions 'extensions' extensions
+                    compadd -- --json --watch
+                    ;;
+                load-image)
+                    _files -g '*.tar'
+                    ;;
+                doctor)
+                    compadd -- --fix --yes
+                    ;;
+                config)
+                    _describe -t config_cmds 'config commands' config_cmds
+                    ;;
+                profile)
+                    _describe -t profile_cmds 'profile commands' profile_cmds
+                    ;;
+                security)
+                    _describe -t security_cmds 'security commands' security_cmds
+                    ;;
+                containers)
+                    _describe -t containers_cmds 'container commands' containers_cmds
+                    ;;
+                firewall)
+                    _describe -t firewall_cmds 'firewall commands' firewall_cmds
+                    ;;
+                extensions)
+                    _describe -t extensions_cmds 'extension commands' extensions_cmds
+                    ;;
+                completion)
+                    _values 'shell' 'bash' 'zsh' 'fish'
+                    ;;
+            esac
+            ;;
+        arg3)
+            case "$words[2]" in
+                config)
+                    case "$words[3]" in
+                        get|set|add|remove)
+                            _describe -t config_keys 'config keys' config_keys
+                            ;;
+                        unset)
+                            _describe -t config_keys 'config keys' config_keys
+                            compadd -- --all
+                            ;;
+                        list)
+                            compadd -- --json -g --project
+                            ;;
+                        extension)
+                            _describe -t extensions 'extensions' extensions
+                            ;;
+                    esac
+                    ;;
+                profile)
+                    case "$words[3]" in
+                        show|apply)
+                            _describe -t profile_names 'profiles' profile_names
+                            ;;
+                    esac
+                    ;;
+                firewall)
+                    _describe -t firewall_actions 'firewall actions' firewall_actions
+                    ;;
+                extensions)
+                    case "$words[3]" in
+                        info)
+                            _describe -t extensions 'extensions' extensions
+                            ;;
+                        list)
+                            _arguments '--installed[Only the selected extensions]' '--json[Print JSON]'
+                            ;;
+                    esac
+                    ;;
+            esac
+            ;;
+        args)
+            if [[ "$line[1]" == "config" && "$line[2]" == "extension" ]]; then
+                if (( CURRENT == 1 )); then
+                    compadd -- list get set unset firewall
+                elif (( CURRENT == 2 )) && [[ "$words[1]" == (get|set|unset) ]]; then
+                    _describe -t extension_keys 'extension keys' extension_keys
+                fi
+            fi
+            ;;
+    esac
+}
+
+_addt "$@"
+`, extensions, profileNames, configKeys, runFlags, extensionKeys)
+}
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	cfgtypes "github.com/jedi4ever/addt/config"
+)
+
+// diffRow is one key of "addt config diff"
+type diffRow struct {
+	Key     string
+	Global  string // value in the global config, "-" when unset
+	Project string // value in the project config, "-" when unset
+	Wins    string // layer supplying the effective value: env, project, global or default
+	Differs bool
+}
+
+// diffCommand handles "addt config diff [--all]"
+func diffCommand(args []string) {
+	all := false
+	for _, arg := range args {
+		if arg != "--all" {
+			fmt.Println("Usage: addt config diff [--all]")
+			os.Exit(1)
+		}
+		all = true
+	}
+
+	projectCfg, err := cfgtypes.LoadProjectConfigFile()
+	if err != nil {
+		fmt.Printf("Error loading project config: %v\n", err)
+		os.Exit(1)
+	}
+	globalCfg, err := cfgtypes.LoadGlobalConfigFile()
+	if err != nil {
+		fmt.Printf("Error loading global config: %v\n", err)
+		os.Exit(1)
+	}
+	printDiffRows(os.Stdout, buildDiffRows(projectCfg, globalCfg, all))
+}
+
+// buildDiffRows compares every config key between the global and project
+// config. A key differs when the project changes the value the global config
+// (or the default) would give it. Unchanged keys are left out unless all.
+func buildDiffRows(projectCfg, globalCfg *cfgtypes.GlobalConfig, all bool) []diffRow {
+	var rows []diffRow
+	for _, k := range GetKeys() {
+		g, p := GetValue(globalCfg, k.Key), GetValue(projectCfg, k.Key)
+		globalEffective := g
+		if globalEffective == "" {
+			globalEffective = GetDefaultValue(k.Key)
+		}
+		differs := p != "" && p != globalEffective
+		if !differs && !all {
+			continue
+		}
+		_, wins := resolveValueAndSource(k, projectCfg, globalCfg)
+		rows = append(rows, diffRow{
+			Key:     k.Key,
+			Global:  orDash(g),
+			Project: orDash(p),
+			Wins:    wins,
+			Differs: differs,
+		})
+	}
+	return rows
+}
+
+// printDiffRows prints the diff table; differing keys are marked with "*"
+func printDiffRows(w io.Writer, rows []diffRow) {
+	if len(rows) == 0 {
+		fmt.Fprintln(w, "No differences between global and project config")
+		return
+	}
+	keyLen, globalLen, projectLen := len("Key"), len("Global"), len("Project")
+	for _, r := range rows {
+		keyLen = max(keyLen, len(r.Key))
+		globalLen = max(globalLen, len(r.Global))
+		projectLen = max(projectLen, len(r.Project))
+	}
+
+	fmt.Fprintf(w, "  %-*s   %-*s   %-*s   %s\n", keyLen, "Key", globalLen, "Global", projectLen, "Project", "Wins")
+	fmt.Fprintf(w, "  %s   %s   %s   %s\n", strings.Repeat("-", keyLen), strings.Repeat("-", globalLen), strings.Repeat("-", projectLen), "-------")
+	for _, r := range rows {
+		prefix := " "
+		if r.Differs {
+			prefix = "*"
+		}
+		fmt.Fprintf(w, "%s %-*s   %-*s   %-*s   %s\n", prefix, keyLen, r.Key, globalLen, r.Global, projectLen, r.Project, r.Wins)
+	}
+}
+
+// orDash returns value, or "-" when it is empty
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
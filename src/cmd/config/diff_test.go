@@ -0,0 +1,77 @@
+package config
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	cfgtypes "github.com/jedi4ever/addt/config"
+)
+
+func TestBuildDiffRows(t *testing.T) {
+	globalCfg := &cfgtypes.GlobalConfig{}
+	projectCfg := &cfgtypes.GlobalConfig{}
+	SetValue(globalCfg, "container.cpus", "2")
+	SetValue(projectCfg, "container.cpus", "4")
+	SetValue(globalCfg, "container.memory", "4g")
+	SetValue(projectCfg, "container.memory", "4g")
+	SetValue(projectCfg, "firewall.mode", "strict") // same as the default
+	SetValue(projectCfg, "persistent", "true")
+
+	rows := buildDiffRows(projectCfg, globalCfg, false)
+	got := map[string]diffRow{}
+	for _, r := range rows {
+		got[r.Key] = r
+	}
+	if len(got) != 2 {
+		t.Fatalf("rows = %+v, want container.cpus and persistent only", rows)
+	}
+	if r := got["container.cpus"]; r.Global != "2" || r.Project != "4" || r.Wins != "project" || !r.Differs {
+		t.Errorf("container.cpus = %+v", r)
+	}
+	if r := got["persistent"]; r.Global != "-" || r.Project != "true" || r.Wins != "project" {
+		t.Errorf("persistent = %+v", r)
+	}
+
+	t.Setenv("ADDT_CONTAINER_CPUS", "8")
+	all := buildDiffRows(projectCfg, globalCfg, true)
+	if len(all) != len(GetKeys()) {
+		t.Errorf("--all rows = %d, want %d", len(all), len(GetKeys()))
+	}
+	for _, r := range all {
+		switch r.Key {
+		case "container.cpus":
+			if r.Wins != "env" {
+				t.Errorf("container.cpus wins = %q, want env", r.Wins)
+			}
+		case "container.memory":
+			if r.Differs || r.Wins != "project" {
+				t.Errorf("container.memory = %+v, want unchanged, project wins", r)
+			}
+		}
+	}
+}
+
+func TestPrintDiffRows(t *testing.T) {
+	var buf bytes.Buffer
+	printDiffRows(&buf, nil)
+	if !strings.Contains(buf.String(), "No differences") {
+		t.Errorf("empty diff output = %q", buf.String())
+	}
+
+	buf.Reset()
+	printDiffRows(&buf, []diffRow{
+		{Key: "container.cpus", Global: "2", Project: "4", Wins: "project", Differs: true},
+		{Key: "container.memory", Global: "4g", Project: "4g", Wins: "project"},
+	})
+	out := buf.String()
+	if !strings.Contains(out, "Global") || !strings.Contains(out, "Wins") {
+		t.Errorf("missing header:\n%s", out)
+	}
+	if !strings.Contains(out, "* container.cpus     2        4         project") {
+		t.Errorf("differing row not marked:\n%s", out)
+	}
+	if !strings.Contains(out, "  container.memory   4g") {
+		t.Errorf("unchanged row marked:\n%s", out)
+	}
+}
@@ -0,0 +1,139 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	cfgtypes "github.com/jedi4ever/addt/config"
+	"gopkg.in/yaml.v3"
+)
+
+// exportCommand handles "addt config export [--shell] [--out <file>] [--show-secrets]"
+func exportCommand(args []string) {
+	out, showSecrets, shell := "", false, false
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "--show-secrets":
+			showSecrets = true
+		case arg == "--shell":
+			shell = true
+		case arg == "--out" && i+1 < len(args):
+			i++
+			out = args[i]
+		case strings.HasPrefix(arg, "--out="):
+			out = strings.TrimPrefix(arg, "--out=")
+		default:
+			fmt.Println("Usage: addt config export [--shell] [--out <file>] [--show-secrets]")
+			os.Exit(1)
+		}
+	}
+
+	projectCfg, err := cfgtypes.LoadProjectConfigFile()
+	if err != nil {
+		fmt.Printf("Error loading project config: %v\n", err)
+		os.Exit(1)
+	}
+	globalCfg, err := cfgtypes.LoadGlobalConfigFile()
+	if err != nil {
+		fmt.Printf("Error loading global config: %v\n", err)
+		os.Exit(1)
+	}
+	var data []byte
+	if shell {
+		data = renderShellExport(projectCfg, globalCfg)
+	} else {
+		data, err = renderExport(projectCfg, globalCfg, showSecrets)
+	}
+	if err != nil {
+		fmt.Printf("Error exporting config: %v\n", err)
+		os.Exit(1)
+	}
+
+	if out == "" {
+		os.Stdout.Write(data)
+		return
+	}
+	if err := os.WriteFile(out, data, 0600); err != nil {
+		fmt.Printf("Error writing %s: %v\n", out, err)
+		os.Exit(1)
+	}
+	fmt.Printf("Exported effective config to %s\n", out)
+}
+
+// renderExport returns the effective config (env > project > global >
+// default) as one YAML document in the config file layout, each key
+// commented with its source. The firewall lists and extension settings of
+// both layers are merged in, project over global. Sensitive values are emptied unless
+// showSecrets is set.
+func renderExport(projectCfg, globalCfg *cfgtypes.GlobalConfig, showSecrets bool) ([]byte, error) {
+	merged := &cfgtypes.GlobalConfig{}
+	sources := make(map[string]string)
+	redacted := make(map[string]bool)
+	for _, k := range GetKeys() {
+		value, source := resolveValueAndSource(k, projectCfg, globalCfg)
+		if source == "" || value == "-" {
+			continue
+		}
+		if err := SetValue(merged, k.Key, value); err != nil {
+			return nil, err
+		}
+		sources[k.Key] = source
+		if kd := GetKeyDef(k.Key); kd != nil && kd.Sensitive && !showSecrets {
+			redacted[k.Key] = true
+		}
+	}
+	mergeFirewallRules(merged, projectCfg, globalCfg, sources)
+	if err := mergeExtensions(merged, projectCfg, globalCfg, sources); err != nil {
+		return nil, err
+	}
+
+	var root yaml.Node
+	if err := root.Encode(merged); err != nil {
+		return nil, err
+	}
+	annotateSources(&root, "", sources, redacted)
+
+	doc := &yaml.Node{
+		Kind:        yaml.DocumentNode,
+		HeadComment: "Effective addt config exported by \"addt config export\"\nEach key notes its source: default, global, project or env",
+		Content:     []*yaml.Node{&root},
+	}
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(4)
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// annotateSources adds the source of each config key as a line comment
+// and empties redacted values
+func annotateSources(m *yaml.Node, prefix string, sources map[string]string, redacted map[string]bool) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		key, val := m.Content[i], m.Content[i+1]
+		path := prefix + key.Value
+		source, ok := sources[path]
+		if !ok {
+			if val.Kind == yaml.MappingNode {
+				annotateSources(val, path+".", sources, redacted)
+			}
+			continue
+		}
+		if redacted[path] {
+			m.Content[i+1] = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Style: yaml.DoubleQuotedStyle}
+			val = m.Content[i+1]
+			source += ", redacted (use --show-secrets)"
+		}
+		if val.Kind == yaml.ScalarNode {
+			val.LineComment = source
+		} else {
+			key.LineComment = source
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package config
+
+import (
+	"slices"
+
+	cfgtypes "github.com/jedi4ever/addt/config"
+	"gopkg.in/yaml.v3"
+)
+
+// mergeFirewallRules folds both layers' firewall.allowed and firewall.denied
+// into merged. The project wins a conflict like the layered evaluation does,
+// and global presets are expanded so they survive a project presets list.
+func mergeFirewallRules(merged, projectCfg, globalCfg *cfgtypes.GlobalConfig, sources map[string]string) {
+	var globalAllowed, globalDenied, projectAllowed, projectDenied []string
+	if globalCfg.Firewall != nil {
+		domains, _ := cfgtypes.ExpandFirewallPresets(globalCfg.Firewall.Presets)
+		globalAllowed = append(append([]string(nil), globalCfg.Firewall.Allowed...), domains...)
+		globalDenied = globalCfg.Firewall.Denied
+	}
+	if projectCfg.Firewall != nil {
+		projectAllowed = projectCfg.Firewall.Allowed
+		projectDenied = projectCfg.Firewall.Denied
+	}
+
+	allowed := mergeRuleList(projectAllowed, globalAllowed, projectDenied)
+	denied := mergeRuleList(projectDenied, globalDenied, projectAllowed)
+	if len(allowed) == 0 && len(denied) == 0 {
+		return
+	}
+	if merged.Firewall == nil {
+		merged.Firewall = &cfgtypes.FirewallSettings{}
+	}
+	merged.Firewall.Allowed = allowed
+	merged.Firewall.Denied = denied
+	if len(allowed) > 0 {
+		sources["firewall.allowed"] = layerSource(len(globalAllowed) > 0, len(projectAllowed) > 0)
+	}
+	if len(denied) > 0 {
+		sources["firewall.denied"] = layerSource(len(globalDenied) > 0, len(projectDenied) > 0)
+	}
+}
+
+// mergeRuleList returns the project entries followed by the global entries
+// the project doesn't list or override
+func mergeRuleList(project, global, projectOpposite []string) []string {
+	out := append([]string(nil), project...)
+	for _, entry := range global {
+		if !slices.Contains(out, entry) && !slices.Contains(projectOpposite, entry) {
+			out = append(out, entry)
+		}
+	}
+	return out
+}
+
+// mergeExtensions folds both layers' extensions.<name> settings into merged,
+// project fields over global ones
+func mergeExtensions(merged, projectCfg, globalCfg *cfgtypes.GlobalConfig, sources map[string]string) error {
+	names := make(map[string]bool)
+	for name := range globalCfg.Extensions {
+		names[name] = true
+	}
+	for name := range projectCfg.Extensions {
+		names[name] = true
+	}
+	for name := range names {
+		global, project := globalCfg.Extensions[name], projectCfg.Extensions[name]
+		ext, err := mergeExtensionSettings(global, project)
+		if err != nil {
+			return err
+		}
+		if merged.Extensions == nil {
+			merged.Extensions = make(map[string]*cfgtypes.ExtensionSettings)
+		}
+		merged.Extensions[name] = ext
+		sources["extensions."+name] = layerSource(global != nil, project != nil)
+	}
+	return nil
+}
+
+// mergeExtensionSettings overlays the project settings on the global ones
+// through their YAML form, so nested fields merge one by one
+func mergeExtensionSettings(global, project *cfgtypes.ExtensionSettings) (*cfgtypes.ExtensionSettings, error) {
+	values := map[string]interface{}{}
+	for _, layer := range []*cfgtypes.ExtensionSettings{global, project} {
+		if layer == nil {
+			continue
+		}
+		data, err := yaml.Marshal(layer)
+		if err != nil {
+			return nil, err
+		}
+		var m map[string]interface{}
+		if err := yaml.Unmarshal(data, &m); err != nil {
+			return nil, err
+		}
+		overlayMap(values, m)
+	}
+	data, err := yaml.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	ext := &cfgtypes.ExtensionSettings{}
+	return ext, yaml.Unmarshal(data, ext)
+}
+
+// overlayMap copies src into dst, merging nested maps key by key
+func overlayMap(dst, src map[string]interface{}) {
+	for k, v := range src {
+		sub, isMap := v.(map[string]interface{})
+		existing, hasMap := dst[k].(map[string]interface{})
+		if isMap && hasMap {
+			overlayMap(existing, sub)
+			continue
+		}
+		dst[k] = v
+	}
+}
+
+// layerSource names the config layers a merged value came from
+func layerSource(global, project bool) string {
+	switch {
+	case global && project:
+		return "global, project"
+	case project:
+		return "project"
+	default:
+		return "global"
+	}
+}
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	cfgtypes "github.com/jedi4ever/addt/config"
+)
+
+// renderShellExport returns the effective config as "export ADDT_...=value"
+// lines, one per key that has an env var, for sourcing into a CI job.
+// Nothing is redacted: the lines are meant to recreate the config.
+func renderShellExport(projectCfg, globalCfg *cfgtypes.GlobalConfig) []byte {
+	var b strings.Builder
+	b.WriteString("# Effective addt config exported by \"addt config export --shell\"\n")
+	for _, k := range GetKeys() {
+		if k.EnvVar == "" {
+			continue
+		}
+		value, source := resolveValueAndSource(k, projectCfg, globalCfg)
+		if source == "" || value == "-" {
+			continue
+		}
+		fmt.Fprintf(&b, "export %s=%s\n", k.EnvVar, shellQuote(value))
+	}
+	return []byte(b.String())
+}
+
+// shellQuote single-quotes value for POSIX shells unless it is made only of
+// characters that need no quoting
+func shellQuote(value string) string {
+	if value != "" && strings.Trim(value, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_-.,:/@%+=") == "" {
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
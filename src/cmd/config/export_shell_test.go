@@ -0,0 +1,54 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	cfgtypes "github.com/jedi4ever/addt/config"
+)
+
+func TestRenderShellExport(t *testing.T) {
+	_, _, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	setGlobal("container.cpus", "3")
+	setProject("firewall.enabled", "true")
+	setProject("env_vars", "OPENAI_API_KEY,GH_TOKEN")
+	t.Setenv("ADDT_OTEL_HEADERS", "Authorization=Bearer s3cret")
+
+	data := shellExport(t)
+	for _, want := range []string{
+		"export ADDT_CONTAINER_CPUS=3\n",
+		"export ADDT_FIREWALL=true\n",
+		"export ADDT_ENV_VARS=OPENAI_API_KEY,GH_TOKEN\n",
+		"export ADDT_SECURITY_PIDS_LIMIT=200\n",
+		"export ADDT_OTEL_HEADERS='Authorization=Bearer s3cret'\n",
+	} {
+		if !strings.Contains(data, want) {
+			t.Errorf("shell export missing %q:\n%s", want, data)
+		}
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	tests := map[string]string{
+		"strict":         "strict",
+		"":               "''",
+		"a b":            "'a b'",
+		"it's":           `'it'\''s'`,
+		"$HOME/.ssh":     "'$HOME/.ssh'",
+		"/data:/data:ro": "/data:/data:ro",
+	}
+	for in, want := range tests {
+		if got := shellQuote(in); got != want {
+			t.Errorf("shellQuote(%q) = %s, want %s", in, got, want)
+		}
+	}
+}
+
+func shellExport(t *testing.T) string {
+	t.Helper()
+	projectCfg, _ := cfgtypes.LoadProjectConfigFile()
+	globalCfg, _ := cfgtypes.LoadGlobalConfigFile()
+	return string(renderShellExport(projectCfg, globalCfg))
+}
@@ -0,0 +1,140 @@
+package config
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	cfgtypes "github.com/jedi4ever/addt/config"
+)
+
+func TestRenderExport_Sources(t *testing.T) {
+	_, _, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	setGlobal("container.cpus", "3")
+	setProject("container.memory", "6g")
+	t.Setenv("ADDT_FIREWALL_MODE", "off")
+
+	data := exportEffective(t, false)
+	for _, want := range []string{
+		`cpus: "3" # global`,
+		"memory: 6g # project",
+		"mode: \"off\" # env",
+		"pids_limit: 200 # default",
+	} {
+		if !strings.Contains(data, want) {
+			t.Errorf("export missing %q:\n%s", want, data)
+		}
+	}
+}
+
+func TestRenderExport_RedactsSecrets(t *testing.T) {
+	_, _, cleanup := setupTestEnv(t)
+	defer cleanup()
+	t.Setenv("ADDT_OTEL_HEADERS", "Authorization=Bearer s3cret")
+
+	if data := exportEffective(t, false); strings.Contains(data, "s3cret") || !strings.Contains(data, `headers: "" # env, redacted`) {
+		t.Errorf("otel.headers not redacted:\n%s", data)
+	}
+	if data := exportEffective(t, true); !strings.Contains(data, "headers: Authorization=Bearer s3cret # env") {
+		t.Errorf("--show-secrets should keep otel.headers:\n%s", data)
+	}
+}
+
+func TestRenderExport_RoundTrip(t *testing.T) {
+	_, _, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	setGlobal("container.cpus", "3")
+	setGlobal("env_vars", "OPENAI_API_KEY,GH_TOKEN")
+	setProject("ports.expose", "3000,8080")
+	setProject("persistent", "true")
+	t.Setenv("ADDT_FIREWALL_MODE", "permissive")
+
+	want := effectiveValues(t)
+	data := exportEffective(t, true)
+
+	// Feed the export back as the only config
+	os.Remove(cfgtypes.GetProjectConfigPath())
+	t.Setenv("ADDT_FIREWALL_MODE", "")
+	if err := os.WriteFile(cfgtypes.GetGlobalConfigPath(), []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got := effectiveValues(t)
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("%s = %q after round-trip, want %q", key, got[key], value)
+		}
+	}
+}
+
+func TestRenderExport_RoundTripExtensionsAndFirewall(t *testing.T) {
+	_, _, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	enabled, disabled := true, false
+	globalCfg := &cfgtypes.GlobalConfig{
+		Firewall: &cfgtypes.FirewallSettings{Allowed: []string{"api.example.com", "cdn.example.com"}, Denied: []string{"ads.example.com"}},
+		Extensions: map[string]*cfgtypes.ExtensionSettings{
+			"claude": {Version: "1.0.0", FirewallAllowed: []string{"claude.ai"}, Config: &cfgtypes.ConfigSettings{Automount: &enabled}},
+		},
+	}
+	projectCfg := &cfgtypes.GlobalConfig{
+		Firewall: &cfgtypes.FirewallSettings{Allowed: []string{"ads.example.com"}, Denied: []string{"cdn.example.com"}},
+		Extensions: map[string]*cfgtypes.ExtensionSettings{
+			"claude": {Version: "2.0.0", Config: &cfgtypes.ConfigSettings{Readonly: &disabled}},
+			"codex":  {Version: "0.5.0"},
+		},
+	}
+	data, err := renderExport(projectCfg, globalCfg, true)
+	if err != nil {
+		t.Fatalf("renderExport() error = %v", err)
+	}
+
+	// Load the export back as the only config
+	if err := os.WriteFile(cfgtypes.GetGlobalConfigPath(), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := cfgtypes.LoadGlobalConfigFile()
+	if err != nil {
+		t.Fatalf("loading export: %v\n%s", err, data)
+	}
+	if want := []string{"ads.example.com", "api.example.com"}; !reflect.DeepEqual(got.Firewall.Allowed, want) {
+		t.Errorf("firewall.allowed = %v, want %v", got.Firewall.Allowed, want)
+	}
+	if want := []string{"cdn.example.com"}; !reflect.DeepEqual(got.Firewall.Denied, want) {
+		t.Errorf("firewall.denied = %v, want %v", got.Firewall.Denied, want)
+	}
+	claude := got.Extensions["claude"]
+	if claude == nil || claude.Version != "2.0.0" || !reflect.DeepEqual(claude.FirewallAllowed, []string{"claude.ai"}) {
+		t.Fatalf("extensions.claude = %+v, want version 2.0.0 and the global firewall_allowed", claude)
+	}
+	if claude.Config == nil || claude.Config.Automount == nil || !*claude.Config.Automount || claude.Config.Readonly == nil || *claude.Config.Readonly {
+		t.Errorf("extensions.claude.config = %+v, want automount from global and readonly from project", claude.Config)
+	}
+	if codex := got.Extensions["codex"]; codex == nil || codex.Version != "0.5.0" {
+		t.Errorf("extensions.codex = %+v, want version 0.5.0", codex)
+	}
+}
+
+func exportEffective(t *testing.T, showSecrets bool) string {
+	t.Helper()
+	projectCfg, _ := cfgtypes.LoadProjectConfigFile()
+	globalCfg, _ := cfgtypes.LoadGlobalConfigFile()
+	data, err := renderExport(projectCfg, globalCfg, showSecrets)
+	if err != nil {
+		t.Fatalf("renderExport() error = %v", err)
+	}
+	return string(data)
+}
+
+func effectiveValues(t *testing.T) map[string]string {
+	t.Helper()
+	values := make(map[string]string)
+	for _, k := range GetKeys() {
+		values[k.Key], _ = EffectiveValue(k.Key)
+	}
+	return values
+}
@@ -0,0 +1,153 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	cfgtypes "github.com/jedi4ever/addt/config"
+	"github.com/jedi4ever/addt/config/security"
+)
+
+// extensionFirewall handles "addt config extension <name> firewall <command>".
+// Extension firewall rules are read from the global config only, so they
+// are always saved there.
+func extensionFirewall(extName string, args []string) {
+	if len(args) == 0 {
+		printExtensionFirewallHelp(extName)
+		return
+	}
+
+	switch args[0] {
+	case "add-allowed", "add-denied":
+		if len(args) < 2 {
+			fmt.Printf("Usage: addt config extension %s firewall %s <host>\n", extName, args[0])
+			os.Exit(1)
+		}
+		host := strings.TrimSpace(args[1])
+		if err := security.ValidateFirewallHost(host); err != nil {
+			fmt.Printf("Invalid host: %v\n", err)
+			os.Exit(1)
+		}
+		list := "allowed"
+		if args[0] == "add-denied" {
+			list = "denied"
+		}
+		added := false
+		err := cfgtypes.UpdateGlobalConfigFile(func(cfg *cfgtypes.GlobalConfig) error {
+			added = addExtensionFirewallHost(cfg, extName, host, list == "denied")
+			return nil
+		})
+		if err != nil {
+			fmt.Printf("Error saving global config: %v\n", err)
+			os.Exit(1)
+		}
+		if !added {
+			fmt.Printf("'%s' already in %s %s hosts\n", host, extName, list)
+			return
+		}
+		fmt.Printf("Added '%s' to %s %s hosts (global)\n", host, extName, list)
+	case "remove", "rm":
+		if len(args) < 2 {
+			fmt.Printf("Usage: addt config extension %s firewall remove <host>\n", extName)
+			os.Exit(1)
+		}
+		host := strings.TrimSpace(args[1])
+		removed := false
+		err := cfgtypes.UpdateGlobalConfigFile(func(cfg *cfgtypes.GlobalConfig) error {
+			removed = removeExtensionFirewallHost(cfg, extName, host)
+			return nil
+		})
+		if err != nil {
+			fmt.Printf("Error saving global config: %v\n", err)
+			os.Exit(1)
+		}
+		if !removed {
+			fmt.Printf("'%s' not found in %s firewall rules\n", host, extName)
+			return
+		}
+		fmt.Printf("Removed '%s' from %s firewall rules (global)\n", host, extName)
+	case "list", "ls":
+		cfg, err := cfgtypes.LoadGlobalConfigFile()
+		if err != nil {
+			fmt.Printf("Error loading global config: %v\n", err)
+			os.Exit(1)
+		}
+		var ext cfgtypes.ExtensionSettings
+		if cfg.Extensions != nil && cfg.Extensions[extName] != nil {
+			ext = *cfg.Extensions[extName]
+		}
+		fmt.Printf("Extension '%s' firewall rules (global):\n", extName)
+		printHostList("Allowed", ext.FirewallAllowed)
+		printHostList("Denied", ext.FirewallDenied)
+	default:
+		fmt.Printf("Unknown extension firewall command: %s\n", args[0])
+		printExtensionFirewallHelp(extName)
+		os.Exit(1)
+	}
+}
+
+// addExtensionFirewallHost adds host to the extension's allowed or denied
+// list, returning false when it is already there
+func addExtensionFirewallHost(cfg *cfgtypes.GlobalConfig, extName, host string, deny bool) bool {
+	if cfg.Extensions == nil {
+		cfg.Extensions = make(map[string]*cfgtypes.ExtensionSettings)
+	}
+	if cfg.Extensions[extName] == nil {
+		cfg.Extensions[extName] = &cfgtypes.ExtensionSettings{}
+	}
+	list := &cfg.Extensions[extName].FirewallAllowed
+	if deny {
+		list = &cfg.Extensions[extName].FirewallDenied
+	}
+	if slices.Contains(*list, host) {
+		return false
+	}
+	*list = append(*list, host)
+	return true
+}
+
+// removeExtensionFirewallHost drops host from both of the extension's
+// lists, returning false when neither had it
+func removeExtensionFirewallHost(cfg *cfgtypes.GlobalConfig, extName, host string) bool {
+	ext := cfg.Extensions[extName]
+	if ext == nil {
+		return false
+	}
+	removed := slices.Contains(ext.FirewallAllowed, host) || slices.Contains(ext.FirewallDenied, host)
+	ext.FirewallAllowed = slices.DeleteFunc(ext.FirewallAllowed, func(h string) bool { return h == host })
+	ext.FirewallDenied = slices.DeleteFunc(ext.FirewallDenied, func(h string) bool { return h == host })
+	if isExtensionSettingsEmpty(ext) {
+		delete(cfg.Extensions, extName)
+	}
+	if len(cfg.Extensions) == 0 {
+		cfg.Extensions = nil
+	}
+	return removed
+}
+
+// printHostList prints a labelled list of firewall hosts
+func printHostList(label string, hosts []string) {
+	if len(hosts) == 0 {
+		fmt.Printf("  %s: (none)\n", label)
+		return
+	}
+	fmt.Printf("  %s:\n", label)
+	for _, h := range hosts {
+		fmt.Printf("    - %s\n", h)
+	}
+}
+
+func printExtensionFirewallHelp(extName string) {
+	fmt.Printf("Usage: addt config extension %s firewall <command>\n", extName)
+	fmt.Println()
+	fmt.Println("Manage the extension's firewall rules. They apply when the extension runs")
+	fmt.Println("and are stored in the global config (~/.addt/config.yaml).")
+	fmt.Println()
+	fmt.Println("Commands:")
+	fmt.Println("  add-allowed <host>   Allow a domain, IP address or CIDR")
+	fmt.Println("  add-denied <host>    Deny a domain, IP address or CIDR")
+	fmt.Println("  remove <host>        Remove a host from both lists")
+	fmt.Println("  list                 Show the extension's rules")
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"slices"
+	"testing"
+
+	cfgtypes "github.com/jedi4ever/addt/config"
+)
+
+func TestExtensionFirewallHosts(t *testing.T) {
+	_, _, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	err := cfgtypes.UpdateGlobalConfigFile(func(cfg *cfgtypes.GlobalConfig) error {
+		if !addExtensionFirewallHost(cfg, "claude", "api.anthropic.com", false) {
+			t.Error("first add-allowed reported a duplicate")
+		}
+		if addExtensionFirewallHost(cfg, "claude", "api.anthropic.com", false) {
+			t.Error("second add-allowed should report a duplicate")
+		}
+		addExtensionFirewallHost(cfg, "claude", "10.0.0.0/8", true)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("UpdateGlobalConfigFile() error = %v", err)
+	}
+
+	cfg, err := cfgtypes.LoadGlobalConfigFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ext := cfg.Extensions["claude"]
+	if ext == nil || !slices.Equal(ext.FirewallAllowed, []string{"api.anthropic.com"}) || !slices.Equal(ext.FirewallDenied, []string{"10.0.0.0/8"}) {
+		t.Fatalf("claude settings = %+v", ext)
+	}
+
+	if removeExtensionFirewallHost(cfg, "claude", "example.com") {
+		t.Error("removing an unknown host reported success")
+	}
+	removeExtensionFirewallHost(cfg, "claude", "api.anthropic.com")
+	removeExtensionFirewallHost(cfg, "claude", "10.0.0.0/8")
+	if cfg.Extensions != nil {
+		t.Errorf("empty extension settings should be dropped, got %+v", cfg.Extensions)
+	}
+}
@@ -87,3 +87,75 @@ func TestExtensionSettingsInProjectConfig(t *testing.T) {
 		t.Errorf("claude.Config.Automount = %v, want false", claudeCfg.Config)
 	}
 }
+
+func TestAuthKeys_GlobalRoundTrip(t *testing.T) {
+	_, _, cleanup := setupTestEnv(t)
+	defer cleanup()
+	t.Setenv("ADDT_AUTH_METHOD", "")
+	t.Setenv("ADDT_AUTH_AUTOLOGIN", "")
+
+	cfg := &cfgtypes.GlobalConfig{}
+	SetValue(cfg, "auth.method", "native")
+	SetValue(cfg, "auth.autologin", "off")
+	if got := GetValue(cfg, "auth.method"); got != "native" {
+		t.Errorf("GetValue(auth.method) = %q, want native", got)
+	}
+	if got := GetValue(cfg, "auth.autologin"); got != "false" {
+		t.Errorf("GetValue(auth.autologin) = %q, want false", got)
+	}
+	if err := cfgtypes.SaveProjectConfigFile(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := cfgtypes.LoadConfig("0.0.0-test", "22", "1.23.5", "0.4.17", 30000)
+	if loaded.AuthMethod != "native" || loaded.AuthAutologin {
+		t.Errorf("LoadConfig() AuthMethod = %q, AuthAutologin = %v, want native, false", loaded.AuthMethod, loaded.AuthAutologin)
+	}
+
+	UnsetValue(cfg, "auth.method")
+	UnsetValue(cfg, "auth.autologin")
+	if cfg.Auth != nil && (cfg.Auth.Method != "" || cfg.Auth.Autologin != nil) {
+		t.Errorf("UnsetValue should clear both auth keys, got %+v", cfg.Auth)
+	}
+}
+
+func TestExtensionKeys_RoundTrip(t *testing.T) {
+	_, _, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	for _, key := range []string{"config.readonly", "workdir.autotrust", "auth.autologin", "auth.method"} {
+		if !IsValidExtensionKey(key, "claude") {
+			t.Errorf("%s should be a valid extension key", key)
+		}
+	}
+
+	setExtension("claude", "config.readonly", "yes", true)
+	setExtension("claude", "workdir.autotrust", "false", true)
+	setExtension("claude", "auth.autologin", "false", false)
+	setExtension("claude", "auth.method", "env", false)
+
+	cfg := cfgtypes.LoadConfig("0.0.0-test", "22", "1.23.5", "0.4.17", 30000)
+	if !cfg.ExtensionConfigReadonly["claude"] {
+		t.Error("config.readonly should resolve to true from the global config")
+	}
+	if v, ok := cfg.ExtensionWorkdirAutotrust["claude"]; !ok || v {
+		t.Errorf("workdir.autotrust = %v (set %v), want false", v, ok)
+	}
+	if v, ok := cfg.ExtensionAuthAutologin["claude"]; !ok || v {
+		t.Errorf("auth.autologin = %v (set %v), want false from the project config", v, ok)
+	}
+	if cfg.ExtensionAuthMethod["claude"] != "env" {
+		t.Errorf("auth.method = %q, want env", cfg.ExtensionAuthMethod["claude"])
+	}
+
+	unsetExtension("claude", "config.readonly", true)
+	unsetExtension("claude", "workdir.autotrust", true)
+	unsetExtension("claude", "auth.autologin", false)
+	unsetExtension("claude", "auth.method", false)
+
+	global, _ := cfgtypes.LoadGlobalConfigFile()
+	project, _ := cfgtypes.LoadProjectConfigFile()
+	if global.Extensions != nil || project.Extensions != nil {
+		t.Errorf("unset should remove the extension entries, got global=%v project=%v", global.Extensions, project.Extensions)
+	}
+}
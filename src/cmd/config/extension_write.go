@@ -0,0 +1,216 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	cfgtypes "github.com/jedi4ever/addt/config"
+)
+
+// errExtensionNotSet aborts an unset when the extension has no settings,
+// leaving the config file untouched
+var errExtensionNotSet = errors.New("extension not set")
+
+func setExtension(extName, key, value string, useGlobal bool) {
+	if !IsValidExtensionKey(key, extName) {
+		fmt.Printf("Unknown extension config key: %s\n", key)
+		fmt.Printf("Available keys: %s\n", AvailableExtensionKeyNames(extName))
+		os.Exit(1)
+	}
+
+	// Validate bool values for automount, workdir.autotrust, auth.autologin, and flag keys
+	if key == "config.automount" || key == "config.readonly" || key == "workdir.autotrust" || key == "auth.autologin" || IsFlagKey(key, extName) {
+		normalized, err := normalizeBool(value)
+		if err != nil {
+			fmt.Printf("Invalid value for %s: %v\n", key, err)
+			os.Exit(1)
+		}
+		value = normalized
+	}
+	if key == "auth.method" {
+		if err := validateAuthMethod(value); err != nil {
+			fmt.Printf("Invalid value for %s: %v\n", key, err)
+			os.Exit(1)
+		}
+	}
+
+	scope := "project"
+	if useGlobal {
+		scope = "global"
+	}
+	err := updateScopedConfig(useGlobal, func(cfg *cfgtypes.GlobalConfig) error {
+		applyExtensionSet(cfg, extName, key, value)
+		return nil
+	})
+	if err != nil {
+		fmt.Printf("Error saving %s config: %v\n", scope, err)
+		os.Exit(1)
+	}
+	fmt.Printf("Set %s.%s = %s (%s)\n", extName, key, value, scope)
+}
+
+// applyExtensionSet sets an extension key in cfg
+func applyExtensionSet(cfg *cfgtypes.GlobalConfig, extName, key, value string) {
+	// Initialize extensions map if needed
+	if cfg.Extensions == nil {
+		cfg.Extensions = make(map[string]*cfgtypes.ExtensionSettings)
+	}
+
+	// Initialize extension config if needed
+	if cfg.Extensions[extName] == nil {
+		cfg.Extensions[extName] = &cfgtypes.ExtensionSettings{}
+	}
+
+	extCfg := cfg.Extensions[extName]
+	switch key {
+	case "version":
+		extCfg.Version = value
+	case "config.automount":
+		if extCfg.Config == nil {
+			extCfg.Config = &cfgtypes.ConfigSettings{}
+		}
+		b := value == "true"
+		extCfg.Config.Automount = &b
+	case "config.readonly":
+		if extCfg.Config == nil {
+			extCfg.Config = &cfgtypes.ConfigSettings{}
+		}
+		b := value == "true"
+		extCfg.Config.Readonly = &b
+	case "workdir.autotrust":
+		if extCfg.Workdir == nil {
+			extCfg.Workdir = &cfgtypes.ExtensionWorkdirSettings{}
+		}
+		b := value == "true"
+		extCfg.Workdir.Autotrust = &b
+	case "auth.autologin":
+		if extCfg.Auth == nil {
+			extCfg.Auth = &cfgtypes.AuthSettings{}
+		}
+		b := value == "true"
+		extCfg.Auth.Autologin = &b
+	case "auth.method":
+		if extCfg.Auth == nil {
+			extCfg.Auth = &cfgtypes.AuthSettings{}
+		}
+		extCfg.Auth.Method = value
+	default:
+		// Handle flag keys
+		if IsFlagKey(key, extName) {
+			if extCfg.Flags == nil {
+				extCfg.Flags = make(map[string]*bool)
+			}
+			b := value == "true"
+			extCfg.Flags[key] = &b
+		}
+	}
+}
+
+func unsetExtension(extName, key string, useGlobal bool) {
+	if !IsValidExtensionKey(key, extName) {
+		fmt.Printf("Unknown extension config key: %s\n", key)
+		fmt.Printf("Available keys: %s\n", AvailableExtensionKeyNames(extName))
+		os.Exit(1)
+	}
+
+	scope := "project"
+	if useGlobal {
+		scope = "global"
+	}
+
+	err := updateScopedConfig(useGlobal, func(cfg *cfgtypes.GlobalConfig) error {
+		if !applyExtensionUnset(cfg, extName, key) {
+			return errExtensionNotSet
+		}
+		return nil
+	})
+	if errors.Is(err, errExtensionNotSet) {
+		fmt.Printf("%s.%s is not set in %s config\n", extName, key, scope)
+		return
+	}
+	if err != nil {
+		fmt.Printf("Error saving %s config: %v\n", scope, err)
+		os.Exit(1)
+	}
+	fmt.Printf("Unset %s.%s (%s)\n", extName, key, scope)
+}
+
+// applyExtensionUnset clears an extension key in cfg, dropping settings left
+// empty. Returns false when the extension has no settings in cfg.
+func applyExtensionUnset(cfg *cfgtypes.GlobalConfig, extName, key string) bool {
+	if cfg.Extensions == nil || cfg.Extensions[extName] == nil {
+		return false
+	}
+
+	extCfg := cfg.Extensions[extName]
+	switch key {
+	case "version":
+		extCfg.Version = ""
+	case "config.automount":
+		if extCfg.Config != nil {
+			extCfg.Config.Automount = nil
+		}
+	case "config.readonly":
+		if extCfg.Config != nil {
+			extCfg.Config.Readonly = nil
+		}
+	case "workdir.autotrust":
+		if extCfg.Workdir != nil {
+			extCfg.Workdir.Autotrust = nil
+		}
+	case "auth.autologin":
+		if extCfg.Auth != nil {
+			extCfg.Auth.Autologin = nil
+		}
+	case "auth.method":
+		if extCfg.Auth != nil {
+			extCfg.Auth.Method = ""
+		}
+	default:
+		// Handle flag keys
+		if IsFlagKey(key, extName) && extCfg.Flags != nil {
+			delete(extCfg.Flags, key)
+			if len(extCfg.Flags) == 0 {
+				extCfg.Flags = nil
+			}
+		}
+	}
+
+	// Clean up empty extension config
+	if isExtensionSettingsEmpty(extCfg) {
+		delete(cfg.Extensions, extName)
+	}
+
+	// Clean up empty extensions map
+	if len(cfg.Extensions) == 0 {
+		cfg.Extensions = nil
+	}
+	return true
+}
+
+// updateScopedConfig applies fn to the global or project config file under
+// the config file lock
+func updateScopedConfig(useGlobal bool, fn func(cfg *cfgtypes.GlobalConfig) error) error {
+	if useGlobal {
+		return cfgtypes.UpdateGlobalConfigFile(fn)
+	}
+	return cfgtypes.UpdateProjectConfigFile(fn)
+}
+
+// isExtensionSettingsEmpty returns true if all fields are zero/nil
+func isExtensionSettingsEmpty(e *cfgtypes.ExtensionSettings) bool {
+	if e.Version != "" || len(e.Flags) > 0 || len(e.FirewallAllowed) > 0 || len(e.FirewallDenied) > 0 {
+		return false
+	}
+	if e.Config != nil && (e.Config.Automount != nil || e.Config.Readonly != nil) {
+		return false
+	}
+	if e.Workdir != nil && e.Workdir.Autotrust != nil {
+		return false
+	}
+	if e.Auth != nil && (e.Auth.Autologin != nil || e.Auth.Method != "") {
+		return false
+	}
+	return true
+}
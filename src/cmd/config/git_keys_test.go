@@ -0,0 +1,75 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	cfgtypes "github.com/jedi4ever/addt/config"
+	"github.com/jedi4ever/addt/provider"
+)
+
+func TestGitKeys_RoundTrip(t *testing.T) {
+	_, projectDir, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	gitconfig := filepath.Join(projectDir, "team.gitconfig")
+	if err := os.WriteFile(gitconfig, []byte("[user]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	setGlobal("git.forward_config", "false")
+	setProject("git.forward_config", "true")
+	setProject("git.config_path", gitconfig)
+	setGlobal("git.disable_hooks", "false")
+
+	global, _ := cfgtypes.LoadGlobalConfigFile()
+	project, _ := cfgtypes.LoadProjectConfigFile()
+	if got := GetValue(global, "git.forward_config"); got != "false" {
+		t.Errorf("global git.forward_config = %q, want false", got)
+	}
+	if got := GetValue(project, "git.config_path"); got != gitconfig {
+		t.Errorf("project git.config_path = %q, want %q", got, gitconfig)
+	}
+
+	cfg := cfgtypes.LoadConfig("0.0.0-test", "22", "1.23.5", "0.4.17", 30000)
+	if !cfg.GitForwardConfig || cfg.GitConfigPath != gitconfig || cfg.GitDisableHooks {
+		t.Fatalf("resolved git settings = forward:%v path:%q disable_hooks:%v", cfg.GitForwardConfig, cfg.GitConfigPath, cfg.GitDisableHooks)
+	}
+
+	// The resolved settings drive the .gitconfig mount
+	provCfg := &provider.Config{GitForwardConfig: cfg.GitForwardConfig, GitConfigPath: cfg.GitConfigPath, GitConfigReadonly: cfg.GitConfigReadonly}
+	if args := provider.GitconfigMountArgs(provCfg, t.TempDir(), "addt"); len(args) != 2 || args[1] != gitconfig+":/home/addt/.gitconfig.host:ro" {
+		t.Errorf("GitconfigMountArgs() = %v, want mount of %s", args, gitconfig)
+	}
+
+	unsetProject("git.forward_config")
+	unsetProject("git.config_path")
+	cfg = cfgtypes.LoadConfig("0.0.0-test", "22", "1.23.5", "0.4.17", 30000)
+	if cfg.GitForwardConfig {
+		t.Error("after unsetting the project override, the global git.forward_config=false should apply")
+	}
+}
+
+func TestGitKeys_ReadonlyAndCopy(t *testing.T) {
+	_, _, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	cfg := cfgtypes.LoadConfig("0.0.0-test", "22", "1.23.5", "0.4.17", 30000)
+	if !cfg.GitConfigReadonly || cfg.GitConfigCopy {
+		t.Fatalf("defaults = readonly:%v copy:%v, want readonly:true copy:false", cfg.GitConfigReadonly, cfg.GitConfigCopy)
+	}
+
+	setGlobal("git.config_readonly", "false")
+	setProject("git.config_copy", "true")
+	cfg = cfgtypes.LoadConfig("0.0.0-test", "22", "1.23.5", "0.4.17", 30000)
+	if cfg.GitConfigReadonly || !cfg.GitConfigCopy {
+		t.Errorf("resolved = readonly:%v copy:%v, want readonly:false copy:true", cfg.GitConfigReadonly, cfg.GitConfigCopy)
+	}
+
+	t.Setenv("ADDT_GIT_CONFIG_COPY", "false")
+	cfg = cfgtypes.LoadConfig("0.0.0-test", "22", "1.23.5", "0.4.17", 30000)
+	if cfg.GitConfigCopy {
+		t.Error("ADDT_GIT_CONFIG_COPY=false should override the project setting")
+	}
+}
@@ -3,30 +3,10 @@ package config
 import (
 	"fmt"
 	"os"
-	"strings"
 
 	cfgtypes "github.com/jedi4ever/addt/config"
 )
 
-func listGlobal(verbose bool) {
-	globalCfg, err := cfgtypes.LoadGlobalConfigFile()
-	if err != nil {
-		fmt.Printf("Error loading global config: %v\n", err)
-		os.Exit(1)
-	}
-
-	projectCfg, err := cfgtypes.LoadProjectConfigFile()
-	if err != nil {
-		fmt.Printf("Error loading project config: %v\n", err)
-		os.Exit(1)
-	}
-
-	fmt.Printf("Global config:  %s\n", cfgtypes.GetGlobalConfigPath())
-	fmt.Printf("Project config: %s\n\n", cfgtypes.GetProjectConfigPath())
-
-	printConfigTable(projectCfg, globalCfg, verbose)
-}
-
 func getGlobal(key string) {
 	// Validate key
 	if !IsValidKey(key) {
@@ -43,7 +23,7 @@ func getGlobal(key string) {
 
 	val := GetValue(cfg, key)
 	if val == "" {
-		fmt.Printf("%s is not set\n", key)
+		fmt.Printf("%s is not set in global config\n", key)
 	} else {
 		fmt.Println(val)
 	}
@@ -59,28 +39,23 @@ func setGlobal(key, value string) {
 	}
 
 	// Validate value based on type
-	if keyInfo.Type == "bool" {
-		value = strings.ToLower(value)
-		if value != "true" && value != "false" {
-			fmt.Printf("Invalid value for %s: must be 'true' or 'false'\n", key)
-			os.Exit(1)
-		}
-	}
-
-	cfg, err := cfgtypes.LoadGlobalConfigFile()
+	normalized, err := normalizeScopedValue(keyInfo, value, true)
 	if err != nil {
-		fmt.Printf("Error loading config: %v\n", err)
+		fmt.Printf("Invalid value for %s: %v\n", key, err)
 		os.Exit(1)
 	}
+	value = normalized
 
-	SetValue(cfg, key, value)
-
-	if err := cfgtypes.SaveGlobalConfigFile(cfg); err != nil {
+	err = cfgtypes.UpdateGlobalConfigFile(func(cfg *cfgtypes.GlobalConfig) error {
+		return SetValue(cfg, key, value)
+	})
+	if err != nil {
 		fmt.Printf("Error saving config: %v\n", err)
 		os.Exit(1)
 	}
 
 	fmt.Printf("Set %s = %s\n", key, value)
+	printSetNote(key, value)
 }
 
 func unsetGlobal(key string) {
@@ -91,15 +66,11 @@ func unsetGlobal(key string) {
 		os.Exit(1)
 	}
 
-	cfg, err := cfgtypes.LoadGlobalConfigFile()
+	err := cfgtypes.UpdateGlobalConfigFile(func(cfg *cfgtypes.GlobalConfig) error {
+		UnsetValue(cfg, key)
+		return nil
+	})
 	if err != nil {
-		fmt.Printf("Error loading config: %v\n", err)
-		os.Exit(1)
-	}
-
-	UnsetValue(cfg, key)
-
-	if err := cfgtypes.SaveGlobalConfigFile(cfg); err != nil {
 		fmt.Printf("Error saving config: %v\n", err)
 		os.Exit(1)
 	}
@@ -3,6 +3,8 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 
 	cfgtypes "github.com/jedi4ever/addt/config"
@@ -108,3 +110,90 @@ func TestSaveAndLoadGlobalConfig(t *testing.T) {
 		t.Errorf("Container.Memory = %q, want %q", loaded.Container.Memory, "4g")
 	}
 }
+
+func TestSetGlobal_PreservesUnknownKeys(t *testing.T) {
+	globalDir, _, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	configPath := filepath.Join(globalDir, "config.yaml")
+	os.WriteFile(configPath, []byte("from_newer_addt:\n  mode: fancy\n"), 0644)
+
+	setGlobal("persistent", "true")
+
+	data, _ := os.ReadFile(configPath)
+	for _, want := range []string{"persistent: true", "from_newer_addt:", "mode: fancy"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("config after set missing %q:\n%s", want, data)
+		}
+	}
+}
+
+func TestSetConfig_ConcurrentWritesSurvive(t *testing.T) {
+	_, _, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	settings := map[string]string{
+		"node_version":     "22",
+		"go_version":       "1.24.0",
+		"uv_version":       "0.5.0",
+		"container.cpus":   "2",
+		"container.memory": "4g",
+		"persistent":       "true",
+		"history_persist":  "true",
+		"tmux_forward":     "true",
+	}
+
+	for _, scope := range []struct {
+		name string
+		set  func(key, value string)
+		load func() (*cfgtypes.GlobalConfig, error)
+		path func() string
+	}{
+		{"global", setGlobal, cfgtypes.LoadGlobalConfigFile, cfgtypes.GetGlobalConfigPath},
+		{"project", setProject, cfgtypes.LoadProjectConfigFile, cfgtypes.GetProjectConfigPath},
+	} {
+		t.Run(scope.name, func(t *testing.T) {
+			// Several rounds, as an unlocked load/modify/save only loses
+			// a write when the setters interleave
+			for round := 0; round < 10; round++ {
+				os.Remove(scope.path())
+
+				var wg sync.WaitGroup
+				for key, value := range settings {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						scope.set(key, value)
+					}()
+				}
+				wg.Wait()
+
+				cfg, err := scope.load()
+				if err != nil {
+					t.Fatalf("load: %v", err)
+				}
+				for key, want := range settings {
+					if got := GetValue(cfg, key); got != want {
+						t.Fatalf("round %d: %s = %q after concurrent sets, want %q", round, key, got, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSetGlobal_KeepsCommentsAndDescribesNewKey(t *testing.T) {
+	globalDir, _, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	configPath := filepath.Join(globalDir, "config.yaml")
+	os.WriteFile(configPath, []byte("# pinned for the team\nnode_version: \"20\"\n"), 0644)
+
+	setGlobal("persistent", "true")
+
+	data, _ := os.ReadFile(configPath)
+	want := "# pinned for the team\nnode_version: \"20\"\n# " + GetKeyInfo("persistent").Description + "\npersistent: true\n"
+	if string(data) != want {
+		t.Errorf("config after set =\n%s\nwant\n%s", data, want)
+	}
+}
@@ -3,6 +3,8 @@ package config
 import (
 	"fmt"
 	"os"
+	"slices"
+	"strings"
 
 	cfgtypes "github.com/jedi4ever/addt/config"
 	"github.com/jedi4ever/addt/extensions"
@@ -22,6 +24,20 @@ func parseGlobalFlag(args []string) ([]string, bool) {
 	return filtered, useGlobal
 }
 
+// parseProjectFlag extracts the --project flag from args and returns filtered args
+func parseProjectFlag(args []string) ([]string, bool) {
+	useProject := false
+	var filtered []string
+	for _, arg := range args {
+		if arg == "--project" {
+			useProject = true
+		} else {
+			filtered = append(filtered, arg)
+		}
+	}
+	return filtered, useProject
+}
+
 // parseVerboseFlag extracts -v/--verbose flag from args and returns filtered args
 func parseVerboseFlag(args []string) ([]string, bool) {
 	verbose := false
@@ -45,33 +61,51 @@ func HandleCommand(args []string) {
 
 	// Parse -g/--global flag
 	args, useGlobal := parseGlobalFlag(args)
+	// Parse --project flag (read commands)
+	args, useProject := parseProjectFlag(args)
 	// Parse -v/--verbose flag
 	args, verbose := parseVerboseFlag(args)
 	if len(args) == 0 {
 		printHelp()
 		return
 	}
+	if useGlobal && useProject {
+		fmt.Println("Error: -g/--global and --project cannot be combined")
+		os.Exit(1)
+	}
 
 	switch args[0] {
 	case "list":
-		if useGlobal {
-			listGlobal(verbose)
-		} else {
-			listProject(verbose)
+		jsonOut := len(args) > 1 && args[1] == "--json"
+		switch {
+		case useGlobal:
+			listLayer("global", verbose, jsonOut)
+		case useProject:
+			listLayer("project", verbose, jsonOut)
+		default:
+			listEffective(verbose, jsonOut)
 		}
 	case "get":
 		if len(args) < 2 {
-			fmt.Println("Usage: addt config get <key> [-g]")
+			fmt.Println("Usage: addt config get <key> [-g|--project]")
 			os.Exit(1)
 		}
-		if useGlobal {
+		switch {
+		case useGlobal:
 			getGlobal(args[1])
-		} else {
+		case useProject:
 			getProject(args[1])
+		default:
+			getEffective(args[1])
 		}
 	case "set":
+		if len(args) >= 2 && strings.Contains(args[1], "=") {
+			setPairs(args[1:], useGlobal)
+			return
+		}
 		if len(args) < 3 {
 			fmt.Println("Usage: addt config set <key> <value> [-g]")
+			fmt.Println("       addt config set <key>=<value> [<key>=<value>...] [-g]")
 			os.Exit(1)
 		}
 		if useGlobal {
@@ -80,8 +114,13 @@ func HandleCommand(args []string) {
 			setProject(args[1], args[2])
 		}
 	case "unset":
+		if len(args) >= 2 && slices.Contains(args[1:], "--all") {
+			unsetAllCommand(args[1:], useGlobal)
+			return
+		}
 		if len(args) < 2 {
 			fmt.Println("Usage: addt config unset <key> [-g]")
+			fmt.Println("       addt config unset --all [--dry-run] [-g]")
 			os.Exit(1)
 		}
 		if useGlobal {
@@ -89,8 +128,18 @@ func HandleCommand(args []string) {
 		} else {
 			unsetProject(args[1])
 		}
+	case "add", "remove":
+		if len(args) < 3 {
+			fmt.Printf("Usage: addt config %s <key> <value> [-g]\n", args[0])
+			os.Exit(1)
+		}
+		editList(args[1], args[2], args[0] == "remove", useGlobal)
 	case "audit":
 		auditCommand()
+	case "export":
+		exportCommand(args[1:])
+	case "diff":
+		diffCommand(args[1:])
 	case "extension":
 		handleExtension(args[1:], useGlobal)
 	case "path":
@@ -167,6 +216,8 @@ func handleExtension(args []string, useGlobal bool) {
 			os.Exit(1)
 		}
 		unsetExtension(extName, args[2], useGlobal)
+	case "firewall":
+		extensionFirewall(extName, args[2:])
 	default:
 		fmt.Printf("Unknown extension config command: %s\n", args[1])
 		printExtensionHelp()
@@ -195,26 +246,44 @@ func printHelp() {
 	fmt.Println("Use -g or --global for global config (~/.addt/config.yaml).")
 	fmt.Println()
 	fmt.Println("Commands:")
-	fmt.Println("  list                                    List configuration values")
-	fmt.Println("  get <key>                               Get a configuration value")
+	fmt.Println("  list [--json]                           List effective values and their source")
+	fmt.Println("  get <key>                               Get the effective value and its source")
 	fmt.Println("  set <key> <value>                       Set a configuration value")
+	fmt.Println("  set <key>=<value> [<key>=<value>...]    Set several values at once")
 	fmt.Println("  unset <key>                             Remove a configuration value")
+	fmt.Println("  unset --all [--dry-run]                 Remove every value, keeping the file")
+	fmt.Println("  add <key> <value>                       Append an entry to a list value")
+	fmt.Println("  remove <key> <value>                    Remove an entry from a list value")
 	fmt.Println("  extension <name> list                   List extension config")
 	fmt.Println("  extension <name> get <key>              Get extension config value")
 	fmt.Println("  extension <name> set <key> <value>      Set extension config value")
 	fmt.Println("  extension <name> unset <key>            Remove extension config value")
+	fmt.Println("  extension <name> firewall <command>     Manage extension firewall rules")
 	fmt.Println("  audit                                   Security audit of effective config")
+	fmt.Println("  export [--out <file>] [--show-secrets]  Print the effective config as YAML")
+	fmt.Println("  export --shell                          Print the effective config as export lines")
+	fmt.Println("  diff [--all]                            Show keys the project sets differently from global")
 	fmt.Println("  path                                    Show config file paths")
 	fmt.Println()
 	fmt.Println("Flags:")
 	fmt.Println("  -g, --global    Use global config instead of project config")
+	fmt.Println("  --project       With list/get: read only the project config file")
 	fmt.Println("  -v, --verbose   Show descriptions for each config key")
 	fmt.Println()
 	fmt.Println("Examples:")
-	fmt.Println("  addt config list                                # project config")
-	fmt.Println("  addt config list -g                             # global config")
+	fmt.Println("  addt config list                                # effective config, with sources")
+	fmt.Println("  addt config list -g                             # keys set in global config")
+	fmt.Println("  addt config list --project                      # keys set in project config")
+	fmt.Println("  addt config get firewall.mode                   # effective value (source)")
+	fmt.Println("  addt config list --json                         # for scripts")
 	fmt.Println("  addt config set container.cpus 2")
 	fmt.Println("  addt config set firewall.enabled true -g")
+	fmt.Println("  addt config set firewall.enabled=true firewall.mode=strict container.cpus=4")
+	fmt.Println("  addt config add env_vars OPENAI_API_KEY         # forward another host var")
+	fmt.Println("  addt config export --out snapshot.yaml          # effective config, with sources")
+	fmt.Println("  eval \"$(addt config export --shell)\"           # effective config as env vars")
+	fmt.Println("  addt config diff                                # where project and global disagree")
+	fmt.Println("  addt config unset --all --dry-run               # what a project reset would clear")
 	fmt.Println()
 	fmt.Println("  addt config extension claude list               # list extension config")
 	fmt.Println("  addt config extension claude set version 1.0.5  # set extension version")
@@ -239,16 +308,19 @@ func printExtensionHelp() {
 	fmt.Println("  get <key>         Get a configuration value")
 	fmt.Println("  set <key> <value> Set a configuration value")
 	fmt.Println("  unset <key>       Remove a configuration value")
+	fmt.Println("  firewall add-allowed|add-denied|remove <host>, firewall list")
+	fmt.Println("                    Manage firewall rules (always global)")
 	fmt.Println()
 	fmt.Println("Flags:")
 	fmt.Println("  -g, --global      Use global config instead of project config")
 	fmt.Println()
 	fmt.Println("Available keys:")
-	fmt.Println("  version     Extension version (e.g., \"1.0.5\", \"latest\", \"stable\")")
-	fmt.Println("  config.automount   Auto-mount extension config directories (true/false)")
+	PrintExtensionKeys()
+	fmt.Println("  ...plus any flag the extension declares (e.g., yolo)")
 	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Println("  addt config extension claude list")
 	fmt.Println("  addt config extension claude set version 1.0.5")
 	fmt.Println("  addt config extension claude set version 1.0.5 -g")
+	fmt.Println("  addt config extension claude firewall add-allowed api.anthropic.com")
 }
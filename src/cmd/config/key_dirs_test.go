@@ -0,0 +1,57 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	cfgtypes "github.com/jedi4ever/addt/config"
+	"github.com/jedi4ever/addt/provider"
+)
+
+func TestKeyDirs_RoundTrip(t *testing.T) {
+	_, _, cleanup := setupTestEnv(t)
+	defer cleanup()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("ADDT_SSH_DIR", "")
+	t.Setenv("ADDT_GPG_DIR", "")
+
+	setGlobal("ssh.dir", "~/work/.ssh")
+	setProject("gpg.dir", "~/work/.gnupg")
+
+	global, _ := cfgtypes.LoadGlobalConfigFile()
+	project, _ := cfgtypes.LoadProjectConfigFile()
+	if got := GetValue(global, "ssh.dir"); got != "~/work/.ssh" {
+		t.Errorf("global ssh.dir = %q, want ~/work/.ssh", got)
+	}
+	if got := GetValue(project, "gpg.dir"); got != "~/work/.gnupg" {
+		t.Errorf("project gpg.dir = %q, want ~/work/.gnupg", got)
+	}
+
+	// ~ is expanded when the config is resolved
+	cfg := cfgtypes.LoadConfig("0.0.0-test", "22", "1.23.5", "0.4.17", 30000)
+	wantSSH, wantGPG := filepath.Join(home, "work", ".ssh"), filepath.Join(home, "work", ".gnupg")
+	if cfg.SSHDir != wantSSH || cfg.GPGDir != wantGPG {
+		t.Fatalf("resolved dirs = %q, %q; want %q, %q", cfg.SSHDir, cfg.GPGDir, wantSSH, wantGPG)
+	}
+
+	// Forwarding reads keys from the configured dirs, not ~/.ssh and ~/.gnupg
+	provCfg := &provider.Config{SSHDir: cfg.SSHDir, GPGDir: cfg.GPGDir}
+	if got := provider.SSHDir(provCfg, home); got != wantSSH {
+		t.Errorf("provider.SSHDir() = %q, want %q", got, wantSSH)
+	}
+	if got := provider.GPGDir(provCfg, home); got != wantGPG {
+		t.Errorf("provider.GPGDir() = %q, want %q", got, wantGPG)
+	}
+
+	unsetGlobal("ssh.dir")
+	unsetProject("gpg.dir")
+	cfg = cfgtypes.LoadConfig("0.0.0-test", "22", "1.23.5", "0.4.17", 30000)
+	provCfg = &provider.Config{SSHDir: cfg.SSHDir, GPGDir: cfg.GPGDir}
+	if got := provider.SSHDir(provCfg, home); got != filepath.Join(home, ".ssh") {
+		t.Errorf("after unset, provider.SSHDir() = %q, want ~/.ssh", got)
+	}
+	if got := provider.GPGDir(provCfg, home); got != filepath.Join(home, ".gnupg") {
+		t.Errorf("after unset, provider.GPGDir() = %q, want ~/.gnupg", got)
+	}
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"strings"
 
 	cfgtypes "github.com/jedi4ever/addt/config"
@@ -13,6 +14,8 @@ type KeyInfo struct {
 	Description string
 	Type        string // "bool", "string", "int"
 	EnvVar      string
+
+	AllowedValues []string // accepted values for enum keys; empty for free-form
 }
 
 // GetKeys returns all valid config keys with their metadata (sorted alphabetically)
@@ -40,9 +43,10 @@ func GetValue(cfg *cfgtypes.GlobalConfig, key string) string {
 	return reflectGetValue(cfg, key)
 }
 
-// SetValue sets a config value in the config struct
-func SetValue(cfg *cfgtypes.GlobalConfig, key, value string) {
-	reflectSetValue(cfg, key, value)
+// SetValue sets a config value in the config struct. It fails when value
+// doesn't parse as the key's type.
+func SetValue(cfg *cfgtypes.GlobalConfig, key, value string) error {
+	return reflectSetValue(cfg, key, value)
 }
 
 // UnsetValue clears a config value in the config struct
@@ -100,6 +104,20 @@ func AvailableExtensionKeyNames(extName string) string {
 	return strings.Join(names, ", ")
 }
 
+// PrintExtensionKeys prints the static extension keys with their descriptions
+func PrintExtensionKeys() {
+	keys := GetExtensionKeys()
+	width := 0
+	for _, k := range keys {
+		if len(k.Key) > width {
+			width = len(k.Key)
+		}
+	}
+	for _, k := range keys {
+		fmt.Printf("  %-*s  %s\n", width, k.Key, k.Description)
+	}
+}
+
 // IsValidExtensionKey checks if a key is a valid extension config key (static or dynamic flag)
 func IsValidExtensionKey(key string, extName string) bool {
 	for _, k := range GetAllExtensionKeys(extName) {
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	cfgtypes "github.com/jedi4ever/addt/config"
+)
+
+// loadLayer loads the config file of one layer, "global" or "project"
+func loadLayer(layer string) (*cfgtypes.GlobalConfig, string, error) {
+	if layer == "global" {
+		cfg, err := cfgtypes.LoadGlobalConfigFile()
+		return cfg, cfgtypes.GetGlobalConfigPath(), err
+	}
+	cfg, err := cfgtypes.LoadProjectConfigFile()
+	return cfg, cfgtypes.GetProjectConfigPath(), err
+}
+
+// buildLayerRows returns a row for every key set in one config file,
+// with the layer as its source
+func buildLayerRows(cfg *cfgtypes.GlobalConfig, layer string) []configRow {
+	var rows []configRow
+	for _, k := range GetKeys() {
+		value := GetValue(cfg, k.Key)
+		if value == "" {
+			continue
+		}
+		def := GetDefaultValue(k.Key)
+		if def == "" {
+			def = "-"
+		}
+		rows = append(rows, configRow{
+			Key:          k.Key,
+			Value:        value,
+			Default:      def,
+			Source:       layer,
+			IsOverridden: true,
+			Description:  k.Description,
+		})
+	}
+	return rows
+}
+
+// listLayer handles "addt config list -g|--project": only the keys set in
+// that config file
+func listLayer(layer string, verbose, jsonOut bool) {
+	cfg, path, err := loadLayer(layer)
+	if err != nil {
+		fmt.Printf("Error loading %s config: %v\n", layer, err)
+		os.Exit(1)
+	}
+	rows := buildLayerRows(cfg, layer)
+	if jsonOut {
+		if err := writeRowsJSON(os.Stdout, rows); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if layer == "global" {
+		fmt.Printf("Global config:  %s\n\n", path)
+	} else {
+		fmt.Printf("Project config: %s\n\n", path)
+	}
+	if len(rows) == 0 {
+		fmt.Printf("No keys set in %s config\n", layer)
+		return
+	}
+	printRows(rows, verbose)
+}
+
+// getEffective handles "addt config get <key>" without -g/--project: the
+// effective value and the layer it comes from
+func getEffective(key string) {
+	if !IsValidKey(key) {
+		fmt.Printf("Unknown config key: %s\n", key)
+		fmt.Println("Use 'addt config list' to see available keys.")
+		os.Exit(1)
+	}
+	writeEffective(os.Stdout, key)
+}
+
+// writeEffective writes "<value> (<source>)" for a key, or a not-set note
+func writeEffective(w io.Writer, key string) {
+	value, source := EffectiveValue(key)
+	if value == "" {
+		fmt.Fprintf(w, "%s is not set\n", key)
+		return
+	}
+	fmt.Fprintf(w, "%s (%s)\n", value, source)
+}
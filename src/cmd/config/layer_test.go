@@ -0,0 +1,70 @@
+package config
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestParseProjectFlag(t *testing.T) {
+	args, useProject := parseProjectFlag([]string{"get", "--project", "firewall.mode"})
+	if !useProject || !reflect.DeepEqual(args, []string{"get", "firewall.mode"}) {
+		t.Errorf("parseProjectFlag = %v, %v", args, useProject)
+	}
+	if _, useProject := parseProjectFlag([]string{"list", "-g"}); useProject {
+		t.Error("parseProjectFlag without --project reported true")
+	}
+}
+
+func TestBuildLayerRows(t *testing.T) {
+	_, _, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	setGlobal("container.cpus", "3")
+	setProject("container.memory", "6g")
+	t.Setenv("ADDT_CONTAINER_MEMORY", "8g")
+
+	for layer, want := range map[string]configRow{
+		"global":  {Key: "container.cpus", Value: "3", Source: "global"},
+		"project": {Key: "container.memory", Value: "6g", Source: "project"},
+	} {
+		cfg, _, err := loadLayer(layer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows := buildLayerRows(cfg, layer)
+		if len(rows) != 1 {
+			t.Fatalf("%s rows = %+v, want only %s", layer, rows, want.Key)
+		}
+		if got := rows[0]; got.Key != want.Key || got.Value != want.Value || got.Source != want.Source {
+			t.Errorf("%s row = %+v, want %+v", layer, got, want)
+		}
+	}
+}
+
+func TestWriteEffective(t *testing.T) {
+	_, _, cleanup := setupTestEnv(t)
+	defer cleanup()
+	t.Setenv("ADDT_CONTAINER_CPUS", "")
+	t.Setenv("ADDT_CONTAINER_MEMORY", "")
+	t.Setenv("ADDT_FIREWALL_MODE", "permissive")
+
+	setGlobal("container.cpus", "3")
+	setGlobal("container.memory", "2g")
+	setProject("container.memory", "6g")
+
+	tests := map[string]string{
+		"container.cpus":      "3 (global)\n",
+		"container.memory":    "6g (project)\n",
+		"firewall.mode":       "permissive (env)\n",
+		"security.pids_limit": "200 (default)\n",
+		"container.name":      "container.name is not set\n",
+	}
+	for key, want := range tests {
+		var buf bytes.Buffer
+		writeEffective(&buf, key)
+		if buf.String() != want {
+			t.Errorf("writeEffective(%s) = %q, want %q", key, buf.String(), want)
+		}
+	}
+}
@@ -1,8 +1,11 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"strconv"
 	"strings"
 
 	cfgtypes "github.com/jedi4ever/addt/config"
@@ -68,9 +71,93 @@ func printRows(rows []configRow, verbose bool) {
 	}
 }
 
+// listEffective handles "addt config list": every key with its effective
+// value and source
+func listEffective(verbose, jsonOut bool) {
+	projectCfg, err := cfgtypes.LoadProjectConfigFile()
+	if err != nil {
+		fmt.Printf("Error loading project config: %v\n", err)
+		os.Exit(1)
+	}
+
+	globalCfg, err := cfgtypes.LoadGlobalConfigFile()
+	if err != nil {
+		fmt.Printf("Error loading global config: %v\n", err)
+		os.Exit(1)
+	}
+
+	if jsonOut {
+		if err := writeConfigJSON(os.Stdout, projectCfg, globalCfg); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	fmt.Printf("Project config: %s\n", cfgtypes.GetProjectConfigPath())
+	fmt.Printf("Global config:  %s\n\n", cfgtypes.GetGlobalConfigPath())
+
+	printConfigTable(projectCfg, globalCfg, verbose)
+}
+
 // printConfigTable prints a formatted table of all config keys with their
 // effective values, defaults, and source (env > project > global > default).
 func printConfigTable(projectCfg, globalCfg *cfgtypes.GlobalConfig, verbose bool) {
+	printRows(buildConfigRows(projectCfg, globalCfg), verbose)
+}
+
+// configJSONRow is one key of "addt config list --json". Bool and int
+// values are typed; unset values are null.
+type configJSONRow struct {
+	Key     string `json:"key"`
+	Value   any    `json:"value"`
+	Source  string `json:"source"`
+	Default any    `json:"default"`
+}
+
+// writeConfigJSON writes the rows of printConfigTable as a JSON array
+func writeConfigJSON(w io.Writer, projectCfg, globalCfg *cfgtypes.GlobalConfig) error {
+	return writeRowsJSON(w, buildConfigRows(projectCfg, globalCfg))
+}
+
+// writeRowsJSON writes config rows as a JSON array of configJSONRow
+func writeRowsJSON(w io.Writer, rows []configRow) error {
+	out := make([]configJSONRow, 0, len(rows))
+	for _, r := range rows {
+		keyType := GetKeyInfo(r.Key).Type
+		out = append(out, configJSONRow{
+			Key:     r.Key,
+			Value:   typedValue(keyType, r.Value),
+			Source:  r.Source,
+			Default: typedValue(keyType, r.Default),
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// typedValue converts a table cell to its JSON value: "-" is null, bool
+// and int keys become booleans and numbers
+func typedValue(keyType, value string) any {
+	if value == "-" || value == "" {
+		return nil
+	}
+	switch keyType {
+	case "bool":
+		if b, err := parseBool(value); err == nil {
+			return b
+		}
+	case "int":
+		if i, err := strconv.Atoi(strings.TrimSpace(value)); err == nil {
+			return i
+		}
+	}
+	return value
+}
+
+// buildConfigRows resolves every config key to a table row
+func buildConfigRows(projectCfg, globalCfg *cfgtypes.GlobalConfig) []configRow {
 	keys := GetKeys()
 	rows := make([]configRow, 0, len(keys))
 
@@ -90,8 +177,7 @@ func printConfigTable(projectCfg, globalCfg *cfgtypes.GlobalConfig, verbose bool
 			Description:  k.Description,
 		})
 	}
-
-	printRows(rows, verbose)
+	return rows
 }
 
 // resolveValueAndSource returns the effective value and its source label.
@@ -114,3 +200,36 @@ func resolveValueAndSource(k KeyInfo, projectCfg, globalCfg *cfgtypes.GlobalConf
 	}
 	return "-", ""
 }
+
+// EffectiveValue returns the effective value of a config key and its source,
+// resolved from env, project config, global config and defaults.
+func EffectiveValue(key string) (string, string) {
+	info := GetKeyInfo(key)
+	if info == nil {
+		return "", ""
+	}
+	projectCfg, err := cfgtypes.LoadProjectConfigFile()
+	if err != nil {
+		projectCfg = &cfgtypes.GlobalConfig{}
+	}
+	globalCfg, err := cfgtypes.LoadGlobalConfigFile()
+	if err != nil {
+		globalCfg = &cfgtypes.GlobalConfig{}
+	}
+	value, source := resolveValueAndSource(*info, projectCfg, globalCfg)
+	if value == "-" {
+		value = ""
+	}
+	return value, source
+}
+
+// resolveSources maps every config key to the layer that supplies its value
+func resolveSources(globalCfg, projectCfg *cfgtypes.GlobalConfig) map[string]string {
+	sources := make(map[string]string, len(allKeyDefs))
+	for _, k := range GetKeys() {
+		if _, source := resolveValueAndSource(k, projectCfg, globalCfg); source != "" {
+			sources[k.Key] = source
+		}
+	}
+	return sources
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	cfgtypes "github.com/jedi4ever/addt/config"
+)
+
+// editList adds or removes a single entry of a list config key. When the key
+// is not set in the target config, the edit starts from the key's default.
+func editList(key, entry string, remove, useGlobal bool) {
+	keyInfo := GetKeyInfo(key)
+	keyDef := GetKeyDef(key)
+	if keyInfo == nil || keyDef == nil {
+		fmt.Printf("Unknown config key: %s\n", key)
+		fmt.Println("Use 'addt config --help' to see available keys.")
+		os.Exit(1)
+	}
+	if keyDef.Type != "string_list" {
+		fmt.Printf("%s is not a list key; use 'addt config set' instead\n", key)
+		os.Exit(1)
+	}
+	entry = strings.TrimSpace(entry)
+	if _, err := normalizeScopedValue(keyInfo, entry, useGlobal); err != nil {
+		fmt.Printf("Invalid value for %s: %v\n", key, err)
+		os.Exit(1)
+	}
+
+	var result string
+	err := updateScopedConfig(useGlobal, func(cfg *cfgtypes.GlobalConfig) error {
+		var err error
+		result, err = applyListEdit(cfg, keyDef, entry, remove)
+		return err
+	})
+	if err != nil {
+		fmt.Printf("Error saving config: %v\n", err)
+		os.Exit(1)
+	}
+	if result == "" {
+		fmt.Printf("Unset %s (default applies: %s)\n", key, keyDef.Default)
+		return
+	}
+	fmt.Printf("Set %s = %s\n", key, result)
+}
+
+// applyListEdit adds entry to (or removes it from) the list stored under
+// keyDef in cfg and returns the new comma-joined value
+func applyListEdit(cfg *cfgtypes.GlobalConfig, keyDef *KeyDef, entry string, remove bool) (string, error) {
+	current := GetValue(cfg, keyDef.Key)
+	if current == "" {
+		current = keyDef.Default
+	}
+	var items []string
+	for _, item := range strings.Split(current, ",") {
+		if item = strings.TrimSpace(item); item != "" && item != entry {
+			items = append(items, item)
+		}
+	}
+	if !remove {
+		items = append(items, entry)
+	}
+	// An empty list cannot be stored, so removing the last entry unsets the key
+	// and the default applies again
+	if len(items) == 0 {
+		UnsetValue(cfg, keyDef.Key)
+		return "", nil
+	}
+	value := strings.Join(items, ",")
+	return value, SetValue(cfg, keyDef.Key, value)
+}
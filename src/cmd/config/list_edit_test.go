@@ -0,0 +1,59 @@
+package config
+
+import (
+	"slices"
+	"testing"
+
+	cfgtypes "github.com/jedi4ever/addt/config"
+)
+
+func TestEnvVars_SetGetRoundTrip(t *testing.T) {
+	cfg := &cfgtypes.GlobalConfig{}
+	SetValue(cfg, "env_vars", "OPENAI_API_KEY, NPM_TOKEN")
+	if !slices.Equal(cfg.EnvVars, []string{"OPENAI_API_KEY", "NPM_TOKEN"}) {
+		t.Errorf("SetValue env_vars = %v", cfg.EnvVars)
+	}
+	if got := GetValue(cfg, "env_vars"); got != "OPENAI_API_KEY,NPM_TOKEN" {
+		t.Errorf("GetValue env_vars = %q", got)
+	}
+	UnsetValue(cfg, "env_vars")
+	if cfg.EnvVars != nil {
+		t.Errorf("UnsetValue env_vars left %v", cfg.EnvVars)
+	}
+}
+
+func TestEnvVars_RejectsInvalidNames(t *testing.T) {
+	keyInfo := GetKeyInfo("env_vars")
+	if _, err := normalizeValue(keyInfo, "GH_TOKEN,NOT-VALID"); err == nil {
+		t.Error("expected error for invalid env var name")
+	}
+	if _, err := normalizeValue(keyInfo, "GH_TOKEN,_PRIVATE1"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := normalizeValue(keyInfo, "GH_TOKEN,NODE_ENV=production"); err != nil {
+		t.Errorf("NAME=value entry rejected: %v", err)
+	}
+	if _, err := normalizeValue(keyInfo, "BAD-NAME=production"); err == nil {
+		t.Error("expected error for invalid name in NAME=value entry")
+	}
+}
+
+func TestApplyListEdit(t *testing.T) {
+	keyDef := GetKeyDef("env_vars")
+	cfg := &cfgtypes.GlobalConfig{}
+
+	if got, _ := applyListEdit(cfg, keyDef, "OPENAI_API_KEY", false); got != "ANTHROPIC_API_KEY,GH_TOKEN,OPENAI_API_KEY" {
+		t.Errorf("add should start from the default, got %q", got)
+	}
+	if got, _ := applyListEdit(cfg, keyDef, "GH_TOKEN", false); got != "ANTHROPIC_API_KEY,OPENAI_API_KEY,GH_TOKEN" {
+		t.Errorf("add of an existing entry should not duplicate it, got %q", got)
+	}
+	applyListEdit(cfg, keyDef, "GH_TOKEN", true)
+	applyListEdit(cfg, keyDef, "ANTHROPIC_API_KEY", true)
+	if !slices.Equal(cfg.EnvVars, []string{"OPENAI_API_KEY"}) {
+		t.Errorf("remove should drop entries, got %v", cfg.EnvVars)
+	}
+	if got, _ := applyListEdit(cfg, keyDef, "OPENAI_API_KEY", true); got != "" || cfg.EnvVars != nil {
+		t.Errorf("removing the last entry should unset the key, got %q %v", got, cfg.EnvVars)
+	}
+}
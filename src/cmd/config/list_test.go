@@ -2,10 +2,13 @@ package config
 
 import (
 	"bytes"
+	"encoding/json"
 	"io"
 	"os"
 	"strings"
 	"testing"
+
+	cfgtypes "github.com/jedi4ever/addt/config"
 )
 
 func TestParseVerboseFlag(t *testing.T) {
@@ -163,3 +166,44 @@ func TestPrintRowsOverriddenPrefix(t *testing.T) {
 		}
 	}
 }
+
+func TestWriteConfigJSON(t *testing.T) {
+	_, _, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	setGlobal("persistent", "true")
+	setProject("container.memory", "6g")
+	t.Setenv("ADDT_SECURITY_PIDS_LIMIT", "300")
+
+	projectCfg, _ := cfgtypes.LoadProjectConfigFile()
+	globalCfg, _ := cfgtypes.LoadGlobalConfigFile()
+	var buf bytes.Buffer
+	if err := writeConfigJSON(&buf, projectCfg, globalCfg); err != nil {
+		t.Fatal(err)
+	}
+
+	var rows []map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &rows); err != nil {
+		t.Fatalf("output is not a JSON array: %v\n%s", err, buf.String())
+	}
+	byKey := make(map[string]map[string]any, len(rows))
+	for _, r := range rows {
+		byKey[r["key"].(string)] = r
+	}
+	if len(byKey) != len(GetKeys()) {
+		t.Errorf("got %d keys, want %d", len(byKey), len(GetKeys()))
+	}
+
+	for key, want := range map[string]map[string]any{
+		"persistent":          {"value": true, "source": "global", "default": false},
+		"container.memory":    {"value": "6g", "source": "project", "default": "4g"},
+		"security.pids_limit": {"value": float64(300), "source": "env", "default": float64(200)},
+		"container.name":      {"value": nil, "source": "default", "default": nil},
+	} {
+		for field, v := range want {
+			if got := byKey[key][field]; got != v {
+				t.Errorf("%s.%s = %#v, want %#v", key, field, got, v)
+			}
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"testing"
+
+	cfgtypes "github.com/jedi4ever/addt/config"
+)
+
+func TestLogKeys_RoundTrip(t *testing.T) {
+	_, _, cleanup := setupTestEnv(t)
+	defer cleanup()
+	t.Setenv("ADDT_LOG_ROTATE", "")
+	t.Setenv("ADDT_LOG_MAX_SIZE", "")
+	t.Setenv("ADDT_LOG_MAX_FILES", "")
+
+	setGlobal("log.rotate", "true")
+	setGlobal("log.max_size", "20m")
+	setGlobal("log.max_files", "9")
+	setProject("log.max_files", "3")
+
+	global, _ := cfgtypes.LoadGlobalConfigFile()
+	project, _ := cfgtypes.LoadProjectConfigFile()
+	if got := GetValue(global, "log.max_size"); got != "20m" {
+		t.Errorf("global log.max_size = %q, want 20m", got)
+	}
+	if got := GetValue(project, "log.max_files"); got != "3" {
+		t.Errorf("project log.max_files = %q, want 3", got)
+	}
+
+	cfg := cfgtypes.LoadConfig("0.0.0-test", "22", "1.23.5", "0.4.17", 30000)
+	if !cfg.LogRotate || cfg.LogMaxSize != "20m" || cfg.LogMaxFiles != 3 {
+		t.Errorf("resolved log settings = rotate:%v max_size:%q max_files:%d", cfg.LogRotate, cfg.LogMaxSize, cfg.LogMaxFiles)
+	}
+}
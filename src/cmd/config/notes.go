@@ -0,0 +1,24 @@
+package config
+
+import "fmt"
+
+// setNotes explains settings whose effect isn't obvious from the value.
+// They are printed after "addt config set", keyed by key and normalized value.
+var setNotes = map[string]map[string]string{
+	"security.isolate_secrets": {
+		"true": "runs that carry secrets start the container detached, write the secrets to a tmpfs and then exec the agent, " +
+			"so startup takes a moment longer. Runs without secrets keep the normal single-step path.",
+	},
+}
+
+// setNote returns the note for setting key to value, or ""
+func setNote(key, value string) string {
+	return setNotes[key][value]
+}
+
+// printSetNote prints the note for key=value, if any
+func printSetNote(key, value string) {
+	if note := setNote(key, value); note != "" {
+		fmt.Printf("Note: %s\n", note)
+	}
+}
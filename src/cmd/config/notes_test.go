@@ -0,0 +1,23 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSetNote_IsolateSecrets(t *testing.T) {
+	keyInfo := GetKeyInfo("security.isolate_secrets")
+	value, err := normalizeValue(keyInfo, "yes")
+	if err != nil {
+		t.Fatalf("normalizeValue() error = %v", err)
+	}
+	if note := setNote("security.isolate_secrets", value); !strings.Contains(note, "detached") {
+		t.Errorf("setNote(isolate_secrets, %s) = %q, want the two-step flow explained", value, note)
+	}
+	if note := setNote("security.isolate_secrets", "false"); note != "" {
+		t.Errorf("setNote(isolate_secrets, false) = %q, want no note", note)
+	}
+	if note := setNote("container.cpus", "2"); note != "" {
+		t.Errorf("setNote(container.cpus) = %q, want no note", note)
+	}
+}
@@ -0,0 +1,225 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	cfgtypes "github.com/jedi4ever/addt/config"
+	"github.com/jedi4ever/addt/config/security"
+	"github.com/jedi4ever/addt/provider"
+	"github.com/jedi4ever/addt/util"
+)
+
+// envVarNamePattern matches a valid environment variable name
+var envVarNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// parseBool parses a user-supplied boolean config value.
+// Accepts true/false, yes/no, 1/0 and on/off (case-insensitive).
+func parseBool(value string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "true", "yes", "1", "on":
+		return true, nil
+	case "false", "no", "0", "off":
+		return false, nil
+	}
+	return false, fmt.Errorf("must be one of true/false, yes/no, 1/0, on/off")
+}
+
+// intRange is the inclusive range accepted for an int config key
+type intRange struct {
+	min, max int
+}
+
+// intKeyRanges limits int keys to sane values. Keys without an entry only
+// need to parse as an integer.
+var intKeyRanges = map[string]intRange{
+	"ports.range_start":   {min: 1024, max: 65535},
+	"security.pids_limit": {min: 1, max: math.MaxInt},
+	"security.time_limit": {min: 0, max: math.MaxInt},
+	"log.max_files":       {min: 1, max: math.MaxInt},
+}
+
+// parseIntKey parses the value of an int config key and checks it against
+// the key's range
+func parseIntKey(key, value string) (int, error) {
+	i, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("expected an integer, got %q", value)
+	}
+	r, ok := intKeyRanges[key]
+	if !ok {
+		return i, nil
+	}
+	if i < r.min || i > r.max {
+		if r.max == math.MaxInt {
+			return 0, fmt.Errorf("must be at least %d, got %d", r.min, i)
+		}
+		return 0, fmt.Errorf("must be between %d and %d, got %d", r.min, r.max, i)
+	}
+	return i, nil
+}
+
+// normalizeValue validates a value for a config key before it is saved and
+// returns it in canonical form. Besides the key's type and allowed_values in
+// config_keys.yaml, keys with a structured format are parsed with the same
+// code that applies them.
+func normalizeValue(keyInfo *KeyInfo, value string) (string, error) {
+	if keyInfo.Type == "bool" {
+		return normalizeBool(value)
+	}
+	if keyInfo.Type == "int" {
+		i, err := parseIntKey(keyInfo.Key, value)
+		if err != nil {
+			return "", err
+		}
+		return strconv.Itoa(i), nil
+	}
+	if err := checkAllowedValue(keyInfo, value); err != nil {
+		return "", err
+	}
+	if keyInfo.Key == "security.ulimits" {
+		if _, err := security.ParseUlimits(value); err != nil {
+			return "", err
+		}
+	}
+	if keyInfo.Key == "security.tmpfs_tmp_size" || keyInfo.Key == "security.tmpfs_home_size" {
+		if err := security.ValidateTmpfsSize(value); err != nil {
+			return "", err
+		}
+	}
+	if keyInfo.Key == "docker.cpuset_cpus" || keyInfo.Key == "docker.cpuset_mems" {
+		if err := provider.ValidateCpuset(value); err != nil {
+			return "", err
+		}
+	}
+	if keyInfo.Key == "log.max_size" {
+		if _, err := util.ParseLogSize(value); err != nil {
+			return "", err
+		}
+	}
+	if keyInfo.Key == "container.name" {
+		if err := provider.ValidateContainerName(value); err != nil {
+			return "", err
+		}
+	}
+	if keyInfo.Key == "forward_files" {
+		homeDir, _ := os.UserHomeDir()
+		for _, spec := range strings.Split(value, ",") {
+			if _, err := provider.ParseForwardFile(spec, homeDir); err != nil {
+				return "", err
+			}
+		}
+	}
+	if keyInfo.Key == "volumes" {
+		homeDir, _ := os.UserHomeDir()
+		cwd, _ := os.Getwd()
+		for _, spec := range strings.Split(value, ",") {
+			v, err := provider.ParseVolumeSpec(spec, homeDir, cwd)
+			if err != nil {
+				return "", err
+			}
+			if _, err := os.Stat(v.Source); err != nil {
+				return "", fmt.Errorf("volumes %q: source %s does not exist", spec, v.Source)
+			}
+		}
+	}
+	if keyInfo.Key == "home.persist_subdirs" {
+		for _, subdir := range strings.Split(value, ",") {
+			if err := provider.ValidateHomeSubdir(subdir); err != nil {
+				return "", err
+			}
+		}
+	}
+	if keyInfo.Key == "ports.prompt_template" {
+		if _, err := provider.ParsePortPromptTemplate(value); err != nil {
+			return "", err
+		}
+	}
+	if keyInfo.Key == "env_vars" {
+		for _, entry := range strings.Split(value, ",") {
+			name, _, _ := strings.Cut(strings.TrimSpace(entry), "=")
+			if name != "" && !envVarNamePattern.MatchString(name) {
+				return "", fmt.Errorf("invalid env var name %q", name)
+			}
+		}
+	}
+	if keyInfo.Key == "auth.method" {
+		if err := validateAuthMethod(value); err != nil {
+			return "", err
+		}
+	}
+	if keyInfo.Key == "mode" && !slices.Contains(cfgtypes.Modes, value) {
+		return "", fmt.Errorf("expected one of %s, got %q", strings.Join(cfgtypes.Modes, ", "), value)
+	}
+	if keyInfo.Key == "provider.name" && !slices.Contains(cfgtypes.Providers, value) {
+		return "", fmt.Errorf("expected one of %s, got %q", strings.Join(cfgtypes.Providers, ", "), value)
+	}
+	return value, nil
+}
+
+// normalizeScopedValue is normalizeValue for a value set in the global
+// config when useGlobal is set. Global volumes can't have a relative source,
+// which would be taken from a different directory in every project.
+func normalizeScopedValue(keyInfo *KeyInfo, value string, useGlobal bool) (string, error) {
+	if useGlobal && keyInfo.Key == "volumes" {
+		homeDir, _ := os.UserHomeDir()
+		for _, spec := range strings.Split(value, ",") {
+			if v, err := provider.ParseVolumeSpec(spec, homeDir, ""); err == nil && !filepath.IsAbs(v.Source) {
+				return "", fmt.Errorf("volumes %q: the global config needs an absolute or ~/ source", spec)
+			}
+		}
+	}
+	return normalizeValue(keyInfo, value)
+}
+
+// checkAllowedValue rejects a value outside the key's allowed values.
+// security.seccomp_profile also takes a path to a profile JSON file.
+func checkAllowedValue(keyInfo *KeyInfo, value string) error {
+	if len(keyInfo.AllowedValues) == 0 || slices.Contains(keyInfo.AllowedValues, value) {
+		return nil
+	}
+	valid := strings.Join(keyInfo.AllowedValues, ", ")
+	if keyInfo.Key == "security.seccomp_profile" {
+		if strings.ContainsRune(value, '/') || strings.HasSuffix(value, ".json") {
+			return nil
+		}
+		valid += " or a profile file path"
+	}
+	return fmt.Errorf("expected one of %s, got %q", valid, value)
+}
+
+// AllowedValueValidator returns a check of a value against key's allowed
+// values, for flags that set an enum key for one run
+func AllowedValueValidator(key string) func(string) error {
+	return func(value string) error {
+		keyInfo := GetKeyInfo(key)
+		if keyInfo == nil {
+			return nil
+		}
+		return checkAllowedValue(keyInfo, value)
+	}
+}
+
+// validateAuthMethod checks an auth.method value, global or per extension
+func validateAuthMethod(value string) error {
+	if !slices.Contains(cfgtypes.AuthMethods, value) {
+		return fmt.Errorf("must be one of %s, got %q", strings.Join(cfgtypes.AuthMethods, ", "), value)
+	}
+	return nil
+}
+
+// normalizeBool parses a boolean config value and returns its canonical
+// "true"/"false" form for storage.
+func normalizeBool(value string) (string, error) {
+	b, err := parseBool(value)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%v", b), nil
+}
@@ -0,0 +1,335 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	cfgtypes "github.com/jedi4ever/addt/config"
+)
+
+func TestParseBool_AcceptedForms(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"true", true},
+		{"TRUE", true},
+		{"yes", true},
+		{"Yes", true},
+		{"1", true},
+		{"on", true},
+		{"ON", true},
+		{"false", false},
+		{"False", false},
+		{"no", false},
+		{"NO", false},
+		{"0", false},
+		{"off", false},
+		{"Off", false},
+	}
+
+	for _, tt := range tests {
+		got, err := parseBool(tt.input)
+		if err != nil {
+			t.Errorf("parseBool(%q) error = %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseBool(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseBool_RejectsInvalid(t *testing.T) {
+	for _, input := range []string{"maybe", "", "2", "y", "n", "truthy", "enable"} {
+		if _, err := parseBool(input); err == nil {
+			t.Errorf("parseBool(%q) expected error, got nil", input)
+		}
+	}
+}
+
+func TestNormalizeBool(t *testing.T) {
+	tests := map[string]string{
+		"yes": "true",
+		"on":  "true",
+		"1":   "true",
+		"no":  "false",
+		"OFF": "false",
+		"0":   "false",
+	}
+	for input, want := range tests {
+		got, err := normalizeBool(input)
+		if err != nil {
+			t.Errorf("normalizeBool(%q) error = %v", input, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("normalizeBool(%q) = %q, want %q", input, got, want)
+		}
+	}
+
+	if _, err := normalizeBool("maybe"); err == nil {
+		t.Error("normalizeBool(\"maybe\") expected error, got nil")
+	}
+}
+
+func TestNormalizeValue_AuthMethod(t *testing.T) {
+	keyInfo := GetKeyInfo("auth.method")
+	for _, method := range []string{"native", "env", "auto"} {
+		if got, err := normalizeValue(keyInfo, method); err != nil || got != method {
+			t.Errorf("normalizeValue(%q) = %q, %v", method, got, err)
+		}
+	}
+	for _, method := range []string{"password", "", "ENV"} {
+		if _, err := normalizeValue(keyInfo, method); err == nil {
+			t.Errorf("normalizeValue(%q) expected error, got nil", method)
+		}
+		if err := validateAuthMethod(method); err == nil {
+			t.Errorf("validateAuthMethod(%q) expected error, got nil", method)
+		}
+	}
+}
+
+func TestNormalizeValue_Mode(t *testing.T) {
+	keyInfo := GetKeyInfo("mode")
+	for _, mode := range []string{"container", "shell"} {
+		if got, err := normalizeValue(keyInfo, mode); err != nil || got != mode {
+			t.Errorf("normalizeValue(%q) = %q, %v", mode, got, err)
+		}
+	}
+	if _, err := normalizeValue(keyInfo, "vm"); err == nil {
+		t.Error("normalizeValue(\"vm\") expected error, got nil")
+	}
+}
+
+func TestSetValue_ModeAndCommandRoundTrip(t *testing.T) {
+	cfg := &cfgtypes.GlobalConfig{}
+
+	SetValue(cfg, "mode", "shell")
+	SetValue(cfg, "command", "codex")
+	if cfg.Mode != "shell" || cfg.Command != "codex" {
+		t.Errorf("SetValue: Mode = %q, Command = %q, want shell, codex", cfg.Mode, cfg.Command)
+	}
+	if got := GetValue(cfg, "mode"); got != "shell" {
+		t.Errorf("GetValue(mode) = %q, want shell", got)
+	}
+	if got := GetValue(cfg, "command"); got != "codex" {
+		t.Errorf("GetValue(command) = %q, want codex", got)
+	}
+
+	UnsetValue(cfg, "mode")
+	UnsetValue(cfg, "command")
+	if cfg.Mode != "" || cfg.Command != "" {
+		t.Errorf("UnsetValue: Mode = %q, Command = %q, want both empty", cfg.Mode, cfg.Command)
+	}
+}
+
+func TestNormalizeValue_PullPolicy(t *testing.T) {
+	keyInfo := GetKeyInfo("docker.pull_policy")
+	for _, policy := range []string{"always", "missing", "never"} {
+		if got, err := normalizeValue(keyInfo, policy); err != nil || got != policy {
+			t.Errorf("normalizeValue(%q) = %q, %v", policy, got, err)
+		}
+	}
+	if _, err := normalizeValue(keyInfo, "sometimes"); err == nil {
+		t.Error("normalizeValue(\"sometimes\") expected error, got nil")
+	}
+}
+
+func TestNormalizeValue_ProviderName(t *testing.T) {
+	keyInfo := GetKeyInfo("provider.name")
+	for _, name := range []string{"orbstack", "docker", "podman"} {
+		if got, err := normalizeValue(keyInfo, name); err != nil || got != name {
+			t.Errorf("normalizeValue(%q) = %q, %v", name, got, err)
+		}
+	}
+	if _, err := normalizeValue(keyInfo, "orb"); err == nil {
+		t.Error("normalizeValue(\"orb\") expected error, got nil")
+	}
+}
+
+func TestNormalizeValue_PortPromptTemplate(t *testing.T) {
+	keyInfo := GetKeyInfo("ports.prompt_template")
+	valid := "{{range .Ports}}{{.Container}} -> {{$.BindAddress}}:{{.Host}}\n{{end}}"
+	if got, err := normalizeValue(keyInfo, valid); err != nil || got != valid {
+		t.Errorf("normalizeValue(%q) = %q, %v", valid, got, err)
+	}
+	if _, err := normalizeValue(keyInfo, "{{range .Ports}}"); err == nil {
+		t.Error("normalizeValue of an unterminated template expected error, got nil")
+	}
+}
+
+func TestNormalizeValue_IntKeys(t *testing.T) {
+	tests := []struct {
+		key     string
+		valid   []string
+		invalid []string
+	}{
+		{"ports.range_start", []string{"1024", "30000", "65535"}, []string{"abc", "30000x", "1023", "65536", "-1"}},
+		{"security.pids_limit", []string{"1", "200"}, []string{"many", "0", "-5"}},
+		{"security.time_limit", []string{"0", "60"}, []string{"1h", "-1"}},
+		{"log.max_files", []string{"1", "5"}, []string{"five", "5.5", "0", "-1"}},
+	}
+	for _, tt := range tests {
+		keyInfo := GetKeyInfo(tt.key)
+		for _, v := range tt.valid {
+			if got, err := normalizeValue(keyInfo, v); err != nil || got != v {
+				t.Errorf("normalizeValue(%s, %q) = %q, %v", tt.key, v, got, err)
+			}
+		}
+		for _, v := range tt.invalid {
+			if _, err := normalizeValue(keyInfo, v); err == nil {
+				t.Errorf("normalizeValue(%s, %q) expected error, got nil", tt.key, v)
+			}
+		}
+	}
+	if got, err := normalizeValue(GetKeyInfo("security.pids_limit"), " 100 "); err != nil || got != "100" {
+		t.Errorf("normalizeValue(security.pids_limit, \" 100 \") = %q, %v, want \"100\"", got, err)
+	}
+}
+
+func TestNormalizeValue_LogMaxSize(t *testing.T) {
+	keyInfo := GetKeyInfo("log.max_size")
+	for _, v := range []string{"10m", "1g", "500k", "20MB", "100"} {
+		if got, err := normalizeValue(keyInfo, v); err != nil || got != v {
+			t.Errorf("normalizeValue(%q) = %q, %v", v, got, err)
+		}
+	}
+	for _, v := range []string{"10x", "lots", "0", "-5m", ""} {
+		if _, err := normalizeValue(keyInfo, v); err == nil {
+			t.Errorf("normalizeValue(%q) expected error, got nil", v)
+		}
+	}
+}
+
+func TestNormalizeValue_ContainerName(t *testing.T) {
+	keyInfo := GetKeyInfo("container.name")
+	if got, err := normalizeValue(keyInfo, "my-project.dev"); err != nil || got != "my-project.dev" {
+		t.Errorf("normalizeValue(\"my-project.dev\") = %q, %v", got, err)
+	}
+	for _, bad := range []string{"my project", "-x", "a/b"} {
+		if _, err := normalizeValue(keyInfo, bad); err == nil {
+			t.Errorf("normalizeValue(%q) expected error, got nil", bad)
+		}
+	}
+}
+
+func TestNormalizeValue_ForwardFiles(t *testing.T) {
+	keyInfo := GetKeyInfo("forward_files")
+	value := "~/.netrc,~/.aws/config:/home/addt/.aws/config:ro"
+	if got, err := normalizeValue(keyInfo, value); err != nil || got != value {
+		t.Errorf("normalizeValue(%q) = %q, %v", value, got, err)
+	}
+	for _, bad := range []string{".netrc", "~/.netrc:relative/path", "/a:/b:/c:ro"} {
+		if _, err := normalizeValue(keyInfo, bad); err == nil {
+			t.Errorf("normalizeValue(%q) expected error, got nil", bad)
+		}
+	}
+}
+
+func TestNormalizeValue_Volumes(t *testing.T) {
+	home, root := t.TempDir(), t.TempDir()
+	t.Setenv("HOME", home)
+	os.MkdirAll(filepath.Join(home, ".cache", "pip"), 0755)
+	os.MkdirAll(filepath.Join(root, "shared"), 0755)
+	os.MkdirAll(filepath.Join(root, "project"), 0755)
+	t.Chdir(filepath.Join(root, "project"))
+
+	keyInfo := GetKeyInfo("volumes")
+	value := "~/.cache/pip:~/.cache/pip,../shared:/shared:ro"
+	if got, err := normalizeScopedValue(keyInfo, value, false); err != nil || got != value {
+		t.Errorf("normalizeScopedValue(%q) = %q, %v", value, got, err)
+	}
+	for _, bad := range []string{"/data", "/data:relative", "/a:/b:/c", "~/missing:/missing", "../nope:/nope"} {
+		if _, err := normalizeScopedValue(keyInfo, bad, false); err == nil {
+			t.Errorf("normalizeScopedValue(%q) expected error, got nil", bad)
+		}
+	}
+
+	// A relative source depends on the project, so -g refuses it
+	if _, err := normalizeScopedValue(keyInfo, "../shared:/shared", true); err == nil || !strings.Contains(err.Error(), "global config") {
+		t.Errorf("global relative source: error = %v, want a global config error", err)
+	}
+	if _, err := normalizeScopedValue(keyInfo, "~/.cache/pip:~/.cache/pip", true); err != nil {
+		t.Errorf("global ~/ source: error = %v", err)
+	}
+}
+
+func TestNormalizeValue_AllowedValues(t *testing.T) {
+	tests := []struct {
+		key     string
+		valid   []string
+		invalid []string
+	}{
+		{"firewall.mode", []string{"strict", "permissive", "off"}, []string{"bogus", "Strict", ""}},
+		{"docker.dind.mode", []string{"host", "isolated", "off"}, []string{"rootless"}},
+		{"ssh.forward_mode", []string{"agent", "keys", "proxy"}, []string{"socket"}},
+		{"security.seccomp_profile", []string{"default", "restrictive", "unconfined", "/etc/addt/seccomp.json", "profile.json"}, []string{"strict"}},
+	}
+	for _, tt := range tests {
+		keyInfo := GetKeyInfo(tt.key)
+		for _, v := range tt.valid {
+			if got, err := normalizeValue(keyInfo, v); err != nil || got != v {
+				t.Errorf("normalizeValue(%s, %q) = %q, %v", tt.key, v, got, err)
+			}
+		}
+		for _, v := range tt.invalid {
+			if _, err := normalizeValue(keyInfo, v); err == nil {
+				t.Errorf("normalizeValue(%s, %q) expected error, got nil", tt.key, v)
+			}
+		}
+	}
+
+	_, err := normalizeValue(GetKeyInfo("firewall.mode"), "bogus")
+	if err == nil || !strings.Contains(err.Error(), "strict, permissive, off") {
+		t.Errorf("error = %v, want the valid modes listed", err)
+	}
+	if _, err := normalizeValue(GetKeyInfo("docker.config_path"), "~/anything-goes"); err != nil {
+		t.Errorf("free-form key rejected value: %v", err)
+	}
+}
+
+func TestSetValue_BoolAliases(t *testing.T) {
+	cfg := &cfgtypes.GlobalConfig{}
+
+	SetValue(cfg, "firewall.enabled", "yes")
+	if cfg.Firewall == nil || cfg.Firewall.Enabled == nil || !*cfg.Firewall.Enabled {
+		t.Errorf("firewall.enabled = yes: got %v, want true", cfg.Firewall)
+	}
+
+	SetValue(cfg, "firewall.enabled", "off")
+	if *cfg.Firewall.Enabled {
+		t.Error("firewall.enabled = off: got true, want false")
+	}
+
+	SetValue(cfg, "persistent", "1")
+	if cfg.Persistent == nil || !*cfg.Persistent {
+		t.Errorf("persistent = 1: got %v, want true", cfg.Persistent)
+	}
+
+	SetValue(cfg, "security.isolate_secrets", "No")
+	if cfg.Security == nil || cfg.Security.IsolateSecrets == nil || *cfg.Security.IsolateSecrets {
+		t.Error("security.isolate_secrets = No: want false")
+	}
+
+	if got := GetValue(cfg, "persistent"); got != "true" {
+		t.Errorf("GetValue(persistent) = %q, want %q", got, "true")
+	}
+}
+
+func TestSetValue_InvalidValues(t *testing.T) {
+	cfg := &cfgtypes.GlobalConfig{}
+	SetValue(cfg, "persistent", "true")
+
+	if err := SetValue(cfg, "persistent", "maybe"); err == nil {
+		t.Error("SetValue(persistent, maybe) = nil, want an error")
+	}
+	if cfg.Persistent == nil || !*cfg.Persistent {
+		t.Errorf("a rejected value changed persistent to %v", cfg.Persistent)
+	}
+	if err := SetValue(cfg, "log.max_files", "many"); err == nil {
+		t.Error("SetValue(log.max_files, many) = nil, want an error")
+	}
+}
@@ -3,30 +3,10 @@ package config
 import (
 	"fmt"
 	"os"
-	"strings"
 
 	cfgtypes "github.com/jedi4ever/addt/config"
 )
 
-func listProject(verbose bool) {
-	projectCfg, err := cfgtypes.LoadProjectConfigFile()
-	if err != nil {
-		fmt.Printf("Error loading project config: %v\n", err)
-		os.Exit(1)
-	}
-
-	globalCfg, err := cfgtypes.LoadGlobalConfigFile()
-	if err != nil {
-		fmt.Printf("Error loading global config: %v\n", err)
-		os.Exit(1)
-	}
-
-	fmt.Printf("Project config: %s\n", cfgtypes.GetProjectConfigPath())
-	fmt.Printf("Global config:  %s\n\n", cfgtypes.GetGlobalConfigPath())
-
-	printConfigTable(projectCfg, globalCfg, verbose)
-}
-
 func getProject(key string) {
 	if !IsValidKey(key) {
 		fmt.Printf("Unknown config key: %s\n", key)
@@ -56,28 +36,23 @@ func setProject(key, value string) {
 		os.Exit(1)
 	}
 
-	if keyInfo.Type == "bool" {
-		value = strings.ToLower(value)
-		if value != "true" && value != "false" {
-			fmt.Printf("Invalid value for %s: must be 'true' or 'false'\n", key)
-			os.Exit(1)
-		}
-	}
-
-	cfg, err := cfgtypes.LoadProjectConfigFile()
+	normalized, err := normalizeScopedValue(keyInfo, value, false)
 	if err != nil {
-		fmt.Printf("Error loading project config: %v\n", err)
+		fmt.Printf("Invalid value for %s: %v\n", key, err)
 		os.Exit(1)
 	}
+	value = normalized
 
-	SetValue(cfg, key, value)
-
-	if err := cfgtypes.SaveProjectConfigFile(cfg); err != nil {
+	err = cfgtypes.UpdateProjectConfigFile(func(cfg *cfgtypes.GlobalConfig) error {
+		return SetValue(cfg, key, value)
+	})
+	if err != nil {
 		fmt.Printf("Error saving project config: %v\n", err)
 		os.Exit(1)
 	}
 
 	fmt.Printf("Set %s = %s (project)\n", key, value)
+	printSetNote(key, value)
 }
 
 func unsetProject(key string) {
@@ -87,15 +62,11 @@ func unsetProject(key string) {
 		os.Exit(1)
 	}
 
-	cfg, err := cfgtypes.LoadProjectConfigFile()
+	err := cfgtypes.UpdateProjectConfigFile(func(cfg *cfgtypes.GlobalConfig) error {
+		UnsetValue(cfg, key)
+		return nil
+	})
 	if err != nil {
-		fmt.Printf("Error loading project config: %v\n", err)
-		os.Exit(1)
-	}
-
-	UnsetValue(cfg, key)
-
-	if err := cfgtypes.SaveProjectConfigFile(cfg); err != nil {
 		fmt.Printf("Error saving project config: %v\n", err)
 		os.Exit(1)
 	}
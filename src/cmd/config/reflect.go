@@ -3,6 +3,8 @@ package config
 import (
 	"fmt"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 
 	cfgtypes "github.com/jedi4ever/addt/config"
@@ -90,17 +92,21 @@ func reflectGetValue(cfg *cfgtypes.GlobalConfig, key string) string {
 	return formatField(field)
 }
 
-// reflectSetValue sets a config value using reflection
-func reflectSetValue(cfg *cfgtypes.GlobalConfig, key, value string) {
+// reflectSetValue sets a config value using reflection. Unknown keys are
+// ignored; a value that doesn't parse as the key's type is an error.
+func reflectSetValue(cfg *cfgtypes.GlobalConfig, key, value string) error {
 	field, ok := resolveField(cfg, key, true)
 	if !ok || !field.IsValid() {
-		return
+		return nil
 	}
 	kd := keyDefMap[key]
 	if kd == nil {
-		return
+		return nil
+	}
+	if err := setField(field, value, kd.Type); err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
 	}
-	setField(field, value, kd.Type)
+	return nil
 }
 
 // reflectUnsetValue clears a config value using reflection
@@ -138,6 +144,17 @@ func formatField(field reflect.Value) string {
 		return fmt.Sprintf("%v", field.Bool())
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		return fmt.Sprintf("%d", field.Int())
+	case reflect.Map:
+		if field.Type().Elem().Kind() == reflect.String {
+			// string_map: "name=value,..." in key order
+			var parts []string
+			iter := field.MapRange()
+			for iter.Next() {
+				parts = append(parts, iter.Key().String()+"="+iter.Value().String())
+			}
+			sort.Strings(parts)
+			return strings.Join(parts, ",")
+		}
 	case reflect.Slice:
 		if field.Type().Elem().Kind() == reflect.String {
 			parts := make([]string, field.Len())
@@ -150,18 +167,24 @@ func formatField(field reflect.Value) string {
 	return ""
 }
 
-// setField sets a reflect.Value from a string, using the type hint from the key definition
-func setField(field reflect.Value, value string, typeName string) {
+// setField sets a reflect.Value from a string, using the type hint from the
+// key definition. The field is left unchanged when value doesn't parse.
+func setField(field reflect.Value, value string, typeName string) error {
 	switch field.Kind() {
 	case reflect.Ptr:
 		elemType := field.Type().Elem()
 		switch elemType.Kind() {
 		case reflect.Bool:
-			b := value == "true"
+			b, err := parseBool(value)
+			if err != nil {
+				return err
+			}
 			field.Set(reflect.ValueOf(&b))
 		case reflect.Int:
-			var i int
-			fmt.Sscanf(value, "%d", &i)
+			i, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return fmt.Errorf("must be an integer, got %q", value)
+			}
 			field.Set(reflect.ValueOf(&i))
 		case reflect.String:
 			field.Set(reflect.ValueOf(&value))
@@ -169,11 +192,31 @@ func setField(field reflect.Value, value string, typeName string) {
 	case reflect.String:
 		field.SetString(value)
 	case reflect.Bool:
-		field.SetBool(value == "true")
+		b, err := parseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
 	case reflect.Int, reflect.Int64:
-		var i int64
-		fmt.Sscanf(value, "%d", &i)
+		i, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			return fmt.Errorf("must be an integer, got %q", value)
+		}
 		field.SetInt(i)
+	case reflect.Map:
+		if field.Type().Elem().Kind() == reflect.String {
+			if value == "" {
+				field.Set(reflect.Zero(field.Type()))
+				return nil
+			}
+			m := reflect.MakeMap(field.Type())
+			for _, entry := range strings.Split(value, ",") {
+				if k, v, ok := strings.Cut(strings.TrimSpace(entry), "="); ok {
+					m.SetMapIndex(reflect.ValueOf(strings.TrimSpace(k)), reflect.ValueOf(strings.TrimSpace(v)))
+				}
+			}
+			field.Set(m)
+		}
 	case reflect.Slice:
 		if field.Type().Elem().Kind() == reflect.String {
 			if value == "" {
@@ -190,6 +233,7 @@ func setField(field reflect.Value, value string, typeName string) {
 			}
 		}
 	}
+	return nil
 }
 
 // unsetField clears a reflect.Value to its zero value
@@ -203,7 +247,7 @@ func unsetField(field reflect.Value) {
 		field.SetBool(false)
 	case reflect.Int, reflect.Int64:
 		field.SetInt(0)
-	case reflect.Slice:
+	case reflect.Slice, reflect.Map:
 		field.Set(reflect.Zero(field.Type()))
 	}
 }
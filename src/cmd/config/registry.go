@@ -19,10 +19,13 @@ var extensionKeysYAML []byte
 type KeyDef struct {
 	Key         string `yaml:"key"`
 	Description string `yaml:"description"`
-	Type        string `yaml:"type"`    // "bool", "string", "int", "string_list"
+	Type        string `yaml:"type"`    // "bool", "string", "int", "string_list", "string_map"
 	EnvVar      string `yaml:"env_var"` // e.g. "ADDT_FIREWALL"
 	Default     string `yaml:"default"`
 	Namespace   string `yaml:"namespace"`
+	Sensitive   bool   `yaml:"sensitive"` // value may hold credentials; redacted by "config export"
+
+	AllowedValues []string `yaml:"allowed_values"` // accepted values for enum keys; empty for free-form
 }
 
 type keysFile struct {
@@ -42,6 +45,8 @@ func init() {
 		panic(fmt.Sprintf("config: failed to parse config_keys.yaml: %v", err))
 	}
 	allKeyDefs = kf.Keys
+	cfgtypes.SourceResolver = resolveSources
+	cfgtypes.KeyDescriber = keyDescription
 	keyDefMap = make(map[string]*KeyDef, len(allKeyDefs))
 	for i := range allKeyDefs {
 		keyDefMap[allKeyDefs[i].Key] = &allKeyDefs[i]
@@ -76,10 +81,11 @@ func registryGetKeys() []KeyInfo {
 			t = "string" // external API shows "string" for comma-separated lists
 		}
 		keys[i] = KeyInfo{
-			Key:         kd.Key,
-			Description: kd.Description,
-			Type:        t,
-			EnvVar:      kd.EnvVar,
+			Key:           kd.Key,
+			Description:   kd.Description,
+			Type:          t,
+			EnvVar:        kd.EnvVar,
+			AllowedValues: kd.AllowedValues,
 		}
 	}
 	sort.Slice(keys, func(i, j int) bool {
@@ -113,10 +119,11 @@ func registryGetKeyInfo(key string) *KeyInfo {
 		t = "string"
 	}
 	return &KeyInfo{
-		Key:         kd.Key,
-		Description: kd.Description,
-		Type:        t,
-		EnvVar:      kd.EnvVar,
+		Key:           kd.Key,
+		Description:   kd.Description,
+		Type:          t,
+		EnvVar:        kd.EnvVar,
+		AllowedValues: kd.AllowedValues,
 	}
 }
 
@@ -165,3 +172,12 @@ func GetExtensionKeyDef(key string) *KeyDef {
 func GetAllExtensionKeyDefs() []KeyDef {
 	return allExtensionKeyDefs
 }
+
+// keyDescription returns the registry description of key, for the comment
+// written above a newly saved key
+func keyDescription(key string) string {
+	if kd := keyDefMap[key]; kd != nil {
+		return kd.Description
+	}
+	return ""
+}
@@ -1,20 +1,22 @@
 package config
 
 import (
+	"slices"
 	"testing"
 
 	cfgtypes "github.com/jedi4ever/addt/config"
 	"github.com/jedi4ever/addt/config/otel"
 	"github.com/jedi4ever/addt/config/security"
+	"github.com/jedi4ever/addt/provider"
 )
 
 func TestRegistryLoadsAllKeys(t *testing.T) {
 	if len(allKeyDefs) == 0 {
 		t.Fatal("allKeyDefs is empty, YAML not loaded")
 	}
-	// We expect 78 keys total
-	if len(allKeyDefs) != 78 {
-		t.Errorf("expected 78 key defs, got %d", len(allKeyDefs))
+	// We expect 118 keys total
+	if len(allKeyDefs) != 118 {
+		t.Errorf("expected 118 key defs, got %d", len(allKeyDefs))
 	}
 }
 
@@ -81,8 +83,8 @@ func TestRegistryIsValidKey(t *testing.T) {
 
 func TestRegistryGetKeys(t *testing.T) {
 	keys := registryGetKeys()
-	if len(keys) != 78 {
-		t.Errorf("registryGetKeys() returned %d keys, want 78", len(keys))
+	if len(keys) != 118 {
+		t.Errorf("registryGetKeys() returned %d keys, want 118", len(keys))
 	}
 	// Verify sorted
 	for i := 1; i < len(keys); i++ {
@@ -115,6 +117,20 @@ func TestRegistryGetKeyInfo(t *testing.T) {
 	}
 }
 
+func TestRegistryAllowedValues(t *testing.T) {
+	for _, kd := range GetAllKeyDefs() {
+		if len(kd.AllowedValues) > 0 && !slices.Contains(kd.AllowedValues, kd.Default) {
+			t.Errorf("%s default %q not in allowed values %v", kd.Key, kd.Default, kd.AllowedValues)
+		}
+	}
+	if got := registryGetKeyInfo("docker.dind.mode").AllowedValues; !slices.Equal(got, provider.DindModes) {
+		t.Errorf("docker.dind.mode allowed values = %v, want %v", got, provider.DindModes)
+	}
+	if got := registryGetKeyInfo("firewall.enabled").AllowedValues; got != nil {
+		t.Errorf("firewall.enabled allowed values = %v, want none", got)
+	}
+}
+
 // --- Reflection Get/Set/Unset tests ---
 
 func TestReflectGetValueBoolPointer(t *testing.T) {
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	cfgtypes "github.com/jedi4ever/addt/config"
+)
+
+// setPair is one validated key=value argument of "addt config set"
+type setPair struct {
+	key, value string
+}
+
+// parseSetPairs validates every key=value argument, returning the
+// normalized values. Nothing is written when any pair is invalid.
+func parseSetPairs(args []string, useGlobal bool) ([]setPair, error) {
+	pairs := make([]setPair, 0, len(args))
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			return nil, fmt.Errorf("expected key=value, got %q", arg)
+		}
+		keyInfo := GetKeyInfo(key)
+		if keyInfo == nil {
+			return nil, fmt.Errorf("unknown config key: %s", key)
+		}
+		normalized, err := normalizeScopedValue(keyInfo, value, useGlobal)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %w", key, err)
+		}
+		pairs = append(pairs, setPair{key: key, value: normalized})
+	}
+	return pairs, nil
+}
+
+// setPairs handles "addt config set k1=v1 k2=v2 ... [-g]", writing all
+// values in one update of the config file
+func setPairs(args []string, useGlobal bool) {
+	pairs, err := parseSetPairs(args, useGlobal)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		fmt.Println("Nothing was changed.")
+		os.Exit(1)
+	}
+
+	update, scope := cfgtypes.UpdateProjectConfigFile, " (project)"
+	if useGlobal {
+		update, scope = cfgtypes.UpdateGlobalConfigFile, ""
+	}
+	err = update(func(cfg *cfgtypes.GlobalConfig) error {
+		for _, p := range pairs {
+			if err := SetValue(cfg, p.key, p.value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		fmt.Printf("Error saving config: %v\n", err)
+		os.Exit(1)
+	}
+
+	for _, p := range pairs {
+		fmt.Printf("Set %s = %s%s\n", p.key, p.value, scope)
+		printSetNote(p.key, p.value)
+	}
+}
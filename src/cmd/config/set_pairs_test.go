@@ -0,0 +1,50 @@
+package config
+
+import (
+	"testing"
+
+	cfgtypes "github.com/jedi4ever/addt/config"
+)
+
+func TestSetPairs(t *testing.T) {
+	_, _, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	for _, useGlobal := range []bool{false, true} {
+		setPairs([]string{"firewall.enabled=yes", "firewall.mode=strict", "container.cpus=4"}, useGlobal)
+
+		load := cfgtypes.LoadProjectConfigFile
+		if useGlobal {
+			load = cfgtypes.LoadGlobalConfigFile
+		}
+		cfg, err := load()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for key, want := range map[string]string{"firewall.enabled": "true", "firewall.mode": "strict", "container.cpus": "4"} {
+			if got := GetValue(cfg, key); got != want {
+				t.Errorf("global=%v: %s = %q, want %q", useGlobal, key, got, want)
+			}
+		}
+	}
+}
+
+func TestParseSetPairs(t *testing.T) {
+	for _, args := range [][]string{
+		{"container.cpus=4", "persistent=maybe", "firewall.mode=strict"},
+		{"container.cpus=4", "no_such.key=1"},
+		{"container.cpus=4", "persistent"},
+	} {
+		if _, err := parseSetPairs(args, false); err == nil {
+			t.Errorf("parseSetPairs(%v) expected error", args)
+		}
+	}
+
+	pairs, err := parseSetPairs([]string{"persistent=on", "otel.headers=a=b"}, false)
+	if err != nil {
+		t.Fatalf("parseSetPairs() error = %v", err)
+	}
+	if pairs[0].value != "true" || pairs[1].value != "a=b" {
+		t.Errorf("parseSetPairs() = %+v, want normalized bool and value split at the first =", pairs)
+	}
+}
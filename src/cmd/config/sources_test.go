@@ -0,0 +1,50 @@
+package config
+
+import (
+	"testing"
+
+	cfgtypes "github.com/jedi4ever/addt/config"
+)
+
+func TestLoadConfig_RecordsSources(t *testing.T) {
+	_, _, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	setGlobal("container.cpus", "2")
+	setGlobal("container.memory", "1g")
+	setProject("container.memory", "4g")
+	setGlobal("firewall.mode", "permissive")
+	t.Setenv("ADDT_FIREWALL_MODE", "off")
+	t.Setenv("ADDT_EXPLAIN_CONFIG", "true")
+
+	cfg := cfgtypes.LoadConfig("0.0.0-test", "22", "1.23.5", "0.4.17", 30000)
+	if cfg.Sources == nil {
+		t.Fatal("Sources should be recorded when ADDT_EXPLAIN_CONFIG=true")
+	}
+
+	want := map[string]string{
+		"container.cpus":   "global",
+		"container.memory": "project",
+		"firewall.mode":    "env",
+		"persistent":       "default",
+	}
+	for key, source := range want {
+		if got := cfg.Sources[key]; got != source {
+			t.Errorf("Sources[%q] = %q, want %q", key, got, source)
+		}
+	}
+	if cfg.ContainerMemory != "4g" || cfg.FirewallMode != "off" {
+		t.Errorf("resolved values = memory:%q firewall:%q, want 4g/off", cfg.ContainerMemory, cfg.FirewallMode)
+	}
+}
+
+func TestLoadConfig_NoSourcesByDefault(t *testing.T) {
+	_, _, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	t.Setenv("ADDT_EXPLAIN_CONFIG", "")
+	cfg := cfgtypes.LoadConfig("0.0.0-test", "22", "1.23.5", "0.4.17", 30000)
+	if cfg.Sources != nil {
+		t.Errorf("Sources = %v, want nil without ADDT_EXPLAIN_CONFIG", cfg.Sources)
+	}
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"testing"
+
+	cfgtypes "github.com/jedi4ever/addt/config"
+)
+
+func TestUlimitsKey_RoundTrip(t *testing.T) {
+	cfg := &cfgtypes.GlobalConfig{}
+	SetValue(cfg, "security.ulimits", "memlock=-1:-1, core=0:0")
+
+	if got := GetValue(cfg, "security.ulimits"); got != "core=0:0,memlock=-1:-1" {
+		t.Errorf("GetValue() = %q, want core=0:0,memlock=-1:-1", got)
+	}
+	if cfg.Security.Ulimits["memlock"] != "-1:-1" {
+		t.Errorf("Security.Ulimits = %v", cfg.Security.Ulimits)
+	}
+
+	UnsetValue(cfg, "security.ulimits")
+	if cfg.Security.Ulimits != nil {
+		t.Errorf("after unset, Security.Ulimits = %v, want nil", cfg.Security.Ulimits)
+	}
+}
+
+func TestNormalizeValue_Ulimits(t *testing.T) {
+	info := GetKeyInfo("security.ulimits")
+	if _, err := normalizeValue(info, "memlock=-1:-1"); err != nil {
+		t.Errorf("normalizeValue(valid) error = %v", err)
+	}
+	if _, err := normalizeValue(info, "memlock=lots"); err == nil {
+		t.Error("normalizeValue(memlock=lots) expected error")
+	}
+	if got, err := normalizeValue(GetKeyInfo("persistent"), "yes"); err != nil || got != "true" {
+		t.Errorf("normalizeValue(bool) = %q, %v", got, err)
+	}
+}
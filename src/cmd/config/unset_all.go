@@ -0,0 +1,84 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	cfgtypes "github.com/jedi4ever/addt/config"
+)
+
+// unsetAllCommand handles "addt config unset --all [--dry-run]": clears
+// every known key of one config file but keeps the file itself.
+// Extension settings are left alone.
+func unsetAllCommand(args []string, useGlobal bool) {
+	dryRun := false
+	for _, arg := range args {
+		switch arg {
+		case "--all":
+		case "--dry-run":
+			dryRun = true
+		default:
+			fmt.Println("Usage: addt config unset --all [--dry-run] [-g]")
+			os.Exit(1)
+		}
+	}
+
+	layer, update := "project", cfgtypes.UpdateProjectConfigFile
+	if useGlobal {
+		layer, update = "global", cfgtypes.UpdateGlobalConfigFile
+	}
+
+	if dryRun {
+		cfg, _, err := loadLayer(layer)
+		if err != nil {
+			fmt.Printf("Error loading %s config: %v\n", layer, err)
+			os.Exit(1)
+		}
+		printUnsetAll(os.Stdout, layer, setKeys(cfg), true)
+		return
+	}
+
+	var cleared []string
+	err := update(func(cfg *cfgtypes.GlobalConfig) error {
+		cleared = unsetAll(cfg)
+		return nil
+	})
+	if err != nil {
+		fmt.Printf("Error saving %s config: %v\n", layer, err)
+		os.Exit(1)
+	}
+	printUnsetAll(os.Stdout, layer, cleared, false)
+}
+
+// setKeys returns the known keys that have a value in cfg
+func setKeys(cfg *cfgtypes.GlobalConfig) []string {
+	var keys []string
+	for _, k := range GetKeys() {
+		if GetValue(cfg, k.Key) != "" {
+			keys = append(keys, k.Key)
+		}
+	}
+	return keys
+}
+
+// unsetAll clears every known key in cfg and returns the keys it cleared
+func unsetAll(cfg *cfgtypes.GlobalConfig) []string {
+	keys := setKeys(cfg)
+	for _, key := range keys {
+		UnsetValue(cfg, key)
+	}
+	return keys
+}
+
+// printUnsetAll lists the cleared keys and their count
+func printUnsetAll(w io.Writer, layer string, keys []string, dryRun bool) {
+	verb := "Unset"
+	if dryRun {
+		verb = "Would unset"
+	}
+	for _, key := range keys {
+		fmt.Fprintf(w, "  %s\n", key)
+	}
+	fmt.Fprintf(w, "%s %d key(s) (%s)\n", verb, len(keys), layer)
+}
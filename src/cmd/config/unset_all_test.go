@@ -0,0 +1,64 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"testing"
+
+	cfgtypes "github.com/jedi4ever/addt/config"
+)
+
+func TestUnsetAll(t *testing.T) {
+	_, _, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	setGlobal("container.cpus", "3")
+	setProject("container.memory", "6g")
+	setProject("firewall.mode", "strict")
+	setExtension("claude", "version", "1.0.5", false)
+
+	project, _ := cfgtypes.LoadProjectConfigFile()
+	if got, want := setKeys(project), []string{"container.memory", "firewall.mode"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("setKeys = %v, want %v", got, want)
+	}
+
+	var cleared []string
+	if err := cfgtypes.UpdateProjectConfigFile(func(cfg *cfgtypes.GlobalConfig) error {
+		cleared = unsetAll(cfg)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(cleared) != 2 {
+		t.Errorf("cleared = %v, want 2 keys", cleared)
+	}
+
+	// The file stays, valid, with extension settings untouched
+	if _, err := os.Stat(cfgtypes.GetProjectConfigPath()); err != nil {
+		t.Fatalf("project config removed: %v", err)
+	}
+	project, err := cfgtypes.LoadProjectConfigFile()
+	if err != nil {
+		t.Fatalf("project config no longer loads: %v", err)
+	}
+	if keys := setKeys(project); len(keys) != 0 {
+		t.Errorf("keys left after unset --all: %v", keys)
+	}
+	if ext := project.Extensions["claude"]; ext == nil || ext.Version != "1.0.5" {
+		t.Errorf("extension config cleared: %+v", ext)
+	}
+
+	global, _ := cfgtypes.LoadGlobalConfigFile()
+	if got := GetValue(global, "container.cpus"); got != "3" {
+		t.Errorf("global container.cpus = %q, want 3", got)
+	}
+}
+
+func TestPrintUnsetAll(t *testing.T) {
+	var buf bytes.Buffer
+	printUnsetAll(&buf, "project", []string{"container.memory"}, true)
+	if want := "  container.memory\nWould unset 1 key(s) (project)\n"; buf.String() != want {
+		t.Errorf("dry run output = %q, want %q", buf.String(), want)
+	}
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jedi4ever/addt/provider"
+)
+
+// resolveContainerName returns the named container, or the current
+// directory's persistent container when no name is given. The container
+// must exist. Used by the commands that act on one container (restart,
+// stop, stats).
+func resolveContainerName(prov provider.Provider, name string) (string, error) {
+	if name == "" {
+		name = prov.GeneratePersistentName()
+		if !prov.Exists(name) {
+			return "", fmt.Errorf("no persistent container for this directory (%s)", name)
+		}
+		return name, nil
+	}
+	if !prov.Exists(name) {
+		return "", fmt.Errorf("container %s not found", name)
+	}
+	return name, nil
+}
+
+// splitExtensionArg interprets the first argument of stop, restart and
+// stats. An existing container, or a name starting with "addt-", is the
+// target and stays in args; anything else that isn't a flag is an extension
+// name selecting the current directory's container for it, and is moved
+// into cfg.Extensions. Checking the container first keeps container.name
+// targets such as "addt stop mybox" working.
+func splitExtensionArg(prov provider.Provider, cfg *provider.Config, args []string) []string {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") || strings.HasPrefix(args[0], "addt-") || prov.Exists(args[0]) {
+		return args
+	}
+	cfg.Extensions = args[0]
+	return args[1:]
+}
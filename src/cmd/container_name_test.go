@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jedi4ever/addt/provider"
+)
+
+func TestResolveContainerName(t *testing.T) {
+	prov := &restartMockProvider{containers: map[string]bool{"test-persistent": true, "addt-persistent-a": false}}
+
+	// Explicit name
+	if got, err := resolveContainerName(prov, "addt-persistent-a"); err != nil || got != "addt-persistent-a" {
+		t.Errorf("resolveContainerName(named) = %q, %v, want addt-persistent-a", got, err)
+	}
+	// Derived from the current directory
+	if got, err := resolveContainerName(prov, ""); err != nil || got != "test-persistent" {
+		t.Errorf("resolveContainerName(\"\") = %q, %v, want test-persistent", got, err)
+	}
+	// Not found
+	if _, err := resolveContainerName(prov, "addt-persistent-missing"); err == nil {
+		t.Error("resolveContainerName() expected error for a missing container")
+	}
+	prov.containers = nil
+	if _, err := resolveContainerName(prov, ""); err == nil {
+		t.Error("resolveContainerName(\"\") expected error without a persistent container")
+	}
+}
+
+func TestSplitExtensionArg(t *testing.T) {
+	prov := &restartMockProvider{containers: map[string]bool{"mybox": true}}
+
+	tests := []struct {
+		args     []string
+		wantArgs []string
+		wantExt  string
+	}{
+		{[]string{"mybox"}, []string{"mybox"}, "claude"},                         // container.name target
+		{[]string{"addt-persistent-x"}, []string{"addt-persistent-x"}, "claude"}, // generated name, even if missing
+		{[]string{"codex", "--json"}, []string{"--json"}, "codex"},               // extension
+		{[]string{"--all"}, []string{"--all"}, "claude"},
+		{nil, nil, "claude"},
+	}
+	for _, tt := range tests {
+		cfg := &provider.Config{Extensions: "claude"}
+		got := splitExtensionArg(prov, cfg, tt.args)
+		if !reflect.DeepEqual(got, tt.wantArgs) || cfg.Extensions != tt.wantExt {
+			t.Errorf("splitExtensionArg(%v) = %v, extensions %q; want %v, %q", tt.args, got, cfg.Extensions, tt.wantArgs, tt.wantExt)
+		}
+	}
+}
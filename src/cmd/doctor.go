@@ -20,12 +20,30 @@ type DoctorCheck struct {
 	Fix     string // Suggested fix for failures
 }
 
-// HandleDoctorCommand runs system health checks
+// HandleDoctorCommand runs system health checks, after the safe fixes with --fix
 func HandleDoctorCommand(args []string) {
+	fix, assumeYes := false, false
+	for _, arg := range args {
+		switch arg {
+		case "--fix":
+			fix = true
+		case "-y", "--yes":
+			assumeYes = true
+		case "-h", "--help", "help":
+			printDoctorHelp()
+			return
+		}
+	}
+
 	fmt.Println("addt doctor - System Health Check")
 	fmt.Println("==================================")
 	fmt.Println()
 
+	if fix {
+		runDoctorFixes(assumeYes)
+		fmt.Println()
+	}
+
 	checks := runAllChecks()
 
 	// Print results
@@ -101,6 +119,9 @@ func runAllChecks() []DoctorCheck {
 	checks = append(checks, checkGlobalConfig())
 	checks = append(checks, checkProjectConfig())
 
+	// Host tools the active extensions need
+	checks = append(checks, checkActiveExtensionHostTools()...)
+
 	// Network connectivity (optional)
 	checks = append(checks, checkNetworkConnectivity())
 
@@ -206,105 +227,6 @@ func checkGit() DoctorCheck {
 	return check
 }
 
-func checkAnthropicKey() DoctorCheck {
-	check := DoctorCheck{Name: "ANTHROPIC_API_KEY"}
-
-	key := os.Getenv("ANTHROPIC_API_KEY")
-	if key == "" {
-		check.Status = "warn"
-		check.Message = "not set"
-		check.Fix = "Set ANTHROPIC_API_KEY or run 'claude login' locally"
-		return check
-	}
-
-	// Mask the key for display
-	if len(key) > 10 {
-		check.Message = fmt.Sprintf("set (%s...)", key[:10])
-	} else {
-		check.Message = "set"
-	}
-	check.Status = "ok"
-	return check
-}
-
-func checkGitHubToken() DoctorCheck {
-	check := DoctorCheck{Name: "GitHub Token"}
-
-	// Load config to check github settings
-	globalCfg, _ := config.LoadGlobalConfigFile()
-	projectCfg, _ := config.LoadProjectConfigFile()
-
-	// Resolve forward_token: default (true) -> global -> project
-	forwardToken := true
-	if globalCfg != nil && globalCfg.GitHub != nil && globalCfg.GitHub.ForwardToken != nil {
-		forwardToken = *globalCfg.GitHub.ForwardToken
-	}
-	if projectCfg != nil && projectCfg.GitHub != nil && projectCfg.GitHub.ForwardToken != nil {
-		forwardToken = *projectCfg.GitHub.ForwardToken
-	}
-
-	// Resolve token_source: default (gh_auth) -> global -> project
-	tokenSource := "gh_auth"
-	if globalCfg != nil && globalCfg.GitHub != nil && globalCfg.GitHub.TokenSource != "" {
-		tokenSource = globalCfg.GitHub.TokenSource
-	}
-	if projectCfg != nil && projectCfg.GitHub != nil && projectCfg.GitHub.TokenSource != "" {
-		tokenSource = projectCfg.GitHub.TokenSource
-	}
-
-	// If forwarding is disabled, nothing to check
-	if !forwardToken {
-		check.Status = "ok"
-		check.Message = "forwarding disabled (github.forward_token=false)"
-		return check
-	}
-
-	// Check GH_TOKEN in env
-	token := os.Getenv("GH_TOKEN")
-	if token == "" {
-		token = os.Getenv("GITHUB_TOKEN")
-	}
-
-	if token != "" {
-		if len(token) > 10 {
-			check.Message = fmt.Sprintf("set via env (%s...)", token[:10])
-		} else {
-			check.Message = "set via env"
-		}
-		check.Status = "ok"
-		return check
-	}
-
-	// Token source: gh_auth (default) or env
-	if tokenSource == "gh_auth" {
-		ghPath, err := exec.LookPath("gh")
-		if err != nil {
-			check.Status = "warn"
-			check.Message = "gh CLI not installed (token_source=gh_auth)"
-			check.Fix = "Install gh CLI: https://cli.github.com/ and run 'gh auth login'"
-			return check
-		}
-
-		cmd := exec.Command(ghPath, "auth", "status")
-		if err := cmd.Run(); err != nil {
-			check.Status = "warn"
-			check.Message = "gh CLI not authenticated (token_source=gh_auth)"
-			check.Fix = "Run 'gh auth login'"
-			return check
-		}
-
-		check.Status = "ok"
-		check.Message = "available via gh CLI (token_source=gh_auth)"
-		return check
-	}
-
-	// token_source=env but no GH_TOKEN set
-	check.Status = "warn"
-	check.Message = "GH_TOKEN not set (token_source=env)"
-	check.Fix = "Set GH_TOKEN or switch to gh_auth: addt config set github.token_source gh_auth"
-	return check
-}
-
 func checkDiskSpace() DoctorCheck {
 	check := DoctorCheck{Name: "Disk Space"}
 
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/jedi4ever/addt/config"
+	"github.com/jedi4ever/addt/extensions"
+)
+
+// checkActiveExtensionHostTools checks requires_host for the extensions a
+// run in this directory would use: ADDT_EXTENSIONS, else the extensions
+// configured in the project and global config files
+func checkActiveExtensionHostTools() []DoctorCheck {
+	active := runExtensions(os.Getenv("ADDT_EXTENSIONS"))
+	if len(active) == 0 {
+		active = configuredExtensions(config.LoadProjectConfig(), config.LoadGlobalConfig())
+	}
+	exts, err := extensions.GetExtensions()
+	if err != nil {
+		return nil
+	}
+	return checkExtensionHostTools(active, exts, exec.LookPath)
+}
+
+// checkExtensionHostTools returns one check per active extension that
+// declares requires_host, warning about the tools lookPath can't find
+func checkExtensionHostTools(active []string, exts []extensions.ExtensionConfig, lookPath func(string) (string, error)) []DoctorCheck {
+	byName := make(map[string]extensions.ExtensionConfig, len(exts))
+	for _, ext := range exts {
+		byName[ext.Name] = ext
+	}
+
+	var checks []DoctorCheck
+	for _, name := range active {
+		ext, ok := byName[name]
+		if !ok || len(ext.RequiresHost) == 0 {
+			continue
+		}
+		var missing []string
+		for _, tool := range ext.RequiresHost {
+			if _, err := lookPath(tool); err != nil {
+				missing = append(missing, tool)
+			}
+		}
+
+		check := DoctorCheck{Name: "Extension " + name}
+		if len(missing) == 0 {
+			check.Status = "ok"
+			check.Message = "host tools found: " + strings.Join(ext.RequiresHost, ", ")
+		} else {
+			check.Status = "warn"
+			check.Message = "missing host tools: " + strings.Join(missing, ", ")
+			check.Fix = fmt.Sprintf("Install %s on the host and make sure it is on PATH", strings.Join(missing, ", "))
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jedi4ever/addt/extensions"
+)
+
+func TestCheckExtensionHostTools(t *testing.T) {
+	exts := []extensions.ExtensionConfig{
+		{Name: "ghagent", RequiresHost: []string{"gh", "ssh-agent"}},
+		{Name: "plain"},
+	}
+	onPath := map[string]bool{"ssh-agent": true}
+	lookPath := func(tool string) (string, error) {
+		if onPath[tool] {
+			return "/usr/bin/" + tool, nil
+		}
+		return "", errors.New("not found")
+	}
+
+	checks := checkExtensionHostTools([]string{"ghagent", "plain", "unknown"}, exts, lookPath)
+	if len(checks) != 1 {
+		t.Fatalf("checks = %+v, want one for ghagent", checks)
+	}
+	if c := checks[0]; c.Name != "Extension ghagent" || c.Status != "warn" || c.Message != "missing host tools: gh" || c.Fix == "" {
+		t.Errorf("check = %+v, want a warning about gh", c)
+	}
+
+	onPath["gh"] = true
+	checks = checkExtensionHostTools([]string{"ghagent"}, exts, lookPath)
+	if len(checks) != 1 || checks[0].Status != "ok" {
+		t.Errorf("checks = %+v, want ok with gh installed", checks)
+	}
+
+	if checks := checkExtensionHostTools([]string{"plain"}, exts, lookPath); len(checks) != 0 {
+		t.Errorf("checks = %+v, want none for an extension without requires_host", checks)
+	}
+}
@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/jedi4ever/addt/config"
+	"github.com/jedi4ever/addt/util"
+)
+
+// addtSubdirs are the directories addt creates under ADDT_HOME on demand
+var addtSubdirs = []string{"extensions", "history", "logs", "sockets"}
+
+// doctorState is what "addt doctor --fix" detected about the host
+type doctorState struct {
+	AddtHome           string
+	MissingDirs        []string // ADDT_HOME and subdirectories that don't exist
+	FirewallDirMissing bool
+	SSHForwardMode     string
+	SSHAgentRunning    bool   // SSH_AUTH_SOCK points at a live agent
+	AgentSocket        string // Socket "addt doctor --fix" starts ssh-agent on
+	AgentSocketLive    bool   // An agent already answers on AgentSocket
+	AgentSocketStale   bool   // AgentSocket exists but nothing answers
+	Provider           string
+	PodmanMachine      string // "running", "stopped" or "" (none, or not macOS)
+}
+
+// doctorFix is a single remediation. Fixes are idempotent; Confirm marks the
+// ones that remove something and are only applied after the user agrees.
+type doctorFix struct {
+	Name    string
+	Confirm bool
+	Apply   func() (string, error) // Returns what was done
+}
+
+// runDoctorFixes applies the fixes selected for the detected state
+func runDoctorFixes(assumeYes bool) {
+	fixes := selectDoctorFixes(detectDoctorState())
+	if len(fixes) == 0 {
+		fmt.Println("Nothing to fix.")
+		return
+	}
+	for _, fix := range fixes {
+		if fix.Confirm && !assumeYes {
+			fmt.Printf("%s? [y/N] ", fix.Name)
+			var response string
+			fmt.Scanln(&response)
+			if response != "y" && response != "Y" {
+				fmt.Printf("- Skipped: %s\n", fix.Name)
+				continue
+			}
+		}
+		done, err := fix.Apply()
+		if err != nil {
+			fmt.Printf("✗ %s: %v\n", fix.Name, err)
+			continue
+		}
+		fmt.Printf("✓ %s\n", done)
+	}
+}
+
+// selectDoctorFixes returns the fixes that apply to state, in order
+func selectDoctorFixes(state doctorState) []doctorFix {
+	var fixes []doctorFix
+
+	for _, dir := range state.MissingDirs {
+		fixes = append(fixes, mkdirFix(dir))
+	}
+	if state.FirewallDirMissing {
+		fixes = append(fixes, mkdirFix(filepath.Join(state.AddtHome, "firewall")))
+	}
+
+	if state.SSHForwardMode == "agent" && !state.SSHAgentRunning {
+		sock := state.AgentSocket
+		switch {
+		case state.AgentSocketLive:
+			fixes = append(fixes, doctorFix{
+				Name: "Use the ssh-agent at " + sock,
+				Apply: func() (string, error) {
+					return fmt.Sprintf("ssh-agent already running; run: export SSH_AUTH_SOCK=%s", sock), nil
+				},
+			})
+		case state.AgentSocketStale:
+			fixes = append(fixes, doctorFix{
+				Name:    "Remove the stale socket " + sock + " and start ssh-agent",
+				Confirm: true,
+				Apply: func() (string, error) {
+					if err := os.Remove(sock); err != nil && !os.IsNotExist(err) {
+						return "", err
+					}
+					return startSSHAgent(sock)
+				},
+			})
+		default:
+			fixes = append(fixes, doctorFix{
+				Name:  "Start ssh-agent",
+				Apply: func() (string, error) { return startSSHAgent(sock) },
+			})
+		}
+	}
+
+	if state.Provider == "podman" && state.PodmanMachine == "stopped" {
+		fixes = append(fixes, doctorFix{
+			Name: "Start the Podman machine",
+			Apply: func() (string, error) {
+				if output, err := exec.Command(config.GetPodmanPath(), "machine", "start").CombinedOutput(); err != nil {
+					return "", fmt.Errorf("%w\n%s", err, output)
+				}
+				return "Started the Podman machine", nil
+			},
+		})
+	}
+
+	return fixes
+}
+
+// mkdirFix creates dir (0700) if it is still missing
+func mkdirFix(dir string) doctorFix {
+	return doctorFix{
+		Name: "Create " + dir,
+		Apply: func() (string, error) {
+			if err := os.MkdirAll(dir, 0700); err != nil {
+				return "", err
+			}
+			return "Created " + dir, nil
+		},
+	}
+}
+
+// startSSHAgent starts ssh-agent on sock. The agent outlives addt, but the
+// calling shell still has to point SSH_AUTH_SOCK at it.
+func startSSHAgent(sock string) (string, error) {
+	if err := os.MkdirAll(filepath.Dir(sock), 0700); err != nil {
+		return "", err
+	}
+	if output, err := exec.Command("ssh-agent", "-a", sock).CombinedOutput(); err != nil {
+		return "", fmt.Errorf("ssh-agent: %w\n%s", err, output)
+	}
+	return fmt.Sprintf("Started ssh-agent; run: export SSH_AUTH_SOCK=%s", sock), nil
+}
+
+// detectDoctorState inspects the host for the problems --fix can remedy
+func detectDoctorState() doctorState {
+	state := doctorState{
+		AddtHome:       util.GetAddtHome(),
+		SSHForwardMode: resolveSSHForwardMode(),
+		Provider:       config.DetectContainerRuntime(),
+	}
+
+	if state.AddtHome != "" {
+		for _, dir := range append([]string{state.AddtHome}, addtSubdirs...) {
+			if dir != state.AddtHome {
+				dir = filepath.Join(state.AddtHome, dir)
+			}
+			if _, err := os.Stat(dir); os.IsNotExist(err) {
+				state.MissingDirs = append(state.MissingDirs, dir)
+			}
+		}
+		_, err := os.Stat(filepath.Join(state.AddtHome, "firewall"))
+		state.FirewallDirMissing = os.IsNotExist(err)
+		state.AgentSocket = filepath.Join(state.AddtHome, "sockets", "ssh-agent.sock")
+	}
+
+	if state.SSHForwardMode == "agent" {
+		if sock := os.Getenv("SSH_AUTH_SOCK"); sock != "" {
+			state.SSHAgentRunning = sshAgentAlive(sock)
+		}
+		if _, err := os.Stat(state.AgentSocket); state.AgentSocket != "" && err == nil {
+			state.AgentSocketLive = sshAgentAlive(state.AgentSocket)
+			state.AgentSocketStale = !state.AgentSocketLive
+		}
+	}
+
+	if state.Provider == "podman" && runtime.GOOS == "darwin" {
+		state.PodmanMachine = podmanMachineState()
+	}
+	return state
+}
+
+// resolveSSHForwardMode resolves ssh.forward_mode: default (proxy) -> global -> project -> env
+func resolveSSHForwardMode() string {
+	mode := "proxy"
+	if globalCfg, _ := config.LoadGlobalConfigFile(); globalCfg != nil && globalCfg.SSH != nil && globalCfg.SSH.ForwardMode != "" {
+		mode = globalCfg.SSH.ForwardMode
+	}
+	if projectCfg, _ := config.LoadProjectConfigFile(); projectCfg != nil && projectCfg.SSH != nil && projectCfg.SSH.ForwardMode != "" {
+		mode = projectCfg.SSH.ForwardMode
+	}
+	if v := os.Getenv("ADDT_SSH_FORWARD_MODE"); v != "" {
+		mode = v
+	}
+	return mode
+}
+
+// sshAgentAlive reports whether an ssh-agent answers on sock. ssh-add -l
+// exits 1 for an agent without keys and 2 when it can't reach one.
+func sshAgentAlive(sock string) bool {
+	cmd := exec.Command("ssh-add", "-l")
+	cmd.Env = append(os.Environ(), "SSH_AUTH_SOCK="+sock)
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	return err == nil || (errors.As(err, &exitErr) && exitErr.ExitCode() == 1)
+}
+
+// podmanMachineState returns "running", "stopped", or "" if there is no machine
+func podmanMachineState() string {
+	podmanPath := config.GetPodmanPath()
+	if podmanPath == "" {
+		return ""
+	}
+	output, err := exec.Command(podmanPath, "machine", "list", "--format", "{{.Running}}").Output()
+	if err != nil || strings.TrimSpace(string(output)) == "" {
+		return ""
+	}
+	if strings.Contains(string(output), "true") {
+		return "running"
+	}
+	return "stopped"
+}
+
+func printDoctorHelp() {
+	fmt.Println(`Usage: addt doctor [--fix] [-y]
+
+Check system health: container runtimes, git, API keys, disk space,
+config files and network access.
+
+Flags:
+  --fix         Apply safe fixes before checking: create ~/.addt and its
+                subdirectories, create the firewall config directory, start
+                ssh-agent (ssh.forward_mode=agent) and start a stopped
+                Podman machine (macOS)
+  -y, --yes     Don't ask before fixes that remove something (a stale
+                ssh-agent socket)`)
+}
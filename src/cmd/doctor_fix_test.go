@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func doctorFixNames(fixes []doctorFix) []string {
+	var names []string
+	for _, fix := range fixes {
+		names = append(names, fix.Name)
+	}
+	return names
+}
+
+func TestSelectDoctorFixes_Healthy(t *testing.T) {
+	state := doctorState{
+		AddtHome:        "/home/u/.addt",
+		SSHForwardMode:  "agent",
+		SSHAgentRunning: true,
+		Provider:        "podman",
+		PodmanMachine:   "running",
+	}
+	if fixes := selectDoctorFixes(state); len(fixes) != 0 {
+		t.Errorf("selectDoctorFixes() = %v, want none", doctorFixNames(fixes))
+	}
+}
+
+func TestSelectDoctorFixes_Directories(t *testing.T) {
+	state := doctorState{
+		AddtHome:           "/home/u/.addt",
+		MissingDirs:        []string{"/home/u/.addt/history", "/home/u/.addt/logs"},
+		FirewallDirMissing: true,
+		SSHForwardMode:     "proxy",
+	}
+	want := []string{"Create /home/u/.addt/history", "Create /home/u/.addt/logs", "Create /home/u/.addt/firewall"}
+	if got := doctorFixNames(selectDoctorFixes(state)); !reflect.DeepEqual(got, want) {
+		t.Errorf("selectDoctorFixes() = %v, want %v", got, want)
+	}
+}
+
+func TestSelectDoctorFixes_SSHAgent(t *testing.T) {
+	base := doctorState{AgentSocket: "/home/u/.addt/sockets/ssh-agent.sock"}
+
+	tests := []struct {
+		name        string
+		mutate      func(*doctorState)
+		wantName    string
+		wantConfirm bool
+	}{
+		{"proxy mode", func(s *doctorState) { s.SSHForwardMode = "proxy" }, "", false},
+		{"agent missing", func(s *doctorState) { s.SSHForwardMode = "agent" }, "Start ssh-agent", false},
+		{"agent on addt socket", func(s *doctorState) { s.SSHForwardMode = "agent"; s.AgentSocketLive = true },
+			"Use the ssh-agent at /home/u/.addt/sockets/ssh-agent.sock", false},
+		{"stale socket", func(s *doctorState) { s.SSHForwardMode = "agent"; s.AgentSocketStale = true },
+			"Remove the stale socket /home/u/.addt/sockets/ssh-agent.sock and start ssh-agent", true},
+	}
+	for _, tt := range tests {
+		state := base
+		tt.mutate(&state)
+		fixes := selectDoctorFixes(state)
+		if tt.wantName == "" {
+			if len(fixes) != 0 {
+				t.Errorf("%s: selectDoctorFixes() = %v, want none", tt.name, doctorFixNames(fixes))
+			}
+			continue
+		}
+		if len(fixes) != 1 || fixes[0].Name != tt.wantName || fixes[0].Confirm != tt.wantConfirm {
+			t.Errorf("%s: selectDoctorFixes() = %+v, want %q (confirm=%v)", tt.name, fixes, tt.wantName, tt.wantConfirm)
+		}
+	}
+}
+
+func TestSelectDoctorFixes_PodmanMachine(t *testing.T) {
+	for _, tt := range []struct {
+		provider, machine string
+		want              bool
+	}{
+		{"podman", "stopped", true},
+		{"podman", "running", false},
+		{"podman", "", false},
+		{"docker", "stopped", false},
+	} {
+		fixes := selectDoctorFixes(doctorState{Provider: tt.provider, PodmanMachine: tt.machine})
+		if got := len(fixes) == 1 && fixes[0].Name == "Start the Podman machine"; got != tt.want {
+			t.Errorf("provider=%s machine=%q: fixes = %v, want podman start %v", tt.provider, tt.machine, doctorFixNames(fixes), tt.want)
+		}
+	}
+}
+
+func TestMkdirFix_Idempotent(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), ".addt", "firewall")
+	fix := mkdirFix(dir)
+	for i := 0; i < 2; i++ {
+		if _, err := fix.Apply(); err != nil {
+			t.Fatalf("Apply() #%d error = %v", i+1, err)
+		}
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		t.Errorf("expected %s to be a directory (err %v)", dir, err)
+	}
+}
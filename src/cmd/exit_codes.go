@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/jedi4ever/addt/provider"
+)
+
+// Exit codes for provider failure kinds; anything else exits with 1
+const (
+	exitRuntimeNotFound      = 3
+	exitRuntimeNotRunning    = 4
+	exitImageBuildFailed     = 5
+	exitSecretsCopyFailed    = 6
+	exitContainerStartFailed = 7
+	exitRunTimeout           = 124 // same as timeout(1)
+)
+
+// exitCodeForError maps a provider error to the addt exit code
+func exitCodeForError(err error) int {
+	switch {
+	case errors.Is(err, provider.ErrRuntimeNotFound):
+		return exitRuntimeNotFound
+	case errors.Is(err, provider.ErrRuntimeNotRunning):
+		return exitRuntimeNotRunning
+	case errors.Is(err, provider.ErrImageBuildFailed):
+		return exitImageBuildFailed
+	case errors.Is(err, provider.ErrSecretsCopyFailed):
+		return exitSecretsCopyFailed
+	case errors.Is(err, provider.ErrContainerStartFailed):
+		return exitContainerStartFailed
+	case errors.Is(err, provider.ErrRunTimeout):
+		return exitRunTimeout
+	}
+	return 1
+}
+
+// exitWithError prints err and exits with the code for its failure kind.
+// An untagged exit error comes from the agent process itself, whose output
+// is already on the terminal, so it exits with 1 without a message.
+func exitWithError(err error) {
+	code := exitCodeForError(err)
+	var exitErr *exec.ExitError
+	if code != 1 || !errors.As(err, &exitErr) {
+		fmt.Printf("Error: %v\n", err)
+	}
+	os.Exit(code)
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jedi4ever/addt/provider"
+)
+
+func TestExitCodeForError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"runtime not found", provider.Tag(provider.ErrRuntimeNotFound, errors.New("Docker is not installed")), exitRuntimeNotFound},
+		{"runtime not running", provider.Tag(provider.ErrRuntimeNotRunning, errors.New("daemon down")), exitRuntimeNotRunning},
+		{"build failed", fmt.Errorf("build: %w", provider.Tag(provider.ErrImageBuildFailed, errors.New("exit 1"))), exitImageBuildFailed},
+		{"secrets", provider.Tag(provider.ErrSecretsCopyFailed, errors.New("exec failed")), exitSecretsCopyFailed},
+		{"start", provider.Tag(provider.ErrContainerStartFailed, errors.New("run -d failed")), exitContainerStartFailed},
+		{"timeout", provider.Tag(provider.ErrRunTimeout, errors.New("run exceeded --timeout 1s")), exitRunTimeout},
+		{"untagged", errors.New("something else"), 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCodeForError(tt.err); got != tt.want {
+				t.Errorf("exitCodeForError() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/jedi4ever/addt/config"
+)
+
+// printConfigSources writes a compact report of the config keys that don't
+// use their default, with the layer (and file) that supplied each one
+func printConfigSources(w io.Writer, sources map[string]string) {
+	var keys []string
+	defaults := 0
+	for key, source := range sources {
+		if source == "default" {
+			defaults++
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fmt.Fprintln(w, "Config sources:")
+	for _, key := range keys {
+		fmt.Fprintf(w, "  %-32s %s\n", key, describeConfigSource(sources[key]))
+	}
+	fmt.Fprintf(w, "  (%d keys at defaults)\n", defaults)
+}
+
+// describeConfigSource adds the config file path to project/global sources
+func describeConfigSource(source string) string {
+	switch source {
+	case "project":
+		return fmt.Sprintf("project (%s)", config.GetProjectConfigPath())
+	case "global":
+		return fmt.Sprintf("global (%s)", config.GetGlobalConfigPath())
+	}
+	return source
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestParseRunFlags_ExplainConfig(t *testing.T) {
+	t.Setenv("ADDT_EXPLAIN_CONFIG", "")
+
+	flags, rest, err := parseRunFlags([]string{"--explain-config", "claude"})
+	if err != nil {
+		t.Fatalf("parseRunFlags() error = %v", err)
+	}
+	if !flags.ExplainConfig || len(rest) != 1 {
+		t.Errorf("ExplainConfig=%v rest=%v", flags.ExplainConfig, rest)
+	}
+
+	flags.apply()
+	if got := os.Getenv("ADDT_EXPLAIN_CONFIG"); got != "true" {
+		t.Errorf("ADDT_EXPLAIN_CONFIG = %q, want true", got)
+	}
+}
+
+func TestPrintConfigSources(t *testing.T) {
+	var buf bytes.Buffer
+	printConfigSources(&buf, map[string]string{
+		"firewall.mode":    "env",
+		"container.memory": "project",
+		"persistent":       "default",
+		"log.enabled":      "default",
+	})
+	out := buf.String()
+
+	if strings.Contains(out, "persistent") {
+		t.Errorf("default keys should only be counted:\n%s", out)
+	}
+	if !strings.Contains(out, "(2 keys at defaults)") {
+		t.Errorf("missing defaults count:\n%s", out)
+	}
+	if !strings.Contains(out, "project (") {
+		t.Errorf("project source should name its file:\n%s", out)
+	}
+	if strings.Index(out, "container.memory") > strings.Index(out, "firewall.mode") {
+		t.Errorf("keys should be sorted:\n%s", out)
+	}
+}
@@ -7,15 +7,16 @@ import (
 	configcmd "github.com/jedi4ever/addt/cmd/config"
 )
 
-// HandleCommand handles the "extensions" subcommand
-func HandleCommand(args []string) {
+// HandleCommand handles the "extensions" subcommand. resolve supplies the
+// active extension set for "list --installed".
+func HandleCommand(args []string, resolve ActiveSetResolver) {
 	if len(args) == 0 {
 		printUsage("")
 		return
 	}
 	switch args[0] {
 	case "list":
-		List()
+		List(args[1:], resolve)
 	case "info":
 		if len(args) < 2 {
 			fmt.Println("Usage: addt extensions info <name>")
@@ -45,6 +46,16 @@ func HandleCommand(args []string) {
 		}
 		force := len(args) > 2 && args[2] == "--force"
 		Remove(args[1], force)
+	case "validate":
+		if len(args) < 2 {
+			fmt.Println("Usage: addt extensions validate <path>")
+			os.Exit(1)
+		}
+		if args[1] == "--help" || args[1] == "-h" {
+			printValidateHelp("addt")
+			return
+		}
+		Validate(args[1])
 	case "config":
 		handleConfigCommand(args[1:], "addt")
 	default:
@@ -54,14 +65,14 @@ func HandleCommand(args []string) {
 }
 
 // HandleCommandAgent handles the "extensions" subcommand when invoked via agent (e.g., "claude addt extensions")
-func HandleCommandAgent(args []string) {
+func HandleCommandAgent(args []string, resolve ActiveSetResolver) {
 	if len(args) == 0 {
 		printUsage("<agent>")
 		return
 	}
 	switch args[0] {
 	case "list":
-		List()
+		List(args[1:], resolve)
 	case "info":
 		if len(args) < 2 {
 			fmt.Println("Usage: <agent> addt extensions info <name>")
@@ -91,6 +102,16 @@ func HandleCommandAgent(args []string) {
 		}
 		force := len(args) > 2 && args[2] == "--force"
 		Remove(args[1], force)
+	case "validate":
+		if len(args) < 2 {
+			fmt.Println("Usage: <agent> addt extensions validate <path>")
+			os.Exit(1)
+		}
+		if args[1] == "--help" || args[1] == "-h" {
+			printValidateHelp("<agent> addt")
+			return
+		}
+		Validate(args[1])
 	case "config":
 		handleConfigCommand(args[1:], "<agent>")
 	default:
@@ -111,8 +132,8 @@ func handleConfigCommand(args []string, prefix string) {
 		fmt.Println("  unset <key>       Remove a configuration value")
 		fmt.Println()
 		fmt.Println("Available keys:")
-		fmt.Println("  version     Extension version (e.g., \"1.0.5\", \"latest\", \"stable\")")
-		fmt.Println("  config.automount   Auto-mount extension config directories (true/false)")
+		configcmd.PrintExtensionKeys()
+		fmt.Println("  ...plus any flag the extension declares (e.g., yolo)")
 		fmt.Println()
 		fmt.Println("Examples:")
 		if prefix == "<agent>" {
@@ -136,10 +157,11 @@ func printUsage(prefix string) {
 	fmt.Printf("Usage: %s extensions <command>\n", prefix)
 	fmt.Println()
 	fmt.Println("Commands:")
-	fmt.Println("  list                       List available extensions")
+	fmt.Println("  list [--installed]         List available (or selected) extensions")
 	fmt.Println("  info <name>                Show extension details")
 	fmt.Println("  new <name>                 Create a new local extension")
 	fmt.Println("  clone <source> [target]    Copy built-in extension for customization")
 	fmt.Println("  remove <name> [--force]    Remove a local extension")
+	fmt.Println("  validate <path>            Lint an extension config.yaml")
 	fmt.Println("  config <name> <subcommand> Configure extension settings")
 }
@@ -2,6 +2,7 @@ package extensions
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -18,61 +19,7 @@ func ShowInfo(name string) {
 
 	for _, ext := range exts {
 		if ext.Name == name {
-			version := ext.DefaultVersion
-			if version == "" {
-				version = "latest"
-			}
-
-			fmt.Printf("%s\n", ext.Name)
-			fmt.Printf("%s\n\n", strings.Repeat("=", len(ext.Name)))
-
-			fmt.Printf("  %s\n\n", ext.Description)
-
-			source := "built-in"
-			if ext.IsLocal {
-				source = "local (~/.addt/extensions/" + ext.Name + ")"
-			}
-
-			fmt.Println("Configuration:")
-			fmt.Printf("  Entrypoint:  %s\n", ext.Entrypoint)
-			fmt.Printf("  Version:     %s\n", version)
-			fmt.Printf("  Auto-mount:  %v\n", ext.Config.Automount)
-			fmt.Printf("  Source:      %s\n", source)
-
-			if len(ext.Dependencies) > 0 {
-				fmt.Printf("  Depends on:  %s\n", strings.Join(ext.Dependencies, ", "))
-			}
-
-			if len(ext.EnvVars) > 0 {
-				fmt.Println("\nEnvironment Variables:")
-				for _, env := range ext.EnvVars {
-					fmt.Printf("  - %s\n", env)
-				}
-			}
-
-			if len(ext.OtelVars) > 0 {
-				fmt.Println("\nOpenTelemetry Variables:")
-				for _, env := range ext.OtelVars {
-					fmt.Printf("  - %s\n", env)
-				}
-			}
-
-			if len(ext.Config.Mounts) > 0 {
-				fmt.Println("\nMounts:")
-				for _, m := range ext.Config.Mounts {
-					fmt.Printf("  - %s -> %s\n", m.Source, m.Target)
-				}
-			}
-
-			if len(ext.Flags) > 0 {
-				fmt.Println("\nFlags:")
-				for _, f := range ext.Flags {
-					fmt.Printf("  %-12s %s\n", f.Flag, f.Description)
-				}
-			}
-
-			fmt.Println("\nUsage:")
-			fmt.Printf("  addt run %s [args...]\n", ext.Name)
+			writeInfo(os.Stdout, ext)
 			return
 		}
 	}
@@ -81,3 +28,65 @@ func ShowInfo(name string) {
 	fmt.Println("Run 'addt extensions list' to see available extensions")
 	os.Exit(1)
 }
+
+// writeInfo writes the details of ext shown by "addt extensions info"
+func writeInfo(w io.Writer, ext extensions.ExtensionConfig) {
+	version := ext.DefaultVersion
+	if version == "" {
+		version = "latest"
+	}
+
+	fmt.Fprintf(w, "%s\n", ext.Name)
+	fmt.Fprintf(w, "%s\n\n", strings.Repeat("=", len(ext.Name)))
+
+	fmt.Fprintf(w, "  %s\n\n", ext.Description)
+
+	source := "built-in"
+	if ext.IsLocal {
+		source = "local (~/.addt/extensions/" + ext.Name + ")"
+	}
+
+	fmt.Fprintln(w, "Configuration:")
+	fmt.Fprintf(w, "  Entrypoint:  %s\n", ext.Entrypoint)
+	fmt.Fprintf(w, "  Version:     %s\n", version)
+	fmt.Fprintf(w, "  Auto-mount:  %v\n", ext.Config.Automount)
+	fmt.Fprintf(w, "  Source:      %s\n", source)
+
+	if len(ext.Dependencies) > 0 {
+		fmt.Fprintf(w, "  Depends on:  %s\n", strings.Join(ext.Dependencies, ", "))
+	}
+	if len(ext.RequiresHost) > 0 {
+		fmt.Fprintf(w, "  Host tools:  %s\n", strings.Join(ext.RequiresHost, ", "))
+	}
+
+	if len(ext.EnvVars) > 0 {
+		fmt.Fprintln(w, "\nEnvironment Variables:")
+		for _, env := range ext.EnvVars {
+			fmt.Fprintf(w, "  - %s\n", env)
+		}
+	}
+
+	if len(ext.OtelVars) > 0 {
+		fmt.Fprintln(w, "\nOpenTelemetry Variables:")
+		for _, env := range ext.OtelVars {
+			fmt.Fprintf(w, "  - %s\n", env)
+		}
+	}
+
+	if len(ext.Config.Mounts) > 0 {
+		fmt.Fprintln(w, "\nMounts:")
+		for _, m := range ext.Config.Mounts {
+			fmt.Fprintf(w, "  - %s -> %s\n", m.Source, m.Target)
+		}
+	}
+
+	if len(ext.Flags) > 0 {
+		fmt.Fprintln(w, "\nFlags:")
+		for _, f := range ext.Flags {
+			fmt.Fprintf(w, "  %-12s %s\n", f.Flag, f.Description)
+		}
+	}
+
+	fmt.Fprintln(w, "\nUsage:")
+	fmt.Fprintf(w, "  addt run %s [args...]\n", ext.Name)
+}
@@ -0,0 +1,30 @@
+package extensions
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/jedi4ever/addt/extensions"
+)
+
+func TestWriteInfo_RequiresHost(t *testing.T) {
+	ext := extensions.ExtensionConfig{
+		Name:         "ghagent",
+		Description:  "Agent that drives the GitHub CLI",
+		Entrypoint:   extensions.Entrypoint{"ghagent"},
+		RequiresHost: []string{"gh", "ssh-agent"},
+	}
+	var buf bytes.Buffer
+	writeInfo(&buf, ext)
+	if !strings.Contains(buf.String(), "Host tools:  gh, ssh-agent") {
+		t.Errorf("info missing host tools:\n%s", buf.String())
+	}
+
+	ext.RequiresHost = nil
+	buf.Reset()
+	writeInfo(&buf, ext)
+	if strings.Contains(buf.String(), "Host tools") {
+		t.Errorf("info shows host tools for an extension without requires_host:\n%s", buf.String())
+	}
+}
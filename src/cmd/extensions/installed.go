@@ -0,0 +1,99 @@
+package extensions
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jedi4ever/addt/extensions"
+)
+
+// ActiveSet is the extension set a run in the current directory would use
+type ActiveSet struct {
+	Extensions  []string                // selected extensions, in selection order
+	Versions    map[string]string       // resolved version per extension
+	Image       string                  // image tag for the whole set
+	ImageExists func(image string) bool // nil when the provider can't tell
+}
+
+// ActiveSetResolver resolves the active set. It is supplied by the root
+// command, which owns config loading and provider creation.
+type ActiveSetResolver func() (*ActiveSet, error)
+
+// InstalledExtension is one row of "extensions list --installed"
+type InstalledExtension struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Source      string `json:"source"` // built-in, local or unknown
+	Image       string `json:"image,omitempty"`
+	ImageExists bool   `json:"image_exists"`
+}
+
+// installedExtensions annotates each selected extension with its resolved
+// version, where it comes from and whether the set's image has been built
+func installedExtensions(set *ActiveSet, available []extensions.ExtensionConfig) []InstalledExtension {
+	sources := make(map[string]string, len(available))
+	for _, ext := range available {
+		sources[ext.Name] = "built-in"
+		if ext.IsLocal {
+			sources[ext.Name] = "local"
+		}
+	}
+
+	exists := set.Image != "" && set.ImageExists != nil && set.ImageExists(set.Image)
+	list := make([]InstalledExtension, 0, len(set.Extensions))
+	for _, name := range set.Extensions {
+		source := sources[name]
+		if source == "" {
+			source = "unknown"
+		}
+		version := set.Versions[name]
+		if version == "" {
+			version = "latest"
+		}
+		list = append(list, InstalledExtension{
+			Name:        name,
+			Version:     version,
+			Source:      source,
+			Image:       set.Image,
+			ImageExists: exists,
+		})
+	}
+	return list
+}
+
+// writeInstalled prints the installed extensions as a table or JSON
+func writeInstalled(w io.Writer, list []InstalledExtension, asJSON bool) error {
+	if asJSON {
+		if list == nil {
+			list = []InstalledExtension{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(list)
+	}
+	if len(list) == 0 {
+		fmt.Fprintln(w, "No extensions selected (set ADDT_EXTENSIONS or run addt run <extension>)")
+		return nil
+	}
+
+	maxName, maxVer := len("Name"), len("Version")
+	for _, ext := range list {
+		maxName = max(maxName, len(ext.Name))
+		maxVer = max(maxVer, len(ext.Version))
+	}
+	fmt.Fprintf(w, "  %-*s  %-*s  %-8s  %s\n", maxName, "Name", maxVer, "Version", "Source", "Image")
+	fmt.Fprintf(w, "  %s  %s  %s  %s\n", strings.Repeat("-", maxName), strings.Repeat("-", maxVer), strings.Repeat("-", 8), "-----")
+	for _, ext := range list {
+		image := "not built"
+		if ext.ImageExists {
+			image = "built"
+		}
+		fmt.Fprintf(w, "  %-*s  %-*s  %-8s  %s\n", maxName, ext.Name, maxVer, ext.Version, ext.Source, image)
+	}
+	if list[0].Image != "" {
+		fmt.Fprintf(w, "\nImage: %s\n", list[0].Image)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package extensions
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jedi4ever/addt/extensions"
+)
+
+func TestInstalledExtensions(t *testing.T) {
+	available := []extensions.ExtensionConfig{
+		{Name: "claude"},
+		{Name: "codex"},
+		{Name: "mytool", IsLocal: true},
+	}
+	var checked string
+	set := &ActiveSet{
+		Extensions: []string{"claude", "mytool", "ghost"},
+		Versions:   map[string]string{"claude": "2.0.14", "mytool": "1.2.0"},
+		Image:      "addt:v0.1_claude-2.0.14_mytool-1.2.0-abc-def",
+		ImageExists: func(image string) bool {
+			checked = image
+			return true
+		},
+	}
+
+	got := installedExtensions(set, available)
+	want := []InstalledExtension{
+		{Name: "claude", Version: "2.0.14", Source: "built-in", Image: set.Image, ImageExists: true},
+		{Name: "mytool", Version: "1.2.0", Source: "local", Image: set.Image, ImageExists: true},
+		{Name: "ghost", Version: "latest", Source: "unknown", Image: set.Image, ImageExists: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("installedExtensions() =\n  %+v\nwant\n  %+v", got, want)
+	}
+	if checked != set.Image {
+		t.Errorf("ImageExists checked %q, want %q", checked, set.Image)
+	}
+}
+
+func TestInstalledExtensions_ImageMissing(t *testing.T) {
+	set := &ActiveSet{
+		Extensions:  []string{"claude"},
+		Image:       "addt:v0.1_claude-stable",
+		ImageExists: func(string) bool { return false },
+	}
+	got := installedExtensions(set, []extensions.ExtensionConfig{{Name: "claude"}})
+	if len(got) != 1 || got[0].ImageExists {
+		t.Errorf("installedExtensions() = %+v, want image_exists false", got)
+	}
+
+	// A provider that can't check images reports them as not built
+	set.ImageExists = nil
+	if got := installedExtensions(set, nil); got[0].ImageExists {
+		t.Errorf("installedExtensions() without checker = %+v, want image_exists false", got)
+	}
+}
+
+func TestWriteInstalled(t *testing.T) {
+	list := []InstalledExtension{
+		{Name: "claude", Version: "2.0.14", Source: "built-in", Image: "addt:img", ImageExists: true},
+	}
+
+	var table bytes.Buffer
+	if err := writeInstalled(&table, list, false); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"claude", "2.0.14", "built-in", "built", "Image: addt:img"} {
+		if !strings.Contains(table.String(), want) {
+			t.Errorf("table missing %q:\n%s", want, table.String())
+		}
+	}
+
+	var out bytes.Buffer
+	if err := writeInstalled(&out, list, true); err != nil {
+		t.Fatal(err)
+	}
+	var decoded []InstalledExtension
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out.String())
+	}
+	if !reflect.DeepEqual(decoded, list) {
+		t.Errorf("JSON round trip = %+v, want %+v", decoded, list)
+	}
+
+	var empty bytes.Buffer
+	writeInstalled(&empty, nil, true)
+	if strings.TrimSpace(empty.String()) != "[]" {
+		t.Errorf("empty JSON = %q, want []", empty.String())
+	}
+}
@@ -1,7 +1,9 @@
 package extensions
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/jedi4ever/addt/extensions"
@@ -16,14 +18,63 @@ func ListExtensions() []extensions.ExtensionConfig {
 	return exts
 }
 
-// List prints all available extensions
-func List() {
+// availableExtension is one entry of "extensions list --json"
+type availableExtension struct {
+	Name        string `json:"name"`
+	Entrypoint  string `json:"entrypoint"`
+	Version     string `json:"version"`
+	Source      string `json:"source"`
+	Description string `json:"description"`
+
+	RequiresHost []string `json:"requires_host,omitempty"`
+}
+
+// List prints the available extensions, or with --installed only the active
+// set resolved by resolve. --json switches either listing to JSON.
+func List(args []string, resolve ActiveSetResolver) {
+	installed, asJSON := false, false
+	for _, arg := range args {
+		switch arg {
+		case "--installed":
+			installed = true
+		case "--json":
+			asJSON = true
+		case "-h", "--help":
+			fmt.Println("Usage: addt extensions list [--installed] [--json]")
+			fmt.Println()
+			fmt.Println("  --installed   Only the extensions selected for this directory, with their")
+			fmt.Println("                resolved version and whether their image is built")
+			fmt.Println("  --json        Print JSON")
+			return
+		default:
+			fmt.Printf("Unknown flag: %s\n", arg)
+			os.Exit(1)
+		}
+	}
+
 	exts, err := extensions.GetExtensions()
 	if err != nil {
 		fmt.Printf("Error reading extensions: %v\n", err)
 		return
 	}
 
+	if installed {
+		set, err := resolve()
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		if err := writeInstalled(os.Stdout, installedExtensions(set, exts), asJSON); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if asJSON {
+		printAvailableJSON(exts)
+		return
+	}
+
 	// Find max lengths for alignment
 	maxName := 4   // "Name"
 	maxEntry := 10 // "Entrypoint"
@@ -67,3 +118,30 @@ func List() {
 		fmt.Printf("\nLocal extensions directory: %s\n", localDir)
 	}
 }
+
+// printAvailableJSON prints the available extensions as JSON
+func printAvailableJSON(exts []extensions.ExtensionConfig) {
+	list := make([]availableExtension, 0, len(exts))
+	for _, ext := range exts {
+		version := ext.DefaultVersion
+		if version == "" {
+			version = "latest"
+		}
+		source := "built-in"
+		if ext.IsLocal {
+			source = "local"
+		}
+		list = append(list, availableExtension{
+			Name:        ext.Name,
+			Entrypoint:  ext.Entrypoint.Command(),
+			Version:     version,
+			Source:      source,
+			Description: ext.Description,
+
+			RequiresHost: ext.RequiresHost,
+		})
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	enc.Encode(list)
+}
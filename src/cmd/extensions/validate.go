@@ -0,0 +1,39 @@
+package extensions
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jedi4ever/addt/extensions"
+)
+
+// Validate lints an extension config.yaml (or extension directory) and
+// exits non-zero when problems are found
+func Validate(path string) {
+	issues, err := extensions.ValidateConfigFile(path)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(issues) == 0 {
+		fmt.Printf("✓ %s is valid\n", path)
+		return
+	}
+
+	fmt.Printf("✗ %s has %d problem(s):\n", path, len(issues))
+	for _, issue := range issues {
+		fmt.Printf("  %s\n", issue)
+	}
+	os.Exit(1)
+}
+
+// printValidateHelp prints usage for "extensions validate"
+func printValidateHelp(prefix string) {
+	fmt.Printf("Usage: %s extensions validate <path>\n", prefix)
+	fmt.Println()
+	fmt.Println("Lint an extension config.yaml, or the extension directory holding it.")
+	fmt.Println("Checks the required fields (name, default_version, entrypoint), flag")
+	fmt.Println("definitions, env var entries, config.mounts and firewall rules, and")
+	fmt.Println("reports each problem with its line. Exits non-zero when problems are found.")
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"sort"
+	"strings"
+
+	extcmd "github.com/jedi4ever/addt/cmd/extensions"
+	"github.com/jedi4ever/addt/config"
+	"github.com/jedi4ever/addt/provider"
+)
+
+// imageChecker is implemented by providers that can look up a local image
+type imageChecker interface {
+	ImageExists(imageName string) bool
+}
+
+// activeSetResolver returns the resolver behind "extensions list --installed".
+// The selection is ADDT_EXTENSIONS (also set from the binary name), else the
+// extensions configured in the project and global config files.
+func activeSetResolver(version, defaultNodeVersion, defaultGoVersion, defaultUvVersion string, defaultPortRangeStart int) extcmd.ActiveSetResolver {
+	return func() (*extcmd.ActiveSet, error) {
+		cfg := config.LoadConfig(version, defaultNodeVersion, defaultGoVersion, defaultUvVersion, defaultPortRangeStart)
+		exts := runExtensions(cfg.Extensions)
+		if len(exts) == 0 {
+			exts = configuredExtensions(config.LoadProjectConfig(), config.LoadGlobalConfig())
+		}
+		if len(exts) == 0 {
+			return &extcmd.ActiveSet{}, nil
+		}
+
+		providerCfg := &provider.Config{
+			AddtVersion:       cfg.AddtVersion,
+			ExtensionVersions: cfg.ExtensionVersions,
+			NodeVersion:       cfg.NodeVersion,
+			GoVersion:         cfg.GoVersion,
+			UvVersion:         cfg.UvVersion,
+			Provider:          cfg.Provider,
+			Extensions:        strings.Join(exts, ","),
+			Workdir:           cfg.Workdir,
+			ContainerPlatform: cfg.ContainerPlatform,
+		}
+		prov, err := NewProvider(cfg.Provider, providerCfg)
+		if err != nil {
+			return nil, err
+		}
+
+		set := &extcmd.ActiveSet{Extensions: exts}
+		// DetermineImageName resolves dist-tags into providerCfg.ExtensionVersions
+		set.Image = prov.DetermineImageName()
+		set.Versions = extensionVersions(exts, providerCfg.ExtensionVersions)
+		if checker, ok := prov.(imageChecker); ok {
+			set.ImageExists = checker.ImageExists
+		}
+		return set, nil
+	}
+}
+
+// configuredExtensions lists the extensions that have settings in any of
+// cfgs, sorted by name
+func configuredExtensions(cfgs ...*config.GlobalConfig) []string {
+	seen := make(map[string]bool)
+	var exts []string
+	for _, cfg := range cfgs {
+		if cfg == nil {
+			continue
+		}
+		for name := range cfg.Extensions {
+			if !seen[name] {
+				seen[name] = true
+				exts = append(exts, name)
+			}
+		}
+	}
+	sort.Strings(exts)
+	return exts
+}
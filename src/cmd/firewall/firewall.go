@@ -42,11 +42,13 @@ func HandleCommand(args []string) {
 		handleProject(args[1:])
 	case "extension":
 		handleExtension(args[1:])
+	case "log":
+		handleLog(args[1:])
 	case "help", "--help", "-h":
 		printHelp()
 	default:
 		fmt.Printf("Unknown firewall scope: %s\n", scope)
-		fmt.Println("Use: global, project, or extension")
+		fmt.Println("Use: global, project, extension or log")
 		printHelp()
 		os.Exit(1)
 	}
@@ -61,6 +63,7 @@ Scopes:
   global                   Manage global firewall rules (~/.addt/config.yaml)
   project                  Manage project firewall rules (.addt.yaml)
   extension <name>         Manage per-extension firewall rules
+  log [<container>] [-f]   Show connections the firewall blocked (firewall.log_blocked)
 
 Commands:
   allow <domain>           Add domain to allowed list
@@ -85,6 +85,7 @@ func globalList(cfg *config.GlobalConfig) {
 	fw := ensureFirewall(cfg)
 	fmt.Println("Global firewall rules:")
 	printDomainList("  Allowed", fw.Allowed, DefaultAllowedDomains(), fw.Denied)
+	printPresets(fw.Presets)
 	fmt.Printf("  Denied:\n")
 	if len(fw.Denied) == 0 {
 		fmt.Printf("    (none)\n")
@@ -2,6 +2,7 @@ package firewall
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/jedi4ever/addt/config"
 )
@@ -72,6 +73,22 @@ func printDomainList(label string, domains []string, defaults []string, denied [
 	}
 }
 
+// printPresets prints the ecosystem presets and the domains they add
+func printPresets(presets []string) {
+	if len(presets) == 0 {
+		return
+	}
+	fmt.Printf("  Presets:\n")
+	for _, name := range presets {
+		domains, unknown := config.ExpandFirewallPresets([]string{name})
+		if len(unknown) > 0 {
+			fmt.Printf("    - %s (unknown preset)\n", name)
+			continue
+		}
+		fmt.Printf("    - %s: %s\n", name, strings.Join(domains, ", "))
+	}
+}
+
 // ensureFirewall initializes the Firewall settings struct if nil
 func ensureFirewall(cfg *config.GlobalConfig) *config.FirewallSettings {
 	if cfg.Firewall == nil {
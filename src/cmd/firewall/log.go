@@ -0,0 +1,114 @@
+package firewall
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jedi4ever/addt/provider"
+)
+
+// handleLog handles "addt firewall log [<container>] [-f]", printing the
+// destinations firewall.log_blocked recorded for a container
+func handleLog(args []string) {
+	name, follow := "", false
+	for _, arg := range args {
+		switch arg {
+		case "-f", "--follow":
+			follow = true
+		case "-h", "--help", "help":
+			printLogHelp()
+			return
+		default:
+			if name != "" || strings.HasPrefix(arg, "-") {
+				printLogHelp()
+				os.Exit(1)
+			}
+			name = arg
+		}
+	}
+
+	if name == "" {
+		if err := listBlockedLogs(os.Stdout, provider.FirewallBlockedLogDir()); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	path := provider.FirewallBlockedLogPath(name)
+	f, err := os.Open(path)
+	if err != nil {
+		fmt.Printf("No blocked-connection log for %s (%s)\n", name, path)
+		fmt.Println("Enable it with: addt config set firewall.log_blocked true")
+		os.Exit(1)
+	}
+	defer f.Close()
+	io.Copy(os.Stdout, f)
+	// Reading past EOF returns lines the firewall appended since
+	for follow {
+		time.Sleep(time.Second)
+		io.Copy(os.Stdout, f)
+	}
+}
+
+// listBlockedLogs prints each container with a blocked-connection log in
+// dir and how many destinations it recorded
+func listBlockedLogs(w io.Writer, dir string) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.log"))
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		fmt.Fprintln(w, "No blocked-connection logs. Enable them with: addt config set firewall.log_blocked true")
+		return nil
+	}
+	sort.Strings(paths)
+	fmt.Fprintf(w, "%-40s  %s\n", "CONTAINER", "BLOCKED")
+	for _, path := range paths {
+		count, err := countLines(path)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%-40s  %d\n", strings.TrimSuffix(filepath.Base(path), ".log"), count)
+	}
+	return nil
+}
+
+func countLines(path string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	count := 0
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		count++
+	}
+	return count, scanner.Err()
+}
+
+func printLogHelp() {
+	fmt.Println(`Usage: addt firewall log [<container>] [-f]
+
+Show the destinations the firewall blocked for a container, one line per
+new address (would-block in permissive mode). Needs firewall.log_blocked:
+
+  addt config set firewall.log_blocked true
+
+Without a container, lists the containers that have a log. Logs are kept
+in ~/.addt/firewall/blocked/<container>.log.
+
+Flags:
+  -f, --follow   Keep printing new entries
+
+Examples:
+  addt firewall log
+  addt firewall log addt-persistent-app-1a2b3c4d -f`)
+}
@@ -0,0 +1,32 @@
+package firewall
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestListBlockedLogs(t *testing.T) {
+	dir := t.TempDir()
+	var buf bytes.Buffer
+	if err := listBlockedLogs(&buf, dir); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "firewall.log_blocked true") {
+		t.Errorf("empty dir output = %q, want a hint to enable logging", buf.String())
+	}
+
+	log := "2026-01-02T03:04:05Z blocked 203.0.113.7\n2026-01-02T03:04:07Z blocked 198.51.100.2\n"
+	if err := os.WriteFile(filepath.Join(dir, "addt-persistent-app-1a2b3c4d.log"), []byte(log), 0644); err != nil {
+		t.Fatal(err)
+	}
+	buf.Reset()
+	if err := listBlockedLogs(&buf, dir); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "addt-persistent-app-1a2b3c4d") || !strings.HasSuffix(buf.String(), "  2\n") {
+		t.Errorf("output = %q, want the container with 2 blocked entries", buf.String())
+	}
+}
@@ -85,6 +85,7 @@ func projectList(cfg *config.GlobalConfig) {
 	fw := ensureFirewall(cfg)
 	fmt.Println("Project firewall rules:")
 	printDomainList("  Allowed", fw.Allowed, nil, fw.Denied)
+	printPresets(fw.Presets)
 	fmt.Printf("  Denied:\n")
 	if len(fw.Denied) == 0 {
 		fmt.Printf("    (none)\n")
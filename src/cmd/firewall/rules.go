@@ -0,0 +1,73 @@
+package firewall
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/jedi4ever/addt/config"
+)
+
+// Rule is a domain with the layer whose rule decided it
+type Rule struct {
+	Domain string
+	Layer  string
+}
+
+// ResolveFirewallRules merges the four layers (defaults, extension, global,
+// project) into the effective allow and deny lists. Every domain named in
+// any layer is evaluated with CheckDomain, so the result matches what a
+// check of that domain reports. Both lists are sorted by domain.
+func ResolveFirewallRules(cfg *config.Config) (allowed, denied []Rule) {
+	seen := make(map[string]bool)
+	var domains []string
+	for _, list := range [][]string{
+		DefaultAllowedDomains(),
+		cfg.ExtensionFirewallAllowed, cfg.ExtensionFirewallDenied,
+		cfg.GlobalFirewallAllowed, cfg.GlobalFirewallDenied,
+		cfg.ProjectFirewallAllowed, cfg.ProjectFirewallDenied,
+	} {
+		for _, domain := range list {
+			if !seen[domain] {
+				seen[domain] = true
+				domains = append(domains, domain)
+			}
+		}
+	}
+	sort.Strings(domains)
+
+	for _, domain := range domains {
+		ok, layer := CheckDomain(domain, cfg, "")
+		if ok {
+			allowed = append(allowed, Rule{Domain: domain, Layer: layer})
+		} else {
+			denied = append(denied, Rule{Domain: domain, Layer: layer})
+		}
+	}
+	return allowed, denied
+}
+
+// PrintRules writes the effective allow and deny lists with the layer
+// each entry came from
+func PrintRules(w io.Writer, cfg *config.Config) {
+	allowed, denied := ResolveFirewallRules(cfg)
+
+	if cfg.FirewallEnabled {
+		fmt.Fprintf(w, "Firewall: enabled (mode: %s)\n", cfg.FirewallMode)
+	} else {
+		fmt.Fprintf(w, "Firewall: disabled (rules below apply once enabled, mode: %s)\n", cfg.FirewallMode)
+	}
+	printRuleList(w, "Allowed", allowed)
+	printRuleList(w, "Denied", denied)
+}
+
+func printRuleList(w io.Writer, label string, rules []Rule) {
+	fmt.Fprintf(w, "\n%s (%d):\n", label, len(rules))
+	if len(rules) == 0 {
+		fmt.Fprintln(w, "  (none)")
+		return
+	}
+	for _, rule := range rules {
+		fmt.Fprintf(w, "  %-40s [%s]\n", rule.Domain, rule.Layer)
+	}
+}
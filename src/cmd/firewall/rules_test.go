@@ -0,0 +1,100 @@
+package firewall
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jedi4ever/addt/config"
+)
+
+func TestResolveFirewallRules_Layers(t *testing.T) {
+	cfg := &config.Config{
+		ExtensionFirewallAllowed: []string{"api.openai.com", "ext.example.com"},
+		GlobalFirewallDenied:     []string{"registry.npmjs.org", "ext.example.com"},
+		GlobalFirewallAllowed:    []string{"global.example.com"},
+		ProjectFirewallAllowed:   []string{"registry.npmjs.org"},
+		ProjectFirewallDenied:    []string{"pypi.org"},
+	}
+
+	allowed, denied := ResolveFirewallRules(cfg)
+
+	wantDenied := []Rule{
+		{Domain: "ext.example.com", Layer: "global"},
+		{Domain: "pypi.org", Layer: "project"},
+	}
+	if !reflect.DeepEqual(denied, wantDenied) {
+		t.Errorf("denied = %v, want %v", denied, wantDenied)
+	}
+
+	layers := make(map[string]string)
+	for _, rule := range allowed {
+		layers[rule.Domain] = rule.Layer
+	}
+	for domain, layer := range map[string]string{
+		"api.anthropic.com":  "defaults",
+		"api.openai.com":     "extension",
+		"global.example.com": "global",
+		"registry.npmjs.org": "project",
+	} {
+		if layers[domain] != layer {
+			t.Errorf("allowed %s from %q, want %q", domain, layers[domain], layer)
+		}
+	}
+	if _, ok := layers["pypi.org"]; ok {
+		t.Error("pypi.org denied by project but listed as allowed")
+	}
+	if want := len(DefaultAllowedDomains()) + 2 - 1; len(allowed) != want {
+		t.Errorf("len(allowed) = %d, want %d", len(allowed), want)
+	}
+}
+
+func TestResolveFirewallRules_MatchesCheckDomain(t *testing.T) {
+	cfg := &config.Config{
+		GlobalFirewallDenied:   []string{"github.com"},
+		ProjectFirewallAllowed: []string{"internal.example.com"},
+	}
+	allowed, denied := ResolveFirewallRules(cfg)
+	for _, rule := range append(allowed, denied...) {
+		ok, layer := CheckDomain(rule.Domain, cfg, "")
+		if layer != rule.Layer || ok != containsRule(allowed, rule.Domain) {
+			t.Errorf("%s resolved to %s, CheckDomain says allowed=%v from %s", rule.Domain, rule.Layer, ok, layer)
+		}
+	}
+}
+
+func TestPrintRules(t *testing.T) {
+	cfg := &config.Config{
+		FirewallEnabled:      true,
+		FirewallMode:         "strict",
+		GlobalFirewallDenied: []string{"unpkg.com"},
+	}
+	var buf bytes.Buffer
+	PrintRules(&buf, cfg)
+	out := buf.String()
+
+	for _, want := range []string{
+		"Firewall: enabled (mode: strict)",
+		"Denied (1):",
+		"[global]",
+		"[defaults]",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	deniedSection := out[strings.Index(out, "Denied"):]
+	if !strings.Contains(deniedSection, "unpkg.com") {
+		t.Errorf("unpkg.com not in denied section:\n%s", out)
+	}
+}
+
+func containsRule(rules []Rule, domain string) bool {
+	for _, rule := range rules {
+		if rule.Domain == domain {
+			return true
+		}
+	}
+	return false
+}
@@ -14,19 +14,26 @@ func PrintHelp(version string) {
 Version: %s
 
 Commands:
-  addt run <extension> [args...]     Run a specific extension
+  addt run [flags] <extension> [args...]  Run a specific extension
   addt init [-y] [-f]                Initialize project config
   addt update <extension> [version]  Update extension to latest/specific version
   addt build <extension>             Build the container image
   addt shell <extension>             Open bash shell in container
   addt containers [list|stop|rm]     Manage containers
+  addt stop [<extension>] [--all]    Stop persistent containers
+  addt restart [<extension>]         Restart a persistent container
+  addt secrets check [<extension>]   Show which env vars are treated as secrets
+  addt stats [<extension>] [--watch] Show container resource usage
+  addt load-image <file.tar>         Load an image saved with run --save-image
   addt firewall [list|add|rm|reset]  Manage firewall
   addt extensions [list|info|new]    Manage extensions
   addt config [list|set|get|unset|audit] [-g]  Manage configuration
   addt config extension <name> [list|set|get|unset]  Extension config
   addt profile [list|show|apply]     Apply configuration presets
+  addt security explain [--json]     Show the effective security posture
+  addt trust|untrust [dir]           Trust or untrust a workdir for agents
   addt completion [bash|zsh|fish]    Generate shell completions
-  addt doctor                        Check system health
+  addt doctor [--fix]                Check system health (--fix: apply safe fixes)
   addt cli [update|install-podman]   Manage addt CLI
   addt version                       Show version info
 
@@ -53,11 +60,17 @@ Container management (via agent):
   <agent> addt build                         Build the container image
   <agent> addt shell                         Open bash shell in container
   <agent> addt containers [list|stop|rm]     Manage persistent containers
+  <agent> addt stop [--all]                  Stop persistent containers
+  <agent> addt restart                       Restart the persistent container
+  <agent> addt secrets check                 Show which env vars are treated as secrets
+  <agent> addt stats [--json] [--watch]      Show container resource usage
   <agent> addt firewall [list|add|rm|reset]  Manage network firewall
   <agent> addt extensions [list|info|new]    Manage extensions
   <agent> addt config [list|set|get|unset|audit] [-g]  Manage configuration
   <agent> addt config extension <name> [list|set|get|unset]  Extension config
   <agent> addt profile [list|show|apply]     Apply configuration presets
+  <agent> addt security explain [--json]     Show the effective security posture
+  <agent> addt trust|untrust [dir]           Trust or untrust a workdir for agents
   <agent> addt cli [update]                  Manage addt CLI
   <agent> addt version                       Show version info
 
@@ -86,11 +99,14 @@ Environment Variables:
 
   Docker-in-Docker:
     ADDT_DOCKER_DIND_ENABLE  Enable Docker-in-Docker (default: false)
-    ADDT_DOCKER_DIND_MODE    DinD mode: host or isolated (default: isolated)
+    ADDT_DOCKER_DIND_MODE    DinD mode: host, isolated or off (default: isolated)
 
   Security/Network:
     ADDT_FIREWALL          Enable network firewall (default: false)
     ADDT_FIREWALL_MODE     Firewall mode: strict, permissive, off (default: strict)
+    ADDT_FIREWALL_REQUIRE_PASTA  Podman: fail when the firewall is on but pasta is missing
+    ADDT_FIREWALL_LOG_BLOCKED    Record blocked destinations for 'addt firewall log'
+    ADDT_FIREWALL_ASYNC          Start the agent without waiting for firewall init
     ADDT_SSH_FORWARD_KEYS  SSH key forwarding: true or false (default: true)
     ADDT_SSH_FORWARD_MODE  SSH forwarding mode: agent, keys, or proxy (default: proxy)
     ADDT_SSH_ALLOWED_KEYS  Comma-separated key filters for proxy mode (e.g., "github,work")
@@ -103,10 +119,11 @@ Environment Variables:
     ADDT_UV_VERSION        UV Python version (default: latest)
 
   Other:
-    ADDT_PROVIDER          Provider: docker, rancher, podman, orbstack, or daytona (auto-detected)
+    ADDT_PROVIDER          Provider: docker, rancher, podman, orbstack, applecontainer, or daytona (auto-detected)
     ADDT_PROVIDER_AUTOSELECT  Provider auto-detection order (default: orbstack,rancher,docker,podman)
     ADDT_HOME              Addt data directory (default: ~/.addt)
     ADDT_CONFIG_DIR        Global config directory (overrides ADDT_HOME for config only)
+    ADDT_STRICT_CONFIG     Warn about unknown config file keys (default: false)
     ADDT_GITHUB_FORWARD_TOKEN  Forward GH_TOKEN to container (default: true)
     ADDT_GITHUB_TOKEN_SOURCE   Token source: env or gh_auth (default: gh_auth)
     ADDT_GITHUB_SCOPE_TOKEN    Scope GH_TOKEN to workspace repo (default: true)
@@ -114,12 +131,14 @@ Environment Variables:
     ADDT_PORTS_FORWARD     Enable port forwarding (default: true)
     ADDT_PORTS             Comma-separated container ports to expose
     ADDT_PORTS_INJECT_SYSTEM_PROMPT  Inject port mappings into AI system prompt (default: true)
+    ADDT_PORTS_PROMPT_TEMPLATE       Go template for the injected port prompt
     ADDT_PORT_RANGE_START  Starting port for allocation (default: 30000)
     ADDT_ENV_VARS          Env vars to pass (default: ANTHROPIC_API_KEY,GH_TOKEN)
     ADDT_ENV_FILE_LOAD     Load .env file (default: true)
     ADDT_ENV_FILE          Path to .env file (default: .env)
     ADDT_LOG               Enable command logging (default: false)
     ADDT_LOG_FILE          Log file path (default: addt.log)
+    ADDT_LOG_CAPTURE_CONTAINER  Log non-interactive container output (default: false)
     ADDT_EXTENSIONS        Extensions to install (e.g., claude,codex)
     ADDT_COMMAND           Command to run (e.g., codex, gemini)
 
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jedi4ever/addt/config"
+	"github.com/jedi4ever/addt/provider"
+)
+
+// imageTransfer returns the provider's image save/load support
+func imageTransfer(prov provider.Provider) (provider.ImageTransferProvider, error) {
+	t, ok := prov.(provider.ImageTransferProvider)
+	if !ok {
+		return nil, fmt.Errorf("saving and loading images is not supported by the %s provider: %w", prov.GetName(), provider.ErrUnsupported)
+	}
+	return t, nil
+}
+
+// saveImage exports the resolved image after the build when --save-image is set
+func (f *RunFlags) saveImage(prov provider.Provider, imageName string) error {
+	if f == nil || f.SaveImage == "" {
+		return nil
+	}
+	t, err := imageTransfer(prov)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Saving image %s to %s\n", imageName, f.SaveImage)
+	return t.SaveImage(imageName, f.SaveImage)
+}
+
+// HandleLoadImageCommand handles "addt load-image <file.tar>", importing an
+// image saved with "addt run --save-image" on another machine
+func HandleLoadImageCommand(prov provider.Provider, args []string) {
+	if len(args) == 1 && (args[0] == "-h" || args[0] == "--help") {
+		printLoadImageHelp()
+		return
+	}
+	if len(args) != 1 || strings.HasPrefix(args[0], "-") {
+		printLoadImageHelp()
+		os.Exit(1)
+	}
+	if err := loadImage(prov, args[0]); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Loaded image from %s\n", args[0])
+}
+
+// loadImage imports the tarball at path into the provider's image store
+func loadImage(prov provider.Provider, path string) error {
+	t, err := imageTransfer(prov)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); err != nil {
+		return err
+	}
+	return t.LoadImage(path)
+}
+
+// handleLoadImageSubcommand creates the provider for "addt load-image" and runs it
+func handleLoadImageSubcommand(cfg *config.Config, args []string) {
+	providerCfg := &provider.Config{
+		AddtVersion: cfg.AddtVersion,
+		Provider:    cfg.Provider,
+	}
+	prov, err := NewProvider(cfg.Provider, providerCfg)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	HandleLoadImageCommand(prov, args)
+}
+
+func printLoadImageHelp() {
+	fmt.Println(`Usage: addt load-image <file.tar>
+
+Load an image saved with "addt run --save-image <file.tar>", for example on
+a machine without network access. Supported by the docker and podman providers.
+
+Example:
+  addt run --save-image claude.tar claude   # on a connected machine
+  addt load-image claude.tar                # on the offline machine`)
+}
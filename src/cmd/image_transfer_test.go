@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jedi4ever/addt/provider"
+	"github.com/jedi4ever/addt/provider/docker"
+	"github.com/jedi4ever/addt/provider/podman"
+)
+
+// The docker and podman providers support --save-image and load-image
+var (
+	_ provider.ImageTransferProvider = (*docker.DockerProvider)(nil)
+	_ provider.ImageTransferProvider = (*podman.PodmanProvider)(nil)
+)
+
+// transferMockProvider records image save/load calls
+type transferMockProvider struct {
+	mockProvider
+	savedImage, savedPath, loadedPath string
+}
+
+func (m *transferMockProvider) SaveImage(imageName, path string) error {
+	m.savedImage, m.savedPath = imageName, path
+	return nil
+}
+
+func (m *transferMockProvider) LoadImage(path string) error {
+	m.loadedPath = path
+	return nil
+}
+
+func TestSaveImage_UsesResolvedImage(t *testing.T) {
+	flags, rest, err := parseRunFlags([]string{"--save-image", "claude.tar", "claude"})
+	if err != nil {
+		t.Fatalf("parseRunFlags() error = %v", err)
+	}
+	if flags.SaveImage != "claude.tar" || len(rest) != 1 || rest[0] != "claude" {
+		t.Fatalf("SaveImage = %q, rest = %v", flags.SaveImage, rest)
+	}
+
+	prov := &transferMockProvider{}
+	if err := flags.saveImage(prov, prov.DetermineImageName()); err != nil {
+		t.Fatalf("saveImage() error = %v", err)
+	}
+	if prov.savedImage != "test-image" || prov.savedPath != "claude.tar" {
+		t.Errorf("SaveImage(%q, %q), want (test-image, claude.tar)", prov.savedImage, prov.savedPath)
+	}
+}
+
+func TestSaveImage_NotRequested(t *testing.T) {
+	prov := &transferMockProvider{}
+	var nilFlags *RunFlags
+	if err := nilFlags.saveImage(prov, "test-image"); err != nil || prov.savedImage != "" {
+		t.Errorf("saveImage without --save-image should do nothing, got %v, %q", err, prov.savedImage)
+	}
+}
+
+func TestSaveImage_UnsupportedProvider(t *testing.T) {
+	flags := &RunFlags{SaveImage: "claude.tar"}
+	err := flags.saveImage(&daytonaMockProvider{}, "test-image")
+	if !errors.Is(err, provider.ErrUnsupported) {
+		t.Errorf("saveImage() error = %v, want ErrUnsupported", err)
+	}
+}
+
+func TestLoadImage(t *testing.T) {
+	prov := &transferMockProvider{}
+	if err := loadImage(prov, filepath.Join(t.TempDir(), "missing.tar")); err == nil {
+		t.Error("loadImage() of a missing file should fail")
+	}
+
+	path := filepath.Join(t.TempDir(), "claude.tar")
+	if err := os.WriteFile(path, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := loadImage(prov, path); err != nil {
+		t.Fatalf("loadImage() error = %v", err)
+	}
+	if prov.loadedPath != path {
+		t.Errorf("LoadImage(%q), want %q", prov.loadedPath, path)
+	}
+}
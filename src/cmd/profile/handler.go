@@ -145,18 +145,6 @@ func applyProfile(name string, useGlobal bool) {
 		os.Exit(1)
 	}
 
-	// Load config
-	var cfg *cfgtypes.GlobalConfig
-	if useGlobal {
-		cfg, err = cfgtypes.LoadGlobalConfigFile()
-	} else {
-		cfg, err = cfgtypes.LoadProjectConfigFile()
-	}
-	if err != nil {
-		fmt.Printf("Error loading config: %v\n", err)
-		os.Exit(1)
-	}
-
 	// Validate all keys before applying
 	for k := range p.Settings {
 		if !cfgcmd.IsValidKey(k) {
@@ -165,16 +153,19 @@ func applyProfile(name string, useGlobal bool) {
 		}
 	}
 
-	// Apply settings
-	for k, v := range p.Settings {
-		cfgcmd.SetValue(cfg, k, v)
+	// Apply settings under the config file lock
+	apply := func(cfg *cfgtypes.GlobalConfig) error {
+		for k, v := range p.Settings {
+			if err := cfgcmd.SetValue(cfg, k, v); err != nil {
+				return err
+			}
+		}
+		return nil
 	}
-
-	// Save config
 	if useGlobal {
-		err = cfgtypes.SaveGlobalConfigFile(cfg)
+		err = cfgtypes.UpdateGlobalConfigFile(apply)
 	} else {
-		err = cfgtypes.SaveProjectConfigFile(cfg)
+		err = cfgtypes.UpdateProjectConfigFile(apply)
 	}
 	if err != nil {
 		fmt.Printf("Error saving config: %v\n", err)
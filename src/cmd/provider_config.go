@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/jedi4ever/addt/config"
+	"github.com/jedi4ever/addt/provider"
+)
+
+// newProviderConfig converts the loaded config and the run flags into the
+// provider config for a run. The workdir trust check may print a notice.
+func newProviderConfig(cfg *config.Config, runFlags *RunFlags) *provider.Config {
+	return &provider.Config{
+		AddtVersion:               cfg.AddtVersion,
+		ExtensionVersions:         cfg.ExtensionVersions,
+		ExtensionConfigAutomount:  cfg.ExtensionConfigAutomount,
+		ExtensionConfigReadonly:   cfg.ExtensionConfigReadonly,
+		ExtensionWorkdirAutotrust: cfg.ExtensionWorkdirAutotrust,
+		ConfigAutomount:           cfg.ConfigAutomount,
+		ConfigReadonly:            cfg.ConfigReadonly,
+		AuthAutologin:             cfg.AuthAutologin,
+		AuthMethod:                cfg.AuthMethod,
+		ExtensionAuthAutologin:    cfg.ExtensionAuthAutologin,
+		ExtensionAuthMethod:       cfg.ExtensionAuthMethod,
+		ExtensionFlagSettings:     cfg.ExtensionFlagSettings,
+		NodeVersion:               cfg.NodeVersion,
+		GoVersion:                 cfg.GoVersion,
+		UvVersion:                 cfg.UvVersion,
+		EnvVars:                   cfg.EnvVars,
+		GitHubForwardToken:        cfg.GitHubForwardToken,
+		GitHubTokenSource:         cfg.GitHubTokenSource,
+		GitHubScopeToken:          cfg.GitHubScopeToken,
+		GitHubScopeRepos:          cfg.GitHubScopeRepos,
+		Ports:                     cfg.Ports,
+		PortRangeStart:            cfg.PortRangeStart,
+		PortsInjectSystemPrompt:   cfg.PortsInjectSystemPrompt,
+		PortsPromptTemplate:       cfg.PortsPromptTemplate,
+		SSHForwardKeys:            cfg.SSHForwardKeys,
+		SSHForwardMode:            cfg.SSHForwardMode,
+		SSHAllowedKeys:            cfg.SSHAllowedKeys,
+		SSHDir:                    cfg.SSHDir,
+		SSHDirs:                   cfg.SSHDirs,
+		ForwardFiles:              cfg.ForwardFiles,
+		Volumes:                   cfg.Volumes,
+		GitDisableHooks:           cfg.GitDisableHooks,
+		GitForwardConfig:          cfg.GitForwardConfig,
+		GitConfigPath:             cfg.GitConfigPath,
+		GitConfigReadonly:         cfg.GitConfigReadonly,
+		GitConfigCopy:             cfg.GitConfigCopy,
+		GPGForward:                cfg.GPGForward,
+		GPGAllowedKeyIDs:          cfg.GPGAllowedKeyIDs,
+		GPGDir:                    cfg.GPGDir,
+		TmuxForward:               cfg.TmuxForward,
+		HistoryPersist:            cfg.HistoryPersist,
+		HistoryDir:                cfg.HistoryDir,
+		HomePersistSubdirs:        cfg.HomePersistSubdirs,
+		TerminalOSC:               cfg.TerminalOSC,
+		DockerDindMode:            cfg.DockerDindMode,
+		DockerForwardConfig:       cfg.DockerForwardConfig,
+		DockerConfigPath:          cfg.DockerConfigPath,
+		DockerBuildTimeout:        cfg.DockerBuildTimeout,
+		DockerPullPolicy:          cfg.DockerPullPolicy,
+		DockerCpusetCPUs:          cfg.DockerCpusetCPUs,
+		DockerCpusetMems:          cfg.DockerCpusetMems,
+		EnvFileLoad:               cfg.EnvFileLoad,
+		EnvFile:                   cfg.EnvFile,
+		LogEnabled:                cfg.LogEnabled,
+		LogFile:                   cfg.LogFile,
+		LogCaptureContainer:       cfg.LogCaptureContainer,
+		ImageName:                 cfg.ImageName,
+		Persistent:                cfg.Persistent,
+		WorkdirAutomount:          cfg.WorkdirAutomount,
+		WorkdirReadonly:           cfg.WorkdirReadonly,
+		WorkdirOverlay:            cfg.WorkdirOverlay,
+		WorkdirAutotrust:          cfg.WorkdirAutotrust,
+		WorkdirTrusted:            resolveWorkdirTrust(os.Stderr, cfg),
+		Workdir:                   cfg.Workdir,
+		WorkdirTarget:             runFlags.workdirTarget(),
+		NoConfigAutomount:         runFlags.noAutomountConfig(),
+		EnvFiles:                  runFlags.envFiles(),
+		Mounts:                    runFlags.mounts(),
+		EntrypointArgs:            runFlags.entrypointArgs(),
+		FirewallEnabled:           cfg.FirewallEnabled,
+		FirewallMode:              cfg.FirewallMode,
+		FirewallRequirePasta:      cfg.FirewallRequirePasta,
+		FirewallNetworkOverride:   cfg.FirewallNetworkOverride,
+		FirewallLogBlocked:        cfg.FirewallLogBlocked,
+		FirewallAsyncInit:         cfg.FirewallAsyncInit,
+		Mode:                      cfg.Mode,
+		Provider:                  cfg.Provider,
+		Extensions:                cfg.Extensions,
+		Command:                   cfg.Command,
+		ContainerCPUs:             cfg.ContainerCPUs,
+		ContainerMemory:           cfg.ContainerMemory,
+		ContainerMaxAge:           cfg.ContainerMaxAge,
+		ContainerDetachKeys:       cfg.ContainerDetachKeys,
+		ContainerEntrypoint:       cfg.ContainerEntrypoint,
+		ContainerPlatform:         cfg.ContainerPlatform,
+		ContainerInit:             cfg.ContainerInit,
+		ContainerEnvPrecedence:    cfg.ContainerEnvPrecedence,
+		ContainerName:             cfg.ContainerName,
+		Security:                  cfg.Security,
+		Otel:                      cfg.Otel,
+	}
+}
@@ -2,17 +2,32 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/jedi4ever/addt/assets"
 	"github.com/jedi4ever/addt/config"
 	"github.com/jedi4ever/addt/extensions"
 	"github.com/jedi4ever/addt/provider"
+	"github.com/jedi4ever/addt/provider/applecontainer"
 	"github.com/jedi4ever/addt/provider/daytona"
 	"github.com/jedi4ever/addt/provider/docker"
 	"github.com/jedi4ever/addt/provider/orbstack"
 	"github.com/jedi4ever/addt/provider/podman"
 )
 
+// supportedProviders lists the provider types accepted by NewProvider
+var supportedProviders = config.Providers
+
+// validateProviderName checks that name is a supported provider type
+func validateProviderName(name string) error {
+	for _, p := range supportedProviders {
+		if p == name {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown provider type: %s (supported: %s)", name, strings.Join(supportedProviders, ", "))
+}
+
 // NewProvider creates a new provider based on the specified type
 // For podman/default, auto-downloads Podman if not available
 func NewProvider(providerType string, cfg *provider.Config) (provider.Provider, error) {
@@ -35,11 +50,14 @@ func NewProvider(providerType string, cfg *provider.Config) (provider.Provider,
 		return docker.NewDockerProvider(cfg, "rancher-desktop", assets.DockerDockerfile, assets.DockerDockerfileBase, assets.DockerEntrypoint, assets.DockerInitFirewall, assets.DockerInstallSh, extensions.FS)
 	case "orbstack":
 		return orbstack.NewOrbStackProvider(cfg, assets.OrbStackDockerfile, assets.OrbStackDockerfileBase, assets.OrbStackEntrypoint, assets.OrbStackInitFirewall, assets.OrbStackInstallSh, extensions.FS)
+	case "applecontainer":
+		// Apple container builds the Docker images unchanged
+		return applecontainer.NewAppleContainerProvider(cfg, assets.DockerDockerfile, assets.DockerDockerfileBase, assets.DockerEntrypoint, assets.DockerInitFirewall, assets.DockerInstallSh, extensions.FS)
 	case "podman", "":
 		return podman.NewPodmanProvider(cfg, assets.PodmanDockerfile, assets.PodmanDockerfileBase, assets.PodmanEntrypoint, assets.PodmanInitFirewall, assets.PodmanInstallSh, extensions.FS)
 	case "daytona":
 		return daytona.NewDaytonaProvider(cfg, assets.DaytonaDockerfile, assets.DaytonaEntrypoint)
 	default:
-		return nil, fmt.Errorf("unknown provider type: %s (supported: docker, rancher, podman, orbstack, daytona)", providerType)
+		return nil, validateProviderName(providerType)
 	}
 }
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jedi4ever/addt/config"
+	"github.com/jedi4ever/addt/core"
+	"github.com/jedi4ever/addt/provider"
+)
+
+// HandleRestartCommand handles "addt restart [name]". Without a name it
+// restarts the persistent container for the current directory and extensions.
+func HandleRestartCommand(prov provider.Provider, providerCfg *provider.Config, args []string) {
+	name := ""
+	for _, arg := range args {
+		switch arg {
+		case "-h", "--help", "help":
+			printRestartHelp()
+			return
+		default:
+			if name != "" || strings.HasPrefix(arg, "-") {
+				printRestartHelp()
+				os.Exit(1)
+			}
+			name = arg
+		}
+	}
+
+	target, err := resolveContainerName(prov, name)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if err := restartContainer(prov, providerCfg, target); err != nil {
+		fmt.Printf("Failed to restart: %s (%v)\n", target, err)
+		os.Exit(1)
+	}
+	fmt.Printf("Restarted: %s\n", target)
+}
+
+// restartContainer restarts name and, with isolate_secrets on, writes the
+// secrets into its tmpfs again since the restart cleared it
+func restartContainer(prov provider.Provider, cfg *provider.Config, name string) error {
+	if err := prov.Restart(name); err != nil {
+		return err
+	}
+	sp, ok := prov.(provider.SecretsProvider)
+	if !ok || !cfg.Security.IsolateSecrets {
+		return nil
+	}
+	cfg.ImageName = prov.DetermineImageName()
+	env := core.BuildEnvironment(prov, cfg)
+	if err := sp.RestoreSecrets(name, cfg.ImageName, env); err != nil {
+		return fmt.Errorf("restoring secrets: %w", err)
+	}
+	return nil
+}
+
+// handleRestartSubcommand creates the provider for "addt restart" and runs it.
+// A first argument that isn't a container selects the current directory's
+// container for that extension (see splitExtensionArg).
+func handleRestartSubcommand(cfg *config.Config, args []string) {
+	providerCfg := envProviderConfig(cfg)
+	prov, err := NewProvider(cfg.Provider, providerCfg)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	args = splitExtensionArg(prov, providerCfg, args)
+	HandleRestartCommand(prov, providerCfg, args)
+}
+
+// envProviderConfig returns the provider config for commands that resolve
+// the container environment outside a run (restart, secrets check)
+func envProviderConfig(cfg *config.Config) *provider.Config {
+	return &provider.Config{
+		AddtVersion:            cfg.AddtVersion,
+		ExtensionVersions:      cfg.ExtensionVersions,
+		NodeVersion:            cfg.NodeVersion,
+		GoVersion:              cfg.GoVersion,
+		UvVersion:              cfg.UvVersion,
+		EnvVars:                cfg.EnvVars,
+		EnvFileLoad:            cfg.EnvFileLoad,
+		EnvFile:                cfg.EnvFile,
+		Provider:               cfg.Provider,
+		Extensions:             cfg.Extensions,
+		Workdir:                cfg.Workdir,
+		ContainerName:          cfg.ContainerName,
+		Security:               cfg.Security,
+		ContainerEnvPrecedence: cfg.ContainerEnvPrecedence,
+	}
+}
+
+func printRestartHelp() {
+	fmt.Println(`Usage: addt restart [<extension>|<container>]
+
+Restart a persistent container, keeping its filesystem. Less drastic than
+removing it. Without arguments, restarts the persistent container for the
+current directory and configured extensions. With security.isolate_secrets
+on, secrets are written into the container's tmpfs again.
+
+Examples:
+  addt restart                      # Container for this directory
+  addt restart claude               # Container for this directory running claude
+  addt restart addt-persistent-app-1a2b3c4d`)
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jedi4ever/addt/provider"
+	"github.com/jedi4ever/addt/provider/docker"
+	"github.com/jedi4ever/addt/provider/orbstack"
+	"github.com/jedi4ever/addt/provider/podman"
+)
+
+// The tmpfs-secret providers re-copy secrets after a restart
+var (
+	_ provider.SecretsProvider = (*docker.DockerProvider)(nil)
+	_ provider.SecretsProvider = (*orbstack.OrbStackProvider)(nil)
+	_ provider.SecretsProvider = (*podman.PodmanProvider)(nil)
+)
+
+// restartMockProvider records the lifecycle and secret calls in order
+type restartMockProvider struct {
+	mockProvider
+	containers map[string]bool
+	calls      []string
+	secretsEnv map[string]string
+}
+
+func (m *restartMockProvider) Exists(name string) bool {
+	_, ok := m.containers[name]
+	return ok
+}
+
+func (m *restartMockProvider) Restart(name string) error {
+	m.calls = append(m.calls, "stop "+name, "start "+name)
+	return nil
+}
+
+func (m *restartMockProvider) RestoreSecrets(name, imageName string, env map[string]string) error {
+	m.calls = append(m.calls, "secrets "+name)
+	m.secretsEnv = env
+	return nil
+}
+
+func TestRestartContainer_IsolatedSecretsRecopied(t *testing.T) {
+	t.Setenv("ADDT_TEST_RESTART_TOKEN", "s3cret")
+	prov := &restartMockProvider{}
+	cfg := &provider.Config{EnvVars: []string{"ADDT_TEST_RESTART_TOKEN"}}
+	cfg.Security.IsolateSecrets = true
+
+	if err := restartContainer(prov, cfg, "addt-persistent-a"); err != nil {
+		t.Fatalf("restartContainer() error = %v", err)
+	}
+	want := []string{"stop addt-persistent-a", "start addt-persistent-a", "secrets addt-persistent-a"}
+	if !reflect.DeepEqual(prov.calls, want) {
+		t.Errorf("calls = %v, want %v", prov.calls, want)
+	}
+	if got := prov.secretsEnv["ADDT_TEST_RESTART_TOKEN"]; got != "s3cret" {
+		t.Errorf("secrets env ADDT_TEST_RESTART_TOKEN = %q, want s3cret", got)
+	}
+}
+
+func TestRestartContainer_NoIsolationSkipsSecrets(t *testing.T) {
+	prov := &restartMockProvider{}
+	cfg := &provider.Config{}
+
+	if err := restartContainer(prov, cfg, "addt-persistent-a"); err != nil {
+		t.Fatalf("restartContainer() error = %v", err)
+	}
+	want := []string{"stop addt-persistent-a", "start addt-persistent-a"}
+	if !reflect.DeepEqual(prov.calls, want) {
+		t.Errorf("calls = %v, want %v", prov.calls, want)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	extcmd "github.com/jedi4ever/addt/cmd/extensions"
 	firewallcmd "github.com/jedi4ever/addt/cmd/firewall"
 	profilecmd "github.com/jedi4ever/addt/cmd/profile"
+	securitycmd "github.com/jedi4ever/addt/cmd/security"
 	"github.com/jedi4ever/addt/config"
 	"github.com/jedi4ever/addt/core"
 	"github.com/jedi4ever/addt/provider"
@@ -38,7 +39,7 @@ func Execute(version, defaultNodeVersion, defaultGoVersion, defaultUvVersion str
 			os.Setenv("ADDT_EXTENSIONS", extensionFromBinary)
 		}
 		if os.Getenv("ADDT_COMMAND") == "" {
-			os.Setenv("ADDT_COMMAND", extensionFromBinary)
+			config.SetDefaultCommand(extensionFromBinary)
 		}
 	} else if os.Getenv("ADDT_EXTENSIONS") != "" && os.Getenv("ADDT_COMMAND") == "" {
 		// If ADDT_EXTENSIONS is set but ADDT_COMMAND is not, look up the entrypoint
@@ -46,11 +47,12 @@ func Execute(version, defaultNodeVersion, defaultGoVersion, defaultUvVersion str
 		firstExt := strings.Split(extensions, ",")[0]
 		// Get the actual entrypoint command (e.g., "kiro" -> "kiro-cli", "beads" -> "bd")
 		entrypoint := extcmd.GetEntrypoint(firstExt)
-		os.Setenv("ADDT_COMMAND", entrypoint)
+		config.SetDefaultCommand(entrypoint)
 	}
 
 	// Parse command line arguments
 	args := os.Args[1:]
+	var runFlags *RunFlags
 
 	// If running as plain "addt" without extension, check if it's a known command
 	// Otherwise show help - don't default to claude
@@ -61,8 +63,8 @@ func Execute(version, defaultNodeVersion, defaultGoVersion, defaultUvVersion str
 		}
 		// Check if first arg is a known addt command (matches switch cases below)
 		switch args[0] {
-		case "run", "build", "update", "shell", "containers", "firewall",
-			"extensions", "cli", "config", "profile", "version", "completion", "doctor", "init":
+		case "run", "build", "update", "shell", "containers", "stop", "restart", "stats", "load-image", "firewall",
+			"extensions", "cli", "config", "profile", "security", "secrets", "trust", "untrust", "version", "completion", "doctor", "init":
 			// Known command, continue processing
 		default:
 			// Unknown command, show help
@@ -95,12 +97,20 @@ func Execute(version, defaultNodeVersion, defaultGoVersion, defaultUvVersion str
 		case "profile":
 			profilecmd.HandleCommand(args[1:])
 			return
+		case "security":
+			cfg := config.LoadConfig(version, defaultNodeVersion, defaultGoVersion, defaultUvVersion, defaultPortRangeStart)
+			securitycmd.HandleCommand(args[1:], cfg)
+			return
+		case "trust", "untrust":
+			HandleTrustCommand(args[1:], args[0] == "trust")
+			return
 		case "extensions":
-			extcmd.HandleCommand(args[1:])
+			extcmd.HandleCommand(args[1:], activeSetResolver(version, defaultNodeVersion, defaultGoVersion, defaultUvVersion, defaultPortRangeStart))
 			return
 		case "run":
 			// addt run <extension> [args...] - run a specific extension
-			remainingArgs := HandleRunCommand(args[1:])
+			remainingArgs, flags := HandleRunCommandWithFlags(args[1:])
+			runFlags = flags
 			if remainingArgs == nil {
 				return // Help was printed or error occurred
 			}
@@ -110,7 +120,7 @@ func Execute(version, defaultNodeVersion, defaultGoVersion, defaultUvVersion str
 			HandleUpdateCommand(args[1:], version, defaultNodeVersion, defaultGoVersion, defaultUvVersion, defaultPortRangeStart)
 			return
 
-		case "build", "shell", "containers", "firewall":
+		case "build", "shell", "containers", "stop", "restart", "stats", "load-image", "firewall", "secrets":
 			// Top-level subcommands (work for both plain addt and via "addt" namespace)
 			subCmd := args[0]
 			subArgs := args[1:]
@@ -128,7 +138,7 @@ func Execute(version, defaultNodeVersion, defaultGoVersion, defaultUvVersion str
 			subArgs := args[2:]
 			switch subCmd {
 			case "extensions":
-				extcmd.HandleCommandAgent(subArgs)
+				extcmd.HandleCommandAgent(subArgs, activeSetResolver(version, defaultNodeVersion, defaultGoVersion, defaultUvVersion, defaultPortRangeStart))
 			case "update":
 				HandleUpdateCommand(subArgs, version, defaultNodeVersion, defaultGoVersion, defaultUvVersion, defaultPortRangeStart)
 			case "cli":
@@ -137,6 +147,11 @@ func Execute(version, defaultNodeVersion, defaultGoVersion, defaultUvVersion str
 				configcmd.HandleCommand(subArgs)
 			case "profile":
 				profilecmd.HandleCommand(subArgs)
+			case "security":
+				cfg := config.LoadConfig(version, defaultNodeVersion, defaultGoVersion, defaultUvVersion, defaultPortRangeStart)
+				securitycmd.HandleCommand(subArgs, cfg)
+			case "trust", "untrust":
+				HandleTrustCommand(subArgs, subCmd == "trust")
 			case "version":
 				PrintVersion(version, defaultNodeVersion, defaultGoVersion, defaultUvVersion)
 			default:
@@ -149,6 +164,20 @@ func Execute(version, defaultNodeVersion, defaultGoVersion, defaultUvVersion str
 	// Load configuration
 	cfg := config.LoadConfig(version, defaultNodeVersion, defaultGoVersion, defaultUvVersion, defaultPortRangeStart)
 
+	// One-shot capability flags win over configured caps
+	runFlags.applySecurity(&cfg.Security)
+
+	// --print-firewall-rules: preview the merged allow/deny lists and exit
+	if runFlags != nil && runFlags.PrintFirewallRules {
+		firewallcmd.PrintRules(os.Stdout, cfg)
+		return
+	}
+
+	// --explain-config: report where each value came from before the run
+	if cfg.Sources != nil {
+		printConfigSources(os.Stderr, cfg.Sources)
+	}
+
 	// Resolve ~ in LogDir
 	cfg.LogDir = util.ExpandTilde(cfg.LogDir)
 
@@ -160,84 +189,32 @@ func Execute(version, defaultNodeVersion, defaultGoVersion, defaultUvVersion str
 	// by each extension's args.sh script in the container
 
 	// Convert main config to provider config
-	providerCfg := &provider.Config{
-		AddtVersion:               cfg.AddtVersion,
-		ExtensionVersions:         cfg.ExtensionVersions,
-		ExtensionConfigAutomount:  cfg.ExtensionConfigAutomount,
-		ExtensionConfigReadonly:   cfg.ExtensionConfigReadonly,
-		ExtensionWorkdirAutotrust: cfg.ExtensionWorkdirAutotrust,
-		ConfigAutomount:           cfg.ConfigAutomount,
-		ConfigReadonly:            cfg.ConfigReadonly,
-		AuthAutologin:             cfg.AuthAutologin,
-		AuthMethod:                cfg.AuthMethod,
-		ExtensionAuthAutologin:    cfg.ExtensionAuthAutologin,
-		ExtensionAuthMethod:       cfg.ExtensionAuthMethod,
-		ExtensionFlagSettings:     cfg.ExtensionFlagSettings,
-		NodeVersion:               cfg.NodeVersion,
-		GoVersion:                 cfg.GoVersion,
-		UvVersion:                 cfg.UvVersion,
-		EnvVars:                   cfg.EnvVars,
-		GitHubForwardToken:        cfg.GitHubForwardToken,
-		GitHubTokenSource:         cfg.GitHubTokenSource,
-		GitHubScopeToken:          cfg.GitHubScopeToken,
-		GitHubScopeRepos:          cfg.GitHubScopeRepos,
-		Ports:                     cfg.Ports,
-		PortRangeStart:            cfg.PortRangeStart,
-		PortsInjectSystemPrompt:   cfg.PortsInjectSystemPrompt,
-		SSHForwardKeys:            cfg.SSHForwardKeys,
-		SSHForwardMode:            cfg.SSHForwardMode,
-		SSHAllowedKeys:            cfg.SSHAllowedKeys,
-		SSHDir:                    cfg.SSHDir,
-		GitDisableHooks:           cfg.GitDisableHooks,
-		GitForwardConfig:          cfg.GitForwardConfig,
-		GitConfigPath:             cfg.GitConfigPath,
-		GPGForward:                cfg.GPGForward,
-		GPGAllowedKeyIDs:          cfg.GPGAllowedKeyIDs,
-		GPGDir:                    cfg.GPGDir,
-		TmuxForward:               cfg.TmuxForward,
-		HistoryPersist:            cfg.HistoryPersist,
-		TerminalOSC:               cfg.TerminalOSC,
-		DockerDindMode:            cfg.DockerDindMode,
-		EnvFileLoad:               cfg.EnvFileLoad,
-		EnvFile:                   cfg.EnvFile,
-		LogEnabled:                cfg.LogEnabled,
-		LogFile:                   cfg.LogFile,
-		ImageName:                 cfg.ImageName,
-		Persistent:                cfg.Persistent,
-		WorkdirAutomount:          cfg.WorkdirAutomount,
-		WorkdirReadonly:           cfg.WorkdirReadonly,
-		WorkdirAutotrust:          cfg.WorkdirAutotrust,
-		Workdir:                   cfg.Workdir,
-		FirewallEnabled:           cfg.FirewallEnabled,
-		FirewallMode:              cfg.FirewallMode,
-		Mode:                      cfg.Mode,
-		Provider:                  cfg.Provider,
-		Extensions:                cfg.Extensions,
-		Command:                   cfg.Command,
-		ContainerCPUs:             cfg.ContainerCPUs,
-		ContainerMemory:           cfg.ContainerMemory,
-		Security:                  cfg.Security,
-		Otel:                      cfg.Otel,
-	}
+	providerCfg := newProviderConfig(cfg, runFlags)
 
 	// Create provider
 	prov, err := NewProvider(cfg.Provider, providerCfg)
 	if err != nil {
-		fmt.Printf("Error: %v\n", err)
-		os.Exit(1)
+		exitWithError(err)
 	}
 
 	// Initialize provider (checks prerequisites)
 	if err := prov.Initialize(providerCfg); err != nil {
-		fmt.Printf("Error: %v\n", err)
-		os.Exit(1)
+		exitWithError(err)
 	}
 
-	// Determine image name and build if needed (provider-specific)
+	// Determine image name and build if needed (provider-specific).
+	// Image naming resolves dist-tags, so keep the configured versions for .addt.lock
+	exts := runExtensions(providerCfg.Extensions)
+	requested := extensionVersions(exts, providerCfg.ExtensionVersions)
 	providerCfg.ImageName = prov.DetermineImageName()
-	if err := prov.BuildIfNeeded(false, false); err != nil {
-		fmt.Printf("Error: %v\n", err)
-		os.Exit(1)
+	if err := runFlags.applyLockfile(exts, requested, extensionVersions(exts, providerCfg.ExtensionVersions)); err != nil {
+		exitWithError(err)
+	}
+	if err := buildForRun(prov, runFlags); err != nil {
+		exitWithError(err)
+	}
+	if err := runFlags.saveImage(prov, providerCfg.ImageName); err != nil {
+		exitWithError(err)
 	}
 
 	// Create runner
@@ -249,19 +226,43 @@ func Execute(version, defaultNodeVersion, defaultGoVersion, defaultUvVersion str
 	// Filter GH_TOKEN from env vars if forwarding is disabled
 	providerCfg.EnvVars = config.HandleGitHubToken(cfg.GitHubForwardToken, providerCfg.EnvVars)
 
-	// Load env file if enabled
-	if cfg.EnvFileLoad {
-		if err := config.LoadEnvFile(cfg.EnvFile); err != nil {
-			fmt.Printf("Error loading env file: %v\n", err)
-			os.Exit(1)
+	// Load the env file if enabled, then any --env-file
+	if err := loadEnvFiles(cfg, runFlags.envFiles()); err != nil {
+		fmt.Printf("Error loading env file: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Route output to files, set the deadline and start a --record transcript
+	closeOutput, err := runFlags.configureRunner(runner, cfg.LogDir)
+	if err != nil {
+		fmt.Printf("Error opening output file: %v\n", err)
+		os.Exit(1)
+	}
+	defer closeOutput()
+
+	// Print the resolved run environment instead of starting a container
+	if runFlags != nil && runFlags.PrintOnlyEnv {
+		runner.PrintOnlyEnv(os.Stdout, args, false)
+		prov.Cleanup()
+		return
+	}
+	if runFlags != nil && runFlags.DumpSpec {
+		err := runner.DumpSpec(os.Stdout, args, false)
+		prov.Cleanup()
+		if err != nil {
+			exitWithError(err)
 		}
+		return
 	}
 
 	// Run via runner
 	if err := runner.Run(args); err != nil {
-		os.Exit(1)
+		exitWithError(err)
 	}
 
+	// Persist run flags to project config if requested
+	handleSaveConfig(runFlags)
+
 	// Cleanup
 	prov.Cleanup()
 }
@@ -272,61 +273,7 @@ func handleSubcommand(subCmd string, subArgs []string, version, defaultNodeVersi
 
 	switch subCmd {
 	case "build":
-		// Check for --force and --rebuild-base flags
-		forceNoCache := false
-		rebuildBase := false
-		var filteredArgs []string
-		for _, arg := range subArgs {
-			if arg == "--force" {
-				forceNoCache = true
-			} else if arg == "--rebuild-base" {
-				rebuildBase = true
-			} else {
-				filteredArgs = append(filteredArgs, arg)
-			}
-		}
-		subArgs = filteredArgs
-
-		// Check if extension is passed as first arg (addt build claude)
-		if len(subArgs) > 0 && !strings.HasPrefix(subArgs[0], "-") {
-			cfg.Extensions = subArgs[0]
-			subArgs = subArgs[1:]
-		}
-		// Check if extension is specified
-		if cfg.Extensions == "" {
-			fmt.Println("Error: No extension specified")
-			fmt.Println()
-			fmt.Println("Usage: addt build <extension> [--force] [--rebuild-base]")
-			fmt.Println("       ADDT_EXTENSIONS=claude addt build")
-			fmt.Println()
-			fmt.Println("Options:")
-			fmt.Println("  --force         Rebuild without using Docker cache")
-			fmt.Println("  --rebuild-base  Rebuild the base image before building extension image")
-			fmt.Println()
-			fmt.Println("Examples:")
-			fmt.Println("  addt build claude")
-			fmt.Println("  addt build claude --force")
-			fmt.Println("  addt build claude --rebuild-base")
-			fmt.Println("  addt build claude --force --rebuild-base")
-			fmt.Println("  addt build claude,codex")
-			os.Exit(1)
-		}
-		providerCfg := &provider.Config{
-			AddtVersion:       cfg.AddtVersion,
-			ExtensionVersions: cfg.ExtensionVersions,
-			NodeVersion:       cfg.NodeVersion,
-			GoVersion:         cfg.GoVersion,
-			UvVersion:         cfg.UvVersion,
-			Provider:          cfg.Provider,
-			Extensions:        cfg.Extensions,
-			NoCache:           forceNoCache,
-		}
-		prov, err := NewProvider(cfg.Provider, providerCfg)
-		if err != nil {
-			fmt.Printf("Error: %v\n", err)
-			os.Exit(1)
-		}
-		HandleBuildCommand(prov, providerCfg, subArgs, forceNoCache, rebuildBase)
+		handleBuildSubcommand(cfg, subArgs)
 
 	case "shell":
 		HandleShellCommand(subArgs, version, defaultNodeVersion, defaultGoVersion, defaultUvVersion, defaultPortRangeStart)
@@ -340,6 +287,7 @@ func handleSubcommand(subCmd string, subArgs []string, version, defaultNodeVersi
 			UvVersion:         cfg.UvVersion,
 			Provider:          cfg.Provider,
 			Extensions:        cfg.Extensions,
+			ContainerName:     cfg.ContainerName,
 		}
 		prov, err := NewProvider(cfg.Provider, providerCfg)
 		if err != nil {
@@ -348,6 +296,21 @@ func handleSubcommand(subCmd string, subArgs []string, version, defaultNodeVersi
 		}
 		HandleContainersCommand(prov, providerCfg, subArgs)
 
+	case "stop":
+		handleStopSubcommand(cfg, subArgs)
+
+	case "restart":
+		handleRestartSubcommand(cfg, subArgs)
+
+	case "secrets":
+		handleSecretsSubcommand(cfg, subArgs)
+
+	case "stats":
+		handleStatsSubcommand(cfg, subArgs)
+
+	case "load-image":
+		handleLoadImageSubcommand(cfg, subArgs)
+
 	case "firewall":
 		firewallcmd.HandleCommand(subArgs)
 
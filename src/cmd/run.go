@@ -3,8 +3,11 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	extcmd "github.com/jedi4ever/addt/cmd/extensions"
+	"github.com/jedi4ever/addt/config"
+	"github.com/jedi4ever/addt/provider"
 	"github.com/jedi4ever/addt/util"
 )
 
@@ -12,13 +15,28 @@ import (
 // It validates the extension and sets up environment variables.
 // Returns the remaining args for execution, or nil if the command was fully handled (help/error).
 func HandleRunCommand(args []string) []string {
+	remainingArgs, _ := HandleRunCommandWithFlags(args)
+	return remainingArgs
+}
+
+// HandleRunCommandWithFlags handles "addt run [flags] <extension>" and also
+// returns the parsed addt run flags so the caller can act on them after the run.
+func HandleRunCommandWithFlags(args []string) ([]string, *RunFlags) {
 	runLogger := util.Log("run")
 	runLogger.Debugf("HandleRunCommand called with args: %v", args)
 
+	runFlags, args, err := parseRunFlags(args)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		fmt.Println()
+		printRunHelp()
+		os.Exit(1)
+	}
+
 	if len(args) < 1 {
 		runLogger.Debug("No extension specified, showing help")
 		printRunHelp()
-		return nil
+		return nil, nil
 	}
 
 	extName := args[0]
@@ -28,7 +46,7 @@ func HandleRunCommand(args []string) []string {
 	if extName == "--help" || extName == "-h" {
 		runLogger.Debug("Help flag detected, showing help")
 		printRunHelp()
-		return nil
+		return nil, nil
 	}
 
 	// Validate extension exists
@@ -48,21 +66,24 @@ func HandleRunCommand(args []string) []string {
 	// Set the extension environment variables
 	runLogger.Debugf("Setting ADDT_EXTENSIONS=%s", extName)
 	os.Setenv("ADDT_EXTENSIONS", extName)
-	runLogger.Debugf("Setting ADDT_COMMAND=%s", entrypoint)
-	os.Setenv("ADDT_COMMAND", entrypoint)
+	runLogger.Debugf("Setting ADDT_COMMAND=%s unless command is configured", entrypoint)
+	config.SetDefaultCommand(entrypoint)
+
+	// Apply addt run flags as config overrides
+	runFlags.apply()
 
 	// Return remaining args for execution
 	if len(args) > 1 {
 		remainingArgs := args[1:]
 		runLogger.Debugf("Returning remaining args for execution: %v", remainingArgs)
-		return remainingArgs
+		return remainingArgs, runFlags
 	}
 	runLogger.Debug("No remaining args, returning empty slice")
-	return []string{}
+	return []string{}, runFlags
 }
 
 func printRunHelp() {
-	fmt.Println("Usage: addt run <extension> [args...]")
+	fmt.Println("Usage: addt run [flags] <extension> [args...]")
 	fmt.Println()
 	fmt.Println("Run a specific extension in a container.")
 	fmt.Println()
@@ -70,11 +91,76 @@ func printRunHelp() {
 	fmt.Println("  <extension>    Name of the extension to run")
 	fmt.Println("  [args...]      Arguments to pass to the extension")
 	fmt.Println()
+	fmt.Println("Flags (before the extension name, override config for this run):")
+	for _, def := range runFlagDefs {
+		flag := def.Flag
+		if def.Value == "" {
+			flag += " <value>"
+		}
+		fmt.Printf("  %-28s %s (%s)\n", flag, def.Description, def.Key)
+	}
+	fmt.Printf("  %-28s %s\n", providerFlag+" <name>", "Provider for this run: "+strings.Join(supportedProviders, ", "))
+	fmt.Printf("  %-28s %s\n", addCapFlag+" <cap>", "Add a capability for this run (repeatable)")
+	fmt.Printf("  %-28s %s\n", dropCapFlag+" <cap>", "Drop a capability for this run (repeatable)")
+	fmt.Printf("  %-28s %s\n", mountWorkdirAtFlag+" <path>", "Mount the working directory at this container path instead of /workspace")
+	fmt.Printf("  %-28s %s\n", noAutomountConfigFlag, "Skip every extension config mount (e.g. ~/.claude) for a pristine agent config")
+	fmt.Printf("  %-28s %s\n", extraSSHDirFlag+" <dir>", "Forward another SSH key directory (repeatable, adds to ssh.dirs)")
+	fmt.Printf("  %-28s %s\n", mountHomeFlag+" <subdir>", "Keep a home subdir in a per-workdir volume (repeatable, adds to home.persist_subdirs)")
+	fmt.Printf("  %-28s %s\n", envFileFlag+" <file>", "Load another env file after env_file (repeatable, later files win)")
+	fmt.Printf("  %-28s %s\n", mountFlag+" <spec>", "Docker-style mount, e.g. type=bind,source=/a,target=/b,readonly (repeatable)")
+	fmt.Printf("  %-28s %s\n", entrypointArgFlag+" <arg>", "Pass an arg to the built-in entrypoint before the agent args, e.g. --debug (repeatable)")
+	fmt.Printf("  %-28s %s\n", "--save-config", "Save flag settings to .addt.yaml after a successful run")
+	fmt.Printf("  %-28s %s\n", "--rebuild", "Rebuild the extension image before running")
+	fmt.Printf("  %-28s %s\n", "--rebuild-base", "Rebuild the base and extension images before running")
+	fmt.Printf("  %-28s %s\n", saveImageFlag+" <file.tar>", "Export the built image to a tarball (see addt load-image)")
+	fmt.Printf("  %-28s %s\n", "--lock", "Write the resolved extension versions to .addt.lock")
+	fmt.Printf("  %-28s %s\n", "--frozen", "Fail unless the resolved extension versions match .addt.lock (for CI)")
+	fmt.Printf("  %-28s %s\n", "--explain-config", "Show which layer (env, project, global, default) set each config value")
+	fmt.Printf("  %-28s %s\n", "--strict-config", "Warn about unknown keys in .addt.yaml and the global config (typos)")
+	fmt.Printf("  %-28s %s\n", "--print-only-env", "Print the redacted env, mounts and security flags, then exit")
+	fmt.Printf("  %-28s %s\n", "--dump-spec", "Print the resolved run spec (env redacted) as JSON, then exit")
+	fmt.Printf("  %-28s %s\n", "--print-firewall-rules", "Print the merged firewall allow/deny lists with their layer, then exit")
+	fmt.Printf("  %-28s %s\n", timeoutFlag+" <duration>", "Stop the run and remove its container after this long (e.g. 30m; exit code 124)")
+	fmt.Printf("  %-28s %s\n", stdoutFileFlag+" <path>", "Write container stdout to a file (disables the TTY)")
+	fmt.Printf("  %-28s %s\n", stderrFileFlag+" <path>", "Write container stderr to a file (disables the TTY)")
+	fmt.Printf("  %-28s %s\n", recordFlag, "Also record the session output to a timestamped file in the log dir")
+	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Println("  addt run claude \"Fix the bug\"")
 	fmt.Println("  addt run codex --help")
 	fmt.Println("  addt run gemini")
+	fmt.Println("  addt run --firewall --save-config claude")
+	fmt.Println("  addt run --no-firewall claude")
+	fmt.Println("  addt run --rebuild claude")
+	fmt.Println("  addt run --save-image claude.tar claude")
+	fmt.Println("  addt run --add-cap SYS_PTRACE --drop-cap NET_RAW claude")
+	fmt.Println("  addt run --provider podman claude")
+	fmt.Println("  addt run --print-only-env claude")
+	fmt.Println("  addt run --print-firewall-rules claude")
+	fmt.Println("  addt run --frozen claude -p \"Review this PR\"")
+	fmt.Println("  addt run --stdout-file out.log claude -p \"Summarize\"")
+	fmt.Println("  addt run --timeout 30m claude -p \"Fix the failing tests\"")
+	fmt.Println("  addt run --record claude")
 	fmt.Println()
 	fmt.Println("To see available extensions:")
 	fmt.Println("  addt extensions list")
 }
+
+// buildForRun builds the image if needed before a run, forcing a rebuild
+// when --rebuild or --rebuild-base was given
+func buildForRun(prov provider.Provider, flags *RunFlags) error {
+	rebuild, rebuildBase := false, false
+	if flags != nil {
+		rebuildBase = flags.RebuildBase
+		rebuild = flags.Rebuild || rebuildBase // a new base needs a new extension image
+	}
+	switch {
+	case (rebuild || rebuildBase) && prov.GetName() == "daytona":
+		fmt.Println("Note: --rebuild/--rebuild-base have no effect with the daytona provider (no local image)")
+	case rebuildBase:
+		fmt.Println("Rebuilding base and extension images (--rebuild-base)")
+	case rebuild:
+		fmt.Println("Rebuilding extension image (--rebuild)")
+	}
+	return prov.BuildIfNeeded(rebuild, rebuildBase)
+}
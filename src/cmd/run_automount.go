@@ -0,0 +1,10 @@
+package cmd
+
+// noAutomountConfigFlag skips every extension config mount (e.g. ~/.claude)
+// for a single run, so the agent starts with a pristine config
+const noAutomountConfigFlag = "--no-automount-config"
+
+// noAutomountConfig reports whether --no-automount-config was given
+func (f *RunFlags) noAutomountConfig() bool {
+	return f != nil && f.NoAutomountConfig
+}
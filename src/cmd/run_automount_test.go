@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/jedi4ever/addt/core"
+	"github.com/jedi4ever/addt/provider"
+)
+
+func TestNoAutomountConfig_DisablesConfigMounts(t *testing.T) {
+	flags, rest, err := parseRunFlags([]string{"--no-automount-config", "claude"})
+	if err != nil {
+		t.Fatalf("parseRunFlags() error = %v", err)
+	}
+	if len(rest) != 1 || rest[0] != "claude" {
+		t.Errorf("remaining args = %v, want [claude]", rest)
+	}
+	if !flags.noAutomountConfig() {
+		t.Fatal("noAutomountConfig() = false, want true")
+	}
+
+	cfg := &provider.Config{
+		Extensions:               "claude",
+		ConfigAutomount:          true,
+		ExtensionConfigAutomount: map[string]bool{"claude": true},
+		NoConfigAutomount:        flags.noAutomountConfig(),
+	}
+	env := core.BuildEnvironment(&mockProvider{}, cfg)
+	if env["ADDT_CONFIG_AUTOMOUNT"] != "false" {
+		t.Errorf("ADDT_CONFIG_AUTOMOUNT = %q, want false", env["ADDT_CONFIG_AUTOMOUNT"])
+	}
+	if _, ok := env["ADDT_CLAUDE_CONFIG_AUTOMOUNT"]; ok {
+		t.Error("per-extension automount override should not be passed with --no-automount-config")
+	}
+}
+
+func TestNoAutomountConfig_DefaultOff(t *testing.T) {
+	var flags *RunFlags
+	if flags.noAutomountConfig() {
+		t.Error("noAutomountConfig() on nil flags = true, want false")
+	}
+}
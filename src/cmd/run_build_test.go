@@ -0,0 +1,54 @@
+package cmd
+
+import "testing"
+
+// daytonaMockProvider reports the daytona provider name
+type daytonaMockProvider struct{ mockProvider }
+
+func (m *daytonaMockProvider) GetName() string { return "daytona" }
+
+func TestBuildForRun_Flags(t *testing.T) {
+	tests := []struct {
+		args            []string
+		wantRebuild     bool
+		wantRebuildBase bool
+	}{
+		{[]string{"claude"}, false, false},
+		{[]string{"--rebuild", "claude"}, true, false},
+		{[]string{"--rebuild-base", "claude"}, true, true},
+		{[]string{"--rebuild", "--rebuild-base", "claude"}, true, true},
+	}
+	for _, tt := range tests {
+		flags, _, err := parseRunFlags(tt.args)
+		if err != nil {
+			t.Fatalf("parseRunFlags(%v) error = %v", tt.args, err)
+		}
+		prov := &mockProvider{}
+		if err := buildForRun(prov, flags); err != nil {
+			t.Fatalf("buildForRun() error = %v", err)
+		}
+		if !prov.buildCalled || prov.rebuildArg != tt.wantRebuild || prov.rebuildBaseArg != tt.wantRebuildBase {
+			t.Errorf("%v: BuildIfNeeded(%v, %v), want (%v, %v)", tt.args, prov.rebuildArg, prov.rebuildBaseArg, tt.wantRebuild, tt.wantRebuildBase)
+		}
+	}
+}
+
+func TestBuildForRun_NilFlags(t *testing.T) {
+	prov := &mockProvider{}
+	if err := buildForRun(prov, nil); err != nil {
+		t.Fatalf("buildForRun() error = %v", err)
+	}
+	if !prov.buildCalled || prov.rebuildArg || prov.rebuildBaseArg {
+		t.Errorf("BuildIfNeeded(%v, %v), want (false, false)", prov.rebuildArg, prov.rebuildBaseArg)
+	}
+}
+
+func TestBuildForRun_DaytonaStillCallsProvider(t *testing.T) {
+	prov := &daytonaMockProvider{}
+	if err := buildForRun(prov, &RunFlags{Rebuild: true}); err != nil {
+		t.Fatalf("buildForRun() error = %v", err)
+	}
+	if !prov.buildCalled {
+		t.Error("BuildIfNeeded should still be called for daytona")
+	}
+}
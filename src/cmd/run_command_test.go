@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jedi4ever/addt/config"
+	"github.com/jedi4ever/addt/core"
+	"github.com/jedi4ever/addt/provider"
+)
+
+// commandInContainerEnv loads the config and returns ADDT_COMMAND as the
+// container would see it
+func commandInContainerEnv() string {
+	cfg := config.LoadConfig("0.0.0-test", "22", "1.23.5", "0.4.17", 49152)
+	env := core.BuildEnvironment(&mockProvider{}, &provider.Config{Command: cfg.Command})
+	return env["ADDT_COMMAND"]
+}
+
+func TestCommandConfig_ReachesContainerEnv(t *testing.T) {
+	t.Setenv("ADDT_CONFIG_DIR", t.TempDir())
+	projectDir := t.TempDir()
+	t.Chdir(projectDir)
+	t.Setenv("ADDT_COMMAND", "")
+
+	// Without a configured command the extension entrypoint applies
+	config.SetDefaultCommand("claude")
+	if got := commandInContainerEnv(); got != "claude" {
+		t.Errorf("ADDT_COMMAND = %q, want the entrypoint claude", got)
+	}
+
+	os.Unsetenv("ADDT_COMMAND")
+	if err := os.WriteFile(filepath.Join(projectDir, ".addt.yaml"), []byte("command: codex\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	config.SetDefaultCommand("claude")
+	if got := commandInContainerEnv(); got != "codex" {
+		t.Errorf("ADDT_COMMAND = %q, want the configured codex", got)
+	}
+}
+
+func TestRunFlags_CommandOverridesConfig(t *testing.T) {
+	t.Setenv("ADDT_CONFIG_DIR", t.TempDir())
+	projectDir := t.TempDir()
+	t.Chdir(projectDir)
+	t.Setenv("ADDT_COMMAND", "")
+	if err := os.WriteFile(filepath.Join(projectDir, ".addt.yaml"), []byte("command: codex\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	flags, rest, err := parseRunFlags([]string{"--command", "gemini", "claude"})
+	if err != nil {
+		t.Fatalf("parseRunFlags() error = %v", err)
+	}
+	if len(rest) != 1 || rest[0] != "claude" {
+		t.Errorf("remaining args = %v, want [claude]", rest)
+	}
+	flags.apply()
+
+	if got := commandInContainerEnv(); got != "gemini" {
+		t.Errorf("ADDT_COMMAND = %q, want gemini from --command", got)
+	}
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jedi4ever/addt/config"
+)
+
+func TestRunFlags_DindOverridesConfig(t *testing.T) {
+	for _, mode := range []string{"host", "isolated", "off"} {
+		t.Run(mode, func(t *testing.T) {
+			// Start from a different configured mode so the flag has to win
+			configured := "isolated"
+			if mode == configured {
+				configured = "host"
+			}
+			t.Setenv("ADDT_CONFIG_DIR", t.TempDir())
+			t.Setenv("ADDT_DOCKER_DIND_MODE", configured)
+			t.Chdir(t.TempDir())
+
+			flags, rest, err := parseRunFlags([]string{"--dind", mode, "claude"})
+			if err != nil {
+				t.Fatalf("parseRunFlags() error = %v", err)
+			}
+			if len(rest) != 1 || rest[0] != "claude" {
+				t.Errorf("remaining args = %v, want [claude]", rest)
+			}
+			flags.apply()
+
+			cfg := config.LoadConfig("0.0.0-test", "22", "1.23.5", "0.4.17", 49152)
+			if cfg.DockerDindMode != mode {
+				t.Errorf("DockerDindMode = %q, want %q", cfg.DockerDindMode, mode)
+			}
+		})
+	}
+}
+
+func TestParseRunFlags_DindValidation(t *testing.T) {
+	for _, args := range [][]string{
+		{"--dind", "true", "claude"},
+		{"--dind=rootless", "claude"},
+		{"--dind"},
+	} {
+		if _, _, err := parseRunFlags(args); err == nil {
+			t.Errorf("parseRunFlags(%v) succeeded, want an error", args)
+		} else if !strings.Contains(err.Error(), "--dind") {
+			t.Errorf("parseRunFlags(%v) error = %v, want it to name the flag", args, err)
+		}
+	}
+}
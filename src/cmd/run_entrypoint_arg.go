@@ -0,0 +1,13 @@
+package cmd
+
+// entrypointArgFlag is repeatable and passes an arg to the built-in
+// entrypoint, ahead of the agent args, e.g. --entrypoint-arg --debug
+const entrypointArgFlag = "--entrypoint-arg"
+
+// entrypointArgs returns the --entrypoint-arg values in order
+func (f *RunFlags) entrypointArgs() []string {
+	if f == nil {
+		return nil
+	}
+	return f.EntrypointArgs
+}
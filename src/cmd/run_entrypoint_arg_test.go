@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRunFlags_EntrypointArg(t *testing.T) {
+	flags, rest, err := parseRunFlags([]string{"--entrypoint-arg", "--debug", "--entrypoint-arg=--verbose", "claude", "--help"})
+	if err != nil {
+		t.Fatalf("parseRunFlags() error = %v", err)
+	}
+	if want := []string{"--debug", "--verbose"}; !reflect.DeepEqual(flags.entrypointArgs(), want) {
+		t.Errorf("entrypointArgs() = %v, want %v", flags.entrypointArgs(), want)
+	}
+	if want := []string{"claude", "--help"}; !reflect.DeepEqual(rest, want) {
+		t.Errorf("rest = %v, want %v", rest, want)
+	}
+
+	if _, _, err := parseRunFlags([]string{"--entrypoint-arg="}); err == nil {
+		t.Error("parseRunFlags() with an empty --entrypoint-arg should fail")
+	}
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jedi4ever/addt/config"
+)
+
+// envFileFlag is repeatable and loads extra env files after the configured
+// env_file, later files overriding earlier ones, e.g.
+// --env-file .env.shared --env-file .env.local
+const envFileFlag = "--env-file"
+
+// envFiles returns the files from --env-file in order, made absolute so
+// they stay relative to where addt was started rather than the workdir
+func (f *RunFlags) envFiles() []string {
+	if f == nil {
+		return nil
+	}
+	files := make([]string, 0, len(f.EnvFiles))
+	for _, file := range f.EnvFiles {
+		if abs, err := filepath.Abs(file); err == nil {
+			file = abs
+		}
+		files = append(files, file)
+	}
+	return files
+}
+
+// loadEnvFiles loads the configured env file (with env_file_load on) and
+// then each --env-file into the host environment, so forwarded and secret
+// vars see their values. Unlike the configured file, a missing --env-file
+// is an error.
+func loadEnvFiles(cfg *config.Config, files []string) error {
+	if cfg.EnvFileLoad {
+		if err := config.LoadEnvFile(cfg.EnvFile); err != nil {
+			return err
+		}
+	}
+	for _, file := range files {
+		if info, err := os.Stat(file); err != nil || info.IsDir() {
+			return fmt.Errorf("%s %s: file not found", envFileFlag, file)
+		}
+		if err := config.LoadEnvFile(file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/jedi4ever/addt/config"
+)
+
+func TestParseRunFlags_EnvFile(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	flags, rest, err := parseRunFlags([]string{"--env-file", ".env.shared", "--env-file=.env.local", "claude"})
+	if err != nil {
+		t.Fatalf("parseRunFlags() error = %v", err)
+	}
+	if len(rest) != 1 {
+		t.Fatalf("rest = %v, want [claude]", rest)
+	}
+	cwd, _ := os.Getwd()
+	want := []string{filepath.Join(cwd, ".env.shared"), filepath.Join(cwd, ".env.local")}
+	if got := flags.envFiles(); !reflect.DeepEqual(got, want) {
+		t.Errorf("envFiles() = %v, want %v", got, want)
+	}
+}
+
+func TestLoadEnvFiles_Layering(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	os.WriteFile(".env", []byte("ENVFILE_TEST_A=config\nENVFILE_TEST_B=config\nENVFILE_TEST_C=config\n"), 0644)
+	os.WriteFile("shared.env", []byte("ENVFILE_TEST_B=shared\nENVFILE_TEST_C=shared\n"), 0644)
+	os.WriteFile("local.env", []byte("ENVFILE_TEST_C=local\n"), 0644)
+	for _, name := range []string{"ENVFILE_TEST_A", "ENVFILE_TEST_B", "ENVFILE_TEST_C"} {
+		t.Setenv(name, "")
+	}
+
+	cfg := &config.Config{EnvFileLoad: true}
+	if err := loadEnvFiles(cfg, []string{"shared.env", "local.env"}); err != nil {
+		t.Fatalf("loadEnvFiles() error = %v", err)
+	}
+	for name, want := range map[string]string{"ENVFILE_TEST_A": "config", "ENVFILE_TEST_B": "shared", "ENVFILE_TEST_C": "local"} {
+		if got := os.Getenv(name); got != want {
+			t.Errorf("%s = %q, want %q (later files win)", name, got, want)
+		}
+	}
+
+	if err := loadEnvFiles(cfg, []string{"missing.env"}); err == nil {
+		t.Error("loadEnvFiles() expected error for a missing --env-file")
+	}
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jedi4ever/addt/config"
+)
+
+func TestRunFlags_NoInitFirewallWait(t *testing.T) {
+	t.Setenv("ADDT_CONFIG_DIR", t.TempDir())
+	t.Setenv("ADDT_FIREWALL", "") // restored after apply() sets it
+	t.Setenv("ADDT_FIREWALL_ASYNC", "")
+	t.Chdir(t.TempDir())
+
+	cfg := config.LoadConfig("0.0.0-test", "22", "1.23.5", "0.4.17", 49152)
+	if cfg.FirewallAsyncInit {
+		t.Fatal("FirewallAsyncInit should default to false")
+	}
+
+	flags, rest, err := parseRunFlags([]string{"--firewall", "--no-init-firewall-wait", "claude"})
+	if err != nil {
+		t.Fatalf("parseRunFlags() error = %v", err)
+	}
+	if len(rest) != 1 || rest[0] != "claude" {
+		t.Errorf("remaining args = %v, want [claude]", rest)
+	}
+	flags.apply()
+
+	cfg = config.LoadConfig("0.0.0-test", "22", "1.23.5", "0.4.17", 49152)
+	if !cfg.FirewallEnabled || !cfg.FirewallAsyncInit {
+		t.Errorf("FirewallEnabled, FirewallAsyncInit = %v, %v; want true, true", cfg.FirewallEnabled, cfg.FirewallAsyncInit)
+	}
+}
+
+func TestParseRunFlags_FirewallModeValidation(t *testing.T) {
+	for _, mode := range []string{"strict", "permissive", "off"} {
+		if _, _, err := parseRunFlags([]string{"--firewall-mode", mode, "claude"}); err != nil {
+			t.Errorf("parseRunFlags(--firewall-mode %s) error = %v", mode, err)
+		}
+	}
+	for _, args := range [][]string{{"--firewall-mode", "Strict", "claude"}, {"--firewall-mode=lenient", "claude"}} {
+		_, _, err := parseRunFlags(args)
+		if err == nil || !strings.Contains(err.Error(), "--firewall-mode") || !strings.Contains(err.Error(), "strict, permissive, off") {
+			t.Errorf("parseRunFlags(%v) error = %v, want the flag and the allowed values", args, err)
+		}
+	}
+}
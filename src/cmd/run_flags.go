@@ -0,0 +1,371 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	configcmd "github.com/jedi4ever/addt/cmd/config"
+	"github.com/jedi4ever/addt/config"
+	"github.com/jedi4ever/addt/config/security"
+	"github.com/jedi4ever/addt/provider"
+)
+
+// runFlagDef maps an addt run flag to the config key it overrides.
+// Switch flags carry a fixed Value; flags with an empty Value take an
+// argument, checked by Validate when set.
+type runFlagDef struct {
+	Flag        string
+	Key         string
+	Value       string
+	Description string
+	Validate    func(string) error
+}
+
+// runFlagDefs lists the addt-level flags accepted before the extension name
+// in "addt run [flags] <extension> [args...]".
+var runFlagDefs = []runFlagDef{
+	{Flag: "--firewall", Key: "firewall.enabled", Value: "true", Description: "Enable the network firewall"},
+	{Flag: "--no-firewall", Key: "firewall.enabled", Value: "false", Description: "Disable the network firewall"},
+	{Flag: "--firewall-mode", Key: "firewall.mode", Description: "Firewall mode: strict, permissive, off", Validate: configcmd.AllowedValueValidator("firewall.mode")},
+	{Flag: "--no-init-firewall-wait", Key: "firewall.async_init", Value: "true", Description: "Start the agent while the firewall initializes (early traffic may be unfiltered)"},
+	{Flag: "--persistent", Key: "persistent", Value: "true", Description: "Use a persistent container"},
+	{Flag: "--ports", Key: "ports.expose", Description: "Comma-separated container ports to expose"},
+	{Flag: "--cpus", Key: "container.cpus", Description: "Container CPU limit"},
+	{Flag: "--memory", Key: "container.memory", Description: "Container memory limit"},
+	{Flag: "--dind", Key: "docker.dind.mode", Description: "Docker-in-Docker for this run: host, isolated, off", Validate: provider.ValidateDindMode},
+	{Flag: "--command", Key: "command", Description: "Agent command to run in the container"},
+	{Flag: "--pull-policy", Key: "docker.pull_policy", Description: "Base image pull policy: always, missing, never", Validate: configcmd.AllowedValueValidator("docker.pull_policy")},
+	{Flag: "--cpuset-cpus", Key: "docker.cpuset_cpus", Description: "Pin the container to these CPUs (e.g. 0-3,8)", Validate: provider.ValidateCpuset},
+	{Flag: "--detach-keys", Key: "container.detach_keys", Description: "Detach sequence for the interactive session (e.g. ctrl-x,x)"},
+	{Flag: "--entrypoint", Key: "container.entrypoint", Description: "Custom entrypoint, skipping addt's security/firewall/secrets init"},
+	{Flag: "--forward-ssh-keys", Key: "ssh.forward_keys", Value: "true", Description: "Forward SSH keys"},
+	{Flag: "--forward-github-token", Key: "github.forward_token", Value: "true", Description: "Forward GH_TOKEN"},
+	{Flag: "--mount-docker-config", Key: "docker.forward_config", Value: "true", Description: "Mount ~/.docker/config.json for registry logins"},
+	{Flag: "--log-file", Key: "log.file", Description: "Enable logging to this file (- for stdout)"},
+	{Flag: "--workdir-readonly", Key: "workdir.readonly", Value: "true", Description: "Mount the working directory read-only"},
+	{Flag: "--workdir-writable", Key: "workdir.readonly", Value: "false", Description: "Mount the working directory read-write"},
+	{Flag: "--overlay", Key: "workdir.overlay", Value: "true", Description: "Discard container writes to the working directory (podman)"},
+	{Flag: "--read-only-rootfs", Key: "security.read_only_rootfs", Value: "true", Description: "Mount the root filesystem read-only"},
+	{Flag: "--mount-readonly", Key: "security.mount_readonly", Value: "true", Description: "Force every host mount read-only"},
+	{Flag: "--tmp-size", Key: "security.tmpfs_tmp_size", Description: "Size of the /tmp tmpfs with a read-only rootfs (e.g. 2g)", Validate: security.ValidateTmpfsSize},
+	{Flag: "--home-size", Key: "security.tmpfs_home_size", Description: "Size of the /home/addt tmpfs with a read-only rootfs (e.g. 1g)", Validate: security.ValidateTmpfsSize},
+}
+
+// Capability flags are repeatable and merge with security.cap_add/cap_drop
+// for a single run instead of replacing them.
+const (
+	addCapFlag  = "--add-cap"
+	dropCapFlag = "--drop-cap"
+)
+
+// providerFlag selects the provider for a single run, taking precedence
+// over ADDT_PROVIDER and runtime auto-detection.
+const providerFlag = "--provider"
+
+// Output redirection flags route container stdout/stderr to files
+// instead of the terminal, for tooling that parses agent output.
+const (
+	stdoutFileFlag = "--stdout-file"
+	stderrFileFlag = "--stderr-file"
+)
+
+// saveImageFlag exports the resolved image to a tarball after the build,
+// for loading on an offline host with "addt load-image"
+const saveImageFlag = "--save-image"
+
+// mountWorkdirAtFlag moves the workdir mount (and the container's working
+// directory) from /workspace for a single run
+const mountWorkdirAtFlag = "--mount-workdir-at"
+
+// timeoutFlag is a host-side deadline for the run. Unlike
+// security.time_limit (enforced inside the container), addt itself gives
+// up and tears the container down, even if the runtime hangs.
+const timeoutFlag = "--timeout"
+
+// extraSSHDirFlag is repeatable and adds to ssh.dirs for a single run
+const extraSSHDirFlag = "--mount-extra-ssh-dir"
+
+// RunFlags holds the addt-level flags parsed from "addt run".
+type RunFlags struct {
+	SaveConfig         bool
+	PrintOnlyEnv       bool              // print the resolved run environment instead of starting a container
+	DumpSpec           bool              // print the resolved run spec as JSON instead of starting a container
+	ExplainConfig      bool              // print which layer supplied each config value before the run
+	StrictConfig       bool              // warn about unknown keys in the config files
+	PrintFirewallRules bool              // print the merged firewall allow/deny lists instead of starting a container
+	Frozen             bool              // fail unless the resolved extension versions match .addt.lock
+	Lock               bool              // write the resolved extension versions to .addt.lock
+	Provider           string            // provider selected by --provider
+	Rebuild            bool              // rebuild the extension image before the run
+	RebuildBase        bool              // rebuild the base image (and extension image) before the run
+	StdoutFile         string            // write container stdout to this file
+	StderrFile         string            // write container stderr to this file
+	SaveImage          string            // export the resolved image to this tarball after the build
+	WorkdirTarget      string            // container path for the workdir mount from --mount-workdir-at
+	NoAutomountConfig  bool              // skip extension config mounts from --no-automount-config
+	Timeout            time.Duration     // host-side deadline from --timeout (0 = none)
+	Record             bool              // mirror the session output to a transcript under the log dir
+	CapAdd             []string          // normalized capabilities from --add-cap
+	CapDrop            []string          // normalized capabilities from --drop-cap
+	SSHDirs            []string          // extra SSH directories from --mount-extra-ssh-dir
+	HomeSubdirs        []string          // extra persisted home subdirs from --mount-home
+	EnvFiles           []string          // extra env files from --env-file, in order
+	Mounts             []string          // docker-style mount specs from --mount, in order
+	EntrypointArgs     []string          // args for the built-in entrypoint from --entrypoint-arg, in order
+	Overrides          map[string]string // config key -> value set by a flag
+	Previous           map[string]string // config key -> effective value before the flag was applied
+}
+
+// findRunFlagDef looks up a run flag definition by flag name
+func findRunFlagDef(flag string) *runFlagDef {
+	for i := range runFlagDefs {
+		if runFlagDefs[i].Flag == flag {
+			return &runFlagDefs[i]
+		}
+	}
+	return nil
+}
+
+// parseRunFlags consumes addt flags preceding the extension name.
+// Returns the parsed flags and the args starting at the extension name.
+func parseRunFlags(args []string) (*RunFlags, []string, error) {
+	flags := &RunFlags{
+		Overrides: make(map[string]string),
+		Previous:  make(map[string]string),
+	}
+
+	i := 0
+	for ; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "--") || arg == "--help" {
+			break
+		}
+
+		name, value, hasValue := strings.Cut(arg, "=")
+		if flags.setSwitch(name) {
+			continue
+		}
+		def := findRunFlagDef(name)
+		if def == nil && !isValueFlag(name) {
+			return nil, nil, fmt.Errorf("unknown run flag: %s", name)
+		}
+		if def != nil && def.Value != "" {
+			if hasValue {
+				return nil, nil, fmt.Errorf("flag %s does not take a value", name)
+			}
+			flags.Overrides[def.Key] = def.Value
+			continue
+		}
+		if !hasValue {
+			var err error
+			if value, err = takeValue(args, &i, name); err != nil {
+				return nil, nil, err
+			}
+		}
+		if err := flags.setValue(name, value, def); err != nil {
+			return nil, nil, fmt.Errorf("flag %s: %w", name, err)
+		}
+	}
+
+	return flags, args[i:], nil
+}
+
+// apply records the effective pre-flag values and exports each override
+// through the key's environment variable so LoadConfig picks it up.
+// --provider is exported as ADDT_PROVIDER, which both runtime detection
+// and NewProvider honour; --explain-config as ADDT_EXPLAIN_CONFIG and
+// --strict-config as ADDT_STRICT_CONFIG.
+// --mount-extra-ssh-dir and --mount-home become ssh.dirs and
+// home.persist_subdirs overrides appended to the effective lists.
+func (f *RunFlags) apply() {
+	if f.Provider != "" {
+		os.Setenv("ADDT_PROVIDER", f.Provider)
+	}
+	if f.ExplainConfig {
+		os.Setenv("ADDT_EXPLAIN_CONFIG", "true")
+	}
+	if f.StrictConfig {
+		os.Setenv("ADDT_STRICT_CONFIG", "true")
+	}
+	f.appendListOverride("ssh.dirs", f.SSHDirs)
+	f.appendListOverride("home.persist_subdirs", f.HomeSubdirs)
+	for key, value := range f.Overrides {
+		f.Previous[key], _ = configcmd.EffectiveValue(key)
+		if info := configcmd.GetKeyInfo(key); info != nil && info.EnvVar != "" {
+			os.Setenv(info.EnvVar, value)
+		}
+	}
+}
+
+// applySecurity merges the capability flags into the loaded security config
+func (f *RunFlags) applySecurity(sec *security.Config) {
+	if f == nil {
+		return
+	}
+	security.MergeCaps(sec, f.CapAdd, f.CapDrop)
+}
+
+// saveRunFlagsToProject persists flag overrides that differ from their
+// effective pre-flag values into the project config.
+// Returns the keys that were saved, sorted.
+func saveRunFlagsToProject(f *RunFlags) ([]string, error) {
+	var changed []string
+	for key, value := range f.Overrides {
+		if f.Previous[key] != value {
+			changed = append(changed, key)
+		}
+	}
+	sort.Strings(changed)
+	if len(changed) == 0 {
+		return nil, nil
+	}
+
+	err := config.UpdateProjectConfigFile(func(cfg *config.GlobalConfig) error {
+		for _, key := range changed {
+			if err := configcmd.SetValue(cfg, key, f.Overrides[key]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changed, nil
+}
+
+// handleSaveConfig writes flag overrides to the project config after a successful run
+func handleSaveConfig(f *RunFlags) {
+	if f == nil || !f.SaveConfig {
+		return
+	}
+	saved, err := saveRunFlagsToProject(f)
+	if err != nil {
+		fmt.Printf("Error saving project config: %v\n", err)
+		return
+	}
+	if len(saved) == 0 {
+		fmt.Println("No flag settings differ from the current config, nothing saved")
+		return
+	}
+	fmt.Printf("Saved to %s:\n", config.GetProjectConfigPath())
+	for _, key := range saved {
+		fmt.Printf("  %s = %s\n", key, f.Overrides[key])
+	}
+}
+
+// runFlagNames returns all run flag names, for help and completion
+func runFlagNames() []string {
+	names := []string{"--save-config", "--print-only-env", "--dump-spec", "--print-firewall-rules", "--explain-config", "--strict-config", "--frozen", "--lock", "--rebuild", "--rebuild-base", recordFlag, providerFlag, timeoutFlag, extraSSHDirFlag, mountHomeFlag, envFileFlag, mountFlag, addCapFlag, dropCapFlag, stdoutFileFlag, stderrFileFlag, saveImageFlag, mountWorkdirAtFlag, noAutomountConfigFlag}
+	for _, def := range runFlagDefs {
+		names = append(names, def.Flag)
+	}
+	return names
+}
+
+// setSwitch sets the field for a value-less flag and reports whether name is one
+func (f *RunFlags) setSwitch(name string) bool {
+	switch name {
+	case "--save-config":
+		f.SaveConfig = true
+	case "--print-only-env":
+		f.PrintOnlyEnv = true
+	case "--dump-spec":
+		f.DumpSpec = true
+	case "--print-firewall-rules":
+		f.PrintFirewallRules = true
+	case "--frozen":
+		f.Frozen = true
+	case "--lock":
+		f.Lock = true
+	case "--explain-config":
+		f.ExplainConfig = true
+	case "--strict-config":
+		f.StrictConfig = true
+	case "--rebuild":
+		f.Rebuild = true
+	case "--rebuild-base":
+		f.RebuildBase = true
+	case recordFlag:
+		f.Record = true
+	case noAutomountConfigFlag:
+		f.NoAutomountConfig = true
+	default:
+		return false
+	}
+	return true
+}
+
+// isValueFlag reports whether name takes a value but is handled by
+// setValue rather than a runFlagDefs entry
+func isValueFlag(name string) bool {
+	switch name {
+	case providerFlag, timeoutFlag, stdoutFileFlag, stderrFileFlag, saveImageFlag, mountWorkdirAtFlag, addCapFlag, dropCapFlag:
+		return true
+	}
+	return isRepeatableFlag(name)
+}
+
+// takeValue returns the argument following flag name, advancing i to it
+func takeValue(args []string, i *int, name string) (string, error) {
+	if *i+1 >= len(args) {
+		return "", fmt.Errorf("flag %s requires a value", name)
+	}
+	*i++
+	return args[*i], nil
+}
+
+// setValue validates value and stores it for flag name: as a config
+// override when def is set, otherwise in the matching RunFlags field
+func (f *RunFlags) setValue(name, value string, def *runFlagDef) error {
+	if def != nil {
+		if def.Validate != nil {
+			if err := def.Validate(value); err != nil {
+				return err
+			}
+		}
+		f.Overrides[def.Key] = value
+		return nil
+	}
+	if isRepeatableFlag(name) {
+		return f.addRepeatable(name, value)
+	}
+	switch name {
+	case providerFlag:
+		if err := validateProviderName(value); err != nil {
+			return err
+		}
+		f.Provider = value
+	case timeoutFlag:
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("expected a positive duration like 30m or 1h, got %q", value)
+		}
+		f.Timeout = d
+	case stdoutFileFlag:
+		f.StdoutFile = value
+	case stderrFileFlag:
+		f.StderrFile = value
+	case saveImageFlag:
+		f.SaveImage = value
+	case mountWorkdirAtFlag:
+		if err := provider.ValidateWorkdirTarget(value); err != nil {
+			return err
+		}
+		f.WorkdirTarget = value
+	case addCapFlag, dropCapFlag:
+		capName, err := security.NormalizeCap(value)
+		if err != nil {
+			return err
+		}
+		if name == addCapFlag {
+			f.CapAdd = append(f.CapAdd, capName)
+		} else {
+			f.CapDrop = append(f.CapDrop, capName)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,298 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jedi4ever/addt/config"
+	"github.com/jedi4ever/addt/config/security"
+	"github.com/jedi4ever/addt/core"
+	"github.com/jedi4ever/addt/provider"
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseRunFlags(t *testing.T) {
+	flags, rest, err := parseRunFlags([]string{"--firewall", "--ports", "3000,8080", "--memory=2g", "--save-config", "claude", "--firewall"})
+	if err != nil {
+		t.Fatalf("parseRunFlags() error = %v", err)
+	}
+
+	if !flags.SaveConfig {
+		t.Error("SaveConfig = false, want true")
+	}
+	if flags.Overrides["firewall.enabled"] != "true" {
+		t.Errorf("firewall.enabled = %q, want %q", flags.Overrides["firewall.enabled"], "true")
+	}
+	if flags.Overrides["ports.expose"] != "3000,8080" {
+		t.Errorf("ports.expose = %q, want %q", flags.Overrides["ports.expose"], "3000,8080")
+	}
+	if flags.Overrides["container.memory"] != "2g" {
+		t.Errorf("container.memory = %q, want %q", flags.Overrides["container.memory"], "2g")
+	}
+
+	// Flags after the extension name belong to the agent
+	if len(rest) != 2 || rest[0] != "claude" || rest[1] != "--firewall" {
+		t.Errorf("remaining args = %v, want [claude --firewall]", rest)
+	}
+}
+
+func TestParseRunFlags_Errors(t *testing.T) {
+	cases := [][]string{
+		{"--bogus", "claude"},
+		{"--ports"},
+		{"--firewall=false", "claude"},
+		{"--add-cap", "NOT_A_CAP", "claude"},
+		{"--drop-cap"},
+	}
+	for _, args := range cases {
+		if _, _, err := parseRunFlags(args); err == nil {
+			t.Errorf("parseRunFlags(%v) expected error, got nil", args)
+		}
+	}
+}
+
+func TestParseRunFlags_MissingValue(t *testing.T) {
+	for _, flag := range []string{"--ports", providerFlag, timeoutFlag, stdoutFileFlag, mountWorkdirAtFlag, mountFlag, addCapFlag} {
+		_, _, err := parseRunFlags([]string{flag})
+		if err == nil || err.Error() != "flag "+flag+" requires a value" {
+			t.Errorf("parseRunFlags([%s]) error = %v, want requires a value", flag, err)
+		}
+	}
+}
+
+func TestParseRunFlags_PullPolicyValidation(t *testing.T) {
+	for _, policy := range []string{"always", "missing", "never"} {
+		if _, _, err := parseRunFlags([]string{"--pull-policy", policy, "claude"}); err != nil {
+			t.Errorf("parseRunFlags(--pull-policy %s) error = %v", policy, err)
+		}
+	}
+	_, _, err := parseRunFlags([]string{"--pull-policy=sometimes", "claude"})
+	if err == nil || !strings.Contains(err.Error(), "--pull-policy") || !strings.Contains(err.Error(), "always, missing, never") {
+		t.Errorf("parseRunFlags(--pull-policy=sometimes) error = %v, want the flag and the allowed values", err)
+	}
+}
+
+func TestParseRunFlags_Caps(t *testing.T) {
+	flags, rest, err := parseRunFlags([]string{"--add-cap", "sys_ptrace", "--add-cap=CAP_NET_RAW", "--drop-cap", "CHOWN", "claude"})
+	if err != nil {
+		t.Fatalf("parseRunFlags() error = %v", err)
+	}
+	if !reflect.DeepEqual(flags.CapAdd, []string{"SYS_PTRACE", "NET_RAW"}) {
+		t.Errorf("CapAdd = %v, want [SYS_PTRACE NET_RAW]", flags.CapAdd)
+	}
+	if !reflect.DeepEqual(flags.CapDrop, []string{"CHOWN"}) {
+		t.Errorf("CapDrop = %v, want [CHOWN]", flags.CapDrop)
+	}
+	if len(rest) != 1 || rest[0] != "claude" {
+		t.Errorf("remaining args = %v, want [claude]", rest)
+	}
+
+	// CLI caps win over configured caps
+	sec := security.DefaultConfig()
+	flags.applySecurity(&sec)
+	if !reflect.DeepEqual(sec.CapAdd, []string{"SETUID", "SETGID", "SYS_PTRACE", "NET_RAW"}) {
+		t.Errorf("merged CapAdd = %v", sec.CapAdd)
+	}
+	if !reflect.DeepEqual(sec.CapDrop, []string{"ALL", "CHOWN"}) {
+		t.Errorf("merged CapDrop = %v", sec.CapDrop)
+	}
+}
+
+func TestParseRunFlags_PrintOnlyEnv(t *testing.T) {
+	flags, rest, err := parseRunFlags([]string{"--print-only-env", "--print-firewall-rules", "--firewall", "claude"})
+	if err != nil {
+		t.Fatalf("parseRunFlags() error = %v", err)
+	}
+	if !flags.PrintOnlyEnv || !flags.PrintFirewallRules {
+		t.Errorf("PrintOnlyEnv = %v, PrintFirewallRules = %v, want both true", flags.PrintOnlyEnv, flags.PrintFirewallRules)
+	}
+	if len(rest) != 1 || rest[0] != "claude" {
+		t.Errorf("remaining args = %v, want [claude]", rest)
+	}
+}
+
+func TestParseRunFlags_OutputFiles(t *testing.T) {
+	flags, rest, err := parseRunFlags([]string{"--stdout-file", "out.log", "--stderr-file=err.log", "claude", "-p"})
+	if err != nil {
+		t.Fatalf("parseRunFlags() error = %v", err)
+	}
+	if flags.StdoutFile != "out.log" || flags.StderrFile != "err.log" {
+		t.Errorf("StdoutFile=%q StderrFile=%q", flags.StdoutFile, flags.StderrFile)
+	}
+	if len(rest) != 2 || rest[0] != "claude" {
+		t.Errorf("remaining args = %v, want [claude -p]", rest)
+	}
+
+	dir := t.TempDir()
+	flags.StdoutFile = filepath.Join(dir, "out.log")
+	flags.StderrFile = ""
+	stdout, stderr, closeAll, err := flags.openOutputFiles()
+	if err != nil {
+		t.Fatalf("openOutputFiles() error = %v", err)
+	}
+	defer closeAll()
+	if stdout == nil || stderr != nil {
+		t.Errorf("stdout=%v stderr=%v, want only stdout redirected", stdout, stderr)
+	}
+}
+
+func TestParseRunFlags_ProviderOverridesEnv(t *testing.T) {
+	t.Setenv("ADDT_PROVIDER", "docker")
+
+	flags, rest, err := parseRunFlags([]string{"--provider", "podman", "claude"})
+	if err != nil {
+		t.Fatalf("parseRunFlags() error = %v", err)
+	}
+	if flags.Provider != "podman" || len(rest) != 1 {
+		t.Errorf("Provider=%q rest=%v", flags.Provider, rest)
+	}
+
+	flags.apply()
+	if got := config.DetectContainerRuntime(); got != "podman" {
+		t.Errorf("DetectContainerRuntime() = %q, want podman", got)
+	}
+
+	if _, _, err := parseRunFlags([]string{"--provider=lxc", "claude"}); err == nil {
+		t.Error("parseRunFlags(--provider=lxc) expected error for unknown provider")
+	}
+}
+
+func TestRunFlags_NoFirewallOverridesConfig(t *testing.T) {
+	t.Setenv("ADDT_CONFIG_DIR", t.TempDir())
+	t.Setenv("ADDT_FIREWALL", "true")
+	t.Setenv("ADDT_FIREWALL_MODE", "permissive")
+	t.Chdir(t.TempDir())
+
+	flags, _, err := parseRunFlags([]string{"--firewall-mode", "strict", "--no-firewall", "claude"})
+	if err != nil {
+		t.Fatalf("parseRunFlags() error = %v", err)
+	}
+	flags.apply()
+
+	cfg := config.LoadConfig("0.0.0-test", "22", "1.23.5", "0.4.17", 49152)
+	if cfg.FirewallEnabled {
+		t.Fatal("FirewallEnabled = true, want --no-firewall to win over ADDT_FIREWALL")
+	}
+	if cfg.FirewallMode != "strict" {
+		t.Errorf("FirewallMode = %q, want strict", cfg.FirewallMode)
+	}
+
+	// No root phase and no firewall caps or env reach the container
+	posture := security.Explain(cfg.Security, cfg.FirewallEnabled)
+	if posture.StartsAsRoot || len(posture.FirewallCaps) > 0 {
+		t.Errorf("posture = %+v, want no firewall root phase or caps", posture)
+	}
+	env := core.BuildEnvironment(&mockProvider{}, &provider.Config{FirewallEnabled: cfg.FirewallEnabled, FirewallMode: cfg.FirewallMode})
+	if _, ok := env["ADDT_FIREWALL_ENABLED"]; ok {
+		t.Error("ADDT_FIREWALL_ENABLED should not be set with --no-firewall")
+	}
+}
+
+func TestRunSaveConfig_WritesFirewall(t *testing.T) {
+	origConfigDir := os.Getenv("ADDT_CONFIG_DIR")
+	origExtensions := os.Getenv("ADDT_EXTENSIONS")
+	origCommand := os.Getenv("ADDT_COMMAND")
+	origFirewall, hadFirewall := os.LookupEnv("ADDT_FIREWALL")
+	origCwd, _ := os.Getwd()
+	defer func() {
+		os.Setenv("ADDT_CONFIG_DIR", origConfigDir)
+		os.Setenv("ADDT_EXTENSIONS", origExtensions)
+		os.Setenv("ADDT_COMMAND", origCommand)
+		if hadFirewall {
+			os.Setenv("ADDT_FIREWALL", origFirewall)
+		} else {
+			os.Unsetenv("ADDT_FIREWALL")
+		}
+		os.Unsetenv("ADDT_PERSISTENT")
+		os.Chdir(origCwd)
+	}()
+
+	os.Setenv("ADDT_CONFIG_DIR", t.TempDir())
+	os.Unsetenv("ADDT_FIREWALL")
+	projectDir := t.TempDir()
+	os.Chdir(projectDir)
+
+	// persistent is already true in project config, so it must not be rewritten
+	os.WriteFile(filepath.Join(projectDir, ".addt.yaml"), []byte("persistent: true\n"), 0644)
+
+	remaining, flags := HandleRunCommandWithFlags([]string{"--firewall", "--persistent", "--save-config", "claude"})
+	if remaining == nil || flags == nil {
+		t.Fatal("HandleRunCommandWithFlags() returned nil")
+	}
+	if os.Getenv("ADDT_FIREWALL") != "true" {
+		t.Errorf("ADDT_FIREWALL = %q, want %q", os.Getenv("ADDT_FIREWALL"), "true")
+	}
+
+	saved, err := saveRunFlagsToProject(flags)
+	if err != nil {
+		t.Fatalf("saveRunFlagsToProject() error = %v", err)
+	}
+	if len(saved) != 1 || saved[0] != "firewall.enabled" {
+		t.Errorf("saved keys = %v, want [firewall.enabled]", saved)
+	}
+
+	data, err := os.ReadFile(filepath.Join(projectDir, ".addt.yaml"))
+	if err != nil {
+		t.Fatalf("failed to read project config: %v", err)
+	}
+	var cfg struct {
+		Persistent *bool `yaml:"persistent"`
+		Firewall   struct {
+			Enabled *bool `yaml:"enabled"`
+		} `yaml:"firewall"`
+	}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		t.Fatalf("failed to parse project config: %v", err)
+	}
+	if cfg.Firewall.Enabled == nil || !*cfg.Firewall.Enabled {
+		t.Errorf("project config firewall.enabled not true:\n%s", data)
+	}
+	if cfg.Persistent == nil || !*cfg.Persistent {
+		t.Errorf("project config persistent lost:\n%s", data)
+	}
+}
+
+func TestParseRunFlags_Timeout(t *testing.T) {
+	flags, rest, err := parseRunFlags([]string{"--timeout", "90s", "claude"})
+	if err != nil {
+		t.Fatalf("parseRunFlags() error = %v", err)
+	}
+	if flags.Timeout != 90*time.Second || len(rest) != 1 {
+		t.Errorf("Timeout=%s rest=%v, want 1m30s [claude]", flags.Timeout, rest)
+	}
+
+	for _, bad := range []string{"--timeout=soon", "--timeout=0s", "--timeout"} {
+		if _, _, err := parseRunFlags([]string{bad}); err == nil {
+			t.Errorf("parseRunFlags(%s) expected error", bad)
+		}
+	}
+}
+
+func TestParseRunFlags_ExtraSSHDirs(t *testing.T) {
+	t.Setenv("ADDT_CONFIG_DIR", t.TempDir())
+	t.Setenv("ADDT_SSH_DIRS", "~/.ssh-personal")
+	t.Chdir(t.TempDir())
+
+	flags, rest, err := parseRunFlags([]string{"--mount-extra-ssh-dir", "~/work/.ssh", "--mount-extra-ssh-dir=/keys", "claude"})
+	if err != nil {
+		t.Fatalf("parseRunFlags() error = %v", err)
+	}
+	if len(flags.SSHDirs) != 2 || len(rest) != 1 {
+		t.Fatalf("SSHDirs=%v rest=%v", flags.SSHDirs, rest)
+	}
+
+	flags.apply()
+	cfg := config.LoadConfig("0.0.0-test", "22", "1.23.5", "0.4.17", 49152)
+	want := []string{"~/.ssh-personal", "~/work/.ssh", "/keys"}
+	if !reflect.DeepEqual(cfg.SSHDirs, want) {
+		t.Errorf("SSHDirs = %v, want %v (flags add to the configured dirs)", cfg.SSHDirs, want)
+	}
+
+	if _, _, err := parseRunFlags([]string{"--mount-extra-ssh-dir=a,b", "claude"}); err == nil {
+		t.Error("parseRunFlags(--mount-extra-ssh-dir=a,b) expected error")
+	}
+}
@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"strings"
+
+	configcmd "github.com/jedi4ever/addt/cmd/config"
+)
+
+// mountHomeFlag is repeatable and adds to home.persist_subdirs for a
+// single run, e.g. --mount-home .cache
+const mountHomeFlag = "--mount-home"
+
+// appendListOverride sets a list key's override to its effective value
+// plus values, so repeatable flags add to the configured entries instead
+// of replacing them
+func (f *RunFlags) appendListOverride(key string, values []string) {
+	if len(values) == 0 {
+		return
+	}
+	if current, _ := configcmd.EffectiveValue(key); current != "" {
+		values = append(strings.Split(current, ","), values...)
+	}
+	f.Overrides[key] = strings.Join(values, ",")
+}
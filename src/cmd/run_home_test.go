@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jedi4ever/addt/config"
+)
+
+func TestParseRunFlags_MountHome(t *testing.T) {
+	t.Setenv("ADDT_CONFIG_DIR", t.TempDir())
+	t.Setenv("ADDT_HOME_PERSIST_SUBDIRS", ".cache")
+	t.Chdir(t.TempDir())
+
+	flags, rest, err := parseRunFlags([]string{"--mount-home", ".config/gh", "--mount-home=.npm", "claude"})
+	if err != nil {
+		t.Fatalf("parseRunFlags() error = %v", err)
+	}
+	if len(flags.HomeSubdirs) != 2 || len(rest) != 1 {
+		t.Fatalf("HomeSubdirs=%v rest=%v", flags.HomeSubdirs, rest)
+	}
+
+	flags.apply()
+	cfg := config.LoadConfig("0.0.0-test", "22", "1.23.5", "0.4.17", 49152)
+	want := []string{".cache", ".config/gh", ".npm"}
+	if !reflect.DeepEqual(cfg.HomePersistSubdirs, want) {
+		t.Errorf("HomePersistSubdirs = %v, want %v (flags add to the configured subdirs)", cfg.HomePersistSubdirs, want)
+	}
+
+	for _, subdir := range []string{"/etc", "../.ssh", ".cache/", "a,b"} {
+		if _, _, err := parseRunFlags([]string{"--mount-home=" + subdir, "claude"}); err == nil {
+			t.Errorf("parseRunFlags(--mount-home=%s) expected error", subdir)
+		}
+	}
+}
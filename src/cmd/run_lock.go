@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jedi4ever/addt/config"
+)
+
+// runExtensions splits the comma-separated extension list of a run
+func runExtensions(extensions string) []string {
+	var exts []string
+	for _, ext := range strings.Split(extensions, ",") {
+		if ext = strings.TrimSpace(ext); ext != "" {
+			exts = append(exts, ext)
+		}
+	}
+	return exts
+}
+
+// extensionVersions copies the version of each extension, defaulting to
+// "latest" like the providers do. Taken before DetermineImageName, which
+// resolves dist-tags in place.
+func extensionVersions(exts []string, versions map[string]string) map[string]string {
+	out := make(map[string]string, len(exts))
+	for _, ext := range exts {
+		out[ext] = versions[ext]
+		if out[ext] == "" {
+			out[ext] = "latest"
+		}
+	}
+	return out
+}
+
+// applyLockfile checks the resolved versions against .addt.lock (--frozen)
+// or writes them to it (--lock)
+func (f *RunFlags) applyLockfile(exts []string, requested, resolved map[string]string) error {
+	if f == nil || (!f.Frozen && !f.Lock) {
+		return nil
+	}
+	if f.Frozen && f.Lock {
+		return errors.New("--frozen and --lock cannot be combined")
+	}
+
+	if err := checkDistTagsResolved(exts, resolved); err != nil {
+		return err
+	}
+
+	path := config.GetLockfilePath()
+	if f.Lock {
+		if err := config.SaveLockfile(path, config.NewLockfile(exts, requested, resolved)); err != nil {
+			return err
+		}
+		fmt.Printf("Wrote %s\n", config.LockfileName)
+		return nil
+	}
+
+	lock, err := config.LoadLockfile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("--frozen: %s not found (create it with addt run --lock)", config.LockfileName)
+	}
+	if err != nil {
+		return err
+	}
+	return lock.CheckFrozen(exts, requested, resolved)
+}
+
+// distTags are the npm dist-tags an extension version can name
+var distTags = map[string]bool{"latest": true, "stable": true, "next": true}
+
+// checkDistTagsResolved refuses to lock or check a dist-tag the run couldn't
+// resolve to a release. Only claude's tags are looked up on npm, and a tag
+// left as-is would match the lock whatever release it installs.
+func checkDistTagsResolved(exts []string, resolved map[string]string) error {
+	var unresolved []string
+	for _, ext := range exts {
+		if distTags[resolved[ext]] {
+			unresolved = append(unresolved, fmt.Sprintf("%s (%s)", ext, resolved[ext]))
+		}
+	}
+	if len(unresolved) == 0 {
+		return nil
+	}
+	return fmt.Errorf("can't pin the dist-tag of %s; set an explicit version with addt config extension <name> set version <version>", strings.Join(unresolved, ", "))
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestApplyLockfile_LockThenFrozen(t *testing.T) {
+	t.Chdir(t.TempDir())
+	exts := runExtensions("claude, codex")
+	requested := extensionVersions(exts, map[string]string{"claude": "stable", "codex": "0.5.0"})
+	resolved := map[string]string{"claude": "2.0.14", "codex": "0.5.0"}
+
+	frozen := &RunFlags{Frozen: true}
+	if err := frozen.applyLockfile(exts, requested, resolved); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("--frozen without .addt.lock: error = %v, want not found", err)
+	}
+
+	if err := (&RunFlags{Lock: true}).applyLockfile(exts, requested, resolved); err != nil {
+		t.Fatalf("--lock error = %v", err)
+	}
+	if _, err := os.Stat(".addt.lock"); err != nil {
+		t.Fatalf(".addt.lock not written: %v", err)
+	}
+
+	if err := frozen.applyLockfile(exts, requested, resolved); err != nil {
+		t.Errorf("--frozen with matching versions: error = %v", err)
+	}
+
+	// Switching claude to "latest" in config resolves to another release
+	changed := extensionVersions(exts, map[string]string{"claude": "latest", "codex": "0.5.0"})
+	if err := frozen.applyLockfile(exts, changed, map[string]string{"claude": "2.1.0", "codex": "0.5.0"}); err == nil {
+		t.Error("--frozen after a config change: expected an error")
+	}
+}
+
+func TestApplyLockfile_RejectsUnresolvedDistTags(t *testing.T) {
+	t.Chdir(t.TempDir())
+	exts := runExtensions("claude,codex")
+	requested := extensionVersions(exts, map[string]string{"claude": "stable"})
+	resolved := map[string]string{"claude": "2.0.14", "codex": "latest"}
+
+	for _, flags := range []*RunFlags{{Lock: true}, {Frozen: true}} {
+		err := flags.applyLockfile(exts, requested, resolved)
+		if err == nil || !strings.Contains(err.Error(), "codex (latest)") || !strings.Contains(err.Error(), "explicit version") {
+			t.Errorf("applyLockfile(%+v) error = %v, want codex's unresolved dist-tag", *flags, err)
+		}
+	}
+	if _, err := os.Stat(".addt.lock"); err == nil {
+		t.Error("--lock wrote .addt.lock with an unresolved dist-tag")
+	}
+}
+
+func TestApplyLockfile_NoFlags(t *testing.T) {
+	var flags *RunFlags
+	if err := flags.applyLockfile([]string{"claude"}, nil, nil); err != nil {
+		t.Errorf("applyLockfile() without flags error = %v", err)
+	}
+	if err := (&RunFlags{Frozen: true, Lock: true}).applyLockfile(nil, nil, nil); err == nil {
+		t.Error("--frozen with --lock: expected an error")
+	}
+}
+
+func TestParseRunFlags_FrozenLock(t *testing.T) {
+	flags, rest, err := parseRunFlags([]string{"--frozen", "claude"})
+	if err != nil || !flags.Frozen || flags.Lock || len(rest) != 1 {
+		t.Errorf("parseRunFlags(--frozen) = %+v, %v, %v", flags, rest, err)
+	}
+}
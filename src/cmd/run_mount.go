@@ -0,0 +1,13 @@
+package cmd
+
+// mountFlag is repeatable and passes a docker-style mount through to the
+// runtime, e.g. --mount type=bind,source=/data,target=/data,readonly
+const mountFlag = "--mount"
+
+// mounts returns the --mount values in order
+func (f *RunFlags) mounts() []string {
+	if f == nil {
+		return nil
+	}
+	return f.Mounts
+}
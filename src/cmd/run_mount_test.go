@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseRunFlags_Mount(t *testing.T) {
+	bind := "type=bind,source=/data,target=/data,readonly"
+	volume := "type=volume,source=cache,target=/cache"
+	flags, rest, err := parseRunFlags([]string{"--mount", bind, "--mount=" + volume, "claude"})
+	if err != nil {
+		t.Fatalf("parseRunFlags() error = %v", err)
+	}
+	if want := []string{bind, volume}; !reflect.DeepEqual(flags.mounts(), want) || len(rest) != 1 {
+		t.Errorf("mounts=%v rest=%v, want %v", flags.mounts(), rest, want)
+	}
+
+	_, _, err = parseRunFlags([]string{"--mount", "type=bind,source=/data", "claude"})
+	if err == nil || !strings.Contains(err.Error(), "target is required") {
+		t.Errorf("parseRunFlags() without target error = %v", err)
+	}
+}
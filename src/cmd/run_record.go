@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jedi4ever/addt/core"
+	"github.com/jedi4ever/addt/util"
+)
+
+// recordFlag mirrors the session output to a transcript file, keeping the
+// terminal and TTY (unlike --stdout-file)
+const recordFlag = "--record"
+
+// recordingPath returns a timestamped transcript path under logDir,
+// defaulting to ~/.addt/logs like the log file
+func recordingPath(logDir string, now time.Time) string {
+	if logDir == "" {
+		logDir = filepath.Join(util.GetAddtHome(), "logs")
+	}
+	return filepath.Join(logDir, "session-"+now.Format("20060102-150405")+".log")
+}
+
+// configureRunner applies the run flags that shape execution: output files,
+// the host-side timeout and the --record transcript. Returns a func that
+// closes the files it opened.
+func (f *RunFlags) configureRunner(runner *core.Runner, logDir string) (closeAll func(), err error) {
+	stdout, stderr, closeOutput, err := f.openOutputFiles()
+	if err != nil {
+		return nil, err
+	}
+	runner.SetOutput(stdout, stderr)
+	if f == nil {
+		return closeOutput, nil
+	}
+	runner.SetTimeout(f.Timeout)
+	if !f.Record {
+		return closeOutput, nil
+	}
+
+	// Transcripts can contain secrets the agent printed, so keep them private
+	path := recordingPath(logDir, time.Now())
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		closeOutput()
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		closeOutput()
+		return nil, err
+	}
+	runner.SetRecord(file)
+	fmt.Fprintf(os.Stderr, "Recording session to %s\n", path)
+	return func() {
+		file.Close()
+		closeOutput()
+	}, nil
+}
+
+// openOutputFiles creates the --stdout-file/--stderr-file destinations.
+// Returns nil writers for streams that stay on the terminal and a func
+// that closes the opened files.
+func (f *RunFlags) openOutputFiles() (stdout, stderr io.Writer, closeAll func(), err error) {
+	var files []*os.File
+	closeAll = func() {
+		for _, file := range files {
+			file.Close()
+		}
+	}
+	if f == nil {
+		return nil, nil, closeAll, nil
+	}
+	open := func(path string) (io.Writer, error) {
+		if path == "" {
+			return nil, nil
+		}
+		file, err := os.Create(path)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+		return file, nil
+	}
+	if stdout, err = open(f.StdoutFile); err != nil {
+		closeAll()
+		return nil, nil, closeAll, err
+	}
+	if stderr, err = open(f.StderrFile); err != nil {
+		closeAll()
+		return nil, nil, closeAll, err
+	}
+	return stdout, stderr, closeAll, nil
+}
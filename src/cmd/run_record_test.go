@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jedi4ever/addt/core"
+	"github.com/jedi4ever/addt/provider"
+)
+
+func TestParseRunFlags_Record(t *testing.T) {
+	flags, rest, err := parseRunFlags([]string{"--record", "claude", "--record"})
+	if err != nil {
+		t.Fatalf("parseRunFlags() error = %v", err)
+	}
+	if !flags.Record {
+		t.Error("Record = false, want true")
+	}
+	if len(rest) != 2 || rest[1] != "--record" {
+		t.Errorf("remaining args = %v, want flags after the extension left for the agent", rest)
+	}
+}
+
+func TestRecordingPath(t *testing.T) {
+	now := time.Date(2026, 3, 4, 15, 6, 7, 0, time.UTC)
+	if got, want := recordingPath("/var/log/addt", now), "/var/log/addt/session-20260304-150607.log"; got != want {
+		t.Errorf("recordingPath() = %q, want %q", got, want)
+	}
+
+	t.Setenv("ADDT_HOME", "/home/me/.addt")
+	if got, want := recordingPath("", now), "/home/me/.addt/logs/session-20260304-150607.log"; got != want {
+		t.Errorf("recordingPath() = %q, want %q under the default log dir", got, want)
+	}
+}
+
+func TestConfigureRunner_Record(t *testing.T) {
+	logDir := filepath.Join(t.TempDir(), "logs")
+	runner := core.NewRunner(nil, &provider.Config{})
+
+	closeAll, err := (&RunFlags{Record: true}).configureRunner(runner, logDir)
+	if err != nil {
+		t.Fatalf("configureRunner() error = %v", err)
+	}
+	closeAll()
+
+	entries, err := os.ReadDir(logDir)
+	if err != nil || len(entries) != 1 || !strings.HasPrefix(entries[0].Name(), "session-") {
+		t.Fatalf("log dir entries = %v (err %v), want one session transcript", entries, err)
+	}
+	info, _ := entries[0].Info()
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("transcript mode = %o, want 0600", perm)
+	}
+
+	// Without --record nothing is created
+	if _, err := (*RunFlags)(nil).configureRunner(runner, filepath.Join(t.TempDir(), "none")); err != nil {
+		t.Errorf("configureRunner(nil) error = %v", err)
+	}
+}
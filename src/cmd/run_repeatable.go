@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jedi4ever/addt/provider"
+)
+
+// isRepeatableFlag reports whether name is a run flag that takes a value and
+// may be given more than once, each value adding to a list
+func isRepeatableFlag(name string) bool {
+	switch name {
+	case extraSSHDirFlag, mountHomeFlag, envFileFlag, mountFlag, entrypointArgFlag:
+		return true
+	}
+	return false
+}
+
+// addRepeatable validates value and appends it to the list of flag name
+func (f *RunFlags) addRepeatable(name, value string) error {
+	switch name {
+	case entrypointArgFlag:
+		if value == "" {
+			return fmt.Errorf("expected a non-empty arg")
+		}
+		f.EntrypointArgs = append(f.EntrypointArgs, value)
+	case envFileFlag:
+		f.EnvFiles = append(f.EnvFiles, value)
+	case mountFlag:
+		if _, err := provider.ParseMountSpec(value); err != nil {
+			return err
+		}
+		f.Mounts = append(f.Mounts, value)
+	case mountHomeFlag:
+		if err := provider.ValidateHomeSubdir(value); err != nil {
+			return err
+		}
+		f.HomeSubdirs = append(f.HomeSubdirs, value)
+	default:
+		if value == "" || strings.Contains(value, ",") {
+			return fmt.Errorf("expected a single directory, got %q", value)
+		}
+		f.SSHDirs = append(f.SSHDirs, value)
+	}
+	return nil
+}
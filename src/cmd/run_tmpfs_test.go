@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jedi4ever/addt/config"
+)
+
+func TestRunFlags_TmpfsSizesOverrideConfig(t *testing.T) {
+	t.Setenv("ADDT_CONFIG_DIR", t.TempDir())
+	t.Setenv("ADDT_SECURITY_TMPFS_TMP_SIZE", "256m")
+	t.Setenv("ADDT_SECURITY_TMPFS_HOME_SIZE", "512m")
+	t.Chdir(t.TempDir())
+
+	flags, _, err := parseRunFlags([]string{"--tmp-size", "4g", "--home-size=1g", "claude"})
+	if err != nil {
+		t.Fatalf("parseRunFlags() error = %v", err)
+	}
+	flags.apply()
+
+	cfg := config.LoadConfig("0.0.0-test", "22", "1.23.5", "0.4.17", 49152)
+	if cfg.Security.TmpfsTmpSize != "4g" || cfg.Security.TmpfsHomeSize != "1g" {
+		t.Errorf("tmpfs sizes = %q, %q; want 4g, 1g", cfg.Security.TmpfsTmpSize, cfg.Security.TmpfsHomeSize)
+	}
+}
+
+func TestParseRunFlags_TmpfsSizeValidation(t *testing.T) {
+	for _, args := range [][]string{
+		{"--tmp-size", "4gb", "claude"},
+		{"--home-size=lots", "claude"},
+		{"--tmp-size"},
+	} {
+		if _, _, err := parseRunFlags(args); err == nil {
+			t.Errorf("parseRunFlags(%v) succeeded, want an error", args)
+		} else if !strings.Contains(err.Error(), "size") {
+			t.Errorf("parseRunFlags(%v) error = %v, want it to name the flag", args, err)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+package cmd
+
+// workdirTarget returns the container path from --mount-workdir-at, or ""
+// when the workdir stays at /workspace
+func (f *RunFlags) workdirTarget() string {
+	if f == nil {
+		return ""
+	}
+	return f.WorkdirTarget
+}
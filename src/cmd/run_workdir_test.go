@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jedi4ever/addt/core"
+	"github.com/jedi4ever/addt/provider"
+)
+
+func TestMountWorkdirAt_MovesVolumeAndWorkingDir(t *testing.T) {
+	flags, rest, err := parseRunFlags([]string{"--mount-workdir-at", "/src/app", "claude"})
+	if err != nil {
+		t.Fatalf("parseRunFlags() error = %v", err)
+	}
+	if !reflect.DeepEqual(rest, []string{"claude"}) {
+		t.Errorf("remaining args = %v, want [claude]", rest)
+	}
+
+	cfg := &provider.Config{WorkdirAutomount: true, WorkdirTarget: flags.workdirTarget()}
+	volumes := core.BuildVolumes(cfg, "/home/user/project")
+	if len(volumes) != 1 || volumes[0].Target != "/src/app" {
+		t.Errorf("workdir volume = %+v, want target /src/app", volumes)
+	}
+	if got := provider.WorkdirArgs(cfg); !reflect.DeepEqual(got, []string{"-w", "/src/app"}) {
+		t.Errorf("WorkdirArgs() = %v, want [-w /src/app]", got)
+	}
+}
+
+func TestMountWorkdirAt_DefaultKeepsWorkspace(t *testing.T) {
+	var flags *RunFlags
+	cfg := &provider.Config{WorkdirAutomount: true, WorkdirTarget: flags.workdirTarget()}
+	if volumes := core.BuildVolumes(cfg, "/home/user/project"); volumes[0].Target != "/workspace" {
+		t.Errorf("workdir volume target = %q, want /workspace", volumes[0].Target)
+	}
+	if got := provider.WorkdirArgs(cfg); got != nil {
+		t.Errorf("WorkdirArgs() = %v, want nil", got)
+	}
+}
+
+func TestMountWorkdirAt_RejectsRelativePath(t *testing.T) {
+	for _, target := range []string{"src", "/", "/src/../etc", "/src/"} {
+		if _, _, err := parseRunFlags([]string{"--mount-workdir-at=" + target, "claude"}); err == nil {
+			t.Errorf("parseRunFlags(--mount-workdir-at=%s) expected error", target)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jedi4ever/addt/config"
+	"github.com/jedi4ever/addt/core"
+	"github.com/jedi4ever/addt/provider"
+)
+
+// handleSecretsSubcommand handles "addt secrets check [<extension>]"
+func handleSecretsSubcommand(cfg *config.Config, args []string) {
+	if len(args) == 0 || args[0] != "check" {
+		printSecretsHelp()
+		if len(args) == 0 || (args[0] != "-h" && args[0] != "--help" && args[0] != "help") {
+			os.Exit(1)
+		}
+		return
+	}
+	args = args[1:]
+	if len(args) > 1 || (len(args) == 1 && strings.HasPrefix(args[0], "-")) {
+		printSecretsHelp()
+		os.Exit(1)
+	}
+	if len(args) == 1 {
+		cfg.Extensions = args[0]
+	}
+
+	if cfg.EnvFileLoad {
+		if err := config.LoadEnvFile(cfg.EnvFile); err != nil {
+			fmt.Printf("Error loading env file: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	providerCfg := envProviderConfig(cfg)
+	prov, err := NewProvider(cfg.Provider, providerCfg)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	providerCfg.ImageName = prov.DetermineImageName()
+	printSecretsCheck(os.Stdout, core.CheckSecrets(prov, providerCfg), providerCfg)
+}
+
+// printSecretsCheck writes one line per env var with its status, never the value
+func printSecretsCheck(w io.Writer, checks []core.SecretCheck, cfg *provider.Config) {
+	isolation := "off"
+	if cfg.Security.IsolateSecrets {
+		isolation = "on"
+	}
+	fmt.Fprintf(w, "Secrets check for %s (security.isolate_secrets: %s)\n\n", cfg.Extensions, isolation)
+	width := 0
+	for _, c := range checks {
+		width = max(width, len(c.Name))
+	}
+	for _, c := range checks {
+		fmt.Fprintf(w, "  %-*s  %s\n", width, c.Name, c.Status)
+	}
+	if !cfg.Security.IsolateSecrets {
+		fmt.Fprintln(w, "\nWith security.isolate_secrets off, classified vars are passed as plain env vars.")
+	}
+}
+
+func printSecretsHelp() {
+	fmt.Println(`Usage: addt secrets check [<extension>]
+
+Show how each env var of a run would be handled, without starting a
+container. Values are never printed.
+
+  classified  A secret: written to a tmpfs file instead of the container env
+              when security.isolate_secrets is on
+  forwarded   Passed to the container as a plain env var
+  denied      Set on the host and looks like a secret, but not forwarded
+
+addt's own ADDT_* settings are not listed.
+
+Examples:
+  addt secrets check
+  addt secrets check codex`)
+}
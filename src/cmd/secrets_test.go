@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/jedi4ever/addt/core"
+	"github.com/jedi4ever/addt/provider"
+)
+
+func TestPrintSecretsCheck(t *testing.T) {
+	checks := []core.SecretCheck{
+		{Name: "ANTHROPIC_API_KEY", Status: core.SecretClassified},
+		{Name: "TERM", Status: core.SecretForwarded},
+	}
+	cfg := &provider.Config{Extensions: "claude"}
+
+	var buf bytes.Buffer
+	printSecretsCheck(&buf, checks, cfg)
+	out := buf.String()
+	for _, want := range []string{"isolate_secrets: off", "ANTHROPIC_API_KEY  classified", "TERM               forwarded", "passed as plain env vars"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	cfg.Security.IsolateSecrets = true
+	printSecretsCheck(&buf, checks, cfg)
+	if strings.Contains(buf.String(), "plain env vars") {
+		t.Errorf("isolation on should not print the plain env note:\n%s", buf.String())
+	}
+}
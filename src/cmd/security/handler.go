@@ -0,0 +1,145 @@
+package security
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/jedi4ever/addt/config"
+	secconfig "github.com/jedi4ever/addt/config/security"
+)
+
+// HandleCommand handles the security subcommand
+func HandleCommand(args []string, cfg *config.Config) {
+	if len(args) == 0 {
+		printHelp()
+		return
+	}
+
+	switch args[0] {
+	case "explain":
+		asJSON := false
+		for _, arg := range args[1:] {
+			switch arg {
+			case "--json":
+				asJSON = true
+			default:
+				fmt.Printf("Unknown flag: %s\n", arg)
+				fmt.Println("Usage: addt security explain [--json]")
+				os.Exit(1)
+			}
+		}
+		posture := secconfig.Explain(cfg.Security, cfg.FirewallEnabled)
+		if asJSON {
+			if err := writeJSON(os.Stdout, posture); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+		writeText(os.Stdout, posture)
+	case "-h", "--help", "help":
+		printHelp()
+	default:
+		fmt.Printf("Unknown security command: %s\n", args[0])
+		printHelp()
+		os.Exit(1)
+	}
+}
+
+// writeJSON writes the posture as indented JSON
+func writeJSON(w io.Writer, p secconfig.Posture) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(p)
+}
+
+// writeText writes the posture as an aligned human-readable report
+func writeText(w io.Writer, p secconfig.Posture) {
+	row := func(name, value string) {
+		fmt.Fprintf(w, "  %-20s %s\n", name, value)
+	}
+
+	fmt.Fprintln(w, "Effective security posture")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Capabilities:")
+	row("cap_drop", list(p.CapDrop))
+	row("cap_add", list(p.CapAdd))
+	if len(p.FirewallCaps) > 0 {
+		row("firewall adds", list(p.FirewallCaps)+" (root phase, before gosu drops to addt)")
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Privileges:")
+	row("no_new_privileges", onOff(p.NoNewPrivileges))
+	row("starts as root", onOff(p.StartsAsRoot))
+	row("seccomp_profile", p.SeccompProfile)
+	row("user_namespace", orDefault(p.UserNamespace))
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Filesystem:")
+	row("read_only_rootfs", onOff(p.ReadOnlyRootfs))
+	row("mount_readonly", onOff(p.MountReadonly))
+	row("tmpfs", list(p.Tmpfs))
+	row("isolate_secrets", onOff(p.IsolateSecrets))
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Isolation & limits:")
+	row("network_mode", p.NetworkMode)
+	row("disable_ipc", onOff(p.DisableIPC))
+	row("pids_limit", fmt.Sprintf("%d", p.PidsLimit))
+	row("ulimit_nofile", orDefault(p.UlimitNofile))
+	row("ulimit_nproc", orDefault(p.UlimitNproc))
+	for _, name := range sortedNames(p.Ulimits) {
+		row("ulimit "+name, p.Ulimits[name])
+	}
+	row("memory_swap", orDefault(p.MemorySwap))
+	if p.TimeLimit > 0 {
+		row("time_limit", fmt.Sprintf("%dm", p.TimeLimit))
+	} else {
+		row("time_limit", "-")
+	}
+}
+
+func sortedNames(m map[string]string) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func list(items []string) string {
+	if len(items) == 0 {
+		return "-"
+	}
+	return strings.Join(items, ", ")
+}
+
+func onOff(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+func orDefault(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func printHelp() {
+	fmt.Println("Usage: addt security <command>")
+	fmt.Println()
+	fmt.Println("Inspect the container security settings.")
+	fmt.Println()
+	fmt.Println("Commands:")
+	fmt.Println("  explain [--json]      Show the effective security posture for the current config")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  addt security explain")
+	fmt.Println("  addt security explain --json")
+}
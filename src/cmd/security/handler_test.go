@@ -0,0 +1,38 @@
+package security
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	secconfig "github.com/jedi4ever/addt/config/security"
+)
+
+func TestWriteText_Sections(t *testing.T) {
+	var buf bytes.Buffer
+	writeText(&buf, secconfig.Explain(secconfig.DefaultConfig(), true))
+	out := buf.String()
+
+	for _, want := range []string{"Capabilities:", "firewall adds", "NET_ADMIN", "no_new_privileges    yes", "seccomp_profile      default", "pids_limit           200"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteJSON_RoundTrips(t *testing.T) {
+	posture := secconfig.Explain(secconfig.DefaultConfig(), false)
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, posture); err != nil {
+		t.Fatal(err)
+	}
+
+	var got secconfig.Posture
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if got.PidsLimit != 200 || !got.NoNewPrivileges {
+		t.Errorf("decoded posture = %+v", got)
+	}
+}
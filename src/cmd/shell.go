@@ -69,17 +69,34 @@ func HandleShellCommand(args []string, version, defaultNodeVersion, defaultGoVer
 		Ports:                     cfg.Ports,
 		PortRangeStart:            cfg.PortRangeStart,
 		PortsInjectSystemPrompt:   cfg.PortsInjectSystemPrompt,
+		PortsPromptTemplate:       cfg.PortsPromptTemplate,
 		SSHForwardKeys:            cfg.SSHForwardKeys,
 		SSHForwardMode:            cfg.SSHForwardMode,
 		SSHAllowedKeys:            cfg.SSHAllowedKeys,
 		SSHDir:                    cfg.SSHDir,
+		SSHDirs:                   cfg.SSHDirs,
+		ForwardFiles:              cfg.ForwardFiles,
+		Volumes:                   cfg.Volumes,
 		GPGForward:                cfg.GPGForward,
 		GPGAllowedKeyIDs:          cfg.GPGAllowedKeyIDs,
 		GPGDir:                    cfg.GPGDir,
+		GitDisableHooks:           cfg.GitDisableHooks,
+		GitForwardConfig:          cfg.GitForwardConfig,
+		GitConfigPath:             cfg.GitConfigPath,
+		GitConfigReadonly:         cfg.GitConfigReadonly,
+		GitConfigCopy:             cfg.GitConfigCopy,
 		TmuxForward:               cfg.TmuxForward,
 		HistoryPersist:            cfg.HistoryPersist,
+		HistoryDir:                cfg.HistoryDir,
+		HomePersistSubdirs:        cfg.HomePersistSubdirs,
 		TerminalOSC:               cfg.TerminalOSC,
 		DockerDindMode:            cfg.DockerDindMode,
+		DockerForwardConfig:       cfg.DockerForwardConfig,
+		DockerConfigPath:          cfg.DockerConfigPath,
+		DockerBuildTimeout:        cfg.DockerBuildTimeout,
+		DockerPullPolicy:          cfg.DockerPullPolicy,
+		DockerCpusetCPUs:          cfg.DockerCpusetCPUs,
+		DockerCpusetMems:          cfg.DockerCpusetMems,
 		EnvFileLoad:               cfg.EnvFileLoad,
 		EnvFile:                   cfg.EnvFile,
 		LogEnabled:                cfg.LogEnabled,
@@ -87,16 +104,28 @@ func HandleShellCommand(args []string, version, defaultNodeVersion, defaultGoVer
 		Persistent:                cfg.Persistent,
 		WorkdirAutomount:          cfg.WorkdirAutomount,
 		WorkdirReadonly:           cfg.WorkdirReadonly,
+		WorkdirOverlay:            cfg.WorkdirOverlay,
 		WorkdirAutotrust:          cfg.WorkdirAutotrust,
+		WorkdirTrusted:            resolveWorkdirTrust(os.Stderr, cfg),
 		Workdir:                   cfg.Workdir,
 		FirewallEnabled:           cfg.FirewallEnabled,
 		FirewallMode:              cfg.FirewallMode,
+		FirewallRequirePasta:      cfg.FirewallRequirePasta,
+		FirewallNetworkOverride:   cfg.FirewallNetworkOverride,
+		FirewallLogBlocked:        cfg.FirewallLogBlocked,
+		FirewallAsyncInit:         cfg.FirewallAsyncInit,
 		Mode:                      cfg.Mode,
 		Provider:                  cfg.Provider,
 		Extensions:                cfg.Extensions,
 		Command:                   cfg.Command,
 		ContainerCPUs:             cfg.ContainerCPUs,
 		ContainerMemory:           cfg.ContainerMemory,
+		ContainerMaxAge:           cfg.ContainerMaxAge,
+		ContainerDetachKeys:       cfg.ContainerDetachKeys,
+		ContainerPlatform:         cfg.ContainerPlatform,
+		ContainerInit:             cfg.ContainerInit,
+		ContainerEnvPrecedence:    cfg.ContainerEnvPrecedence,
+		ContainerName:             cfg.ContainerName,
 		Security:                  cfg.Security,
 		Otel:                      cfg.Otel,
 	}
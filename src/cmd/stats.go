@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jedi4ever/addt/config"
+	"github.com/jedi4ever/addt/provider"
+)
+
+// statsInterval is the sampling interval for "addt stats --watch"
+const statsInterval = 2 * time.Second
+
+// HandleStatsCommand handles "addt stats [name] [--json] [--watch]". Without
+// a name it samples the persistent container for the current directory.
+func HandleStatsCommand(prov provider.Provider, args []string) {
+	jsonOut, watch := false, false
+	name := ""
+	for _, arg := range args {
+		switch arg {
+		case "--json":
+			jsonOut = true
+		case "--watch", "-w":
+			watch = true
+		case "-h", "--help", "help":
+			printStatsHelp()
+			return
+		default:
+			if name != "" || strings.HasPrefix(arg, "-") {
+				printStatsHelp()
+				os.Exit(1)
+			}
+			name = arg
+		}
+	}
+
+	target, err := resolveStatsTarget(prov, name)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	for header := true; ; header = false {
+		stats, err := prov.Stats(target)
+		if err != nil {
+			if errors.Is(err, provider.ErrUnsupported) {
+				fmt.Printf("Error: addt stats is not supported by the %s provider\n", prov.GetName())
+			} else {
+				fmt.Printf("Error: %v\n", err)
+			}
+			os.Exit(1)
+		}
+		if err := writeStats(os.Stdout, stats, jsonOut, header); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		if !watch {
+			return
+		}
+		time.Sleep(statsInterval)
+	}
+}
+
+// handleStatsSubcommand creates the provider for "addt stats" and runs it.
+// A first argument that isn't a container selects the current directory's
+// container for that extension (see splitExtensionArg).
+func handleStatsSubcommand(cfg *config.Config, args []string) {
+	providerCfg := &provider.Config{
+		AddtVersion:       cfg.AddtVersion,
+		ExtensionVersions: cfg.ExtensionVersions,
+		NodeVersion:       cfg.NodeVersion,
+		GoVersion:         cfg.GoVersion,
+		UvVersion:         cfg.UvVersion,
+		Provider:          cfg.Provider,
+		Extensions:        cfg.Extensions,
+		Workdir:           cfg.Workdir,
+		ContainerName:     cfg.ContainerName,
+	}
+	prov, err := NewProvider(cfg.Provider, providerCfg)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	args = splitExtensionArg(prov, providerCfg, args)
+	HandleStatsCommand(prov, args)
+}
+
+// resolveStatsTarget returns the named container, or the current directory's
+// persistent container when no name is given. It must be running.
+func resolveStatsTarget(prov provider.Provider, name string) (string, error) {
+	name, err := resolveContainerName(prov, name)
+	if err != nil {
+		return "", err
+	}
+	if !prov.IsRunning(name) {
+		return "", fmt.Errorf("container %s is not running", name)
+	}
+	return name, nil
+}
+
+// statsRowFormat keeps --watch rows aligned with the header printed once
+const statsRowFormat = "%-36s  %7s  %-23s  %7s  %-21s  %s\n"
+
+// writeStats prints one sample as a table row (with a header on the first
+// sample) or as a single JSON line
+func writeStats(w io.Writer, stats provider.Stats, jsonOut, header bool) error {
+	if jsonOut {
+		return json.NewEncoder(w).Encode(stats)
+	}
+	if header {
+		fmt.Fprintf(w, statsRowFormat, "NAME", "CPU %", "MEM USAGE / LIMIT", "MEM %", "NET I/O", "PIDS")
+	}
+	_, err := fmt.Fprintf(w, statsRowFormat, stats.Name,
+		fmt.Sprintf("%.2f%%", stats.CPUPercent),
+		stats.MemoryUsage+" / "+stats.MemoryLimit,
+		fmt.Sprintf("%.2f%%", stats.MemoryPercent),
+		stats.NetInput+" / "+stats.NetOutput,
+		fmt.Sprint(stats.PIDs))
+	return err
+}
+
+func printStatsHelp() {
+	fmt.Println(`Usage: addt stats [<extension>|<container>] [--json] [--watch]
+
+Show CPU, memory and network usage of a running persistent container.
+Without arguments, uses the container for the current directory and
+configured extensions. Not supported by the daytona and container providers.
+
+Flags:
+  --json        Print each sample as a JSON object
+  -w, --watch   Keep sampling every 2s until interrupted
+
+Examples:
+  addt stats                        # Container for this directory
+  addt stats claude                 # Container for this directory running claude
+  addt stats addt-persistent-app-1a2b3c4d --json
+  addt stats --watch`)
+}
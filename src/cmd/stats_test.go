@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jedi4ever/addt/provider"
+)
+
+func TestResolveStatsTarget_CurrentDirectory(t *testing.T) {
+	prov := &stopMockProvider{containers: map[string]bool{"test-persistent": true}}
+
+	got, err := resolveStatsTarget(prov, "")
+	if err != nil {
+		t.Fatalf("resolveStatsTarget() error = %v", err)
+	}
+	if got != "test-persistent" {
+		t.Errorf("resolveStatsTarget() = %q, want test-persistent", got)
+	}
+}
+
+func TestResolveStatsTarget_Errors(t *testing.T) {
+	prov := &stopMockProvider{containers: map[string]bool{"addt-persistent-a": false}}
+
+	if _, err := resolveStatsTarget(prov, ""); err == nil {
+		t.Error("expected error when no container exists for this directory")
+	}
+	if _, err := resolveStatsTarget(prov, "addt-persistent-b"); err == nil {
+		t.Error("expected error for unknown container")
+	}
+	if _, err := resolveStatsTarget(prov, "addt-persistent-a"); err == nil {
+		t.Error("expected error for stopped container")
+	}
+}
+
+func TestWriteStats(t *testing.T) {
+	stats := provider.Stats{Name: "addt-persistent-a", CPUPercent: 1.5, MemoryUsage: "10MiB", MemoryLimit: "1GiB", PIDs: 3}
+
+	var buf bytes.Buffer
+	if err := writeStats(&buf, stats, false, true); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "NAME") || !strings.Contains(lines[1], "1.50%") || !strings.Contains(lines[1], "10MiB / 1GiB") {
+		t.Errorf("table output = %q", buf.String())
+	}
+
+	buf.Reset()
+	if err := writeStats(&buf, stats, true, true); err != nil {
+		t.Fatal(err)
+	}
+	var decoded provider.Stats
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil || decoded != stats {
+		t.Errorf("json output = %q (err %v)", buf.String(), err)
+	}
+}
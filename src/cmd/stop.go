@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jedi4ever/addt/config"
+	"github.com/jedi4ever/addt/provider"
+)
+
+// HandleStopCommand handles "addt stop [name] [--all]". Without a name it
+// stops the persistent container for the current directory and extensions.
+func HandleStopCommand(prov provider.Provider, args []string) {
+	all := false
+	name := ""
+	for _, arg := range args {
+		switch arg {
+		case "--all", "-a":
+			all = true
+		case "-h", "--help", "help":
+			printStopHelp()
+			return
+		default:
+			if name != "" {
+				printStopHelp()
+				os.Exit(1)
+			}
+			name = arg
+		}
+	}
+	if all && name != "" {
+		fmt.Println("Error: --all cannot be combined with a container name")
+		os.Exit(1)
+	}
+
+	targets, err := resolveStopTargets(prov, name, all)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if len(targets) == 0 {
+		fmt.Println("No running persistent containers found")
+		return
+	}
+
+	var failed []string
+	for _, target := range targets {
+		if err := prov.Stop(target); err != nil {
+			failed = append(failed, target)
+			fmt.Printf("Failed to stop: %s (%v)\n", target, err)
+		} else {
+			fmt.Printf("Stopped: %s\n", target)
+		}
+	}
+	if len(failed) > 0 {
+		fmt.Printf("Failed to stop %d container(s)\n", len(failed))
+		os.Exit(1)
+	}
+}
+
+// handleStopSubcommand creates the provider for "addt stop" and runs it.
+// A first argument that isn't a container selects the current directory's
+// container for that extension (see splitExtensionArg).
+func handleStopSubcommand(cfg *config.Config, args []string) {
+	providerCfg := &provider.Config{
+		AddtVersion:       cfg.AddtVersion,
+		ExtensionVersions: cfg.ExtensionVersions,
+		NodeVersion:       cfg.NodeVersion,
+		GoVersion:         cfg.GoVersion,
+		UvVersion:         cfg.UvVersion,
+		Provider:          cfg.Provider,
+		Extensions:        cfg.Extensions,
+		Workdir:           cfg.Workdir,
+		ContainerName:     cfg.ContainerName,
+	}
+	prov, err := NewProvider(cfg.Provider, providerCfg)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	args = splitExtensionArg(prov, providerCfg, args)
+	HandleStopCommand(prov, args)
+}
+
+// resolveStopTargets returns the containers to stop: all running persistent
+// containers with all, the named container, or the current directory's
+// persistent container when no name is given
+func resolveStopTargets(prov provider.Provider, name string, all bool) ([]string, error) {
+	if all {
+		envs, err := prov.List()
+		if err != nil {
+			return nil, fmt.Errorf("listing containers: %w", err)
+		}
+		var running []string
+		for _, env := range envs {
+			if prov.IsRunning(env.Name) {
+				running = append(running, env.Name)
+			}
+		}
+		return running, nil
+	}
+
+	name, err := resolveContainerName(prov, name)
+	if err != nil {
+		return nil, err
+	}
+	if !prov.IsRunning(name) {
+		fmt.Printf("Container %s is not running\n", name)
+		return nil, nil
+	}
+	return []string{name}, nil
+}
+
+func printStopHelp() {
+	fmt.Println(`Usage: addt stop [<extension>|<container>] [--all]
+
+Stop persistent containers. Without arguments, stops the persistent
+container for the current directory and configured extensions.
+
+Flags:
+  -a, --all     Stop all running addt persistent containers
+
+Examples:
+  addt stop                         # Container for this directory
+  addt stop claude                  # Container for this directory running claude
+  addt stop addt-persistent-app-1a2b3c4d
+  addt stop --all`)
+}
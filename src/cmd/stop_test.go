@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jedi4ever/addt/provider"
+)
+
+// stopMockProvider records Stop calls and reports a fixed set of containers
+type stopMockProvider struct {
+	mockProvider
+	containers map[string]bool // name -> running
+	stopped    []string
+}
+
+func (m *stopMockProvider) Exists(name string) bool {
+	_, ok := m.containers[name]
+	return ok
+}
+
+func (m *stopMockProvider) IsRunning(name string) bool { return m.containers[name] }
+
+func (m *stopMockProvider) Stop(name string) error {
+	m.stopped = append(m.stopped, name)
+	return nil
+}
+
+func (m *stopMockProvider) List() ([]provider.Environment, error) {
+	var envs []provider.Environment
+	for _, name := range []string{"addt-persistent-a", "addt-persistent-b", "test-persistent"} {
+		if _, ok := m.containers[name]; ok {
+			envs = append(envs, provider.Environment{Name: name})
+		}
+	}
+	return envs, nil
+}
+
+func TestResolveStopTargets_CurrentDirectory(t *testing.T) {
+	prov := &stopMockProvider{containers: map[string]bool{"test-persistent": true, "addt-persistent-a": true}}
+
+	got, err := resolveStopTargets(prov, "", false)
+	if err != nil {
+		t.Fatalf("resolveStopTargets() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, []string{"test-persistent"}) {
+		t.Errorf("resolveStopTargets() = %v, want [test-persistent]", got)
+	}
+}
+
+func TestResolveStopTargets_CurrentDirectoryMissing(t *testing.T) {
+	prov := &stopMockProvider{containers: map[string]bool{"addt-persistent-a": true}}
+
+	if _, err := resolveStopTargets(prov, "", false); err == nil {
+		t.Error("resolveStopTargets() expected error when no container exists for this directory")
+	}
+}
+
+func TestResolveStopTargets_Named(t *testing.T) {
+	prov := &stopMockProvider{containers: map[string]bool{"addt-persistent-a": true, "addt-persistent-b": false}}
+
+	got, err := resolveStopTargets(prov, "addt-persistent-a", false)
+	if err != nil || !reflect.DeepEqual(got, []string{"addt-persistent-a"}) {
+		t.Errorf("resolveStopTargets(running) = %v, %v", got, err)
+	}
+
+	got, err = resolveStopTargets(prov, "addt-persistent-b", false)
+	if err != nil || len(got) != 0 {
+		t.Errorf("resolveStopTargets(stopped) = %v, %v, want nothing to stop", got, err)
+	}
+
+	if _, err := resolveStopTargets(prov, "addt-persistent-missing", false); err == nil {
+		t.Error("resolveStopTargets(missing) expected error")
+	}
+}
+
+func TestHandleStopCommand_All(t *testing.T) {
+	prov := &stopMockProvider{containers: map[string]bool{
+		"addt-persistent-a": true,
+		"addt-persistent-b": false,
+		"test-persistent":   true,
+	}}
+
+	HandleStopCommand(prov, []string{"--all"})
+
+	want := []string{"addt-persistent-a", "test-persistent"}
+	if !reflect.DeepEqual(prov.stopped, want) {
+		t.Errorf("stopped = %v, want %v", prov.stopped, want)
+	}
+}
+
+func TestHandleStopCommand_AllNothingRunning(t *testing.T) {
+	prov := &stopMockProvider{containers: map[string]bool{"addt-persistent-a": false}}
+
+	HandleStopCommand(prov, []string{"-a"})
+
+	if len(prov.stopped) != 0 {
+		t.Errorf("stopped = %v, want none", prov.stopped)
+	}
+}
@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+)
+
+func TestParseRunFlags_StrictConfig(t *testing.T) {
+	t.Setenv("ADDT_STRICT_CONFIG", "")
+
+	flags, rest, err := parseRunFlags([]string{"--strict-config", "claude"})
+	if err != nil {
+		t.Fatalf("parseRunFlags() error = %v", err)
+	}
+	if !flags.StrictConfig || len(rest) != 1 {
+		t.Errorf("StrictConfig=%v rest=%v", flags.StrictConfig, rest)
+	}
+
+	flags.apply()
+	if got := os.Getenv("ADDT_STRICT_CONFIG"); got != "true" {
+		t.Errorf("ADDT_STRICT_CONFIG = %q, want true", got)
+	}
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jedi4ever/addt/config"
+)
+
+// HandleTrustCommand handles "addt trust [dir]" and "addt untrust [dir]",
+// recording whether the agent may trust the workdir regardless of
+// workdir.autotrust.
+func HandleTrustCommand(args []string, trusted bool) {
+	dir := ""
+	for _, arg := range args {
+		switch arg {
+		case "-h", "--help", "help":
+			printTrustHelp()
+			return
+		default:
+			if dir != "" || strings.HasPrefix(arg, "-") {
+				printTrustHelp()
+				os.Exit(1)
+			}
+			dir = arg
+		}
+	}
+
+	dir, err := trustWorkdir(dir)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if err := config.SetWorkdirTrust(dir, trusted); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if trusted {
+		fmt.Printf("Trusted: %s\n", dir)
+	} else {
+		fmt.Printf("Untrusted: %s (agents will not auto-trust it, even with workdir.autotrust)\n", dir)
+	}
+}
+
+// trustWorkdir resolves the directory a trust decision applies to,
+// defaulting to the current directory
+func trustWorkdir(dir string) (string, error) {
+	if dir == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return "", err
+		}
+		dir = cwd
+	}
+	return filepath.Abs(dir)
+}
+
+// resolveWorkdirTrust returns the explicit trust decision for the run's
+// workdir and writes a one-line notice to w the first time autotrust applies
+func resolveWorkdirTrust(w io.Writer, cfg *config.Config) *bool {
+	dir, err := trustWorkdir(cfg.Workdir)
+	if err != nil {
+		return nil
+	}
+	decision, newlyAutotrusted := config.ResolveWorkdirTrust(dir, effectiveAutotrust(cfg))
+	if newlyAutotrusted {
+		fmt.Fprintf(w, "Notice: %s is auto-trusted by the agent (workdir.autotrust); run 'addt untrust' to revoke\n", dir)
+	}
+	return decision
+}
+
+// effectiveAutotrust reports whether any active extension autotrusts the
+// workdir, honouring per-extension workdir.autotrust overrides
+func effectiveAutotrust(cfg *config.Config) bool {
+	if cfg.Extensions == "" {
+		return cfg.WorkdirAutotrust
+	}
+	for _, ext := range strings.Split(cfg.Extensions, ",") {
+		trust := cfg.WorkdirAutotrust
+		if v, ok := cfg.ExtensionWorkdirAutotrust[strings.TrimSpace(ext)]; ok {
+			trust = v
+		}
+		if trust {
+			return true
+		}
+	}
+	return false
+}
+
+func printTrustHelp() {
+	fmt.Println(`Usage: addt trust [dir]
+       addt untrust [dir]
+
+Record whether agents may trust a workdir (default: current directory).
+An explicit decision overrides workdir.autotrust and its per-extension
+overrides. Decisions are stored per directory in ~/.addt/trust.yaml.
+
+Examples:
+  addt trust                  # Trust the current directory
+  addt untrust ~/src/vendor   # Never auto-trust this directory`)
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/jedi4ever/addt/config"
+)
+
+func TestResolveWorkdirTrust_NoticeOnce(t *testing.T) {
+	t.Setenv("ADDT_HOME", t.TempDir())
+	cfg := &config.Config{Workdir: t.TempDir(), WorkdirAutotrust: true}
+
+	var out bytes.Buffer
+	if decision := resolveWorkdirTrust(&out, cfg); decision != nil {
+		t.Errorf("decision = %v, want nil without an explicit trust decision", *decision)
+	}
+	if !strings.Contains(out.String(), "auto-trusted") || !strings.Contains(out.String(), cfg.Workdir) {
+		t.Errorf("notice = %q, want auto-trust notice naming the workdir", out.String())
+	}
+
+	out.Reset()
+	resolveWorkdirTrust(&out, cfg)
+	if out.Len() != 0 {
+		t.Errorf("second run printed %q, want no notice", out.String())
+	}
+}
+
+func TestResolveWorkdirTrust_ExtensionOverrideDisablesNotice(t *testing.T) {
+	t.Setenv("ADDT_HOME", t.TempDir())
+	cfg := &config.Config{
+		Workdir:                   t.TempDir(),
+		WorkdirAutotrust:          true,
+		Extensions:                "claude",
+		ExtensionWorkdirAutotrust: map[string]bool{"claude": false},
+	}
+
+	var out bytes.Buffer
+	resolveWorkdirTrust(&out, cfg)
+	if out.Len() != 0 {
+		t.Errorf("printed %q, want no notice when the extension disables autotrust", out.String())
+	}
+}
+
+func TestResolveWorkdirTrust_Untrusted(t *testing.T) {
+	t.Setenv("ADDT_HOME", t.TempDir())
+	cfg := &config.Config{Workdir: t.TempDir(), WorkdirAutotrust: true}
+	if err := config.SetWorkdirTrust(cfg.Workdir, false); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	decision := resolveWorkdirTrust(&out, cfg)
+	if decision == nil || *decision {
+		t.Errorf("decision = %v, want explicit false", decision)
+	}
+	if out.Len() != 0 {
+		t.Errorf("printed %q, want no notice for an explicit decision", out.String())
+	}
+}
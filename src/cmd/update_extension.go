@@ -47,14 +47,16 @@ func HandleUpdateCommand(args []string, version, defaultNodeVersion, defaultGoVe
 
 	// Create provider config (same minimal set as build command)
 	providerCfg := &provider.Config{
-		AddtVersion:       cfg.AddtVersion,
-		ExtensionVersions: cfg.ExtensionVersions,
-		NodeVersion:       cfg.NodeVersion,
-		GoVersion:         cfg.GoVersion,
-		UvVersion:         cfg.UvVersion,
-		Provider:          cfg.Provider,
-		Extensions:        cfg.Extensions,
-		NoCache:           true,
+		AddtVersion:        cfg.AddtVersion,
+		ExtensionVersions:  cfg.ExtensionVersions,
+		NodeVersion:        cfg.NodeVersion,
+		GoVersion:          cfg.GoVersion,
+		UvVersion:          cfg.UvVersion,
+		Provider:           cfg.Provider,
+		Extensions:         cfg.Extensions,
+		NoCache:            true,
+		DockerBuildTimeout: cfg.DockerBuildTimeout,
+		DockerPullPolicy:   cfg.DockerPullPolicy,
 	}
 
 	prov, err := NewProvider(cfg.Provider, providerCfg)
@@ -0,0 +1,47 @@
+package config
+
+import "os"
+
+// Modes are the accepted values of the top-level mode key
+var Modes = []string{"container", "shell"}
+
+// loadMode resolves mode: default (container) -> global -> project -> env
+func loadMode(globalCfg, projectCfg *GlobalConfig) string {
+	mode := "container"
+	if globalCfg.Mode != "" {
+		mode = globalCfg.Mode
+	}
+	if projectCfg.Mode != "" {
+		mode = projectCfg.Mode
+	}
+	return getEnvOrDefault("ADDT_MODE", mode)
+}
+
+// loadCommand resolves command, the agent command run in the container:
+// global -> project -> env. ADDT_COMMAND also carries the extension
+// entrypoint addt derives, which is only exported when no command is
+// configured (see ConfiguredCommand).
+func loadCommand(globalCfg, projectCfg *GlobalConfig) string {
+	command := globalCfg.Command
+	if projectCfg.Command != "" {
+		command = projectCfg.Command
+	}
+	return getEnvOrDefault("ADDT_COMMAND", command)
+}
+
+// ConfiguredCommand returns the command set in the project or global config,
+// or "" when neither sets it and the extension entrypoint applies
+func ConfiguredCommand() string {
+	if cmd := loadProjectConfig().Command; cmd != "" {
+		return cmd
+	}
+	return loadGlobalConfig().Command
+}
+
+// SetDefaultCommand exports entrypoint as ADDT_COMMAND unless a command is
+// configured, so the extension entrypoint stays the lowest layer
+func SetDefaultCommand(entrypoint string) {
+	if ConfiguredCommand() == "" {
+		os.Setenv("ADDT_COMMAND", entrypoint)
+	}
+}
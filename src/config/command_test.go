@@ -0,0 +1,60 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadModeAndCommand_Precedence(t *testing.T) {
+	t.Setenv("ADDT_MODE", "")
+	t.Setenv("ADDT_COMMAND", "")
+	if got := loadMode(&GlobalConfig{}, &GlobalConfig{}); got != "container" {
+		t.Errorf("unset mode = %q, want container", got)
+	}
+	if got := loadCommand(&GlobalConfig{}, &GlobalConfig{}); got != "" {
+		t.Errorf("unset command = %q, want empty", got)
+	}
+
+	global := &GlobalConfig{Mode: "shell", Command: "claude"}
+	project := &GlobalConfig{Command: "codex"}
+	if got := loadMode(global, project); got != "shell" {
+		t.Errorf("global mode = %q, want shell", got)
+	}
+	if got := loadCommand(global, project); got != "codex" {
+		t.Errorf("project command = %q, want codex", got)
+	}
+
+	t.Setenv("ADDT_MODE", "container")
+	t.Setenv("ADDT_COMMAND", "gemini")
+	if got := loadMode(global, project); got != "container" {
+		t.Errorf("ADDT_MODE should override config, got %q", got)
+	}
+	if got := loadCommand(global, project); got != "gemini" {
+		t.Errorf("ADDT_COMMAND should override config, got %q", got)
+	}
+}
+
+func TestSetDefaultCommand_ConfiguredCommandWins(t *testing.T) {
+	t.Setenv("ADDT_CONFIG_DIR", t.TempDir())
+	projectDir := t.TempDir()
+	t.Chdir(projectDir)
+	t.Setenv("ADDT_COMMAND", "")
+
+	SetDefaultCommand("claude")
+	if got := os.Getenv("ADDT_COMMAND"); got != "claude" {
+		t.Errorf("without a configured command ADDT_COMMAND = %q, want the entrypoint", got)
+	}
+
+	if err := os.WriteFile(filepath.Join(projectDir, ".addt.yaml"), []byte("command: codex\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("ADDT_COMMAND", "")
+	SetDefaultCommand("claude")
+	if got := os.Getenv("ADDT_COMMAND"); got != "" {
+		t.Errorf("with a configured command ADDT_COMMAND = %q, want it left unset", got)
+	}
+	if cfg := LoadConfig("test", "22", "latest", "latest", 30000); cfg.Command != "codex" {
+		t.Errorf("LoadConfig().Command = %q, want codex", cfg.Command)
+	}
+}
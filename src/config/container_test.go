@@ -0,0 +1,84 @@
+package config
+
+import (
+	"os"
+	"testing"
+)
+
+func TestLoadConfig_ContainerInit(t *testing.T) {
+	globalDir, projectDir, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	cfg := LoadConfig("0.0.0-test", "20", "1.21", "0.1.0", 30000)
+	if !cfg.ContainerInit {
+		t.Error("ContainerInit = false by default, want true")
+	}
+
+	disabled := false
+	writeGlobalConfig(t, globalDir, &GlobalConfig{Container: &ContainerSettings{Init: &disabled}})
+	cfg = LoadConfig("0.0.0-test", "20", "1.21", "0.1.0", 30000)
+	if cfg.ContainerInit {
+		t.Error("ContainerInit = true with container.init: false in global config")
+	}
+
+	enabled := true
+	writeProjectConfig(t, projectDir, &GlobalConfig{Container: &ContainerSettings{Init: &enabled}})
+	cfg = LoadConfig("0.0.0-test", "20", "1.21", "0.1.0", 30000)
+	if !cfg.ContainerInit {
+		t.Error("project container.init: true should override global false")
+	}
+
+	os.Setenv("ADDT_CONTAINER_INIT", "false")
+	defer os.Unsetenv("ADDT_CONTAINER_INIT")
+	cfg = LoadConfig("0.0.0-test", "20", "1.21", "0.1.0", 30000)
+	if cfg.ContainerInit {
+		t.Error("ADDT_CONTAINER_INIT=false should override project config")
+	}
+}
+
+func TestLoadConfig_ContainerName(t *testing.T) {
+	globalDir, projectDir, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	cfg := LoadConfig("0.0.0-test", "20", "1.21", "0.1.0", 30000)
+	if cfg.ContainerName != "" {
+		t.Errorf("ContainerName = %q by default, want empty", cfg.ContainerName)
+	}
+
+	writeGlobalConfig(t, globalDir, &GlobalConfig{Container: &ContainerSettings{Name: "global-name"}})
+	writeProjectConfig(t, projectDir, &GlobalConfig{Container: &ContainerSettings{Name: "myproject"}})
+	cfg = LoadConfig("0.0.0-test", "20", "1.21", "0.1.0", 30000)
+	if cfg.ContainerName != "myproject" {
+		t.Errorf("ContainerName = %q, want project override myproject", cfg.ContainerName)
+	}
+
+	os.Setenv("ADDT_CONTAINER_NAME", "bad name!")
+	defer os.Unsetenv("ADDT_CONTAINER_NAME")
+	cfg = LoadConfig("0.0.0-test", "20", "1.21", "0.1.0", 30000)
+	if cfg.ContainerName != "" {
+		t.Errorf("invalid ADDT_CONTAINER_NAME should fall back to the generated name, got %q", cfg.ContainerName)
+	}
+}
+
+func TestLoadConfig_ContainerEnvPrecedence(t *testing.T) {
+	globalDir, projectDir, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	cfg := LoadConfig("0.0.0-test", "20", "1.21", "0.1.0", 30000)
+	if cfg.ContainerEnvPrecedence != "env-wins" {
+		t.Errorf("ContainerEnvPrecedence = %q by default, want env-wins", cfg.ContainerEnvPrecedence)
+	}
+
+	writeGlobalConfig(t, globalDir, &GlobalConfig{Container: &ContainerSettings{EnvPrecedence: "env-wins"}})
+	writeProjectConfig(t, projectDir, &GlobalConfig{Container: &ContainerSettings{EnvPrecedence: "config-wins"}})
+	cfg = LoadConfig("0.0.0-test", "20", "1.21", "0.1.0", 30000)
+	if cfg.ContainerEnvPrecedence != "config-wins" {
+		t.Errorf("ContainerEnvPrecedence = %q, want project override config-wins", cfg.ContainerEnvPrecedence)
+	}
+
+	t.Setenv("ADDT_CONTAINER_ENV_PRECEDENCE", "host-wins")
+	cfg = LoadConfig("0.0.0-test", "20", "1.21", "0.1.0", 30000)
+	if cfg.ContainerEnvPrecedence != "env-wins" {
+		t.Errorf("unknown ADDT_CONTAINER_ENV_PRECEDENCE should fall back to env-wins, got %q", cfg.ContainerEnvPrecedence)
+	}
+}
@@ -0,0 +1,33 @@
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+// DefaultEnvVars are the host env vars forwarded when env_vars is unset
+var DefaultEnvVars = []string{"ANTHROPIC_API_KEY", "GH_TOKEN"}
+
+// loadEnvVars resolves env_vars, the allowlist of host env vars forwarded
+// into the container: project replaces global, then ADDT_ENV_VARS. The
+// default applies when none of them set it.
+func loadEnvVars(globalCfg, projectCfg *GlobalConfig) []string {
+	vars := DefaultEnvVars
+	if len(globalCfg.EnvVars) > 0 {
+		vars = globalCfg.EnvVars
+	}
+	if len(projectCfg.EnvVars) > 0 {
+		vars = projectCfg.EnvVars
+	}
+	if v := os.Getenv("ADDT_ENV_VARS"); v != "" {
+		vars = strings.Split(v, ",")
+	}
+
+	var out []string
+	for _, name := range vars {
+		if name = strings.TrimSpace(name); name != "" {
+			out = append(out, name)
+		}
+	}
+	return out
+}
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLoadEnvVars_Precedence(t *testing.T) {
+	t.Setenv("ADDT_ENV_VARS", "")
+	if got := loadEnvVars(&GlobalConfig{}, &GlobalConfig{}); !slices.Equal(got, DefaultEnvVars) {
+		t.Errorf("unset env_vars should use the default, got %v", got)
+	}
+
+	global := &GlobalConfig{EnvVars: []string{"OPENAI_API_KEY"}}
+	project := &GlobalConfig{EnvVars: []string{"NPM_TOKEN", " AWS_PROFILE "}}
+	if got := loadEnvVars(global, &GlobalConfig{}); !slices.Equal(got, []string{"OPENAI_API_KEY"}) {
+		t.Errorf("global env_vars should replace the default, got %v", got)
+	}
+	if got := loadEnvVars(global, project); !slices.Equal(got, []string{"NPM_TOKEN", "AWS_PROFILE"}) {
+		t.Errorf("project env_vars should replace global, got %v", got)
+	}
+
+	t.Setenv("ADDT_ENV_VARS", "FOO,,BAR")
+	if got := loadEnvVars(global, project); !slices.Equal(got, []string{"FOO", "BAR"}) {
+		t.Errorf("ADDT_ENV_VARS should override config, got %v", got)
+	}
+}
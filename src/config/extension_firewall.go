@@ -0,0 +1,24 @@
+package config
+
+import "github.com/jedi4ever/addt/extensions"
+
+// resolveExtensionFirewall returns the extension firewall layer for extName:
+// the rules shipped in the extension's config.yaml followed by the
+// extensions.<name>.firewall_allowed/denied entries of the global config.
+// Within the layer a deny wins over an allow.
+func resolveExtensionFirewall(extName string, globalCfg *GlobalConfig) (allowed, denied []string) {
+	if exts, err := extensions.GetExtensions(); err == nil {
+		for _, ext := range exts {
+			if ext.Name == extName {
+				allowed = append(allowed, ext.Firewall.Allowed...)
+				denied = append(denied, ext.Firewall.Denied...)
+				break
+			}
+		}
+	}
+	if extCfg := globalCfg.Extensions[extName]; extCfg != nil {
+		allowed = mergeDomains(allowed, extCfg.FirewallAllowed)
+		denied = mergeDomains(denied, extCfg.FirewallDenied)
+	}
+	return allowed, denied
+}
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestResolveExtensionFirewall(t *testing.T) {
+	extDir := filepath.Join(t.TempDir(), "fwext")
+	if err := os.MkdirAll(extDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	yaml := "name: fwext\nentrypoint: fwext\ndefault_version: latest\nfirewall:\n  allowed: [api.fwext.dev, cdn.fwext.dev]\n  denied: [tracking.fwext.dev]\n"
+	if err := os.WriteFile(filepath.Join(extDir, "config.yaml"), []byte(yaml), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("ADDT_EXTENSIONS_DIR", filepath.Dir(extDir))
+
+	globalCfg := &GlobalConfig{Extensions: map[string]*ExtensionSettings{
+		"fwext": {FirewallAllowed: []string{"cdn.fwext.dev", "extra.example.com"}, FirewallDenied: []string{"api.fwext.dev"}},
+	}}
+	allowed, denied := resolveExtensionFirewall("fwext", globalCfg)
+	if want := []string{"api.fwext.dev", "cdn.fwext.dev", "extra.example.com"}; !reflect.DeepEqual(allowed, want) {
+		t.Errorf("allowed = %v, want %v", allowed, want)
+	}
+	if want := []string{"tracking.fwext.dev", "api.fwext.dev"}; !reflect.DeepEqual(denied, want) {
+		t.Errorf("denied = %v, want %v", denied, want)
+	}
+
+	if allowed, denied := resolveExtensionFirewall("missing", &GlobalConfig{}); allowed != nil || denied != nil {
+		t.Errorf("unknown extension = %v, %v; want no rules", allowed, denied)
+	}
+}
@@ -43,6 +43,7 @@ func loadGlobalConfig() *GlobalConfig {
 	if err != nil {
 		return &GlobalConfig{}
 	}
+	warnUnknownKeys(configPath, data)
 
 	var cfg GlobalConfig
 	if err := yaml.Unmarshal(data, &cfg); err != nil {
@@ -66,6 +67,7 @@ func LoadGlobalConfigFile() (*GlobalConfig, error) {
 		}
 		return nil, err
 	}
+	warnUnknownKeys(configPath, data)
 
 	var cfg GlobalConfig
 	if err := yaml.Unmarshal(data, &cfg); err != nil {
@@ -75,7 +77,8 @@ func LoadGlobalConfigFile() (*GlobalConfig, error) {
 	return &cfg, nil
 }
 
-// SaveGlobalConfigFile saves the global config to ~/.addt/config.yaml
+// SaveGlobalConfigFile saves the global config to ~/.addt/config.yaml,
+// keeping the comments and key order of the existing file
 func SaveGlobalConfigFile(cfg *GlobalConfig) error {
 	configPath := GetGlobalConfigPath()
 	if configPath == "" {
@@ -88,12 +91,12 @@ func SaveGlobalConfigFile(cfg *GlobalConfig) error {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	data, err := yaml.Marshal(cfg)
+	data, err := marshalConfigFile(configPath, cfg)
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
 
-	if err := os.WriteFile(configPath, data, 0644); err != nil {
+	if err := writeConfigFile(configPath, data); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
 
@@ -111,6 +114,7 @@ func loadProjectConfig() *GlobalConfig {
 	if err != nil {
 		return &GlobalConfig{}
 	}
+	warnUnknownKeys(configPath, data)
 
 	var cfg GlobalConfig
 	if err := yaml.Unmarshal(data, &cfg); err != nil {
@@ -134,6 +138,7 @@ func LoadProjectConfigFile() (*GlobalConfig, error) {
 		}
 		return nil, err
 	}
+	warnUnknownKeys(configPath, data)
 
 	var cfg GlobalConfig
 	if err := yaml.Unmarshal(data, &cfg); err != nil {
@@ -143,19 +148,20 @@ func LoadProjectConfigFile() (*GlobalConfig, error) {
 	return &cfg, nil
 }
 
-// SaveProjectConfigFile saves the project config to .addt.yaml in current directory
+// SaveProjectConfigFile saves the project config to .addt.yaml in current
+// directory, keeping the comments and key order of the existing file
 func SaveProjectConfigFile(cfg *GlobalConfig) error {
 	configPath := GetProjectConfigPath()
 	if configPath == "" {
 		return fmt.Errorf("could not determine project config file path")
 	}
 
-	data, err := yaml.Marshal(cfg)
+	data, err := marshalConfigFile(configPath, cfg)
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
 
-	if err := os.WriteFile(configPath, data, 0644); err != nil {
+	if err := writeConfigFile(configPath, data); err != nil {
 		return fmt.Errorf("failed to write project config file: %w", err)
 	}
 
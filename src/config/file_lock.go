@@ -0,0 +1,106 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// UpdateGlobalConfigFile loads ~/.addt/config.yaml, applies fn and saves it
+// while holding an advisory lock on the file, so concurrent updates (two
+// "addt config set" calls) don't drop each other's changes.
+func UpdateGlobalConfigFile(fn func(cfg *GlobalConfig) error) error {
+	return updateConfigFile(GetGlobalConfigPath(), LoadGlobalConfigFile, SaveGlobalConfigFile, fn)
+}
+
+// UpdateProjectConfigFile is UpdateGlobalConfigFile for .addt.yaml
+func UpdateProjectConfigFile(fn func(cfg *GlobalConfig) error) error {
+	return updateConfigFile(GetProjectConfigPath(), LoadProjectConfigFile, SaveProjectConfigFile, fn)
+}
+
+// updateConfigFile runs the load/modify/save sequence under the lock.
+// The lock is released as soon as the file is written.
+func updateConfigFile(path string, load func() (*GlobalConfig, error), save func(*GlobalConfig) error, fn func(*GlobalConfig) error) error {
+	if path == "" {
+		return fmt.Errorf("could not determine config file path")
+	}
+	unlock, err := lockConfigFile(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	cfg, err := load()
+	if err != nil {
+		return err
+	}
+	if err := fn(cfg); err != nil {
+		return err
+	}
+	return save(cfg)
+}
+
+// lockConfigFile takes an exclusive advisory lock for the config file at
+// path. The lock file lives in the locks directory next to the global config
+// (~/.addt/locks), named after a hash of the config's absolute path, so no
+// lock file is left in the project. Saves replace the config by rename, so
+// the config file itself can't carry the lock.
+func lockConfigFile(path string) (unlock func(), err error) {
+	lockPath, err := configLockPath(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(lockPath), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create config lock directory: %w", err)
+	}
+	f, err := os.OpenFile(lockPath, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open config lock file: %w", err)
+	}
+	if err := lockFile(f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to lock config file: %w", err)
+	}
+	return func() {
+		unlockFile(f)
+		f.Close()
+	}, nil
+}
+
+// configLockPath returns the lock file for the config file at path
+func configLockPath(path string) (string, error) {
+	globalPath := GetGlobalConfigPath()
+	if globalPath == "" {
+		return "", fmt.Errorf("could not determine config lock directory")
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(abs))
+	return filepath.Join(filepath.Dir(globalPath), "locks", hex.EncodeToString(sum[:8])+".lock"), nil
+}
+
+// writeConfigFile writes data to a temp file next to path and renames it
+// into place, so a reader never sees a partially written config
+func writeConfigFile(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
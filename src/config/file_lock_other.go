@@ -0,0 +1,14 @@
+//go:build !unix
+
+package config
+
+import "os"
+
+// lockFile is a no-op without flock (Windows and other non-Unix systems);
+// concurrent config updates are not guarded
+func lockFile(f *os.File) error {
+	return nil
+}
+
+// unlockFile is a no-op without flock
+func unlockFile(f *os.File) {}
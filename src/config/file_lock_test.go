@@ -0,0 +1,111 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUpdateGlobalConfigFile(t *testing.T) {
+	globalDir, _, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	configPath := filepath.Join(globalDir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("node_version: \"20\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := UpdateGlobalConfigFile(func(cfg *GlobalConfig) error {
+		if cfg.NodeVersion != "20" {
+			t.Errorf("loaded NodeVersion = %q, want 20", cfg.NodeVersion)
+		}
+		cfg.GoVersion = "1.24"
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("UpdateGlobalConfigFile() error = %v", err)
+	}
+
+	cfg, _ := LoadGlobalConfigFile()
+	if cfg.NodeVersion != "20" || cfg.GoVersion != "1.24" {
+		t.Errorf("saved config = node %q go %q, want 20 and 1.24", cfg.NodeVersion, cfg.GoVersion)
+	}
+}
+
+func TestUpdateProjectConfigFile_ErrorSkipsSave(t *testing.T) {
+	_, projectDir, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	configPath := filepath.Join(projectDir, ".addt.yaml")
+	original := "node_version: \"20\"\n"
+	if err := os.WriteFile(configPath, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	errAbort := errors.New("abort")
+	err := UpdateProjectConfigFile(func(cfg *GlobalConfig) error {
+		cfg.NodeVersion = "22"
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("UpdateProjectConfigFile() error = %v, want %v", err, errAbort)
+	}
+
+	data, _ := os.ReadFile(configPath)
+	if string(data) != original {
+		t.Errorf("config changed despite error:\n%s", data)
+	}
+}
+
+func TestUpdateProjectConfigFile_ErrorLeavesNoConfig(t *testing.T) {
+	_, projectDir, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	errAbort := errors.New("abort")
+	if err := UpdateProjectConfigFile(func(cfg *GlobalConfig) error { return errAbort }); !errors.Is(err, errAbort) {
+		t.Fatalf("UpdateProjectConfigFile() error = %v, want %v", err, errAbort)
+	}
+	if _, err := os.Stat(filepath.Join(projectDir, ".addt.yaml")); !os.IsNotExist(err) {
+		t.Errorf("aborted update created .addt.yaml: %v", err)
+	}
+}
+
+func TestUpdateProjectConfigFile_NoFilesLeftInProject(t *testing.T) {
+	globalDir, projectDir, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	if err := UpdateProjectConfigFile(func(cfg *GlobalConfig) error {
+		cfg.NodeVersion = "22"
+		return nil
+	}); err != nil {
+		t.Fatalf("UpdateProjectConfigFile() error = %v", err)
+	}
+
+	entries, _ := os.ReadDir(projectDir)
+	if len(entries) != 1 || entries[0].Name() != ".addt.yaml" {
+		var names []string
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		t.Errorf("project dir holds %v, want only .addt.yaml", names)
+	}
+	locks, _ := filepath.Glob(filepath.Join(globalDir, "locks", "*.lock"))
+	if len(locks) != 1 {
+		t.Errorf("lock files = %v, want one under the global locks dir", locks)
+	}
+}
+
+func TestConfigLockPath_PerConfigFile(t *testing.T) {
+	_, projectDir, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	a, err := configLockPath(filepath.Join(projectDir, ".addt.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := configLockPath(filepath.Join(projectDir, "other", ".addt.yaml"))
+	if a == b {
+		t.Errorf("two config files share the lock %s", a)
+	}
+}
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSaveGlobalConfigFile_PreservesUnknownKeys(t *testing.T) {
+	globalDir, _, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	configPath := filepath.Join(globalDir, "config.yaml")
+	original := `node_version: "20"
+future_feature:
+  enabled: true
+  level: 3
+typo_key: value
+`
+	if err := os.WriteFile(configPath, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadGlobalConfigFile()
+	if err != nil {
+		t.Fatalf("LoadGlobalConfigFile() error = %v", err)
+	}
+	cfg.GoVersion = "1.23"
+	if err := SaveGlobalConfigFile(cfg); err != nil {
+		t.Fatalf("SaveGlobalConfigFile() error = %v", err)
+	}
+
+	data, _ := os.ReadFile(configPath)
+	saved := string(data)
+	for _, want := range []string{"go_version: \"1.23\"", "node_version: \"20\"", "future_feature:", "enabled: true", "level: 3", "typo_key: value"} {
+		if !strings.Contains(saved, want) {
+			t.Errorf("saved config missing %q:\n%s", want, saved)
+		}
+	}
+}
+
+func TestSaveProjectConfigFile_PreservesUnknownKeys(t *testing.T) {
+	_, projectDir, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	configPath := filepath.Join(projectDir, ".addt.yaml")
+	if err := os.WriteFile(configPath, []byte("persistent: true\nnew_section:\n  - a\n  - b\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadProjectConfigFile()
+	if err != nil {
+		t.Fatalf("LoadProjectConfigFile() error = %v", err)
+	}
+	if err := SaveProjectConfigFile(cfg); err != nil {
+		t.Fatalf("SaveProjectConfigFile() error = %v", err)
+	}
+
+	data, _ := os.ReadFile(configPath)
+	if !strings.Contains(string(data), "new_section:") || !strings.Contains(string(data), "- a") {
+		t.Errorf("unknown key lost on save:\n%s", data)
+	}
+}
+
+func TestSaveGlobalConfigFile_KeepsCommentsAndOrder(t *testing.T) {
+	globalDir, _, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	configPath := filepath.Join(globalDir, "config.yaml")
+	original := `# My addt settings
+persistent: true # keep containers around
+container:
+    # sized for the laptop
+    memory: 4g
+node_version: "20"
+`
+	if err := os.WriteFile(configPath, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := UpdateGlobalConfigFile(func(cfg *GlobalConfig) error {
+		cfg.Container.Memory = "8g"
+		cfg.NodeVersion = ""
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("UpdateGlobalConfigFile() error = %v", err)
+	}
+
+	data, _ := os.ReadFile(configPath)
+	want := `# My addt settings
+persistent: true # keep containers around
+container:
+    # sized for the laptop
+    memory: 8g
+`
+	if string(data) != want {
+		t.Errorf("saved config =\n%s\nwant\n%s", data, want)
+	}
+}
+
+func TestSaveGlobalConfigFile_DescribesNewKeys(t *testing.T) {
+	globalDir, _, cleanup := setupTestEnv(t)
+	defer cleanup()
+	orig := KeyDescriber
+	KeyDescriber = func(key string) string {
+		return map[string]string{"persistent": "Keep containers", "container.cpus": "CPU limit"}[key]
+	}
+	defer func() { KeyDescriber = orig }()
+
+	configPath := filepath.Join(globalDir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("go_version: \"1.23\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	persistent := true
+	cfg := &GlobalConfig{GoVersion: "1.23", Persistent: &persistent, Container: &ContainerSettings{CPUs: "2"}}
+	if err := SaveGlobalConfigFile(cfg); err != nil {
+		t.Fatalf("SaveGlobalConfigFile() error = %v", err)
+	}
+
+	data, _ := os.ReadFile(configPath)
+	for _, want := range []string{"go_version: \"1.23\"\n", "# Keep containers\npersistent: true", "container:\n    # CPU limit\n    cpus: \"2\""} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("saved config missing %q:\n%s", want, data)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// KeyDescriber returns the description of a dotted config key (e.g.
+// "container.cpus"), or "" when unknown. Saving writes it as a comment
+// above newly added keys. It is provided by the config key registry
+// (cmd/config).
+var KeyDescriber func(key string) string
+
+// marshalConfigFile encodes cfg as YAML, editing the document already at
+// path in place so comments and key order survive a set/unset. Keys that
+// are new to the file are appended with their description as a comment.
+func marshalConfigFile(path string, cfg *GlobalConfig) ([]byte, error) {
+	var updated yaml.Node
+	if err := updated.Encode(cfg); err != nil {
+		return nil, err
+	}
+
+	doc := &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	if data, err := os.ReadFile(path); err == nil && len(bytes.TrimSpace(data)) > 0 {
+		var existing yaml.Node
+		if err := yaml.Unmarshal(data, &existing); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		if len(existing.Content) == 1 && existing.Content[0].Kind == yaml.MappingNode {
+			doc = &existing
+		}
+	}
+	mergeMapping(doc.Content[0], &updated, "")
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(4)
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// mergeMapping updates dst to hold the keys and values of src. Keys gone
+// from src are removed, kept keys keep their comments and position.
+func mergeMapping(dst, src *yaml.Node, prefix string) {
+	var content []*yaml.Node
+	for i := 0; i+1 < len(dst.Content); i += 2 {
+		key, val := dst.Content[i], dst.Content[i+1]
+		newVal := mappingValue(src, key.Value)
+		if newVal == nil {
+			continue
+		}
+		content = append(content, key, mergeValue(val, newVal, prefix+key.Value))
+	}
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		key, val := src.Content[i], src.Content[i+1]
+		if mappingValue(dst, key.Value) != nil {
+			continue
+		}
+		describeNew(key, val, prefix+key.Value)
+		content = append(content, key, val)
+	}
+	dst.Content = content
+	if len(content) > 0 {
+		dst.Style &^= yaml.FlowStyle
+	}
+}
+
+// mergeValue returns the node to store for a kept key: old updated in
+// place when the kinds match, otherwise new with old's comments
+func mergeValue(old, new *yaml.Node, key string) *yaml.Node {
+	switch {
+	case old.Kind == yaml.MappingNode && new.Kind == yaml.MappingNode:
+		mergeMapping(old, new, key+".")
+		return old
+	case old.Kind == yaml.ScalarNode && new.Kind == yaml.ScalarNode && old.Tag == new.Tag:
+		old.Value = new.Value
+		return old
+	case old.Kind == yaml.SequenceNode && new.Kind == yaml.SequenceNode:
+		old.Content = new.Content
+		return old
+	}
+	new.HeadComment, new.LineComment, new.FootComment = old.HeadComment, old.LineComment, old.FootComment
+	return new
+}
+
+// describeNew adds the key's description as a comment above an appended
+// key, or above each described key of an appended section
+func describeNew(key, val *yaml.Node, path string) {
+	if KeyDescriber == nil {
+		return
+	}
+	if desc := KeyDescriber(path); desc != "" {
+		key.HeadComment = strings.TrimSpace(desc)
+		return
+	}
+	if val.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(val.Content); i += 2 {
+			describeNew(val.Content[i], val.Content[i+1], path+"."+val.Content[i].Value)
+		}
+	}
+}
+
+// mappingValue returns the value for key in a mapping node, or nil
+func mappingValue(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// FirewallPresets maps a package ecosystem to the registry and CDN domains
+// its tooling needs. Presets listed in firewall.presets expand into the
+// allowed domains of the layer (global or project) that lists them.
+var FirewallPresets = map[string][]string{
+	"npm": {
+		"registry.npmjs.org",
+		"registry.yarnpkg.com",
+		"npm.pkg.github.com",
+		"cdn.jsdelivr.net",
+		"unpkg.com",
+	},
+	"pypi": {
+		"pypi.org",
+		"files.pythonhosted.org",
+		"pypi.python.org",
+	},
+	"go": {
+		"proxy.golang.org",
+		"sum.golang.org",
+		"storage.googleapis.com",
+		"golang.org",
+	},
+	"crates": {
+		"crates.io",
+		"static.crates.io",
+		"index.crates.io",
+		"static.rust-lang.org",
+	},
+	"github": {
+		"github.com",
+		"api.github.com",
+		"raw.githubusercontent.com",
+		"objects.githubusercontent.com",
+		"codeload.github.com",
+		"ghcr.io",
+	},
+	"rubygems": {
+		"rubygems.org",
+		"index.rubygems.org",
+	},
+	"maven": {
+		"repo.maven.apache.org",
+		"repo1.maven.org",
+		"plugins.gradle.org",
+		"services.gradle.org",
+	},
+	"docker": {
+		"registry-1.docker.io",
+		"auth.docker.io",
+		"production.cloudflare.docker.com",
+	},
+}
+
+// FirewallPresetNames returns the known preset names, sorted
+func FirewallPresetNames() []string {
+	names := make([]string, 0, len(FirewallPresets))
+	for name := range FirewallPresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ExpandFirewallPresets returns the domains for the given presets in order,
+// without duplicates, and the names of any unknown presets
+func ExpandFirewallPresets(presets []string) (domains []string, unknown []string) {
+	for _, preset := range presets {
+		name := strings.ToLower(strings.TrimSpace(preset))
+		if name == "" {
+			continue
+		}
+		presetDomains, ok := FirewallPresets[name]
+		if !ok {
+			unknown = append(unknown, preset)
+			continue
+		}
+		domains = mergeDomains(domains, presetDomains)
+	}
+	return domains, unknown
+}
+
+// mergeDomains appends the domains in add that are not already in base
+func mergeDomains(base, add []string) []string {
+	for _, d := range add {
+		found := false
+		for _, existing := range base {
+			if existing == d {
+				found = true
+				break
+			}
+		}
+		if !found {
+			base = append(base, d)
+		}
+	}
+	return base
+}
+
+// resolveFirewallAllowed merges a layer's preset domains with its explicit
+// allow entries, warning about unknown presets
+func resolveFirewallAllowed(allowed, presets []string) []string {
+	domains, unknown := ExpandFirewallPresets(presets)
+	for _, name := range unknown {
+		fmt.Fprintf(os.Stderr, "Warning: unknown firewall preset %q (known: %s)\n", name, strings.Join(FirewallPresetNames(), ", "))
+	}
+	if len(domains) == 0 {
+		return allowed
+	}
+	return mergeDomains(append([]string(nil), allowed...), domains)
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExpandFirewallPresets_EachPresetContributesDomains(t *testing.T) {
+	for _, name := range FirewallPresetNames() {
+		domains, unknown := ExpandFirewallPresets([]string{name})
+		if len(unknown) != 0 {
+			t.Errorf("preset %q reported unknown", name)
+		}
+		if !reflect.DeepEqual(domains, FirewallPresets[name]) {
+			t.Errorf("preset %q = %v, want %v", name, domains, FirewallPresets[name])
+		}
+	}
+}
+
+func TestExpandFirewallPresets_DedupesAndReportsUnknown(t *testing.T) {
+	domains, unknown := ExpandFirewallPresets([]string{"NPM", "npm", "bogus", " pypi "})
+
+	want := append(append([]string(nil), FirewallPresets["npm"]...), FirewallPresets["pypi"]...)
+	if !reflect.DeepEqual(domains, want) {
+		t.Errorf("domains = %v, want %v", domains, want)
+	}
+	if !reflect.DeepEqual(unknown, []string{"bogus"}) {
+		t.Errorf("unknown = %v, want [bogus]", unknown)
+	}
+}
+
+func TestLoadConfig_FirewallPresetsCombineWithAllowed(t *testing.T) {
+	globalDir, projectDir, cleanup := setupTestEnv(t)
+	defer cleanup()
+	t.Setenv("ADDT_FIREWALL_PRESETS", "")
+
+	writeGlobalConfig(t, globalDir, &GlobalConfig{
+		Firewall: &FirewallSettings{Presets: []string{"go"}},
+	})
+	writeProjectConfig(t, projectDir, &GlobalConfig{
+		Firewall: &FirewallSettings{
+			Allowed: []string{"internal.example.com", "registry.npmjs.org"},
+			Presets: []string{"npm"},
+		},
+	})
+
+	cfg := LoadConfig("0.0.0-test", "20", "1.21", "0.1.0", 30000)
+
+	if !reflect.DeepEqual(cfg.GlobalFirewallAllowed, FirewallPresets["go"]) {
+		t.Errorf("GlobalFirewallAllowed = %v, want go preset %v", cfg.GlobalFirewallAllowed, FirewallPresets["go"])
+	}
+
+	// Explicit entries come first, preset domains follow without duplicates
+	want := []string{"internal.example.com", "registry.npmjs.org"}
+	want = mergeDomains(want, FirewallPresets["npm"])
+	if !reflect.DeepEqual(cfg.ProjectFirewallAllowed, want) {
+		t.Errorf("ProjectFirewallAllowed = %v, want %v", cfg.ProjectFirewallAllowed, want)
+	}
+}
+
+func TestLoadConfig_FirewallPresetsFromEnv(t *testing.T) {
+	_, _, cleanup := setupTestEnv(t)
+	defer cleanup()
+	t.Setenv("ADDT_FIREWALL_PRESETS", "crates,github")
+
+	cfg := LoadConfig("0.0.0-test", "20", "1.21", "0.1.0", 30000)
+
+	want := mergeDomains(append([]string(nil), FirewallPresets["crates"]...), FirewallPresets["github"])
+	if !reflect.DeepEqual(cfg.ProjectFirewallAllowed, want) {
+		t.Errorf("ProjectFirewallAllowed = %v, want %v", cfg.ProjectFirewallAllowed, want)
+	}
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jedi4ever/addt/provider"
+)
+
+// loadForwardFiles resolves forward_files (project replaces global, then
+// ADDT_FORWARD_FILES) into tilde-expanded host/container pairs. Invalid
+// entries are reported and skipped; missing files are skipped at run time.
+func loadForwardFiles(globalCfg, projectCfg *GlobalConfig) []provider.ForwardFile {
+	var specs []string
+	if len(globalCfg.ForwardFiles) > 0 {
+		specs = globalCfg.ForwardFiles
+	}
+	if len(projectCfg.ForwardFiles) > 0 {
+		specs = projectCfg.ForwardFiles
+	}
+	if v := os.Getenv("ADDT_FORWARD_FILES"); v != "" {
+		specs = strings.Split(v, ",")
+	}
+
+	homeDir, _ := os.UserHomeDir()
+	var files []provider.ForwardFile
+	for _, spec := range specs {
+		if strings.TrimSpace(spec) == "" {
+			continue
+		}
+		f, err := provider.ParseForwardFile(spec, homeDir)
+		if err != nil {
+			fmt.Printf("Warning: %v, skipping\n", err)
+			continue
+		}
+		files = append(files, f)
+	}
+	return files
+}
@@ -0,0 +1,24 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadForwardFiles_Precedence(t *testing.T) {
+	home, _ := os.UserHomeDir()
+	global := &GlobalConfig{ForwardFiles: []string{"~/.netrc"}}
+	project := &GlobalConfig{ForwardFiles: []string{"~/.npmrc:rw"}}
+
+	files := loadForwardFiles(global, project)
+	if len(files) != 1 || files[0].Source != filepath.Join(home, ".npmrc") || files[0].ReadOnly {
+		t.Errorf("project forward_files should replace global, got %+v", files)
+	}
+
+	t.Setenv("ADDT_FORWARD_FILES", "/etc/ca.pem:/usr/local/share/ca.pem,relative/path")
+	files = loadForwardFiles(global, project)
+	if len(files) != 1 || files[0].Target != "/usr/local/share/ca.pem" || !files[0].ReadOnly {
+		t.Errorf("env should override config and invalid entries be skipped, got %+v", files)
+	}
+}
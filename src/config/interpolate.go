@@ -0,0 +1,105 @@
+package config
+
+import (
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// interpolationPattern matches $$, ${VAR}, ${VAR:-default} and $VAR
+var interpolationPattern = regexp.MustCompile(`\$\$|\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}|\$([A-Za-z_][A-Za-z0-9_]*)`)
+
+var yamlNodeType = reflect.TypeOf(yaml.Node{})
+
+// interpolateString expands ${VAR}, $VAR and ${VAR:-default} from the host
+// environment; $$ is a literal $. The default applies when VAR is unset or
+// empty. References to unset variables without a default expand to "" and
+// are reported in missing.
+func interpolateString(s string) (result string, missing []string) {
+	result = interpolationPattern.ReplaceAllStringFunc(s, func(ref string) string {
+		if ref == "$$" {
+			return "$"
+		}
+		m := interpolationPattern.FindStringSubmatch(ref)
+		name, hasDefault, def := m[1], m[2] != "", m[3]
+		if name == "" {
+			name = m[4]
+		}
+		value, ok := os.LookupEnv(name)
+		if hasDefault && value == "" {
+			return def
+		}
+		if !ok {
+			missing = append(missing, name)
+		}
+		return value
+	})
+	return result, missing
+}
+
+// interpolateConfig expands env references in every string value of the loaded
+// config files (string fields, string lists and string maps). Non-string values
+// are left untouched. Returns the sorted names of referenced but unset variables.
+func interpolateConfig(cfgs ...*GlobalConfig) []string {
+	seen := make(map[string]bool)
+	for _, cfg := range cfgs {
+		interpolateValue(reflect.ValueOf(cfg), seen)
+	}
+
+	missing := make([]string, 0, len(seen))
+	for name := range seen {
+		missing = append(missing, name)
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// interpolateValue walks v and expands settable strings in place
+func interpolateValue(v reflect.Value, missing map[string]bool) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			interpolateValue(v.Elem(), missing)
+		}
+	case reflect.Struct:
+		if v.Type() == yamlNodeType {
+			return // unknown keys are preserved verbatim
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				interpolateValue(v.Field(i), missing)
+			}
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			interpolateValue(v.Index(i), missing)
+		}
+	case reflect.Map:
+		if v.Type().Elem().Kind() == reflect.String {
+			for _, key := range v.MapKeys() {
+				expanded := expandString(v.MapIndex(key).String(), missing)
+				v.SetMapIndex(key, reflect.ValueOf(expanded).Convert(v.Type().Elem()))
+			}
+			return
+		}
+		for _, key := range v.MapKeys() {
+			interpolateValue(v.MapIndex(key), missing)
+		}
+	case reflect.String:
+		if v.CanSet() {
+			v.SetString(expandString(v.String(), missing))
+		}
+	}
+}
+
+// expandString interpolates s and records missing variable names
+func expandString(s string, missing map[string]bool) string {
+	result, names := interpolateString(s)
+	for _, name := range names {
+		missing[name] = true
+	}
+	return result
+}
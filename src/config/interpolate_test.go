@@ -0,0 +1,133 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestInterpolateString(t *testing.T) {
+	os.Setenv("ADDT_TEST_HOST", "collector")
+	os.Setenv("ADDT_TEST_EMPTY", "")
+	os.Unsetenv("ADDT_TEST_UNSET")
+	defer os.Unsetenv("ADDT_TEST_HOST")
+	defer os.Unsetenv("ADDT_TEST_EMPTY")
+
+	tests := []struct {
+		input       string
+		want        string
+		wantMissing []string
+	}{
+		{"http://${ADDT_TEST_HOST}:4318", "http://collector:4318", nil},
+		{"${ADDT_TEST_UNSET:-fallback}/x", "fallback/x", nil},
+		{"${ADDT_TEST_EMPTY:-fallback}", "fallback", nil},
+		{"${ADDT_TEST_HOST:-fallback}", "collector", nil},
+		{"${ADDT_TEST_EMPTY}", "", nil},
+		{"${ADDT_TEST_UNSET}/log", "/log", []string{"ADDT_TEST_UNSET"}},
+		{"plain $ADDT_TEST_HOST value", "plain collector value", nil},
+		{"$ADDT_TEST_HOST/$ADDT_TEST_UNSET", "collector/", []string{"ADDT_TEST_UNSET"}},
+		{"cost: $$5 and $${ADDT_TEST_HOST}", "cost: $5 and ${ADDT_TEST_HOST}", nil},
+		{"$$$ADDT_TEST_HOST", "$collector", nil},
+		{"trailing $ and $1", "trailing $ and $1", nil},
+	}
+
+	for _, tt := range tests {
+		got, missing := interpolateString(tt.input)
+		if got != tt.want {
+			t.Errorf("interpolateString(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+		if !reflect.DeepEqual(missing, tt.wantMissing) {
+			t.Errorf("interpolateString(%q) missing = %v, want %v", tt.input, missing, tt.wantMissing)
+		}
+	}
+}
+
+func TestInterpolateConfig_StringFieldsOnly(t *testing.T) {
+	os.Setenv("ADDT_TEST_DIR", "/data")
+	defer os.Unsetenv("ADDT_TEST_DIR")
+
+	persistent := true
+	maxFiles := 3
+	cfg := &GlobalConfig{
+		Persistent: &persistent,
+		Log:        &LogSettings{Dir: "${ADDT_TEST_DIR}/logs", MaxFiles: &maxFiles},
+		Extensions: map[string]*ExtensionSettings{
+			"claude": {Version: "${ADDT_TEST_VERSION:-stable}"},
+		},
+	}
+
+	missing := interpolateConfig(cfg, &GlobalConfig{NodeVersion: "${ADDT_TEST_NOPE}"})
+
+	if cfg.Log.Dir != "/data/logs" {
+		t.Errorf("log.dir = %q, want /data/logs", cfg.Log.Dir)
+	}
+	if cfg.Extensions["claude"].Version != "stable" {
+		t.Errorf("extensions.claude.version = %q, want stable", cfg.Extensions["claude"].Version)
+	}
+	if *cfg.Log.MaxFiles != 3 || !*cfg.Persistent {
+		t.Error("non-string fields must not change")
+	}
+	if !reflect.DeepEqual(missing, []string{"ADDT_TEST_NOPE"}) {
+		t.Errorf("missing = %v, want [ADDT_TEST_NOPE]", missing)
+	}
+}
+
+func TestLoadConfig_Interpolation(t *testing.T) {
+	globalDir, projectDir, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	os.Setenv("ADDT_TEST_LOGDIR", "/tmp/addt-logs")
+	defer os.Unsetenv("ADDT_TEST_LOGDIR")
+
+	os.WriteFile(filepath.Join(globalDir, "config.yaml"), []byte("log:\n  dir: ${ADDT_TEST_LOGDIR}\n"), 0644)
+	os.WriteFile(filepath.Join(projectDir, ".addt.yaml"), []byte("node_version: ${ADDT_TEST_NODE:-22}\n"), 0644)
+
+	cfg := LoadConfig("1.0.0", "20", "1.21", "0.1.0", 30000)
+
+	if cfg.LogDir != "/tmp/addt-logs" {
+		t.Errorf("LogDir = %q, want /tmp/addt-logs", cfg.LogDir)
+	}
+	if cfg.NodeVersion != "22" {
+		t.Errorf("NodeVersion = %q, want 22", cfg.NodeVersion)
+	}
+
+	// Env var overrides still win over interpolated file values
+	os.Setenv("ADDT_NODE_VERSION", "18")
+	cfg = LoadConfig("1.0.0", "20", "1.21", "0.1.0", 30000)
+	if cfg.NodeVersion != "18" {
+		t.Errorf("NodeVersion with env override = %q, want 18", cfg.NodeVersion)
+	}
+}
+
+func TestLoadConfig_InterpolatesPaths(t *testing.T) {
+	_, projectDir, cleanup := setupTestEnv(t)
+	defer cleanup()
+	t.Setenv("ADDT_TEST_BASE", "/srv/dev")
+	os.Unsetenv("ADDT_TEST_MISSING")
+
+	yaml := `env_file: ${ADDT_TEST_BASE}/.secrets/prod.env
+workdir:
+  path: $ADDT_TEST_BASE/app
+ssh:
+  dir: ${ADDT_TEST_BASE}/ssh$ADDT_TEST_MISSING
+gpg:
+  dir: $ADDT_TEST_BASE/gnupg
+git:
+  config_path: /home/$$USER/.gitconfig
+`
+	os.WriteFile(filepath.Join(projectDir, ".addt.yaml"), []byte(yaml), 0644)
+
+	cfg := LoadConfig("1.0.0", "20", "1.21", "0.1.0", 30000)
+	for name, got := range map[string][2]string{
+		"env_file":        {cfg.EnvFile, "/srv/dev/.secrets/prod.env"},
+		"workdir.path":    {cfg.Workdir, "/srv/dev/app"},
+		"ssh.dir":         {cfg.SSHDir, "/srv/dev/ssh"},
+		"gpg.dir":         {cfg.GPGDir, "/srv/dev/gnupg"},
+		"git.config_path": {cfg.GitConfigPath, "/home/$USER/.gitconfig"},
+	} {
+		if got[0] != got[1] {
+			t.Errorf("%s = %q, want %q", name, got[0], got[1])
+		}
+	}
+}
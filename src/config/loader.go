@@ -8,6 +8,7 @@ import (
 	"github.com/jedi4ever/addt/config/otel"
 	"github.com/jedi4ever/addt/config/security"
 	"github.com/jedi4ever/addt/extensions"
+	"github.com/jedi4ever/addt/util"
 )
 
 // LoadConfig loads configuration with precedence: defaults < global config < project config < env vars
@@ -16,6 +17,11 @@ func LoadConfig(addtVersion, defaultNodeVersion, defaultGoVersion, defaultUvVers
 	globalCfg := loadGlobalConfig()
 	projectCfg := loadProjectConfig()
 
+	// Expand $VAR/${VAR} references in config values before env var overrides apply
+	for _, name := range interpolateConfig(globalCfg, projectCfg) {
+		util.Log("config").Debugf("Warning: config references undefined env var %s, expanding it to \"\"", name)
+	}
+
 	// Start with defaults, then apply global config, then project config, then env vars
 	cfg := &Config{
 		AddtVersion:               addtVersion,
@@ -28,6 +34,11 @@ func LoadConfig(addtVersion, defaultNodeVersion, defaultGoVersion, defaultUvVers
 		ExtensionFlagSettings:     make(map[string]map[string]bool),
 	}
 
+	// Record which layer supplies each key (debug only)
+	if explainConfigEnabled() && SourceResolver != nil {
+		cfg.Sources = SourceResolver(globalCfg, projectCfg)
+	}
+
 	// Node version: default -> global -> project -> env
 	cfg.NodeVersion = defaultNodeVersion
 	if globalCfg.NodeVersion != "" {
@@ -102,459 +113,24 @@ func LoadConfig(addtVersion, defaultNodeVersion, defaultGoVersion, defaultUvVers
 		cfg.PortsInjectSystemPrompt = v == "true"
 	}
 
-	// SSH forward keys: default (false) -> global -> project -> env
-	cfg.SSHForwardKeys = false
-	cfg.SSHForwardMode = "proxy"
-	if globalCfg.SSH != nil {
-		if globalCfg.SSH.ForwardKeys != nil {
-			cfg.SSHForwardKeys = *globalCfg.SSH.ForwardKeys
-		}
-		if globalCfg.SSH.ForwardMode != "" {
-			cfg.SSHForwardMode = globalCfg.SSH.ForwardMode
-		}
-		if len(globalCfg.SSH.AllowedKeys) > 0 {
-			cfg.SSHAllowedKeys = globalCfg.SSH.AllowedKeys
-		}
-	}
-	if projectCfg.SSH != nil {
-		if projectCfg.SSH.ForwardKeys != nil {
-			cfg.SSHForwardKeys = *projectCfg.SSH.ForwardKeys
-		}
-		if projectCfg.SSH.ForwardMode != "" {
-			cfg.SSHForwardMode = projectCfg.SSH.ForwardMode
-		}
-		if len(projectCfg.SSH.AllowedKeys) > 0 {
-			cfg.SSHAllowedKeys = projectCfg.SSH.AllowedKeys
-		}
-	}
-	if v := os.Getenv("ADDT_SSH_FORWARD_KEYS"); v != "" {
-		cfg.SSHForwardKeys = v == "true"
-	}
-	if v := os.Getenv("ADDT_SSH_FORWARD_MODE"); v != "" {
-		cfg.SSHForwardMode = v
-	}
-	if v := os.Getenv("ADDT_SSH_ALLOWED_KEYS"); v != "" {
-		cfg.SSHAllowedKeys = strings.Split(v, ",")
-	}
-
-	// SSH dir: default ("") -> global -> project -> env
-	cfg.SSHDir = ""
-	if globalCfg.SSH != nil && globalCfg.SSH.Dir != "" {
-		cfg.SSHDir = globalCfg.SSH.Dir
-	}
-	if projectCfg.SSH != nil && projectCfg.SSH.Dir != "" {
-		cfg.SSHDir = projectCfg.SSH.Dir
-	}
-	if v := os.Getenv("ADDT_SSH_DIR"); v != "" {
-		cfg.SSHDir = v
-	}
-
-	// Tmux forward: default (false) -> global -> project -> env
-	cfg.TmuxForward = false
-	if globalCfg.TmuxForward != nil {
-		cfg.TmuxForward = *globalCfg.TmuxForward
-	}
-	if projectCfg.TmuxForward != nil {
-		cfg.TmuxForward = *projectCfg.TmuxForward
-	}
-	if v := os.Getenv("ADDT_TMUX_FORWARD"); v != "" {
-		cfg.TmuxForward = v == "true"
-	}
-
-	// History persist: default (false) -> global -> project -> env
-	cfg.HistoryPersist = false
-	if globalCfg.HistoryPersist != nil {
-		cfg.HistoryPersist = *globalCfg.HistoryPersist
-	}
-	if projectCfg.HistoryPersist != nil {
-		cfg.HistoryPersist = *projectCfg.HistoryPersist
-	}
-	if v := os.Getenv("ADDT_HISTORY_PERSIST"); v != "" {
-		cfg.HistoryPersist = v == "true"
-	}
-
-	// Terminal OSC: default (false) -> global -> project -> env
-	cfg.TerminalOSC = false
-	if globalCfg.Terminal != nil && globalCfg.Terminal.OSC != nil {
-		cfg.TerminalOSC = *globalCfg.Terminal.OSC
-	}
-	if projectCfg.Terminal != nil && projectCfg.Terminal.OSC != nil {
-		cfg.TerminalOSC = *projectCfg.Terminal.OSC
-	}
-	if v := os.Getenv("ADDT_TERMINAL_OSC"); v != "" {
-		cfg.TerminalOSC = v == "true"
-	}
-
-	// GPG forward: default (off) -> global -> project -> env
-	cfg.GPGForward = ""
-	if globalCfg.GPG != nil && globalCfg.GPG.Forward != "" {
-		cfg.GPGForward = globalCfg.GPG.Forward
-	}
-	if projectCfg.GPG != nil && projectCfg.GPG.Forward != "" {
-		cfg.GPGForward = projectCfg.GPG.Forward
-	}
-	if v := os.Getenv("ADDT_GPG_FORWARD"); v != "" {
-		// Support legacy boolean values
-		if v == "true" {
-			cfg.GPGForward = "keys"
-		} else if v == "false" {
-			cfg.GPGForward = ""
-		} else {
-			cfg.GPGForward = v
-		}
-	}
-
-	// GPG allowed key IDs: global -> project -> env
-	if globalCfg.GPG != nil {
-		cfg.GPGAllowedKeyIDs = globalCfg.GPG.AllowedKeyIDs
-	}
-	if projectCfg.GPG != nil && len(projectCfg.GPG.AllowedKeyIDs) > 0 {
-		cfg.GPGAllowedKeyIDs = projectCfg.GPG.AllowedKeyIDs
-	}
-	if v := os.Getenv("ADDT_GPG_ALLOWED_KEY_IDS"); v != "" {
-		cfg.GPGAllowedKeyIDs = strings.Split(v, ",")
-	}
-
-	// GPG dir: default ("") -> global -> project -> env
-	cfg.GPGDir = ""
-	if globalCfg.GPG != nil && globalCfg.GPG.Dir != "" {
-		cfg.GPGDir = globalCfg.GPG.Dir
-	}
-	if projectCfg.GPG != nil && projectCfg.GPG.Dir != "" {
-		cfg.GPGDir = projectCfg.GPG.Dir
-	}
-	if v := os.Getenv("ADDT_GPG_DIR"); v != "" {
-		cfg.GPGDir = v
-	}
-
-	// DinD mode: default -> global -> project -> env
-	if globalCfg.Docker != nil && globalCfg.Docker.Dind != nil {
-		cfg.DockerDindMode = globalCfg.Docker.Dind.Mode
-	}
-	if projectCfg.Docker != nil && projectCfg.Docker.Dind != nil && projectCfg.Docker.Dind.Mode != "" {
-		cfg.DockerDindMode = projectCfg.Docker.Dind.Mode
-	}
-	if v := os.Getenv("ADDT_DOCKER_DIND_MODE"); v != "" {
-		cfg.DockerDindMode = v
-	}
-
-	// Log output: default (stderr) -> global -> project -> env
-	cfg.LogOutput = "stderr"
-	if globalCfg.Log != nil && globalCfg.Log.Output != "" {
-		cfg.LogOutput = globalCfg.Log.Output
-	}
-	if projectCfg.Log != nil && projectCfg.Log.Output != "" {
-		cfg.LogOutput = projectCfg.Log.Output
-	}
-	if v := os.Getenv("ADDT_LOG_OUTPUT"); v != "" {
-		cfg.LogOutput = v
-	}
-
-	// Log file: default -> global -> project -> env
-	// Check this first because setting ADDT_LOG_FILE should auto-enable logging
-	cfg.LogFile = "addt.log"
-	if globalCfg.Log != nil && globalCfg.Log.File != "" {
-		cfg.LogFile = globalCfg.Log.File
-	}
-	if projectCfg.Log != nil && projectCfg.Log.File != "" {
-		cfg.LogFile = projectCfg.Log.File
-	}
-	// Check if ADDT_LOG_FILE is set (even if empty, to allow stderr logging)
-	logFileEnvSet := false
-	if v, ok := os.LookupEnv("ADDT_LOG_FILE"); ok {
-		cfg.LogFile = v // Empty string means stderr, non-empty means file
-		logFileEnvSet = true
-	}
-
-	// Log enabled: default (false) -> global -> project -> env
-	// Auto-enable if ADDT_LOG_FILE is set (even if empty)
-	cfg.LogEnabled = logFileEnvSet
-	if globalCfg.Log != nil && globalCfg.Log.Enabled != nil {
-		cfg.LogEnabled = *globalCfg.Log.Enabled
-	}
-	if projectCfg.Log != nil && projectCfg.Log.Enabled != nil {
-		cfg.LogEnabled = *projectCfg.Log.Enabled
-	}
-	if v := os.Getenv("ADDT_LOG"); v != "" {
-		cfg.LogEnabled = v == "true"
-	}
-
-	// Log dir: default (~/.addt/logs) -> global -> project -> env
-	cfg.LogDir = ""
-	if globalCfg.Log != nil && globalCfg.Log.Dir != "" {
-		cfg.LogDir = globalCfg.Log.Dir
-	}
-	if projectCfg.Log != nil && projectCfg.Log.Dir != "" {
-		cfg.LogDir = projectCfg.Log.Dir
-	}
-	if v := os.Getenv("ADDT_LOG_DIR"); v != "" {
-		cfg.LogDir = v
-	}
-
-	// Log level: default (INFO) -> global -> project -> env
-	cfg.LogLevel = "INFO"
-	if globalCfg.Log != nil && globalCfg.Log.Level != "" {
-		cfg.LogLevel = globalCfg.Log.Level
-	}
-	if projectCfg.Log != nil && projectCfg.Log.Level != "" {
-		cfg.LogLevel = projectCfg.Log.Level
-	}
-	if v := os.Getenv("ADDT_LOG_LEVEL"); v != "" {
-		cfg.LogLevel = v
-	}
-
-	// Log modules: default (*) -> global -> project -> env
-	cfg.LogModules = "*"
-	if globalCfg.Log != nil && globalCfg.Log.Modules != "" {
-		cfg.LogModules = globalCfg.Log.Modules
-	}
-	if projectCfg.Log != nil && projectCfg.Log.Modules != "" {
-		cfg.LogModules = projectCfg.Log.Modules
-	}
-	if v := os.Getenv("ADDT_LOG_MODULES"); v != "" {
-		cfg.LogModules = v
-	}
-
-	// Log rotate: default (false) -> global -> project -> env
-	cfg.LogRotate = false
-	if globalCfg.Log != nil && globalCfg.Log.Rotate != nil {
-		cfg.LogRotate = *globalCfg.Log.Rotate
-	}
-	if projectCfg.Log != nil && projectCfg.Log.Rotate != nil {
-		cfg.LogRotate = *projectCfg.Log.Rotate
-	}
-	if v := os.Getenv("ADDT_LOG_ROTATE"); v != "" {
-		cfg.LogRotate = v == "true"
-	}
-
-	// Log max size: default (10m) -> global -> project -> env
-	cfg.LogMaxSize = "10m"
-	if globalCfg.Log != nil && globalCfg.Log.MaxSize != "" {
-		cfg.LogMaxSize = globalCfg.Log.MaxSize
-	}
-	if projectCfg.Log != nil && projectCfg.Log.MaxSize != "" {
-		cfg.LogMaxSize = projectCfg.Log.MaxSize
-	}
-	if v := os.Getenv("ADDT_LOG_MAX_SIZE"); v != "" {
-		cfg.LogMaxSize = v
-	}
-
-	// Log max files: default (5) -> global -> project -> env
-	cfg.LogMaxFiles = 5
-	if globalCfg.Log != nil && globalCfg.Log.MaxFiles != nil {
-		cfg.LogMaxFiles = *globalCfg.Log.MaxFiles
-	}
-	if projectCfg.Log != nil && projectCfg.Log.MaxFiles != nil {
-		cfg.LogMaxFiles = *projectCfg.Log.MaxFiles
-	}
-	if v := os.Getenv("ADDT_LOG_MAX_FILES"); v != "" {
-		if i, err := strconv.Atoi(v); err == nil {
-			cfg.LogMaxFiles = i
-		}
-	}
-
-	// Persistent: default (false) -> global -> project -> env
-	cfg.Persistent = false
-	if globalCfg.Persistent != nil {
-		cfg.Persistent = *globalCfg.Persistent
-	}
-	if projectCfg.Persistent != nil {
-		cfg.Persistent = *projectCfg.Persistent
-	}
-	if v := os.Getenv("ADDT_PERSISTENT"); v != "" {
-		cfg.Persistent = v == "true"
-	}
-
-	// Workdir automount: default (true) -> global -> project -> env
-	cfg.WorkdirAutomount = true
-	if globalCfg.Workdir != nil && globalCfg.Workdir.Automount != nil {
-		cfg.WorkdirAutomount = *globalCfg.Workdir.Automount
-	}
-	if projectCfg.Workdir != nil && projectCfg.Workdir.Automount != nil {
-		cfg.WorkdirAutomount = *projectCfg.Workdir.Automount
-	}
-	if v := os.Getenv("ADDT_WORKDIR_AUTOMOUNT"); v != "" {
-		cfg.WorkdirAutomount = v != "false"
-	}
-
-	// Workdir readonly: default (false) -> global -> project -> env
-	cfg.WorkdirReadonly = false
-	if globalCfg.Workdir != nil && globalCfg.Workdir.Readonly != nil {
-		cfg.WorkdirReadonly = *globalCfg.Workdir.Readonly
-	}
-	if projectCfg.Workdir != nil && projectCfg.Workdir.Readonly != nil {
-		cfg.WorkdirReadonly = *projectCfg.Workdir.Readonly
-	}
-	if v := os.Getenv("ADDT_WORKDIR_READONLY"); v != "" {
-		cfg.WorkdirReadonly = v == "true"
-	}
-
-	// Workdir autotrust: default (true) -> global -> project -> env
-	cfg.WorkdirAutotrust = true
-	if globalCfg.Workdir != nil && globalCfg.Workdir.Autotrust != nil {
-		cfg.WorkdirAutotrust = *globalCfg.Workdir.Autotrust
-	}
-	if projectCfg.Workdir != nil && projectCfg.Workdir.Autotrust != nil {
-		cfg.WorkdirAutotrust = *projectCfg.Workdir.Autotrust
-	}
-	if v := os.Getenv("ADDT_WORKDIR_AUTOTRUST"); v != "" {
-		cfg.WorkdirAutotrust = v == "true"
-	}
-
-	// Firewall: default (false) -> global -> project -> env
-	cfg.FirewallEnabled = false
-	if globalCfg.Firewall != nil && globalCfg.Firewall.Enabled != nil {
-		cfg.FirewallEnabled = *globalCfg.Firewall.Enabled
-	}
-	if projectCfg.Firewall != nil && projectCfg.Firewall.Enabled != nil {
-		cfg.FirewallEnabled = *projectCfg.Firewall.Enabled
-	}
-	if v := os.Getenv("ADDT_FIREWALL"); v != "" {
-		cfg.FirewallEnabled = v == "true"
-	}
-
-	// Firewall mode: default (strict) -> global -> project -> env
-	cfg.FirewallMode = "strict"
-	if globalCfg.Firewall != nil && globalCfg.Firewall.Mode != "" {
-		cfg.FirewallMode = globalCfg.Firewall.Mode
-	}
-	if projectCfg.Firewall != nil && projectCfg.Firewall.Mode != "" {
-		cfg.FirewallMode = projectCfg.Firewall.Mode
-	}
-	if v := os.Getenv("ADDT_FIREWALL_MODE"); v != "" {
-		cfg.FirewallMode = v
-	}
-
-	// Firewall rules: keep each layer separate for layered override evaluation
-	// Order: Defaults → Extension → Global → Project (project wins)
-	if globalCfg.Firewall != nil {
-		cfg.GlobalFirewallAllowed = globalCfg.Firewall.Allowed
-		cfg.GlobalFirewallDenied = globalCfg.Firewall.Denied
-	}
-	if projectCfg.Firewall != nil {
-		cfg.ProjectFirewallAllowed = projectCfg.Firewall.Allowed
-		cfg.ProjectFirewallDenied = projectCfg.Firewall.Denied
-	}
-	// Extension firewall rules are loaded below after determining the extension
-
-	// GitHub forward token: default (false) -> global -> project -> env
-	cfg.GitHubForwardToken = false
-	if globalCfg.GitHub != nil && globalCfg.GitHub.ForwardToken != nil {
-		cfg.GitHubForwardToken = *globalCfg.GitHub.ForwardToken
-	}
-	if projectCfg.GitHub != nil && projectCfg.GitHub.ForwardToken != nil {
-		cfg.GitHubForwardToken = *projectCfg.GitHub.ForwardToken
-	}
-	if v := os.Getenv("ADDT_GITHUB_FORWARD_TOKEN"); v != "" {
-		cfg.GitHubForwardToken = v == "true"
-	}
-
-	// Git disable hooks: default (true) -> global -> project -> env
-	cfg.GitDisableHooks = true
-	if globalCfg.Git != nil && globalCfg.Git.DisableHooks != nil {
-		cfg.GitDisableHooks = *globalCfg.Git.DisableHooks
-	}
-	if projectCfg.Git != nil && projectCfg.Git.DisableHooks != nil {
-		cfg.GitDisableHooks = *projectCfg.Git.DisableHooks
-	}
-	if v := os.Getenv("ADDT_GIT_DISABLE_HOOKS"); v != "" {
-		cfg.GitDisableHooks = v == "true"
-	}
-
-	// Git forward config: default (true) -> global -> project -> env
-	cfg.GitForwardConfig = true
-	if globalCfg.Git != nil && globalCfg.Git.ForwardConfig != nil {
-		cfg.GitForwardConfig = *globalCfg.Git.ForwardConfig
-	}
-	if projectCfg.Git != nil && projectCfg.Git.ForwardConfig != nil {
-		cfg.GitForwardConfig = *projectCfg.Git.ForwardConfig
-	}
-	if v := os.Getenv("ADDT_GIT_FORWARD_CONFIG"); v != "" {
-		cfg.GitForwardConfig = v == "true"
-	}
-
-	// Git config path: default ("") -> global -> project -> env
-	cfg.GitConfigPath = ""
-	if globalCfg.Git != nil && globalCfg.Git.ConfigPath != "" {
-		cfg.GitConfigPath = globalCfg.Git.ConfigPath
+	// Ports prompt template: default (built-in wording) -> global -> project -> env
+	if globalCfg.Ports != nil && globalCfg.Ports.PromptTemplate != "" {
+		cfg.PortsPromptTemplate = globalCfg.Ports.PromptTemplate
 	}
-	if projectCfg.Git != nil && projectCfg.Git.ConfigPath != "" {
-		cfg.GitConfigPath = projectCfg.Git.ConfigPath
+	if projectCfg.Ports != nil && projectCfg.Ports.PromptTemplate != "" {
+		cfg.PortsPromptTemplate = projectCfg.Ports.PromptTemplate
 	}
-	if v := os.Getenv("ADDT_GIT_CONFIG_PATH"); v != "" {
-		cfg.GitConfigPath = v
+	if v := os.Getenv("ADDT_PORTS_PROMPT_TEMPLATE"); v != "" {
+		cfg.PortsPromptTemplate = v
 	}
 
-	// GitHub token source: default ("gh_auth") -> global -> project -> env
-	cfg.GitHubTokenSource = "gh_auth"
-	if globalCfg.GitHub != nil && globalCfg.GitHub.TokenSource != "" {
-		cfg.GitHubTokenSource = globalCfg.GitHub.TokenSource
-	}
-	if projectCfg.GitHub != nil && projectCfg.GitHub.TokenSource != "" {
-		cfg.GitHubTokenSource = projectCfg.GitHub.TokenSource
-	}
-	if v := os.Getenv("ADDT_GITHUB_TOKEN_SOURCE"); v != "" {
-		cfg.GitHubTokenSource = v
-	}
-
-	// GitHub scope token: default (true) -> global -> project -> env
-	cfg.GitHubScopeToken = true
-	if globalCfg.GitHub != nil && globalCfg.GitHub.ScopeToken != nil {
-		cfg.GitHubScopeToken = *globalCfg.GitHub.ScopeToken
-	}
-	if projectCfg.GitHub != nil && projectCfg.GitHub.ScopeToken != nil {
-		cfg.GitHubScopeToken = *projectCfg.GitHub.ScopeToken
-	}
-	if v := os.Getenv("ADDT_GITHUB_SCOPE_TOKEN"); v != "" {
-		cfg.GitHubScopeToken = v == "true"
-	}
-
-	// GitHub scope repos: default ([]) -> global -> project -> env
-	cfg.GitHubScopeRepos = nil
-	if globalCfg.GitHub != nil && len(globalCfg.GitHub.ScopeRepos) > 0 {
-		cfg.GitHubScopeRepos = globalCfg.GitHub.ScopeRepos
-	}
-	if projectCfg.GitHub != nil && len(projectCfg.GitHub.ScopeRepos) > 0 {
-		cfg.GitHubScopeRepos = projectCfg.GitHub.ScopeRepos
-	}
-	if v := os.Getenv("ADDT_GITHUB_SCOPE_REPOS"); v != "" {
-		cfg.GitHubScopeRepos = strings.Split(v, ",")
-	}
-
-	// Container CPUs: default (2) -> global -> project -> env
-	cfg.ContainerCPUs = "2" // Secure default: limit CPU usage
-	if globalCfg.Container != nil && globalCfg.Container.CPUs != "" {
-		cfg.ContainerCPUs = globalCfg.Container.CPUs
-	}
-	if projectCfg.Container != nil && projectCfg.Container.CPUs != "" {
-		cfg.ContainerCPUs = projectCfg.Container.CPUs
-	}
-	if v := os.Getenv("ADDT_CONTAINER_CPUS"); v != "" {
-		cfg.ContainerCPUs = v
-	}
-
-	// Container Memory: default (4g) -> global -> project -> env
-	cfg.ContainerMemory = "4g" // Secure default: limit memory usage
-	if globalCfg.Container != nil && globalCfg.Container.Memory != "" {
-		cfg.ContainerMemory = globalCfg.Container.Memory
-	}
-	if projectCfg.Container != nil && projectCfg.Container.Memory != "" {
-		cfg.ContainerMemory = projectCfg.Container.Memory
-	}
-	if v := os.Getenv("ADDT_CONTAINER_MEMORY"); v != "" {
-		cfg.ContainerMemory = v
-	}
-
-	// Workdir path: default (empty = current dir) -> global -> project -> env
-	if globalCfg.Workdir != nil {
-		cfg.Workdir = globalCfg.Workdir.Path
-	}
-	if projectCfg.Workdir != nil && projectCfg.Workdir.Path != "" {
-		cfg.Workdir = projectCfg.Workdir.Path
-	}
-	if v := os.Getenv("ADDT_WORKDIR"); v != "" {
-		cfg.Workdir = v
-	}
+	loadForwardConfig(cfg, globalCfg, projectCfg)
+	loadDockerConfig(cfg, globalCfg, projectCfg)
+	loadLogConfig(cfg, globalCfg, projectCfg)
+	loadContainerConfig(cfg, globalCfg, projectCfg)
+	loadWorkdirConfig(cfg, globalCfg, projectCfg)
+	loadFirewallConfig(cfg, globalCfg, projectCfg)
+	loadGitConfig(cfg, globalCfg, projectCfg)
 
 	// Env file load: default (true) -> global -> project -> env
 	cfg.EnvFileLoad = true
@@ -577,193 +153,19 @@ func LoadConfig(addtVersion, defaultNodeVersion, defaultGoVersion, defaultUvVers
 		cfg.EnvFile = v
 	}
 
-	// Config automount: default (false) -> global -> project -> env
-	cfg.ConfigAutomount = false
-	if globalCfg.Config != nil && globalCfg.Config.Automount != nil {
-		cfg.ConfigAutomount = *globalCfg.Config.Automount
-	}
-	if projectCfg.Config != nil && projectCfg.Config.Automount != nil {
-		cfg.ConfigAutomount = *projectCfg.Config.Automount
-	}
-	if v := os.Getenv("ADDT_CONFIG_AUTOMOUNT"); v != "" {
-		cfg.ConfigAutomount = v == "true"
-	}
-
-	// Config readonly: default (false) -> global -> project -> env
-	cfg.ConfigReadonly = false
-	if globalCfg.Config != nil && globalCfg.Config.Readonly != nil {
-		cfg.ConfigReadonly = *globalCfg.Config.Readonly
-	}
-	if projectCfg.Config != nil && projectCfg.Config.Readonly != nil {
-		cfg.ConfigReadonly = *projectCfg.Config.Readonly
-	}
-	if v := os.Getenv("ADDT_CONFIG_READONLY"); v != "" {
-		cfg.ConfigReadonly = v == "true"
-	}
+	// env_vars: project replaces global, ADDT_ENV_VARS wins
+	cfg.EnvVars = loadEnvVars(globalCfg, projectCfg)
 
-	// Auth autologin: default (true) -> global -> project -> env
-	cfg.AuthAutologin = true
-	if globalCfg.Auth != nil && globalCfg.Auth.Autologin != nil {
-		cfg.AuthAutologin = *globalCfg.Auth.Autologin
-	}
-	if projectCfg.Auth != nil && projectCfg.Auth.Autologin != nil {
-		cfg.AuthAutologin = *projectCfg.Auth.Autologin
-	}
-	if v := os.Getenv("ADDT_AUTH_AUTOLOGIN"); v != "" {
-		cfg.AuthAutologin = v == "true"
-	}
-
-	// Auth method: default (auto) -> global -> project -> env
-	cfg.AuthMethod = "auto"
-	if globalCfg.Auth != nil && globalCfg.Auth.Method != "" {
-		cfg.AuthMethod = globalCfg.Auth.Method
-	}
-	if projectCfg.Auth != nil && projectCfg.Auth.Method != "" {
-		cfg.AuthMethod = projectCfg.Auth.Method
-	}
-	if v := os.Getenv("ADDT_AUTH_METHOD"); v != "" {
-		cfg.AuthMethod = v
-	}
+	cfg.Mode = loadMode(globalCfg, projectCfg)
+	cfg.Command = loadCommand(globalCfg, projectCfg)
 
 	// These don't have global config equivalents
-	cfg.EnvVars = strings.Split(getEnvOrDefault("ADDT_ENV_VARS", "ANTHROPIC_API_KEY,GH_TOKEN"), ",")
-	cfg.Mode = getEnvOrDefault("ADDT_MODE", "container")
 	// Auto-detect container runtime (Docker > Podman) if not explicitly set
 	cfg.Provider = DetectContainerRuntime()
 	cfg.Extensions = os.Getenv("ADDT_EXTENSIONS")
-	cfg.Command = os.Getenv("ADDT_COMMAND")
-
-	// Load per-extension config from config files
-	// Precedence: global config < project config < environment variables
-	if globalCfg.Extensions != nil {
-		for extName, extCfg := range globalCfg.Extensions {
-			if extCfg.Version != "" {
-				cfg.ExtensionVersions[extName] = extCfg.Version
-			}
-			if extCfg.Config != nil && extCfg.Config.Automount != nil {
-				cfg.ExtensionConfigAutomount[extName] = *extCfg.Config.Automount
-			}
-			if extCfg.Config != nil && extCfg.Config.Readonly != nil {
-				cfg.ExtensionConfigReadonly[extName] = *extCfg.Config.Readonly
-			}
-			if extCfg.Workdir != nil && extCfg.Workdir.Autotrust != nil {
-				cfg.ExtensionWorkdirAutotrust[extName] = *extCfg.Workdir.Autotrust
-			}
-			if extCfg.Auth != nil && extCfg.Auth.Autologin != nil {
-				cfg.ExtensionAuthAutologin[extName] = *extCfg.Auth.Autologin
-			}
-			if extCfg.Auth != nil && extCfg.Auth.Method != "" {
-				cfg.ExtensionAuthMethod[extName] = extCfg.Auth.Method
-			}
-		}
-	}
-	if projectCfg.Extensions != nil {
-		for extName, extCfg := range projectCfg.Extensions {
-			if extCfg.Version != "" {
-				cfg.ExtensionVersions[extName] = extCfg.Version
-			}
-			if extCfg.Config != nil && extCfg.Config.Automount != nil {
-				cfg.ExtensionConfigAutomount[extName] = *extCfg.Config.Automount
-			}
-			if extCfg.Config != nil && extCfg.Config.Readonly != nil {
-				cfg.ExtensionConfigReadonly[extName] = *extCfg.Config.Readonly
-			}
-			if extCfg.Workdir != nil && extCfg.Workdir.Autotrust != nil {
-				cfg.ExtensionWorkdirAutotrust[extName] = *extCfg.Workdir.Autotrust
-			}
-			if extCfg.Auth != nil && extCfg.Auth.Autologin != nil {
-				cfg.ExtensionAuthAutologin[extName] = *extCfg.Auth.Autologin
-			}
-			if extCfg.Auth != nil && extCfg.Auth.Method != "" {
-				cfg.ExtensionAuthMethod[extName] = extCfg.Auth.Method
-			}
-		}
-	}
 
-	// Load per-extension flag settings from config files
-	// Precedence: global config < project config < env vars
-	resolveExtensionFlagSettings(cfg, globalCfg, projectCfg)
-
-	// Load extension-specific firewall rules based on ADDT_EXTENSIONS
-	// Extension firewall rules are stored in global config under extensions.<name>
-	currentExt := os.Getenv("ADDT_EXTENSIONS")
-	if currentExt != "" {
-		// Use first extension if multiple specified
-		extName := strings.Split(currentExt, ",")[0]
-		if globalCfg.Extensions != nil && globalCfg.Extensions[extName] != nil {
-			extCfg := globalCfg.Extensions[extName]
-			cfg.ExtensionFirewallAllowed = extCfg.FirewallAllowed
-			cfg.ExtensionFirewallDenied = extCfg.FirewallDenied
-		}
-	}
-
-	// Load per-extension versions and mount configs from environment (overrides config files)
-	// Pattern: ADDT_<EXT>_VERSION and ADDT_<EXT>_AUTOMOUNT
-	for _, env := range os.Environ() {
-		parts := strings.SplitN(env, "=", 2)
-		if len(parts) != 2 {
-			continue
-		}
-		key, value := parts[0], parts[1]
-
-		// Check for ADDT_<EXT>_VERSION pattern
-		if strings.HasPrefix(key, "ADDT_") && strings.HasSuffix(key, "_VERSION") {
-			// Extract extension name (e.g., "ADDT_CLAUDE_VERSION" -> "claude")
-			extName := strings.TrimPrefix(key, "ADDT_")
-			extName = strings.TrimSuffix(extName, "_VERSION")
-			extName = strings.ToLower(extName)
-			// Skip non-extension versions (node, go, uv)
-			if extName != "node" && extName != "go" && extName != "uv" {
-				cfg.ExtensionVersions[extName] = value
-			}
-		}
-
-		// Check for ADDT_<EXT>_CONFIG_AUTOMOUNT pattern
-		if strings.HasPrefix(key, "ADDT_") && strings.HasSuffix(key, "_CONFIG_AUTOMOUNT") {
-			// Extract extension name (e.g., "ADDT_CLAUDE_CONFIG_AUTOMOUNT" -> "claude")
-			extName := strings.TrimPrefix(key, "ADDT_")
-			extName = strings.TrimSuffix(extName, "_CONFIG_AUTOMOUNT")
-			extName = strings.ToLower(extName)
-			cfg.ExtensionConfigAutomount[extName] = value != "false"
-		}
-
-		// Check for ADDT_<EXT>_CONFIG_READONLY pattern
-		if strings.HasPrefix(key, "ADDT_") && strings.HasSuffix(key, "_CONFIG_READONLY") {
-			extName := strings.TrimPrefix(key, "ADDT_")
-			extName = strings.TrimSuffix(extName, "_CONFIG_READONLY")
-			extName = strings.ToLower(extName)
-			cfg.ExtensionConfigReadonly[extName] = value == "true"
-		}
-
-		// Check for ADDT_<EXT>_WORKDIR_AUTOTRUST pattern
-		if strings.HasPrefix(key, "ADDT_") && strings.HasSuffix(key, "_WORKDIR_AUTOTRUST") {
-			extName := strings.TrimPrefix(key, "ADDT_")
-			extName = strings.TrimSuffix(extName, "_WORKDIR_AUTOTRUST")
-			extName = strings.ToLower(extName)
-			cfg.ExtensionWorkdirAutotrust[extName] = value == "true"
-		}
-
-		// Check for ADDT_<EXT>_AUTH_AUTOLOGIN pattern
-		if strings.HasPrefix(key, "ADDT_") && strings.HasSuffix(key, "_AUTH_AUTOLOGIN") {
-			extName := strings.TrimPrefix(key, "ADDT_")
-			extName = strings.TrimSuffix(extName, "_AUTH_AUTOLOGIN")
-			extName = strings.ToLower(extName)
-			cfg.ExtensionAuthAutologin[extName] = value == "true"
-		}
-
-		// Check for ADDT_<EXT>_AUTH_METHOD pattern
-		if strings.HasPrefix(key, "ADDT_") && strings.HasSuffix(key, "_AUTH_METHOD") {
-			extName := strings.TrimPrefix(key, "ADDT_")
-			extName = strings.TrimSuffix(extName, "_AUTH_METHOD")
-			extName = strings.ToLower(extName)
-			cfg.ExtensionAuthMethod[extName] = value
-		}
-	}
-
-	// Set default version for claude if not specified
-	if _, exists := cfg.ExtensionVersions["claude"]; !exists {
-		cfg.ExtensionVersions["claude"] = "stable"
-	}
+	// Per-extension settings, flag settings and firewall rules
+	loadExtensionConfig(cfg, globalCfg, projectCfg)
 
 	// Ports expose: global -> project -> env
 	if globalCfg.Ports != nil && len(globalCfg.Ports.Expose) > 0 {
@@ -784,11 +186,6 @@ func LoadConfig(addtVersion, defaultNodeVersion, defaultGoVersion, defaultUvVers
 		cfg.Ports = nil
 	}
 
-	// Trim env vars
-	for i := range cfg.EnvVars {
-		cfg.EnvVars[i] = strings.TrimSpace(cfg.EnvVars[i])
-	}
-
 	// Load security configuration using the security package
 	cfg.Security = security.LoadConfig(globalCfg.Security, projectCfg.Security)
 
@@ -0,0 +1,135 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/jedi4ever/addt/provider"
+)
+
+// loadContainerConfig resolves the container.* settings and persistent
+func loadContainerConfig(cfg *Config, globalCfg, projectCfg *GlobalConfig) {
+	// Persistent: default (false) -> global -> project -> env
+	cfg.Persistent = false
+	if globalCfg.Persistent != nil {
+		cfg.Persistent = *globalCfg.Persistent
+	}
+	if projectCfg.Persistent != nil {
+		cfg.Persistent = *projectCfg.Persistent
+	}
+	if v := os.Getenv("ADDT_PERSISTENT"); v != "" {
+		cfg.Persistent = v == "true"
+	}
+
+	// Container CPUs: default (2) -> global -> project -> env
+	cfg.ContainerCPUs = "2" // Secure default: limit CPU usage
+	if globalCfg.Container != nil && globalCfg.Container.CPUs != "" {
+		cfg.ContainerCPUs = globalCfg.Container.CPUs
+	}
+	if projectCfg.Container != nil && projectCfg.Container.CPUs != "" {
+		cfg.ContainerCPUs = projectCfg.Container.CPUs
+	}
+	if v := os.Getenv("ADDT_CONTAINER_CPUS"); v != "" {
+		cfg.ContainerCPUs = v
+	}
+
+	// Container Memory: default (4g) -> global -> project -> env
+	cfg.ContainerMemory = "4g" // Secure default: limit memory usage
+	if globalCfg.Container != nil && globalCfg.Container.Memory != "" {
+		cfg.ContainerMemory = globalCfg.Container.Memory
+	}
+	if projectCfg.Container != nil && projectCfg.Container.Memory != "" {
+		cfg.ContainerMemory = projectCfg.Container.Memory
+	}
+	if v := os.Getenv("ADDT_CONTAINER_MEMORY"); v != "" {
+		cfg.ContainerMemory = v
+	}
+
+	// Container max age: default ("" = disabled) -> global -> project -> env
+	if globalCfg.Container != nil && globalCfg.Container.MaxAge != "" {
+		cfg.ContainerMaxAge = globalCfg.Container.MaxAge
+	}
+	if projectCfg.Container != nil && projectCfg.Container.MaxAge != "" {
+		cfg.ContainerMaxAge = projectCfg.Container.MaxAge
+	}
+	if v := os.Getenv("ADDT_CONTAINER_MAX_AGE"); v != "" {
+		cfg.ContainerMaxAge = v
+	}
+
+	// Container detach keys: default ("" = runtime default) -> global -> project -> env
+	if globalCfg.Container != nil && globalCfg.Container.DetachKeys != "" {
+		cfg.ContainerDetachKeys = globalCfg.Container.DetachKeys
+	}
+	if projectCfg.Container != nil && projectCfg.Container.DetachKeys != "" {
+		cfg.ContainerDetachKeys = projectCfg.Container.DetachKeys
+	}
+	if v := os.Getenv("ADDT_CONTAINER_DETACH_KEYS"); v != "" {
+		cfg.ContainerDetachKeys = v
+	}
+
+	// Container entrypoint: default ("" = image entrypoint) -> global -> project -> env
+	if globalCfg.Container != nil && globalCfg.Container.Entrypoint != "" {
+		cfg.ContainerEntrypoint = globalCfg.Container.Entrypoint
+	}
+	if projectCfg.Container != nil && projectCfg.Container.Entrypoint != "" {
+		cfg.ContainerEntrypoint = projectCfg.Container.Entrypoint
+	}
+	if v := os.Getenv("ADDT_CONTAINER_ENTRYPOINT"); v != "" {
+		cfg.ContainerEntrypoint = v
+	}
+
+	// Container platform: default ("" = host platform) -> global -> project -> env
+	if globalCfg.Container != nil && globalCfg.Container.Platform != "" {
+		cfg.ContainerPlatform = globalCfg.Container.Platform
+	}
+	if projectCfg.Container != nil && projectCfg.Container.Platform != "" {
+		cfg.ContainerPlatform = projectCfg.Container.Platform
+	}
+	if v := os.Getenv("ADDT_CONTAINER_PLATFORM"); v != "" {
+		cfg.ContainerPlatform = v
+	}
+
+	// Container init: default (true) -> global -> project -> env
+	cfg.ContainerInit = true
+	if globalCfg.Container != nil && globalCfg.Container.Init != nil {
+		cfg.ContainerInit = *globalCfg.Container.Init
+	}
+	if projectCfg.Container != nil && projectCfg.Container.Init != nil {
+		cfg.ContainerInit = *projectCfg.Container.Init
+	}
+	if v := os.Getenv("ADDT_CONTAINER_INIT"); v != "" {
+		cfg.ContainerInit = v == "true"
+	}
+
+	// Container name: default ("" = generated per workdir and extensions) -> global -> project -> env
+	if globalCfg.Container != nil && globalCfg.Container.Name != "" {
+		cfg.ContainerName = globalCfg.Container.Name
+	}
+	if projectCfg.Container != nil && projectCfg.Container.Name != "" {
+		cfg.ContainerName = projectCfg.Container.Name
+	}
+	if v := os.Getenv("ADDT_CONTAINER_NAME"); v != "" {
+		cfg.ContainerName = v
+	}
+	if err := provider.ValidateContainerName(cfg.ContainerName); cfg.ContainerName != "" && err != nil {
+		fmt.Printf("Warning: container.name: %v, using the generated name\n", err)
+		cfg.ContainerName = ""
+	}
+
+	// Container env precedence: default (env-wins) -> global -> project -> env
+	cfg.ContainerEnvPrecedence = provider.EnvPrecedenceEnvWins
+	if globalCfg.Container != nil && globalCfg.Container.EnvPrecedence != "" {
+		cfg.ContainerEnvPrecedence = globalCfg.Container.EnvPrecedence
+	}
+	if projectCfg.Container != nil && projectCfg.Container.EnvPrecedence != "" {
+		cfg.ContainerEnvPrecedence = projectCfg.Container.EnvPrecedence
+	}
+	if v := os.Getenv("ADDT_CONTAINER_ENV_PRECEDENCE"); v != "" {
+		cfg.ContainerEnvPrecedence = v
+	}
+	if !slices.Contains(provider.EnvPrecedences, cfg.ContainerEnvPrecedence) {
+		fmt.Printf("Warning: container.env_precedence: unknown value %q, using %s\n", cfg.ContainerEnvPrecedence, provider.EnvPrecedenceEnvWins)
+		cfg.ContainerEnvPrecedence = provider.EnvPrecedenceEnvWins
+	}
+}
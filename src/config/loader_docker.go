@@ -0,0 +1,85 @@
+package config
+
+import (
+	"os"
+)
+
+// loadDockerConfig resolves the docker.* settings
+func loadDockerConfig(cfg *Config, globalCfg, projectCfg *GlobalConfig) {
+	// DinD mode: default -> global -> project -> env
+	if globalCfg.Docker != nil && globalCfg.Docker.Dind != nil {
+		cfg.DockerDindMode = globalCfg.Docker.Dind.Mode
+	}
+	if projectCfg.Docker != nil && projectCfg.Docker.Dind != nil && projectCfg.Docker.Dind.Mode != "" {
+		cfg.DockerDindMode = projectCfg.Docker.Dind.Mode
+	}
+	if v := os.Getenv("ADDT_DOCKER_DIND_MODE"); v != "" {
+		cfg.DockerDindMode = v
+	}
+
+	// Docker config forwarding: default (false) -> global -> project -> env
+	cfg.DockerForwardConfig = false
+	if globalCfg.Docker != nil && globalCfg.Docker.ForwardConfig != nil {
+		cfg.DockerForwardConfig = *globalCfg.Docker.ForwardConfig
+	}
+	if projectCfg.Docker != nil && projectCfg.Docker.ForwardConfig != nil {
+		cfg.DockerForwardConfig = *projectCfg.Docker.ForwardConfig
+	}
+	if v := os.Getenv("ADDT_DOCKER_FORWARD_CONFIG"); v != "" {
+		cfg.DockerForwardConfig = v == "true"
+	}
+
+	// Docker config path: default ("") -> global -> project -> env
+	cfg.DockerConfigPath = ""
+	if globalCfg.Docker != nil && globalCfg.Docker.ConfigPath != "" {
+		cfg.DockerConfigPath = globalCfg.Docker.ConfigPath
+	}
+	if projectCfg.Docker != nil && projectCfg.Docker.ConfigPath != "" {
+		cfg.DockerConfigPath = projectCfg.Docker.ConfigPath
+	}
+	if v := os.Getenv("ADDT_DOCKER_CONFIG_PATH"); v != "" {
+		cfg.DockerConfigPath = v
+	}
+
+	// Docker build timeout: default (60m) -> global -> project -> env
+	cfg.DockerBuildTimeout = "60m"
+	if globalCfg.Docker != nil && globalCfg.Docker.BuildTimeout != "" {
+		cfg.DockerBuildTimeout = globalCfg.Docker.BuildTimeout
+	}
+	if projectCfg.Docker != nil && projectCfg.Docker.BuildTimeout != "" {
+		cfg.DockerBuildTimeout = projectCfg.Docker.BuildTimeout
+	}
+	if v := os.Getenv("ADDT_DOCKER_BUILD_TIMEOUT"); v != "" {
+		cfg.DockerBuildTimeout = v
+	}
+
+	// Docker pull policy: default (missing) -> global -> project -> env
+	cfg.DockerPullPolicy = "missing"
+	if globalCfg.Docker != nil && globalCfg.Docker.PullPolicy != "" {
+		cfg.DockerPullPolicy = globalCfg.Docker.PullPolicy
+	}
+	if projectCfg.Docker != nil && projectCfg.Docker.PullPolicy != "" {
+		cfg.DockerPullPolicy = projectCfg.Docker.PullPolicy
+	}
+	if v := os.Getenv("ADDT_DOCKER_PULL_POLICY"); v != "" {
+		cfg.DockerPullPolicy = v
+	}
+
+	// CPU/NUMA pinning: global -> project -> env
+	if globalCfg.Docker != nil {
+		cfg.DockerCpusetCPUs = globalCfg.Docker.CpusetCPUs
+		cfg.DockerCpusetMems = globalCfg.Docker.CpusetMems
+	}
+	if projectCfg.Docker != nil && projectCfg.Docker.CpusetCPUs != "" {
+		cfg.DockerCpusetCPUs = projectCfg.Docker.CpusetCPUs
+	}
+	if projectCfg.Docker != nil && projectCfg.Docker.CpusetMems != "" {
+		cfg.DockerCpusetMems = projectCfg.Docker.CpusetMems
+	}
+	if v := os.Getenv("ADDT_DOCKER_CPUSET_CPUS"); v != "" {
+		cfg.DockerCpusetCPUs = v
+	}
+	if v := os.Getenv("ADDT_DOCKER_CPUSET_MEMS"); v != "" {
+		cfg.DockerCpusetMems = v
+	}
+}
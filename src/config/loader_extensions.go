@@ -0,0 +1,188 @@
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+// loadExtensionConfig resolves the config.* and auth.* defaults and the
+// per-extension settings under extensions.<name> and ADDT_<EXT>_*.
+// Precedence: global config < project config < environment variables
+func loadExtensionConfig(cfg *Config, globalCfg, projectCfg *GlobalConfig) {
+	// Config automount: default (false) -> global -> project -> env
+	cfg.ConfigAutomount = false
+	if globalCfg.Config != nil && globalCfg.Config.Automount != nil {
+		cfg.ConfigAutomount = *globalCfg.Config.Automount
+	}
+	if projectCfg.Config != nil && projectCfg.Config.Automount != nil {
+		cfg.ConfigAutomount = *projectCfg.Config.Automount
+	}
+	if v := os.Getenv("ADDT_CONFIG_AUTOMOUNT"); v != "" {
+		cfg.ConfigAutomount = v == "true"
+	}
+
+	// Config readonly: default (false) -> global -> project -> env
+	cfg.ConfigReadonly = false
+	if globalCfg.Config != nil && globalCfg.Config.Readonly != nil {
+		cfg.ConfigReadonly = *globalCfg.Config.Readonly
+	}
+	if projectCfg.Config != nil && projectCfg.Config.Readonly != nil {
+		cfg.ConfigReadonly = *projectCfg.Config.Readonly
+	}
+	if v := os.Getenv("ADDT_CONFIG_READONLY"); v != "" {
+		cfg.ConfigReadonly = v == "true"
+	}
+
+	// Auth autologin: default (true) -> global -> project -> env
+	cfg.AuthAutologin = true
+	if globalCfg.Auth != nil && globalCfg.Auth.Autologin != nil {
+		cfg.AuthAutologin = *globalCfg.Auth.Autologin
+	}
+	if projectCfg.Auth != nil && projectCfg.Auth.Autologin != nil {
+		cfg.AuthAutologin = *projectCfg.Auth.Autologin
+	}
+	if v := os.Getenv("ADDT_AUTH_AUTOLOGIN"); v != "" {
+		cfg.AuthAutologin = v == "true"
+	}
+
+	// Auth method: default (auto) -> global -> project -> env
+	cfg.AuthMethod = "auto"
+	if globalCfg.Auth != nil && globalCfg.Auth.Method != "" {
+		cfg.AuthMethod = globalCfg.Auth.Method
+	}
+	if projectCfg.Auth != nil && projectCfg.Auth.Method != "" {
+		cfg.AuthMethod = projectCfg.Auth.Method
+	}
+	if v := os.Getenv("ADDT_AUTH_METHOD"); v != "" {
+		cfg.AuthMethod = v
+	}
+
+	// Load per-extension config from config files
+	// Precedence: global config < project config < environment variables
+	if globalCfg.Extensions != nil {
+		for extName, extCfg := range globalCfg.Extensions {
+			if extCfg.Version != "" {
+				cfg.ExtensionVersions[extName] = extCfg.Version
+			}
+			if extCfg.Config != nil && extCfg.Config.Automount != nil {
+				cfg.ExtensionConfigAutomount[extName] = *extCfg.Config.Automount
+			}
+			if extCfg.Config != nil && extCfg.Config.Readonly != nil {
+				cfg.ExtensionConfigReadonly[extName] = *extCfg.Config.Readonly
+			}
+			if extCfg.Workdir != nil && extCfg.Workdir.Autotrust != nil {
+				cfg.ExtensionWorkdirAutotrust[extName] = *extCfg.Workdir.Autotrust
+			}
+			if extCfg.Auth != nil && extCfg.Auth.Autologin != nil {
+				cfg.ExtensionAuthAutologin[extName] = *extCfg.Auth.Autologin
+			}
+			if extCfg.Auth != nil && extCfg.Auth.Method != "" {
+				cfg.ExtensionAuthMethod[extName] = extCfg.Auth.Method
+			}
+		}
+	}
+	if projectCfg.Extensions != nil {
+		for extName, extCfg := range projectCfg.Extensions {
+			if extCfg.Version != "" {
+				cfg.ExtensionVersions[extName] = extCfg.Version
+			}
+			if extCfg.Config != nil && extCfg.Config.Automount != nil {
+				cfg.ExtensionConfigAutomount[extName] = *extCfg.Config.Automount
+			}
+			if extCfg.Config != nil && extCfg.Config.Readonly != nil {
+				cfg.ExtensionConfigReadonly[extName] = *extCfg.Config.Readonly
+			}
+			if extCfg.Workdir != nil && extCfg.Workdir.Autotrust != nil {
+				cfg.ExtensionWorkdirAutotrust[extName] = *extCfg.Workdir.Autotrust
+			}
+			if extCfg.Auth != nil && extCfg.Auth.Autologin != nil {
+				cfg.ExtensionAuthAutologin[extName] = *extCfg.Auth.Autologin
+			}
+			if extCfg.Auth != nil && extCfg.Auth.Method != "" {
+				cfg.ExtensionAuthMethod[extName] = extCfg.Auth.Method
+			}
+		}
+	}
+
+	// Load per-extension flag settings from config files
+	// Precedence: global config < project config < env vars
+	resolveExtensionFlagSettings(cfg, globalCfg, projectCfg)
+
+	// Load extension-specific firewall rules based on ADDT_EXTENSIONS
+	// Extension firewall rules come from the extension's config.yaml and
+	// the global config under extensions.<name>
+	currentExt := os.Getenv("ADDT_EXTENSIONS")
+	if currentExt != "" {
+		// Use first extension if multiple specified
+		extName := strings.Split(currentExt, ",")[0]
+		cfg.ExtensionFirewallAllowed, cfg.ExtensionFirewallDenied = resolveExtensionFirewall(extName, globalCfg)
+	}
+
+	// Load per-extension versions and mount configs from environment (overrides config files)
+	// Pattern: ADDT_<EXT>_VERSION and ADDT_<EXT>_AUTOMOUNT
+	for _, env := range os.Environ() {
+		parts := strings.SplitN(env, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		key, value := parts[0], parts[1]
+
+		// Check for ADDT_<EXT>_VERSION pattern
+		if strings.HasPrefix(key, "ADDT_") && strings.HasSuffix(key, "_VERSION") {
+			// Extract extension name (e.g., "ADDT_CLAUDE_VERSION" -> "claude")
+			extName := strings.TrimPrefix(key, "ADDT_")
+			extName = strings.TrimSuffix(extName, "_VERSION")
+			extName = strings.ToLower(extName)
+			// Skip non-extension versions (node, go, uv)
+			if extName != "node" && extName != "go" && extName != "uv" {
+				cfg.ExtensionVersions[extName] = value
+			}
+		}
+
+		// Check for ADDT_<EXT>_CONFIG_AUTOMOUNT pattern
+		if strings.HasPrefix(key, "ADDT_") && strings.HasSuffix(key, "_CONFIG_AUTOMOUNT") {
+			// Extract extension name (e.g., "ADDT_CLAUDE_CONFIG_AUTOMOUNT" -> "claude")
+			extName := strings.TrimPrefix(key, "ADDT_")
+			extName = strings.TrimSuffix(extName, "_CONFIG_AUTOMOUNT")
+			extName = strings.ToLower(extName)
+			cfg.ExtensionConfigAutomount[extName] = value != "false"
+		}
+
+		// Check for ADDT_<EXT>_CONFIG_READONLY pattern
+		if strings.HasPrefix(key, "ADDT_") && strings.HasSuffix(key, "_CONFIG_READONLY") {
+			extName := strings.TrimPrefix(key, "ADDT_")
+			extName = strings.TrimSuffix(extName, "_CONFIG_READONLY")
+			extName = strings.ToLower(extName)
+			cfg.ExtensionConfigReadonly[extName] = value == "true"
+		}
+
+		// Check for ADDT_<EXT>_WORKDIR_AUTOTRUST pattern
+		if strings.HasPrefix(key, "ADDT_") && strings.HasSuffix(key, "_WORKDIR_AUTOTRUST") {
+			extName := strings.TrimPrefix(key, "ADDT_")
+			extName = strings.TrimSuffix(extName, "_WORKDIR_AUTOTRUST")
+			extName = strings.ToLower(extName)
+			cfg.ExtensionWorkdirAutotrust[extName] = value == "true"
+		}
+
+		// Check for ADDT_<EXT>_AUTH_AUTOLOGIN pattern
+		if strings.HasPrefix(key, "ADDT_") && strings.HasSuffix(key, "_AUTH_AUTOLOGIN") {
+			extName := strings.TrimPrefix(key, "ADDT_")
+			extName = strings.TrimSuffix(extName, "_AUTH_AUTOLOGIN")
+			extName = strings.ToLower(extName)
+			cfg.ExtensionAuthAutologin[extName] = value == "true"
+		}
+
+		// Check for ADDT_<EXT>_AUTH_METHOD pattern
+		if strings.HasPrefix(key, "ADDT_") && strings.HasSuffix(key, "_AUTH_METHOD") {
+			extName := strings.TrimPrefix(key, "ADDT_")
+			extName = strings.TrimSuffix(extName, "_AUTH_METHOD")
+			extName = strings.ToLower(extName)
+			cfg.ExtensionAuthMethod[extName] = value
+		}
+	}
+
+	// Set default version for claude if not specified
+	if _, exists := cfg.ExtensionVersions["claude"]; !exists {
+		cfg.ExtensionVersions["claude"] = "stable"
+	}
+}
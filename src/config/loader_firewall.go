@@ -0,0 +1,99 @@
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+// loadFirewallConfig resolves the firewall.* settings and the global and
+// project rule layers. Extension rules are resolved in loadExtensionConfig.
+func loadFirewallConfig(cfg *Config, globalCfg, projectCfg *GlobalConfig) {
+	// Firewall: default (false) -> global -> project -> env
+	cfg.FirewallEnabled = false
+	if globalCfg.Firewall != nil && globalCfg.Firewall.Enabled != nil {
+		cfg.FirewallEnabled = *globalCfg.Firewall.Enabled
+	}
+	if projectCfg.Firewall != nil && projectCfg.Firewall.Enabled != nil {
+		cfg.FirewallEnabled = *projectCfg.Firewall.Enabled
+	}
+	if v := os.Getenv("ADDT_FIREWALL"); v != "" {
+		cfg.FirewallEnabled = v == "true"
+	}
+
+	// Firewall mode: default (strict) -> global -> project -> env
+	cfg.FirewallMode = "strict"
+	if globalCfg.Firewall != nil && globalCfg.Firewall.Mode != "" {
+		cfg.FirewallMode = globalCfg.Firewall.Mode
+	}
+	if projectCfg.Firewall != nil && projectCfg.Firewall.Mode != "" {
+		cfg.FirewallMode = projectCfg.Firewall.Mode
+	}
+	if v := os.Getenv("ADDT_FIREWALL_MODE"); v != "" {
+		cfg.FirewallMode = v
+	}
+
+	// Firewall require pasta: default (false) -> global -> project -> env
+	cfg.FirewallRequirePasta = false
+	if globalCfg.Firewall != nil && globalCfg.Firewall.RequirePasta != nil {
+		cfg.FirewallRequirePasta = *globalCfg.Firewall.RequirePasta
+	}
+	if projectCfg.Firewall != nil && projectCfg.Firewall.RequirePasta != nil {
+		cfg.FirewallRequirePasta = *projectCfg.Firewall.RequirePasta
+	}
+	if v := os.Getenv("ADDT_FIREWALL_REQUIRE_PASTA"); v != "" {
+		cfg.FirewallRequirePasta = v == "true"
+	}
+
+	// Firewall allow network override: default (false) -> global -> project -> env
+	cfg.FirewallNetworkOverride = false
+	if globalCfg.Firewall != nil && globalCfg.Firewall.AllowNetworkOverride != nil {
+		cfg.FirewallNetworkOverride = *globalCfg.Firewall.AllowNetworkOverride
+	}
+	if projectCfg.Firewall != nil && projectCfg.Firewall.AllowNetworkOverride != nil {
+		cfg.FirewallNetworkOverride = *projectCfg.Firewall.AllowNetworkOverride
+	}
+	if v := os.Getenv("ADDT_FIREWALL_ALLOW_NETWORK_OVERRIDE"); v != "" {
+		cfg.FirewallNetworkOverride = v == "true"
+	}
+
+	// Firewall blocked-connection log: default (false) -> global -> project -> env
+	if globalCfg.Firewall != nil && globalCfg.Firewall.LogBlocked != nil {
+		cfg.FirewallLogBlocked = *globalCfg.Firewall.LogBlocked
+	}
+	if projectCfg.Firewall != nil && projectCfg.Firewall.LogBlocked != nil {
+		cfg.FirewallLogBlocked = *projectCfg.Firewall.LogBlocked
+	}
+	if v := os.Getenv("ADDT_FIREWALL_LOG_BLOCKED"); v != "" {
+		cfg.FirewallLogBlocked = v == "true"
+	}
+
+	// Async firewall init: default (false) -> global -> project -> env
+	if globalCfg.Firewall != nil && globalCfg.Firewall.AsyncInit != nil {
+		cfg.FirewallAsyncInit = *globalCfg.Firewall.AsyncInit
+	}
+	if projectCfg.Firewall != nil && projectCfg.Firewall.AsyncInit != nil {
+		cfg.FirewallAsyncInit = *projectCfg.Firewall.AsyncInit
+	}
+	if v := os.Getenv("ADDT_FIREWALL_ASYNC"); v != "" {
+		cfg.FirewallAsyncInit = v == "true"
+	}
+
+	// Firewall rules: keep each layer separate for layered override evaluation
+	// Order: Defaults → Extension → Global → Project (project wins)
+	// Presets expand into the allowed domains of the layer that lists them;
+	// ADDT_FIREWALL_PRESETS adds to the project layer.
+	if globalCfg.Firewall != nil {
+		cfg.GlobalFirewallAllowed = resolveFirewallAllowed(globalCfg.Firewall.Allowed, globalCfg.Firewall.Presets)
+		cfg.GlobalFirewallDenied = globalCfg.Firewall.Denied
+	}
+	var projectPresets []string
+	if projectCfg.Firewall != nil {
+		cfg.ProjectFirewallAllowed = projectCfg.Firewall.Allowed
+		cfg.ProjectFirewallDenied = projectCfg.Firewall.Denied
+		projectPresets = projectCfg.Firewall.Presets
+	}
+	if v := os.Getenv("ADDT_FIREWALL_PRESETS"); v != "" {
+		projectPresets = append(append([]string(nil), projectPresets...), strings.Split(v, ",")...)
+	}
+	cfg.ProjectFirewallAllowed = resolveFirewallAllowed(cfg.ProjectFirewallAllowed, projectPresets)
+}
@@ -0,0 +1,188 @@
+package config
+
+import (
+	"os"
+	"strings"
+
+	"github.com/jedi4ever/addt/util"
+)
+
+// loadForwardConfig resolves what is forwarded from the host: SSH, files,
+// volumes, tmux, shell history, persisted home subdirs, terminal and GPG
+func loadForwardConfig(cfg *Config, globalCfg, projectCfg *GlobalConfig) {
+	// SSH forward keys: default (false) -> global -> project -> env
+	cfg.SSHForwardKeys = false
+	cfg.SSHForwardMode = "proxy"
+	if globalCfg.SSH != nil {
+		if globalCfg.SSH.ForwardKeys != nil {
+			cfg.SSHForwardKeys = *globalCfg.SSH.ForwardKeys
+		}
+		if globalCfg.SSH.ForwardMode != "" {
+			cfg.SSHForwardMode = globalCfg.SSH.ForwardMode
+		}
+		if len(globalCfg.SSH.AllowedKeys) > 0 {
+			cfg.SSHAllowedKeys = globalCfg.SSH.AllowedKeys
+		}
+	}
+	if projectCfg.SSH != nil {
+		if projectCfg.SSH.ForwardKeys != nil {
+			cfg.SSHForwardKeys = *projectCfg.SSH.ForwardKeys
+		}
+		if projectCfg.SSH.ForwardMode != "" {
+			cfg.SSHForwardMode = projectCfg.SSH.ForwardMode
+		}
+		if len(projectCfg.SSH.AllowedKeys) > 0 {
+			cfg.SSHAllowedKeys = projectCfg.SSH.AllowedKeys
+		}
+	}
+	if v := os.Getenv("ADDT_SSH_FORWARD_KEYS"); v != "" {
+		cfg.SSHForwardKeys = v == "true"
+	}
+	if v := os.Getenv("ADDT_SSH_FORWARD_MODE"); v != "" {
+		cfg.SSHForwardMode = v
+	}
+	if v := os.Getenv("ADDT_SSH_ALLOWED_KEYS"); v != "" {
+		cfg.SSHAllowedKeys = strings.Split(v, ",")
+	}
+
+	// SSH dir: default ("") -> global -> project -> env
+	cfg.SSHDir = ""
+	if globalCfg.SSH != nil && globalCfg.SSH.Dir != "" {
+		cfg.SSHDir = globalCfg.SSH.Dir
+	}
+	if projectCfg.SSH != nil && projectCfg.SSH.Dir != "" {
+		cfg.SSHDir = projectCfg.SSH.Dir
+	}
+	if v := os.Getenv("ADDT_SSH_DIR"); v != "" {
+		cfg.SSHDir = v
+	}
+	cfg.SSHDir = util.ExpandTilde(cfg.SSHDir)
+
+	// Extra SSH dirs: default (none) -> global -> project -> env
+	if globalCfg.SSH != nil && len(globalCfg.SSH.Dirs) > 0 {
+		cfg.SSHDirs = globalCfg.SSH.Dirs
+	}
+	if projectCfg.SSH != nil && len(projectCfg.SSH.Dirs) > 0 {
+		cfg.SSHDirs = projectCfg.SSH.Dirs
+	}
+	if v := os.Getenv("ADDT_SSH_DIRS"); v != "" {
+		cfg.SSHDirs = strings.Split(v, ",")
+	}
+
+	// Forwarded files: default (none) -> global -> project -> env
+	cfg.ForwardFiles = loadForwardFiles(globalCfg, projectCfg)
+
+	// Extra volumes: default (none) -> global -> project -> env
+	cfg.Volumes = nil
+	if len(globalCfg.Volumes) > 0 {
+		cfg.Volumes = globalCfg.Volumes
+	}
+	if len(projectCfg.Volumes) > 0 {
+		cfg.Volumes = projectCfg.Volumes
+	}
+	if v := os.Getenv("ADDT_VOLUMES"); v != "" {
+		cfg.Volumes = strings.Split(v, ",")
+	}
+
+	// Tmux forward: default (false) -> global -> project -> env
+	cfg.TmuxForward = false
+	if globalCfg.TmuxForward != nil {
+		cfg.TmuxForward = *globalCfg.TmuxForward
+	}
+	if projectCfg.TmuxForward != nil {
+		cfg.TmuxForward = *projectCfg.TmuxForward
+	}
+	if v := os.Getenv("ADDT_TMUX_FORWARD"); v != "" {
+		cfg.TmuxForward = v == "true"
+	}
+
+	// History persist: default (false) -> global -> project -> env
+	cfg.HistoryPersist = false
+	if globalCfg.HistoryPersist != nil {
+		cfg.HistoryPersist = *globalCfg.HistoryPersist
+	}
+	if projectCfg.HistoryPersist != nil {
+		cfg.HistoryPersist = *projectCfg.HistoryPersist
+	}
+	if v := os.Getenv("ADDT_HISTORY_PERSIST"); v != "" {
+		cfg.HistoryPersist = v == "true"
+	}
+
+	// History dir: default (~/.addt/history) -> global -> project -> env
+	cfg.HistoryDir = ""
+	if globalCfg.History != nil && globalCfg.History.Dir != "" {
+		cfg.HistoryDir = globalCfg.History.Dir
+	}
+	if projectCfg.History != nil && projectCfg.History.Dir != "" {
+		cfg.HistoryDir = projectCfg.History.Dir
+	}
+	if v := os.Getenv("ADDT_HISTORY_DIR"); v != "" {
+		cfg.HistoryDir = v
+	}
+
+	// Persisted home subdirs: default (none) -> global -> project -> env
+	if globalCfg.Home != nil && len(globalCfg.Home.PersistSubdirs) > 0 {
+		cfg.HomePersistSubdirs = globalCfg.Home.PersistSubdirs
+	}
+	if projectCfg.Home != nil && len(projectCfg.Home.PersistSubdirs) > 0 {
+		cfg.HomePersistSubdirs = projectCfg.Home.PersistSubdirs
+	}
+	if v := os.Getenv("ADDT_HOME_PERSIST_SUBDIRS"); v != "" {
+		cfg.HomePersistSubdirs = strings.Split(v, ",")
+	}
+
+	// Terminal OSC: default (false) -> global -> project -> env
+	cfg.TerminalOSC = false
+	if globalCfg.Terminal != nil && globalCfg.Terminal.OSC != nil {
+		cfg.TerminalOSC = *globalCfg.Terminal.OSC
+	}
+	if projectCfg.Terminal != nil && projectCfg.Terminal.OSC != nil {
+		cfg.TerminalOSC = *projectCfg.Terminal.OSC
+	}
+	if v := os.Getenv("ADDT_TERMINAL_OSC"); v != "" {
+		cfg.TerminalOSC = v == "true"
+	}
+
+	// GPG forward: default (off) -> global -> project -> env
+	cfg.GPGForward = ""
+	if globalCfg.GPG != nil && globalCfg.GPG.Forward != "" {
+		cfg.GPGForward = globalCfg.GPG.Forward
+	}
+	if projectCfg.GPG != nil && projectCfg.GPG.Forward != "" {
+		cfg.GPGForward = projectCfg.GPG.Forward
+	}
+	if v := os.Getenv("ADDT_GPG_FORWARD"); v != "" {
+		// Support legacy boolean values
+		if v == "true" {
+			cfg.GPGForward = "keys"
+		} else if v == "false" {
+			cfg.GPGForward = ""
+		} else {
+			cfg.GPGForward = v
+		}
+	}
+
+	// GPG allowed key IDs: global -> project -> env
+	if globalCfg.GPG != nil {
+		cfg.GPGAllowedKeyIDs = globalCfg.GPG.AllowedKeyIDs
+	}
+	if projectCfg.GPG != nil && len(projectCfg.GPG.AllowedKeyIDs) > 0 {
+		cfg.GPGAllowedKeyIDs = projectCfg.GPG.AllowedKeyIDs
+	}
+	if v := os.Getenv("ADDT_GPG_ALLOWED_KEY_IDS"); v != "" {
+		cfg.GPGAllowedKeyIDs = strings.Split(v, ",")
+	}
+
+	// GPG dir: default ("") -> global -> project -> env
+	cfg.GPGDir = ""
+	if globalCfg.GPG != nil && globalCfg.GPG.Dir != "" {
+		cfg.GPGDir = globalCfg.GPG.Dir
+	}
+	if projectCfg.GPG != nil && projectCfg.GPG.Dir != "" {
+		cfg.GPGDir = projectCfg.GPG.Dir
+	}
+	if v := os.Getenv("ADDT_GPG_DIR"); v != "" {
+		cfg.GPGDir = v
+	}
+	cfg.GPGDir = util.ExpandTilde(cfg.GPGDir)
+}
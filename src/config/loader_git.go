@@ -0,0 +1,117 @@
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+// loadGitConfig resolves the git.* and github.* settings
+func loadGitConfig(cfg *Config, globalCfg, projectCfg *GlobalConfig) {
+	// GitHub forward token: default (false) -> global -> project -> env
+	cfg.GitHubForwardToken = false
+	if globalCfg.GitHub != nil && globalCfg.GitHub.ForwardToken != nil {
+		cfg.GitHubForwardToken = *globalCfg.GitHub.ForwardToken
+	}
+	if projectCfg.GitHub != nil && projectCfg.GitHub.ForwardToken != nil {
+		cfg.GitHubForwardToken = *projectCfg.GitHub.ForwardToken
+	}
+	if v := os.Getenv("ADDT_GITHUB_FORWARD_TOKEN"); v != "" {
+		cfg.GitHubForwardToken = v == "true"
+	}
+
+	// Git disable hooks: default (true) -> global -> project -> env
+	cfg.GitDisableHooks = true
+	if globalCfg.Git != nil && globalCfg.Git.DisableHooks != nil {
+		cfg.GitDisableHooks = *globalCfg.Git.DisableHooks
+	}
+	if projectCfg.Git != nil && projectCfg.Git.DisableHooks != nil {
+		cfg.GitDisableHooks = *projectCfg.Git.DisableHooks
+	}
+	if v := os.Getenv("ADDT_GIT_DISABLE_HOOKS"); v != "" {
+		cfg.GitDisableHooks = v == "true"
+	}
+
+	// Git forward config: default (true) -> global -> project -> env
+	cfg.GitForwardConfig = true
+	if globalCfg.Git != nil && globalCfg.Git.ForwardConfig != nil {
+		cfg.GitForwardConfig = *globalCfg.Git.ForwardConfig
+	}
+	if projectCfg.Git != nil && projectCfg.Git.ForwardConfig != nil {
+		cfg.GitForwardConfig = *projectCfg.Git.ForwardConfig
+	}
+	if v := os.Getenv("ADDT_GIT_FORWARD_CONFIG"); v != "" {
+		cfg.GitForwardConfig = v == "true"
+	}
+
+	// Git config path: default ("") -> global -> project -> env
+	cfg.GitConfigPath = ""
+	if globalCfg.Git != nil && globalCfg.Git.ConfigPath != "" {
+		cfg.GitConfigPath = globalCfg.Git.ConfigPath
+	}
+	if projectCfg.Git != nil && projectCfg.Git.ConfigPath != "" {
+		cfg.GitConfigPath = projectCfg.Git.ConfigPath
+	}
+	if v := os.Getenv("ADDT_GIT_CONFIG_PATH"); v != "" {
+		cfg.GitConfigPath = v
+	}
+
+	// Git config readonly: default (true) -> global -> project -> env
+	cfg.GitConfigReadonly = true
+	if globalCfg.Git != nil && globalCfg.Git.ConfigReadonly != nil {
+		cfg.GitConfigReadonly = *globalCfg.Git.ConfigReadonly
+	}
+	if projectCfg.Git != nil && projectCfg.Git.ConfigReadonly != nil {
+		cfg.GitConfigReadonly = *projectCfg.Git.ConfigReadonly
+	}
+	if v := os.Getenv("ADDT_GIT_CONFIG_READONLY"); v != "" {
+		cfg.GitConfigReadonly = v == "true"
+	}
+
+	// Git config copy: default (false) -> global -> project -> env
+	cfg.GitConfigCopy = false
+	if globalCfg.Git != nil && globalCfg.Git.ConfigCopy != nil {
+		cfg.GitConfigCopy = *globalCfg.Git.ConfigCopy
+	}
+	if projectCfg.Git != nil && projectCfg.Git.ConfigCopy != nil {
+		cfg.GitConfigCopy = *projectCfg.Git.ConfigCopy
+	}
+	if v := os.Getenv("ADDT_GIT_CONFIG_COPY"); v != "" {
+		cfg.GitConfigCopy = v == "true"
+	}
+
+	// GitHub token source: default ("gh_auth") -> global -> project -> env
+	cfg.GitHubTokenSource = "gh_auth"
+	if globalCfg.GitHub != nil && globalCfg.GitHub.TokenSource != "" {
+		cfg.GitHubTokenSource = globalCfg.GitHub.TokenSource
+	}
+	if projectCfg.GitHub != nil && projectCfg.GitHub.TokenSource != "" {
+		cfg.GitHubTokenSource = projectCfg.GitHub.TokenSource
+	}
+	if v := os.Getenv("ADDT_GITHUB_TOKEN_SOURCE"); v != "" {
+		cfg.GitHubTokenSource = v
+	}
+
+	// GitHub scope token: default (true) -> global -> project -> env
+	cfg.GitHubScopeToken = true
+	if globalCfg.GitHub != nil && globalCfg.GitHub.ScopeToken != nil {
+		cfg.GitHubScopeToken = *globalCfg.GitHub.ScopeToken
+	}
+	if projectCfg.GitHub != nil && projectCfg.GitHub.ScopeToken != nil {
+		cfg.GitHubScopeToken = *projectCfg.GitHub.ScopeToken
+	}
+	if v := os.Getenv("ADDT_GITHUB_SCOPE_TOKEN"); v != "" {
+		cfg.GitHubScopeToken = v == "true"
+	}
+
+	// GitHub scope repos: default ([]) -> global -> project -> env
+	cfg.GitHubScopeRepos = nil
+	if globalCfg.GitHub != nil && len(globalCfg.GitHub.ScopeRepos) > 0 {
+		cfg.GitHubScopeRepos = globalCfg.GitHub.ScopeRepos
+	}
+	if projectCfg.GitHub != nil && len(projectCfg.GitHub.ScopeRepos) > 0 {
+		cfg.GitHubScopeRepos = projectCfg.GitHub.ScopeRepos
+	}
+	if v := os.Getenv("ADDT_GITHUB_SCOPE_REPOS"); v != "" {
+		cfg.GitHubScopeRepos = strings.Split(v, ",")
+	}
+}
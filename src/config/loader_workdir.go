@@ -0,0 +1,66 @@
+package config
+
+import (
+	"os"
+)
+
+// loadWorkdirConfig resolves the workdir.* settings
+func loadWorkdirConfig(cfg *Config, globalCfg, projectCfg *GlobalConfig) {
+	// Workdir automount: default (true) -> global -> project -> env
+	cfg.WorkdirAutomount = true
+	if globalCfg.Workdir != nil && globalCfg.Workdir.Automount != nil {
+		cfg.WorkdirAutomount = *globalCfg.Workdir.Automount
+	}
+	if projectCfg.Workdir != nil && projectCfg.Workdir.Automount != nil {
+		cfg.WorkdirAutomount = *projectCfg.Workdir.Automount
+	}
+	if v := os.Getenv("ADDT_WORKDIR_AUTOMOUNT"); v != "" {
+		cfg.WorkdirAutomount = v != "false"
+	}
+
+	// Workdir readonly: default (false) -> global -> project -> env
+	cfg.WorkdirReadonly = false
+	if globalCfg.Workdir != nil && globalCfg.Workdir.Readonly != nil {
+		cfg.WorkdirReadonly = *globalCfg.Workdir.Readonly
+	}
+	if projectCfg.Workdir != nil && projectCfg.Workdir.Readonly != nil {
+		cfg.WorkdirReadonly = *projectCfg.Workdir.Readonly
+	}
+	if v := os.Getenv("ADDT_WORKDIR_READONLY"); v != "" {
+		cfg.WorkdirReadonly = v == "true"
+	}
+
+	// Workdir overlay: default (false) -> global -> project -> env
+	if globalCfg.Workdir != nil && globalCfg.Workdir.Overlay != nil {
+		cfg.WorkdirOverlay = *globalCfg.Workdir.Overlay
+	}
+	if projectCfg.Workdir != nil && projectCfg.Workdir.Overlay != nil {
+		cfg.WorkdirOverlay = *projectCfg.Workdir.Overlay
+	}
+	if v := os.Getenv("ADDT_WORKDIR_OVERLAY"); v != "" {
+		cfg.WorkdirOverlay = v == "true"
+	}
+
+	// Workdir autotrust: default (true) -> global -> project -> env
+	cfg.WorkdirAutotrust = true
+	if globalCfg.Workdir != nil && globalCfg.Workdir.Autotrust != nil {
+		cfg.WorkdirAutotrust = *globalCfg.Workdir.Autotrust
+	}
+	if projectCfg.Workdir != nil && projectCfg.Workdir.Autotrust != nil {
+		cfg.WorkdirAutotrust = *projectCfg.Workdir.Autotrust
+	}
+	if v := os.Getenv("ADDT_WORKDIR_AUTOTRUST"); v != "" {
+		cfg.WorkdirAutotrust = v == "true"
+	}
+
+	// Workdir path: default (empty = current dir) -> global -> project -> env
+	if globalCfg.Workdir != nil {
+		cfg.Workdir = globalCfg.Workdir.Path
+	}
+	if projectCfg.Workdir != nil && projectCfg.Workdir.Path != "" {
+		cfg.Workdir = projectCfg.Workdir.Path
+	}
+	if v := os.Getenv("ADDT_WORKDIR"); v != "" {
+		cfg.Workdir = v
+	}
+}
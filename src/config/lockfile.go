@@ -0,0 +1,97 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LockfileName is the project lockfile written by "addt run --lock"
+const LockfileName = ".addt.lock"
+
+// lockfileHeader is written above the lockfile content
+const lockfileHeader = "# Written by addt run --lock. Commit it and use addt run --frozen in CI.\n"
+
+// Lockfile pins the extension versions a project resolved, so dist-tags such
+// as "stable" can't move between a local run and CI
+type Lockfile struct {
+	Extensions map[string]LockedExtension `yaml:"extensions"`
+}
+
+// LockedExtension records the configured version and what it resolved to
+type LockedExtension struct {
+	Requested string `yaml:"requested"` // As configured, e.g. "stable"
+	Version   string `yaml:"version"`   // Resolved, e.g. "2.0.14"
+}
+
+// GetLockfilePath returns the path to .addt.lock in the current directory
+func GetLockfilePath() string {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(cwd, LockfileName)
+}
+
+// NewLockfile records requested and resolved versions for the given extensions
+func NewLockfile(exts []string, requested, resolved map[string]string) *Lockfile {
+	lock := &Lockfile{Extensions: make(map[string]LockedExtension)}
+	for _, ext := range exts {
+		lock.Extensions[ext] = LockedExtension{Requested: requested[ext], Version: resolved[ext]}
+	}
+	return lock
+}
+
+// LoadLockfile reads a lockfile. A missing file is reported as an error
+// matching os.ErrNotExist.
+func LoadLockfile(path string) (*Lockfile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var lock Lockfile
+	if err := yaml.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &lock, nil
+}
+
+// SaveLockfile writes lock to path
+func SaveLockfile(path string, lock *Lockfile) error {
+	data, err := yaml.Marshal(lock)
+	if err != nil {
+		return fmt.Errorf("failed to marshal lockfile: %w", err)
+	}
+	if err := os.WriteFile(path, append([]byte(lockfileHeader), data...), 0644); err != nil {
+		return fmt.Errorf("failed to write lockfile: %w", err)
+	}
+	return nil
+}
+
+// CheckFrozen returns an error listing every extension whose configured or
+// resolved version differs from the lock, or that the lock doesn't cover
+func (l *Lockfile) CheckFrozen(exts []string, requested, resolved map[string]string) error {
+	var problems []string
+	sorted := append([]string(nil), exts...)
+	sort.Strings(sorted)
+	for _, ext := range sorted {
+		locked, ok := l.Extensions[ext]
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("%s is not in %s", ext, LockfileName))
+		case requested[ext] != locked.Requested:
+			problems = append(problems, fmt.Sprintf("%s: configured version %q, locked %q", ext, requested[ext], locked.Requested))
+		case resolved[ext] != locked.Version:
+			problems = append(problems, fmt.Sprintf("%s: %q now resolves to %s, locked %s", ext, requested[ext], resolved[ext], locked.Version))
+		}
+	}
+	if len(problems) > 0 {
+		return errors.New("extension versions differ from " + LockfileName + " (run addt run --lock to update it):\n  " + strings.Join(problems, "\n  "))
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLockfile_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), LockfileName)
+	lock := NewLockfile([]string{"claude", "codex"},
+		map[string]string{"claude": "stable", "codex": "latest"},
+		map[string]string{"claude": "2.0.14", "codex": "latest"})
+
+	if err := SaveLockfile(path, lock); err != nil {
+		t.Fatalf("SaveLockfile() error = %v", err)
+	}
+	got, err := LoadLockfile(path)
+	if err != nil {
+		t.Fatalf("LoadLockfile() error = %v", err)
+	}
+	if got.Extensions["claude"] != (LockedExtension{Requested: "stable", Version: "2.0.14"}) {
+		t.Errorf("claude = %+v, want stable -> 2.0.14", got.Extensions["claude"])
+	}
+
+	if _, err := LoadLockfile(filepath.Join(t.TempDir(), LockfileName)); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("LoadLockfile(missing) error = %v, want os.ErrNotExist", err)
+	}
+}
+
+func TestLockfile_CheckFrozen(t *testing.T) {
+	lock := &Lockfile{Extensions: map[string]LockedExtension{
+		"claude": {Requested: "stable", Version: "2.0.14"},
+	}}
+	exts := []string{"claude"}
+
+	tests := []struct {
+		name      string
+		requested string
+		resolved  string
+		wantErr   string
+	}{
+		{"matches", "stable", "2.0.14", ""},
+		{"config change", "latest", "2.1.0", `configured version "latest", locked "stable"`},
+		{"dist-tag moved", "stable", "2.0.20", `"stable" now resolves to 2.0.20, locked 2.0.14`},
+	}
+	for _, tt := range tests {
+		err := lock.CheckFrozen(exts, map[string]string{"claude": tt.requested}, map[string]string{"claude": tt.resolved})
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: CheckFrozen() error = %v", tt.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: CheckFrozen() error = %v, want %q", tt.name, err, tt.wantErr)
+		}
+	}
+
+	err := lock.CheckFrozen([]string{"claude", "codex"},
+		map[string]string{"claude": "stable", "codex": "latest"},
+		map[string]string{"claude": "2.0.14", "codex": "latest"})
+	if err == nil || !strings.Contains(err.Error(), "codex is not in .addt.lock") {
+		t.Errorf("CheckFrozen() with an unlocked extension error = %v", err)
+	}
+}
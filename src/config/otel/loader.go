@@ -43,6 +43,15 @@ func applySettings(cfg *Config, settings *Settings) {
 	if settings.Headers != nil {
 		cfg.Headers = *settings.Headers
 	}
+	if settings.TracesEndpoint != nil {
+		cfg.TracesEndpoint = *settings.TracesEndpoint
+	}
+	if settings.MetricsEndpoint != nil {
+		cfg.MetricsEndpoint = *settings.MetricsEndpoint
+	}
+	if settings.LogsEndpoint != nil {
+		cfg.LogsEndpoint = *settings.LogsEndpoint
+	}
 }
 
 // applyEnvOverrides applies environment variable overrides to the config.
@@ -62,6 +71,15 @@ func applyEnvOverrides(cfg *Config) {
 	if val := os.Getenv("ADDT_OTEL_HEADERS"); val != "" {
 		cfg.Headers = val
 	}
+	if val := os.Getenv("ADDT_OTEL_TRACES_ENDPOINT"); val != "" {
+		cfg.TracesEndpoint = val
+	}
+	if val := os.Getenv("ADDT_OTEL_METRICS_ENDPOINT"); val != "" {
+		cfg.MetricsEndpoint = val
+	}
+	if val := os.Getenv("ADDT_OTEL_LOGS_ENDPOINT"); val != "" {
+		cfg.LogsEndpoint = val
+	}
 }
 
 // GetEnvVars returns a map of OTEL environment variables to pass to containers.
@@ -94,6 +112,19 @@ func GetEnvVars(cfg Config, attrs ResourceAttrs) map[string]string {
 		env["OTEL_EXPORTER_OTLP_HEADERS"] = cfg.Headers
 	}
 
+	// Signal-specific endpoints take precedence over OTEL_EXPORTER_OTLP_ENDPOINT
+	// in the SDKs. They are used as-is, so HTTP endpoints need the full path
+	// (e.g. https://vendor.example.com/v1/traces).
+	if cfg.TracesEndpoint != "" {
+		env["OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"] = cfg.TracesEndpoint
+	}
+	if cfg.MetricsEndpoint != "" {
+		env["OTEL_EXPORTER_OTLP_METRICS_ENDPOINT"] = cfg.MetricsEndpoint
+	}
+	if cfg.LogsEndpoint != "" {
+		env["OTEL_EXPORTER_OTLP_LOGS_ENDPOINT"] = cfg.LogsEndpoint
+	}
+
 	// Build OTEL_RESOURCE_ATTRIBUTES for runtime context
 	if ra := buildResourceAttrs(attrs); ra != "" {
 		env["OTEL_RESOURCE_ATTRIBUTES"] = ra
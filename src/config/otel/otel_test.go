@@ -159,6 +159,40 @@ func TestGetEnvVars(t *testing.T) {
 	}
 }
 
+func TestGetEnvVars_PerSignalEndpoints(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Enabled = true
+	cfg.TracesEndpoint = "https://vendor.example.com/v1/traces"
+	cfg.LogsEndpoint = "http://collector:4318/v1/logs"
+
+	env := GetEnvVars(cfg, ResourceAttrs{})
+
+	if env["OTEL_EXPORTER_OTLP_ENDPOINT"] != cfg.Endpoint {
+		t.Errorf("OTEL_EXPORTER_OTLP_ENDPOINT=%s, want %s", env["OTEL_EXPORTER_OTLP_ENDPOINT"], cfg.Endpoint)
+	}
+	if env["OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"] != cfg.TracesEndpoint {
+		t.Errorf("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT=%s, want %s", env["OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"], cfg.TracesEndpoint)
+	}
+	if env["OTEL_EXPORTER_OTLP_LOGS_ENDPOINT"] != cfg.LogsEndpoint {
+		t.Errorf("OTEL_EXPORTER_OTLP_LOGS_ENDPOINT=%s, want %s", env["OTEL_EXPORTER_OTLP_LOGS_ENDPOINT"], cfg.LogsEndpoint)
+	}
+	if _, ok := env["OTEL_EXPORTER_OTLP_METRICS_ENDPOINT"]; ok {
+		t.Error("Expected no OTEL_EXPORTER_OTLP_METRICS_ENDPOINT when unset")
+	}
+}
+
+func TestLoadConfig_PerSignalEndpoints(t *testing.T) {
+	traces := "http://global:4318/v1/traces"
+	metrics := "http://project:4318/v1/metrics"
+	t.Setenv("ADDT_OTEL_LOGS_ENDPOINT", "http://env:4318/v1/logs")
+
+	cfg := LoadConfig(&Settings{TracesEndpoint: &traces}, &Settings{MetricsEndpoint: &metrics})
+
+	if cfg.TracesEndpoint != traces || cfg.MetricsEndpoint != metrics || cfg.LogsEndpoint != "http://env:4318/v1/logs" {
+		t.Errorf("per-signal endpoints = %q, %q, %q", cfg.TracesEndpoint, cfg.MetricsEndpoint, cfg.LogsEndpoint)
+	}
+}
+
 func TestGetEnvVars_ServiceNameWithExtension(t *testing.T) {
 	// Default service name "addt" gets extension appended
 	cfg := Config{Enabled: true, Endpoint: "http://otel:4318", Protocol: "http/json", ServiceName: "addt"}
@@ -9,6 +9,12 @@ type Settings struct {
 	Protocol    *string `yaml:"protocol,omitempty"`     // Protocol: http/json, http/protobuf, or grpc (default: http/json)
 	ServiceName *string `yaml:"service_name,omitempty"` // Service name for traces
 	Headers     *string `yaml:"headers,omitempty"`      // Additional headers (key=value,key2=value2)
+
+	// Per-signal endpoints override Endpoint for one signal, e.g. to send
+	// traces to a vendor while metrics and logs go to a local collector
+	TracesEndpoint  *string `yaml:"traces_endpoint,omitempty"`
+	MetricsEndpoint *string `yaml:"metrics_endpoint,omitempty"`
+	LogsEndpoint    *string `yaml:"logs_endpoint,omitempty"`
 }
 
 // Config represents the runtime OTEL configuration with defaults applied.
@@ -18,6 +24,10 @@ type Config struct {
 	Protocol    string
 	ServiceName string
 	Headers     string
+
+	TracesEndpoint  string // Empty = Endpoint
+	MetricsEndpoint string // Empty = Endpoint
+	LogsEndpoint    string // Empty = Endpoint
 }
 
 // ResourceAttrs holds runtime context injected as OTEL resource attributes.
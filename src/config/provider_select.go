@@ -0,0 +1,44 @@
+package config
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/jedi4ever/addt/provider"
+)
+
+// Providers lists the provider types addt supports
+var Providers = []string{"docker", "rancher", "podman", "orbstack", "applecontainer", "daytona"}
+
+// hostOS and hasDockerContext are variables so tests can stub runtime
+// detection without a Mac or a docker CLI
+var (
+	hostOS           = runtime.GOOS
+	hasDockerContext = provider.HasDockerContext
+)
+
+// explicitProvider returns the provider forced by ADDT_PROVIDER (which
+// addt run --provider sets) or else by provider.name in the project or
+// global config, or "" to auto-detect
+func explicitProvider() string {
+	if p := os.Getenv("ADDT_PROVIDER"); p != "" {
+		return p
+	}
+	for _, cfg := range []*GlobalConfig{loadProjectConfig(), loadGlobalConfig()} {
+		if cfg != nil && cfg.Provider != nil && cfg.Provider.Name != "" {
+			return cfg.Provider.Name
+		}
+	}
+	return ""
+}
+
+// isOrbstackAvailable reports whether OrbStack can serve containers. With
+// orbctl installed its status decides; without it (e.g. the CLI tools
+// weren't linked) the "orbstack" docker context OrbStack registers does.
+func isOrbstackAvailable() bool {
+	if _, err := exec.LookPath("orbctl"); err == nil {
+		return isOrbstackRunning()
+	}
+	return hasDockerContext("orbstack")
+}
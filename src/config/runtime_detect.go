@@ -6,8 +6,6 @@ import (
 	"os/exec"
 	"runtime"
 	"strings"
-
-	"github.com/jedi4ever/addt/provider"
 )
 
 // defaultAutoselect is the default provider priority order.
@@ -43,10 +41,12 @@ func splitTrimmed(s string) []string {
 }
 
 // DetectContainerRuntime automatically detects which container runtime to use.
-// Priority: explicit ADDT_PROVIDER > autoselect order > podman (fallback)
+// Priority: ADDT_PROVIDER > provider.name > autoselect order > podman (fallback).
+// OrbStack is detected on macOS by orbctl or its "orbstack" docker context,
+// Docker Desktop by its "desktop-linux" context.
 func DetectContainerRuntime() string {
 	// If explicitly set, use that
-	if p := os.Getenv("ADDT_PROVIDER"); p != "" {
+	if p := explicitProvider(); p != "" {
 		return p
 	}
 
@@ -54,17 +54,21 @@ func DetectContainerRuntime() string {
 	for _, candidate := range getAutoselect() {
 		switch candidate {
 		case "orbstack":
-			if runtime.GOOS == "darwin" && isOrbstackRunning() {
+			if hostOS == "darwin" && isOrbstackAvailable() {
 				return "orbstack"
 			}
 		case "rancher":
-			if provider.HasDockerContext("rancher-desktop") {
+			if hasDockerContext("rancher-desktop") {
 				return "rancher"
 			}
 		case "docker":
-			if provider.HasDockerContext("desktop-linux") {
+			if hasDockerContext("desktop-linux") {
 				return "docker"
 			}
+		case "applecontainer":
+			if hostOS == "darwin" && isAppleContainerAvailable() {
+				return "applecontainer"
+			}
 		case "podman":
 			if isPodmanAvailable() {
 				return "podman"
@@ -79,25 +83,30 @@ func DetectContainerRuntime() string {
 // EnsureContainerRuntime ensures a container runtime is available.
 // Downloads Podman automatically if needed (unless another provider is explicitly selected).
 func EnsureContainerRuntime() (string, error) {
-	p := os.Getenv("ADDT_PROVIDER")
+	p := explicitProvider()
 
 	// Handle explicitly selected providers
 	switch p {
 	case "orbstack":
-		if !isOrbstackRunning() {
-			return "", fmt.Errorf("OrbStack is explicitly selected but not running")
+		if !isOrbstackAvailable() {
+			return "", fmt.Errorf("OrbStack is explicitly selected but not running (no orbstack docker context)")
 		}
 		return "orbstack", nil
 	case "docker":
-		if !provider.HasDockerContext("desktop-linux") {
+		if !hasDockerContext("desktop-linux") {
 			return "", fmt.Errorf("Docker Desktop is explicitly selected but desktop-linux context not found")
 		}
 		return "docker", nil
 	case "rancher":
-		if !provider.HasDockerContext("rancher-desktop") {
+		if !hasDockerContext("rancher-desktop") {
 			return "", fmt.Errorf("Rancher Desktop is explicitly selected but rancher-desktop context not found")
 		}
 		return "rancher", nil
+	case "applecontainer":
+		if runtime.GOOS != "darwin" || !isAppleContainerAvailable() {
+			return "", fmt.Errorf("Apple container is explicitly selected but the container CLI was not found (macOS 15+ only)")
+		}
+		return "applecontainer", nil
 	}
 
 	// If explicitly set to something else (e.g. podman), honour it
@@ -152,6 +161,12 @@ func isOrbstackRunning() bool {
 	return strings.TrimSpace(string(output)) == "Running"
 }
 
+// isAppleContainerAvailable checks if Apple's container CLI is installed
+func isAppleContainerAvailable() bool {
+	_, err := exec.LookPath("container")
+	return err == nil
+}
+
 // isPodmanAvailable checks if Podman is available and functional
 // Checks both system Podman and bundled Podman
 // On macOS, also verifies that a machine is running
@@ -235,6 +250,8 @@ func GetRuntimeInfo() (rt string, version string, extras []string) {
 		version = getDockerVersion()
 	case "orbstack":
 		version = getOrbstackVersion()
+	case "applecontainer":
+		version = getAppleContainerVersion()
 	case "podman":
 		version = getPodmanVersion()
 		if hasPasta() {
@@ -258,6 +275,14 @@ func getOrbstackVersion() string {
 	return strings.TrimSpace(string(output))
 }
 
+func getAppleContainerVersion() string {
+	output, err := exec.Command("container", "--version").Output()
+	if err != nil {
+		return "unknown"
+	}
+	return strings.TrimSpace(string(output))
+}
+
 func getDockerVersion() string {
 	cmd := exec.Command("docker", "version", "--format", "{{.Server.Version}}")
 	output, err := cmd.Output()
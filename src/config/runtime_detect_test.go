@@ -0,0 +1,99 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"testing"
+)
+
+func TestDetectContainerRuntime_AppleContainer(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "container"), []byte("#!/bin/sh\nexit 0\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir)
+	t.Setenv("ADDT_PROVIDER", "")
+	t.Setenv("ADDT_PROVIDER_AUTOSELECT", "applecontainer")
+
+	// Only selected on macOS; elsewhere detection falls back to podman
+	want := "podman"
+	if runtime.GOOS == "darwin" {
+		want = "applecontainer"
+	}
+	if got := DetectContainerRuntime(); got != want {
+		t.Errorf("DetectContainerRuntime() = %q, want %q", got, want)
+	}
+
+	t.Setenv("PATH", t.TempDir())
+	if got := DetectContainerRuntime(); got != "podman" {
+		t.Errorf("DetectContainerRuntime() without the container CLI = %q, want podman", got)
+	}
+}
+
+// stubDetection makes detection run as on hostOS with the given docker
+// contexts and no runtime CLIs on PATH
+func stubDetection(t *testing.T, goos string, contexts ...string) {
+	t.Helper()
+	origOS, origHas := hostOS, hasDockerContext
+	t.Cleanup(func() { hostOS, hasDockerContext = origOS, origHas })
+	hostOS = goos
+	hasDockerContext = func(name string) bool { return slices.Contains(contexts, name) }
+	t.Setenv("PATH", t.TempDir())
+	t.Setenv("ADDT_CONFIG_DIR", t.TempDir())
+	t.Setenv("ADDT_PROVIDER", "")
+	t.Setenv("ADDT_PROVIDER_AUTOSELECT", "")
+	t.Chdir(t.TempDir())
+}
+
+func TestDetectContainerRuntime_OrbstackContext(t *testing.T) {
+	tests := []struct {
+		name     string
+		goos     string
+		contexts []string
+		want     string
+	}{
+		{"orbstack context on macOS", "darwin", []string{"default", "orbstack"}, "orbstack"},
+		{"orbstack preferred over docker desktop", "darwin", []string{"desktop-linux", "orbstack"}, "orbstack"},
+		{"docker desktop only", "darwin", []string{"default", "desktop-linux"}, "docker"},
+		{"orbstack context ignored off macOS", "linux", []string{"orbstack"}, "podman"},
+		{"no contexts", "darwin", nil, "podman"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stubDetection(t, tt.goos, tt.contexts...)
+			if got := DetectContainerRuntime(); got != tt.want {
+				t.Errorf("DetectContainerRuntime() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectContainerRuntime_OrbctlStatusWins(t *testing.T) {
+	stubDetection(t, "darwin", "orbstack", "desktop-linux")
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "orbctl"), []byte("#!/bin/sh\necho Stopped\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir)
+
+	if got := DetectContainerRuntime(); got != "docker" {
+		t.Errorf("DetectContainerRuntime() with OrbStack stopped = %q, want docker", got)
+	}
+}
+
+func TestDetectContainerRuntime_ProviderName(t *testing.T) {
+	stubDetection(t, "darwin", "desktop-linux")
+	if err := os.WriteFile(filepath.Join(os.Getenv("ADDT_CONFIG_DIR"), "config.yaml"), []byte("provider:\n  name: orbstack\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := DetectContainerRuntime(); got != "orbstack" {
+		t.Errorf("DetectContainerRuntime() with provider.name = %q, want orbstack", got)
+	}
+
+	t.Setenv("ADDT_PROVIDER", "podman")
+	if got := DetectContainerRuntime(); got != "podman" {
+		t.Errorf("DetectContainerRuntime() with ADDT_PROVIDER = %q, want podman (env wins)", got)
+	}
+}
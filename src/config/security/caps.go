@@ -0,0 +1,101 @@
+package security
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GosuCaps are the capabilities gosu needs to switch from root to the addt
+// user. The firewall starts containers as root and relies on them, which
+// works with no-new-privileges because gosu only lowers privileges.
+var GosuCaps = []string{"SETUID", "SETGID"}
+
+// FirewallCaps are added for the firewall's root phase, before gosu drops to
+// addt: NET_ADMIN for iptables/nftables rules, DAC_OVERRIDE to create files in
+// addt's home, CHOWN to fix ownership, and SETUID/SETGID for gosu itself.
+var FirewallCaps = []string{"NET_ADMIN", "DAC_OVERRIDE", "CHOWN", "SETUID", "SETGID"}
+
+// normalizeCap uppercases a capability name and strips the CAP_ prefix
+func normalizeCap(name string) string {
+	return strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(name)), "CAP_")
+}
+
+// containsCap reports whether caps contains name (normalized)
+func containsCap(caps []string, name string) bool {
+	for _, c := range caps {
+		if normalizeCap(c) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// DroppedGosuCaps returns the gosu capabilities explicitly dropped by capDrop
+// and not added back by capAdd. Dropping ALL is not reported: the firewall
+// adds SETUID/SETGID back explicitly.
+func DroppedGosuCaps(capDrop, capAdd []string) []string {
+	var dropped []string
+	for _, c := range GosuCaps {
+		if containsCap(capDrop, c) && !containsCap(capAdd, c) {
+			dropped = append(dropped, c)
+		}
+	}
+	return dropped
+}
+
+// KnownCaps lists the Linux capability names accepted by container runtimes
+// (without the CAP_ prefix). ALL is accepted as a wildcard.
+var KnownCaps = []string{
+	"ALL",
+	"AUDIT_CONTROL", "AUDIT_READ", "AUDIT_WRITE",
+	"BLOCK_SUSPEND", "BPF", "CHECKPOINT_RESTORE", "CHOWN",
+	"DAC_OVERRIDE", "DAC_READ_SEARCH",
+	"FOWNER", "FSETID",
+	"IPC_LOCK", "IPC_OWNER",
+	"KILL", "LEASE", "LINUX_IMMUTABLE",
+	"MAC_ADMIN", "MAC_OVERRIDE", "MKNOD",
+	"NET_ADMIN", "NET_BIND_SERVICE", "NET_BROADCAST", "NET_RAW",
+	"PERFMON", "SETFCAP", "SETGID", "SETPCAP", "SETUID",
+	"SYS_ADMIN", "SYS_BOOT", "SYS_CHROOT", "SYS_MODULE", "SYS_NICE",
+	"SYS_PACCT", "SYS_PTRACE", "SYS_RAWIO", "SYS_RESOURCE", "SYS_TIME",
+	"SYS_TTY_CONFIG", "SYSLOG", "WAKE_ALARM",
+}
+
+// NormalizeCap validates a capability name and returns its canonical form
+// (uppercase, no CAP_ prefix).
+func NormalizeCap(name string) (string, error) {
+	c := normalizeCap(name)
+	if !containsCap(KnownCaps, c) {
+		return "", fmt.Errorf("unknown capability: %s", name)
+	}
+	return c, nil
+}
+
+// removeCap returns caps without name (normalized)
+func removeCap(caps []string, name string) []string {
+	var out []string
+	for _, c := range caps {
+		if normalizeCap(c) != name {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// MergeCaps applies one-shot capability overrides on top of the configured
+// CapAdd/CapDrop. The overrides win: an added cap is removed from CapDrop
+// and a dropped cap is removed from CapAdd. Names must be normalized.
+func MergeCaps(cfg *Config, add, drop []string) {
+	for _, c := range add {
+		cfg.CapDrop = removeCap(cfg.CapDrop, c)
+		if !containsCap(cfg.CapAdd, c) {
+			cfg.CapAdd = append(cfg.CapAdd, c)
+		}
+	}
+	for _, c := range drop {
+		cfg.CapAdd = removeCap(cfg.CapAdd, c)
+		if !containsCap(cfg.CapDrop, c) {
+			cfg.CapDrop = append(cfg.CapDrop, c)
+		}
+	}
+}
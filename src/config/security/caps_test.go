@@ -0,0 +1,83 @@
+package security
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDroppedGosuCaps(t *testing.T) {
+	tests := []struct {
+		name    string
+		capDrop []string
+		capAdd  []string
+		want    []string
+	}{
+		{"default drop ALL", []string{"ALL"}, []string{"CHOWN", "SETUID", "SETGID"}, nil},
+		{"drop ALL without add", []string{"ALL"}, nil, nil},
+		{"drop SETUID", []string{"SETUID"}, nil, []string{"SETUID"}},
+		{"drop both, prefixed and lowercase", []string{"cap_setuid", "setgid"}, nil, []string{"SETUID", "SETGID"}},
+		{"dropped but added back", []string{"SETUID", "SETGID"}, []string{"CAP_SETUID", "SETGID"}, nil},
+		{"unrelated drop", []string{"NET_RAW"}, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DroppedGosuCaps(tt.capDrop, tt.capAdd)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DroppedGosuCaps(%v, %v) = %v, want %v", tt.capDrop, tt.capAdd, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeCap(t *testing.T) {
+	for input, want := range map[string]string{
+		"NET_ADMIN":      "NET_ADMIN",
+		"net_admin":      "NET_ADMIN",
+		"CAP_SYS_PTRACE": "SYS_PTRACE",
+		"all":            "ALL",
+	} {
+		got, err := NormalizeCap(input)
+		if err != nil {
+			t.Errorf("NormalizeCap(%q) error = %v", input, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("NormalizeCap(%q) = %q, want %q", input, got, want)
+		}
+	}
+
+	for _, input := range []string{"", "NET_ADMINN", "SUPERUSER"} {
+		if _, err := NormalizeCap(input); err == nil {
+			t.Errorf("NormalizeCap(%q) expected error, got nil", input)
+		}
+	}
+}
+
+func TestMergeCaps(t *testing.T) {
+	cfg := DefaultConfig() // CapDrop [ALL], CapAdd [CHOWN SETUID SETGID]
+
+	MergeCaps(&cfg, []string{"SYS_PTRACE", "CHOWN"}, []string{"SETGID"})
+
+	wantAdd := []string{"CHOWN", "SETUID", "SYS_PTRACE"}
+	if !reflect.DeepEqual(cfg.CapAdd, wantAdd) {
+		t.Errorf("CapAdd = %v, want %v", cfg.CapAdd, wantAdd)
+	}
+	wantDrop := []string{"ALL", "SETGID"}
+	if !reflect.DeepEqual(cfg.CapDrop, wantDrop) {
+		t.Errorf("CapDrop = %v, want %v", cfg.CapDrop, wantDrop)
+	}
+}
+
+func TestMergeCaps_AddWinsOverConfigDrop(t *testing.T) {
+	cfg := Config{CapDrop: []string{"NET_RAW", "SYS_ADMIN"}}
+
+	MergeCaps(&cfg, []string{"NET_RAW"}, nil)
+
+	if !reflect.DeepEqual(cfg.CapDrop, []string{"SYS_ADMIN"}) {
+		t.Errorf("CapDrop = %v, want [SYS_ADMIN]", cfg.CapDrop)
+	}
+	if !reflect.DeepEqual(cfg.CapAdd, []string{"NET_RAW"}) {
+		t.Errorf("CapAdd = %v, want [NET_RAW]", cfg.CapAdd)
+	}
+}
@@ -0,0 +1,37 @@
+package security
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+)
+
+// domainLabelPattern matches one DNS label: letters, digits and inner hyphens
+var domainLabelPattern = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?$`)
+
+// ValidateFirewallHost checks a firewall allow/deny entry: a domain name
+// such as api.github.com, an IP address or a CIDR range like 10.0.0.0/8
+func ValidateFirewallHost(host string) error {
+	if host == "" {
+		return fmt.Errorf("host is empty")
+	}
+	if strings.Contains(host, "/") {
+		if _, _, err := net.ParseCIDR(host); err != nil {
+			return fmt.Errorf("invalid CIDR %q", host)
+		}
+		return nil
+	}
+	if net.ParseIP(host) != nil {
+		return nil
+	}
+	if len(host) > 253 {
+		return fmt.Errorf("domain %q is longer than 253 characters", host)
+	}
+	for _, label := range strings.Split(strings.TrimSuffix(host, "."), ".") {
+		if !domainLabelPattern.MatchString(label) {
+			return fmt.Errorf("%q is not a domain, IP address or CIDR", host)
+		}
+	}
+	return nil
+}
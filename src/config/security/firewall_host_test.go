@@ -0,0 +1,16 @@
+package security
+
+import "testing"
+
+func TestValidateFirewallHost(t *testing.T) {
+	for _, host := range []string{"api.github.com", "localhost", "registry.npmjs.org.", "x-1.example.io", "10.0.0.0/8", "192.168.1.10", "2001:db8::/32", "::1"} {
+		if err := ValidateFirewallHost(host); err != nil {
+			t.Errorf("ValidateFirewallHost(%q) = %v, want nil", host, err)
+		}
+	}
+	for _, host := range []string{"", "https://github.com", "*.github.com", "git hub.com", "-bad.com", "a..b", "10.0.0.0/33", "github.com:443"} {
+		if err := ValidateFirewallHost(host); err == nil {
+			t.Errorf("ValidateFirewallHost(%q) = nil, want an error", host)
+		}
+	}
+}
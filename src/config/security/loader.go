@@ -24,6 +24,7 @@ func ApplySettings(cfg *Config, settings *Settings) {
 	if settings.UlimitNproc != "" {
 		cfg.UlimitNproc = settings.UlimitNproc
 	}
+	cfg.Ulimits = mergeUlimits(cfg.Ulimits, settings.Ulimits)
 	if settings.NoNewPrivileges != nil {
 		cfg.NoNewPrivileges = *settings.NoNewPrivileges
 	}
@@ -36,6 +37,9 @@ func ApplySettings(cfg *Config, settings *Settings) {
 	if settings.ReadOnlyRootfs != nil {
 		cfg.ReadOnlyRootfs = *settings.ReadOnlyRootfs
 	}
+	if settings.MountReadonly != nil {
+		cfg.MountReadonly = *settings.MountReadonly
+	}
 	if settings.TmpfsTmpSize != "" {
 		cfg.TmpfsTmpSize = settings.TmpfsTmpSize
 	}
@@ -66,6 +70,15 @@ func ApplySettings(cfg *Config, settings *Settings) {
 	if settings.IsolateSecrets != nil {
 		cfg.IsolateSecrets = *settings.IsolateSecrets
 	}
+	if len(settings.SecretConsumers) > 0 {
+		cfg.SecretConsumers = settings.SecretConsumers
+	}
+	if settings.FetchEntrypointLog != nil {
+		cfg.FetchEntrypointLog = *settings.FetchEntrypointLog
+	}
+	if settings.RedactEntrypointLog != nil {
+		cfg.RedactEntrypointLog = *settings.RedactEntrypointLog
+	}
 	if settings.AuditLog != nil {
 		cfg.AuditLog = *settings.AuditLog
 	}
@@ -90,6 +103,11 @@ func ApplyEnvOverrides(cfg *Config) {
 	if v := os.Getenv("ADDT_SECURITY_ULIMIT_NPROC"); v != "" {
 		cfg.UlimitNproc = v
 	}
+	if v := os.Getenv("ADDT_SECURITY_ULIMITS"); v != "" {
+		if ulimits, err := ParseUlimits(v); err == nil {
+			cfg.Ulimits = mergeUlimits(cfg.Ulimits, ulimits)
+		}
+	}
 	if v := os.Getenv("ADDT_SECURITY_NO_NEW_PRIVILEGES"); v != "" {
 		cfg.NoNewPrivileges = v != "false"
 	}
@@ -102,6 +120,9 @@ func ApplyEnvOverrides(cfg *Config) {
 	if v := os.Getenv("ADDT_SECURITY_READ_ONLY_ROOTFS"); v != "" {
 		cfg.ReadOnlyRootfs = v == "true"
 	}
+	if v := os.Getenv("ADDT_SECURITY_MOUNT_READONLY"); v != "" {
+		cfg.MountReadonly = v == "true"
+	}
 	if v := os.Getenv("ADDT_SECURITY_TMPFS_TMP_SIZE"); v != "" {
 		cfg.TmpfsTmpSize = v
 	}
@@ -134,6 +155,15 @@ func ApplyEnvOverrides(cfg *Config) {
 	if v := os.Getenv("ADDT_SECURITY_ISOLATE_SECRETS"); v != "" {
 		cfg.IsolateSecrets = v == "true"
 	}
+	if v := os.Getenv("ADDT_SECURITY_SECRET_CONSUMERS"); v != "" {
+		cfg.SecretConsumers = strings.Split(v, ",")
+	}
+	if v := os.Getenv("ADDT_SECURITY_FETCH_ENTRYPOINT_LOG"); v != "" {
+		cfg.FetchEntrypointLog = v != "false"
+	}
+	if v := os.Getenv("ADDT_SECURITY_REDACT_ENTRYPOINT_LOG"); v != "" {
+		cfg.RedactEntrypointLog = v != "false"
+	}
 	if v := os.Getenv("ADDT_SECURITY_AUDIT_LOG"); v != "" {
 		cfg.AuditLog = v == "true"
 	}
@@ -0,0 +1,100 @@
+package security
+
+import "fmt"
+
+// Posture is the effective container security posture for a config,
+// consolidating what the providers apply at container start
+type Posture struct {
+	CapAdd          []string          `json:"cap_add"`
+	CapDrop         []string          `json:"cap_drop"`
+	FirewallCaps    []string          `json:"firewall_caps,omitempty"` // caps added implicitly for the firewall's root phase
+	StartsAsRoot    bool              `json:"starts_as_root"`          // root phase before gosu drops to addt
+	NoNewPrivileges bool              `json:"no_new_privileges"`
+	SeccompProfile  string            `json:"seccomp_profile"`
+	NetworkMode     string            `json:"network_mode"`
+	ReadOnlyRootfs  bool              `json:"read_only_rootfs"`
+	MountReadonly   bool              `json:"mount_readonly"`
+	Tmpfs           []string          `json:"tmpfs"`
+	PidsLimit       int               `json:"pids_limit"`
+	UlimitNofile    string            `json:"ulimit_nofile,omitempty"`
+	UlimitNproc     string            `json:"ulimit_nproc,omitempty"`
+	Ulimits         map[string]string `json:"ulimits,omitempty"` // other ulimits from security.ulimits
+	DisableIPC      bool              `json:"disable_ipc"`
+	UserNamespace   string            `json:"user_namespace,omitempty"`
+	MemorySwap      string            `json:"memory_swap,omitempty"`
+	TimeLimit       int               `json:"time_limit_minutes,omitempty"`
+	IsolateSecrets  bool              `json:"isolate_secrets"`
+}
+
+// Explain resolves the effective security posture for cfg. firewallEnabled
+// adds the firewall's implicit capabilities and root start.
+func Explain(cfg Config, firewallEnabled bool) Posture {
+	p := Posture{
+		CapAdd:          appendUniqueCaps(nil, cfg.CapAdd...),
+		CapDrop:         appendUniqueCaps(nil, cfg.CapDrop...),
+		NoNewPrivileges: cfg.NoNewPrivileges,
+		SeccompProfile:  cfg.SeccompProfile,
+		NetworkMode:     cfg.NetworkMode,
+		ReadOnlyRootfs:  cfg.ReadOnlyRootfs,
+		MountReadonly:   cfg.MountReadonly,
+		PidsLimit:       cfg.PidsLimit,
+		DisableIPC:      cfg.DisableIPC,
+		UserNamespace:   cfg.UserNamespace,
+		MemorySwap:      cfg.MemorySwap,
+		TimeLimit:       cfg.TimeLimit,
+		IsolateSecrets:  cfg.IsolateSecrets,
+	}
+
+	for name, value := range EffectiveUlimits(cfg) {
+		switch name {
+		case "nofile":
+			p.UlimitNofile = value
+		case "nproc":
+			p.UlimitNproc = value
+		default:
+			if p.Ulimits == nil {
+				p.Ulimits = make(map[string]string)
+			}
+			p.Ulimits[name] = value
+		}
+	}
+
+	if firewallEnabled {
+		p.StartsAsRoot = true
+		p.FirewallCaps = FirewallCaps
+		p.CapAdd = appendUniqueCaps(p.CapAdd, FirewallCaps...)
+	}
+	if cfg.DisableDevices {
+		p.CapDrop = appendUniqueCaps(p.CapDrop, "MKNOD")
+	}
+
+	if p.SeccompProfile == "" {
+		p.SeccompProfile = "default"
+	}
+	if p.NetworkMode == "" {
+		p.NetworkMode = "bridge"
+	}
+
+	if cfg.ReadOnlyRootfs {
+		p.Tmpfs = append(p.Tmpfs,
+			fmt.Sprintf("/tmp (size=%s, noexec)", cfg.TmpfsTmpSize),
+			"/var/tmp (size=128m, noexec)",
+			fmt.Sprintf("/home/addt (size=%s)", cfg.TmpfsHomeSize))
+	}
+	if cfg.IsolateSecrets {
+		p.Tmpfs = append(p.Tmpfs, "/run/secrets (size=1m)")
+	}
+
+	return p
+}
+
+// appendUniqueCaps appends normalized caps that are not already present
+func appendUniqueCaps(caps []string, add ...string) []string {
+	for _, c := range add {
+		c = normalizeCap(c)
+		if !containsCap(caps, c) {
+			caps = append(caps, c)
+		}
+	}
+	return caps
+}
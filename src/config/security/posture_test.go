@@ -0,0 +1,52 @@
+package security
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExplain_Defaults(t *testing.T) {
+	p := Explain(DefaultConfig(), false)
+
+	if !reflect.DeepEqual(p.CapDrop, []string{"ALL"}) {
+		t.Errorf("CapDrop = %v, want [ALL]", p.CapDrop)
+	}
+	if !reflect.DeepEqual(p.CapAdd, []string{"CHOWN", "SETUID", "SETGID"}) {
+		t.Errorf("CapAdd = %v", p.CapAdd)
+	}
+	if p.StartsAsRoot || len(p.FirewallCaps) != 0 {
+		t.Error("firewall caps should not apply when the firewall is disabled")
+	}
+	if !p.NoNewPrivileges {
+		t.Error("NoNewPrivileges should hold by default")
+	}
+	if p.SeccompProfile != "default" || p.NetworkMode != "bridge" {
+		t.Errorf("seccomp=%q network=%q, want default/bridge", p.SeccompProfile, p.NetworkMode)
+	}
+	if !reflect.DeepEqual(p.Tmpfs, []string{"/run/secrets (size=1m)"}) {
+		t.Errorf("Tmpfs = %v, want only the secrets tmpfs", p.Tmpfs)
+	}
+}
+
+func TestExplain_FirewallAndHardening(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.ReadOnlyRootfs = true
+	cfg.DisableDevices = true
+	cfg.IsolateSecrets = false
+
+	p := Explain(cfg, true)
+
+	if !p.StartsAsRoot {
+		t.Error("firewall should start the container as root")
+	}
+	want := []string{"CHOWN", "SETUID", "SETGID", "NET_ADMIN", "DAC_OVERRIDE"}
+	if !reflect.DeepEqual(p.CapAdd, want) {
+		t.Errorf("CapAdd = %v, want %v", p.CapAdd, want)
+	}
+	if !reflect.DeepEqual(p.CapDrop, []string{"ALL", "MKNOD"}) {
+		t.Errorf("CapDrop = %v, want [ALL MKNOD]", p.CapDrop)
+	}
+	if len(p.Tmpfs) != 3 {
+		t.Errorf("Tmpfs = %v, want /tmp, /var/tmp and /home/addt", p.Tmpfs)
+	}
+}
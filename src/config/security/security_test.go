@@ -196,6 +196,24 @@ func TestIsolateSecretsEnvOverride(t *testing.T) {
 	}
 }
 
+func TestSecretConsumers(t *testing.T) {
+	cfg := DefaultConfig()
+	if len(cfg.SecretConsumers) != 0 {
+		t.Errorf("SecretConsumers = %v, want empty by default", cfg.SecretConsumers)
+	}
+
+	ApplySettings(&cfg, &Settings{SecretConsumers: []string{"claude"}})
+	if len(cfg.SecretConsumers) != 1 || cfg.SecretConsumers[0] != "claude" {
+		t.Errorf("SecretConsumers = %v, want [claude] (from settings)", cfg.SecretConsumers)
+	}
+
+	t.Setenv("ADDT_SECURITY_SECRET_CONSUMERS", "claude,codex")
+	ApplyEnvOverrides(&cfg)
+	if len(cfg.SecretConsumers) != 2 || cfg.SecretConsumers[1] != "codex" {
+		t.Errorf("SecretConsumers = %v, want [claude codex] (from env)", cfg.SecretConsumers)
+	}
+}
+
 func TestYoloDefault(t *testing.T) {
 	cfg := DefaultConfig()
 	if cfg.Yolo {
@@ -0,0 +1,17 @@
+package security
+
+import (
+	"fmt"
+	"regexp"
+)
+
+var tmpfsSizePattern = regexp.MustCompile(`^[1-9][0-9]*[kKmMgG]?$`)
+
+// ValidateTmpfsSize checks a tmpfs size: a positive byte count with an
+// optional k, m or g suffix, as accepted by --tmpfs size=
+func ValidateTmpfsSize(size string) error {
+	if !tmpfsSizePattern.MatchString(size) {
+		return fmt.Errorf("expected a size like 512m or 2g, got %q", size)
+	}
+	return nil
+}
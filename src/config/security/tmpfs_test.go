@@ -0,0 +1,16 @@
+package security
+
+import "testing"
+
+func TestValidateTmpfsSize(t *testing.T) {
+	for _, size := range []string{"256m", "2g", "2G", "1048576", "512k"} {
+		if err := ValidateTmpfsSize(size); err != nil {
+			t.Errorf("ValidateTmpfsSize(%q) = %v, want nil", size, err)
+		}
+	}
+	for _, size := range []string{"", "0", "0m", "2gb", "-1m", "1.5g", "big"} {
+		if err := ValidateTmpfsSize(size); err == nil {
+			t.Errorf("ValidateTmpfsSize(%q) = nil, want an error", size)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package security
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ulimitNames are the resource names accepted by docker/podman --ulimit
+var ulimitNames = map[string]bool{
+	"core": true, "cpu": true, "data": true, "fsize": true, "locks": true,
+	"memlock": true, "msgqueue": true, "nice": true, "nofile": true, "nproc": true,
+	"rss": true, "rtprio": true, "rttime": true, "sigpending": true, "stack": true,
+}
+
+var ulimitValuePattern = regexp.MustCompile(`^(-1|\d+):(-1|\d+)$`)
+
+// ValidateUlimit checks a ulimit name and its "soft:hard" value (-1 = unlimited)
+func ValidateUlimit(name, value string) error {
+	if !ulimitNames[name] {
+		return fmt.Errorf("unknown ulimit %q", name)
+	}
+	m := ulimitValuePattern.FindStringSubmatch(value)
+	if m == nil {
+		return fmt.Errorf("ulimit %s: expected soft:hard (e.g. 1024:2048, -1 for unlimited), got %q", name, value)
+	}
+	soft, _ := strconv.ParseInt(m[1], 10, 64)
+	hard, _ := strconv.ParseInt(m[2], 10, 64)
+	if hard != -1 && (soft == -1 || soft > hard) {
+		return fmt.Errorf("ulimit %s: soft limit %s exceeds hard limit %s", name, m[1], m[2])
+	}
+	return nil
+}
+
+// ParseUlimits parses "name=soft:hard,name=soft:hard", the form used by
+// ADDT_SECURITY_ULIMITS and "addt config set security.ulimits"
+func ParseUlimits(s string) (map[string]string, error) {
+	ulimits := make(map[string]string)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("expected name=soft:hard, got %q", entry)
+		}
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if err := ValidateUlimit(name, value); err != nil {
+			return nil, err
+		}
+		ulimits[name] = value
+	}
+	return ulimits, nil
+}
+
+// EffectiveUlimits merges ulimit_nofile/ulimit_nproc with the ulimits map.
+// Entries in the map win over the two convenience keys.
+func EffectiveUlimits(cfg Config) map[string]string {
+	ulimits := make(map[string]string)
+	if cfg.UlimitNofile != "" {
+		ulimits["nofile"] = cfg.UlimitNofile
+	}
+	if cfg.UlimitNproc != "" {
+		ulimits["nproc"] = cfg.UlimitNproc
+	}
+	for name, value := range cfg.Ulimits {
+		ulimits[name] = value
+	}
+	return ulimits
+}
+
+// UlimitArgs returns the --ulimit flags for cfg, ordered by name
+func UlimitArgs(cfg Config) []string {
+	ulimits := EffectiveUlimits(cfg)
+	names := make([]string, 0, len(ulimits))
+	for name := range ulimits {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var args []string
+	for _, name := range names {
+		args = append(args, "--ulimit", name+"="+ulimits[name])
+	}
+	return args
+}
+
+// mergeUlimits copies src entries over dst, allocating dst if needed
+func mergeUlimits(dst, src map[string]string) map[string]string {
+	if len(src) == 0 {
+		return dst
+	}
+	if dst == nil {
+		dst = make(map[string]string, len(src))
+	}
+	for name, value := range src {
+		dst[name] = value
+	}
+	return dst
+}
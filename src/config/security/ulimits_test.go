@@ -0,0 +1,54 @@
+package security
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseUlimits(t *testing.T) {
+	got, err := ParseUlimits("memlock=-1:-1, core=0:0,nofile=1024:2048")
+	if err != nil {
+		t.Fatalf("ParseUlimits() error = %v", err)
+	}
+	want := map[string]string{"memlock": "-1:-1", "core": "0:0", "nofile": "1024:2048"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseUlimits() = %v, want %v", got, want)
+	}
+}
+
+func TestParseUlimits_Malformed(t *testing.T) {
+	for _, value := range []string{
+		"memlock",            // no value
+		"memlock=1024",       // missing hard limit
+		"memlock=soft:hard",  // not numbers
+		"memlock=2048:1024",  // soft > hard
+		"memlock=-1:1024",    // unlimited soft, limited hard
+		"bogus=1:2",          // unknown ulimit
+		"core=0:0,nofile=1:", // second entry malformed
+	} {
+		if _, err := ParseUlimits(value); err == nil {
+			t.Errorf("ParseUlimits(%q) expected error", value)
+		}
+	}
+}
+
+func TestUlimits_Precedence(t *testing.T) {
+	t.Setenv("ADDT_SECURITY_ULIMITS", "core=0:0")
+
+	global := &Settings{Ulimits: map[string]string{"memlock": "64:64", "core": "1:1"}}
+	project := &Settings{Ulimits: map[string]string{"memlock": "-1:-1"}}
+	cfg := LoadConfig(global, project)
+
+	want := map[string]string{"memlock": "-1:-1", "core": "0:0"}
+	if !reflect.DeepEqual(cfg.Ulimits, want) {
+		t.Errorf("Ulimits = %v, want %v", cfg.Ulimits, want)
+	}
+	if global.Ulimits["memlock"] != "64:64" {
+		t.Error("merging must not modify the global settings map")
+	}
+
+	posture := Explain(cfg, false)
+	if posture.UlimitNofile != "4096:8192" || !reflect.DeepEqual(posture.Ulimits, want) {
+		t.Errorf("posture nofile=%q ulimits=%v", posture.UlimitNofile, posture.Ulimits)
+	}
+}
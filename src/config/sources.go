@@ -0,0 +1,14 @@
+package config
+
+import "os"
+
+// SourceResolver maps each config key to the layer that supplies its value
+// ("env", "project", "global" or "default") for the loaded config files.
+// It is provided by the config key registry (cmd/config).
+var SourceResolver func(globalCfg, projectCfg *GlobalConfig) map[string]string
+
+// explainConfigEnabled reports whether LoadConfig should record value sources.
+// Off by default so normal startup doesn't pay for it.
+func explainConfigEnabled() bool {
+	return os.Getenv("ADDT_EXPLAIN_CONFIG") == "true"
+}
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// UnknownKey is a key in a config file that GlobalConfig has no field for
+type UnknownKey struct {
+	Key  string
+	Line int
+}
+
+// strictConfigEnabled reports whether unknown config keys are reported,
+// set by ADDT_STRICT_CONFIG=true or addt run --strict-config. Off by
+// default so files with extra keys keep loading quietly.
+func strictConfigEnabled() bool {
+	return os.Getenv("ADDT_STRICT_CONFIG") == "true"
+}
+
+// unknownFieldPattern matches the errors of a KnownFields decode, e.g.
+// "line 3: field firewal not found in type config.GlobalConfig"
+var unknownFieldPattern = regexp.MustCompile(`^line (\d+): field (\S+) not found in type`)
+
+// UnknownConfigKeys decodes a config file strictly and returns the keys
+// that don't map to a GlobalConfig field, in file order. Nested keys are
+// caught by KnownFields; top-level ones land in GlobalConfig.Unknown.
+func UnknownConfigKeys(data []byte) ([]UnknownKey, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	var cfg GlobalConfig
+	err := dec.Decode(&cfg)
+	if errors.Is(err, io.EOF) {
+		return nil, nil // empty file
+	}
+
+	var keys []UnknownKey
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) {
+		for _, msg := range typeErr.Errors {
+			if m := unknownFieldPattern.FindStringSubmatch(msg); m != nil {
+				line, _ := strconv.Atoi(m[1])
+				keys = append(keys, UnknownKey{Key: m[2], Line: line})
+			}
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	if len(cfg.Unknown) > 0 {
+		var root yaml.Node
+		if err := yaml.Unmarshal(data, &root); err == nil && len(root.Content) > 0 {
+			top := root.Content[0].Content
+			for i := 0; i+1 < len(top); i += 2 {
+				if _, ok := cfg.Unknown[top[i].Value]; ok {
+					keys = append(keys, UnknownKey{Key: top[i].Value, Line: top[i].Line})
+				}
+			}
+		}
+	}
+	sort.SliceStable(keys, func(i, j int) bool { return keys[i].Line < keys[j].Line })
+	return keys, nil
+}
+
+// reportedUnknownKeys holds the config files already checked, so a file
+// loaded several times during one command warns once
+var reportedUnknownKeys sync.Map
+
+// warnUnknownKeys prints a warning for each unknown key in a config file
+// when strict config is on
+func warnUnknownKeys(path string, data []byte) {
+	if !strictConfigEnabled() {
+		return
+	}
+	if _, seen := reportedUnknownKeys.LoadOrStore(path, true); seen {
+		return
+	}
+	keys, err := UnknownConfigKeys(data)
+	if err != nil {
+		return // parse errors are reported by the regular load
+	}
+	for _, k := range keys {
+		fmt.Fprintf(os.Stderr, "Warning: unknown config key %q in %s (line %d)\n", k.Key, path, k.Line)
+	}
+}
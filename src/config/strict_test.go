@@ -0,0 +1,44 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestUnknownConfigKeys(t *testing.T) {
+	data := []byte("firewal: true\ncontainer:\n  cpus: \"2\"\n  memroy: 4g\nenv_vars: [GH_TOKEN]\n")
+	keys, err := UnknownConfigKeys(data)
+	if err != nil {
+		t.Fatalf("UnknownConfigKeys() error = %v", err)
+	}
+	want := []UnknownKey{{Key: "firewal", Line: 1}, {Key: "memroy", Line: 4}}
+	if !reflect.DeepEqual(keys, want) {
+		t.Errorf("UnknownConfigKeys() = %+v, want %+v", keys, want)
+	}
+
+	for _, clean := range []string{"", "firewall:\n  enabled: true\n"} {
+		if keys, err := UnknownConfigKeys([]byte(clean)); err != nil || len(keys) != 0 {
+			t.Errorf("UnknownConfigKeys(%q) = %v, %v, want none", clean, keys, err)
+		}
+	}
+}
+
+func TestLoadProjectConfigFile_UnknownKeysStillLoad(t *testing.T) {
+	_, projectDir, cleanup := setupTestEnv(t)
+	defer cleanup()
+	t.Setenv("ADDT_STRICT_CONFIG", "true")
+
+	path := filepath.Join(projectDir, ".addt.yaml")
+	if err := os.WriteFile(path, []byte("firewal: true\npersistent: true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadProjectConfigFile()
+	if err != nil {
+		t.Fatalf("LoadProjectConfigFile() error = %v, want unknown keys to only warn", err)
+	}
+	if cfg.Persistent == nil || !*cfg.Persistent {
+		t.Errorf("persistent not loaded next to an unknown key: %+v", cfg.Persistent)
+	}
+}
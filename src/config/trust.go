@@ -0,0 +1,125 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jedi4ever/addt/util"
+	"gopkg.in/yaml.v3"
+)
+
+// Trust sources recorded per workdir
+const (
+	TrustSourceExplicit = "explicit" // set by addt trust / addt untrust
+	TrustSourceAuto     = "auto"     // workdir.autotrust applied (notice shown once)
+)
+
+// TrustEntry records the trust state of one workdir
+type TrustEntry struct {
+	Path    string `yaml:"path"`
+	Trusted bool   `yaml:"trusted"`
+	Source  string `yaml:"source"`
+}
+
+type trustFile struct {
+	Workdirs map[string]TrustEntry `yaml:"workdirs"`
+}
+
+// GetTrustFilePath returns the path of the workdir trust store (~/.addt/trust.yaml)
+func GetTrustFilePath() string {
+	addtHome := util.GetAddtHome()
+	if addtHome == "" {
+		return ""
+	}
+	return filepath.Join(addtHome, "trust.yaml")
+}
+
+// WorkdirTrustKey returns the key a workdir's trust is stored under:
+// a hash of its absolute path
+func WorkdirTrustKey(dir string) string {
+	return util.WorkdirKey(dir)
+}
+
+// loadTrust reads the trust store; a missing file is an empty store
+func loadTrust() (*trustFile, error) {
+	tf := &trustFile{Workdirs: make(map[string]TrustEntry)}
+	path := GetTrustFilePath()
+	if path == "" {
+		return tf, nil
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return tf, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trust file: %w", err)
+	}
+	if err := yaml.Unmarshal(data, tf); err != nil {
+		return nil, fmt.Errorf("failed to parse trust file: %w", err)
+	}
+	if tf.Workdirs == nil {
+		tf.Workdirs = make(map[string]TrustEntry)
+	}
+	return tf, nil
+}
+
+func saveTrust(tf *trustFile) error {
+	path := GetTrustFilePath()
+	if path == "" {
+		return fmt.Errorf("could not determine addt home directory")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create trust directory: %w", err)
+	}
+	data, err := yaml.Marshal(tf)
+	if err != nil {
+		return fmt.Errorf("failed to marshal trust file: %w", err)
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// GetWorkdirTrust returns the recorded trust entry for dir, if any
+func GetWorkdirTrust(dir string) (TrustEntry, bool) {
+	tf, err := loadTrust()
+	if err != nil {
+		return TrustEntry{}, false
+	}
+	entry, ok := tf.Workdirs[WorkdirTrustKey(dir)]
+	return entry, ok
+}
+
+// SetWorkdirTrust records an explicit trust decision for dir
+func SetWorkdirTrust(dir string, trusted bool) error {
+	return putWorkdirTrust(dir, trusted, TrustSourceExplicit)
+}
+
+func putWorkdirTrust(dir string, trusted bool, source string) error {
+	tf, err := loadTrust()
+	if err != nil {
+		return err
+	}
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	tf.Workdirs[WorkdirTrustKey(dir)] = TrustEntry{Path: dir, Trusted: trusted, Source: source}
+	return saveTrust(tf)
+}
+
+// ResolveWorkdirTrust returns the explicit trust decision for dir (nil when
+// none was made and the workdir.autotrust settings apply). newlyAutotrusted
+// reports the first run that autotrusts dir; it is recorded so the caller
+// only notifies the user once.
+func ResolveWorkdirTrust(dir string, autotrust bool) (decision *bool, newlyAutotrusted bool) {
+	entry, ok := GetWorkdirTrust(dir)
+	if ok && entry.Source == TrustSourceExplicit {
+		trusted := entry.Trusted
+		return &trusted, false
+	}
+	if !autotrust || ok {
+		return nil, false
+	}
+	// Unwritable store: the notice repeats on the next run, which is acceptable
+	_ = putWorkdirTrust(dir, true, TrustSourceAuto)
+	return nil, true
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSetWorkdirTrust_PersistsPerWorkdir(t *testing.T) {
+	t.Setenv("ADDT_HOME", t.TempDir())
+	dirA, dirB := t.TempDir(), t.TempDir()
+
+	if err := SetWorkdirTrust(dirA, false); err != nil {
+		t.Fatalf("SetWorkdirTrust() error = %v", err)
+	}
+	if err := SetWorkdirTrust(dirB, true); err != nil {
+		t.Fatalf("SetWorkdirTrust() error = %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(os.Getenv("ADDT_HOME"), "trust.yaml")); err != nil {
+		t.Fatalf("trust.yaml not written: %v", err)
+	}
+	a, ok := GetWorkdirTrust(dirA)
+	if !ok || a.Trusted || a.Source != TrustSourceExplicit || a.Path != dirA {
+		t.Errorf("GetWorkdirTrust(A) = %+v, %v", a, ok)
+	}
+	b, ok := GetWorkdirTrust(dirB)
+	if !ok || !b.Trusted {
+		t.Errorf("GetWorkdirTrust(B) = %+v, %v", b, ok)
+	}
+	if WorkdirTrustKey(dirA) == WorkdirTrustKey(dirB) {
+		t.Error("different workdirs should have different trust keys")
+	}
+}
+
+func TestResolveWorkdirTrust(t *testing.T) {
+	t.Setenv("ADDT_HOME", t.TempDir())
+	dir := t.TempDir()
+
+	// First autotrusted run is reported once, later runs are quiet
+	decision, first := ResolveWorkdirTrust(dir, true)
+	if decision != nil || !first {
+		t.Errorf("first run = %v, %v; want nil, true", decision, first)
+	}
+	decision, again := ResolveWorkdirTrust(dir, true)
+	if decision != nil || again {
+		t.Errorf("second run = %v, %v; want nil, false", decision, again)
+	}
+
+	// Autotrust off records nothing
+	other := t.TempDir()
+	if _, notice := ResolveWorkdirTrust(other, false); notice {
+		t.Error("autotrust disabled should not report a notice")
+	}
+	if _, ok := GetWorkdirTrust(other); ok {
+		t.Error("autotrust disabled should not record an entry")
+	}
+
+	// An explicit decision wins over autotrust
+	if err := SetWorkdirTrust(dir, false); err != nil {
+		t.Fatal(err)
+	}
+	decision, notice := ResolveWorkdirTrust(dir, true)
+	if decision == nil || *decision || notice {
+		t.Errorf("after untrust = %v, %v; want false, no notice", decision, notice)
+	}
+}
@@ -3,6 +3,9 @@ package config
 import (
 	"github.com/jedi4ever/addt/config/otel"
 	"github.com/jedi4ever/addt/config/security"
+	"github.com/jedi4ever/addt/extensions"
+	"github.com/jedi4ever/addt/provider"
+	"gopkg.in/yaml.v3"
 )
 
 // ExtensionSettings holds per-extension configuration settings
@@ -27,15 +30,28 @@ type DindSettings struct {
 	Mode   string `yaml:"mode,omitempty"`
 }
 
-// DockerSettings holds Docker-specific configuration (DinD)
+// DockerSettings holds Docker-specific configuration (DinD, CLI config forwarding)
 type DockerSettings struct {
-	Dind *DindSettings `yaml:"dind,omitempty"`
+	Dind          *DindSettings `yaml:"dind,omitempty"`
+	ForwardConfig *bool         `yaml:"forward_config,omitempty"`
+	ConfigPath    string        `yaml:"config_path,omitempty"`
+	BuildTimeout  string        `yaml:"build_timeout,omitempty"` // e.g. "60m"; "0" disables
+	PullPolicy    string        `yaml:"pull_policy,omitempty"`   // "always", "missing" or "never"
+	CpusetCPUs    string        `yaml:"cpuset_cpus,omitempty"`   // e.g. "0-3,8"
+	CpusetMems    string        `yaml:"cpuset_mems,omitempty"`   // e.g. "0"
 }
 
 // ContainerSettings holds container resource limits
 type ContainerSettings struct {
-	CPUs   string `yaml:"cpus,omitempty"`
-	Memory string `yaml:"memory,omitempty"`
+	CPUs          string `yaml:"cpus,omitempty"`
+	Memory        string `yaml:"memory,omitempty"`
+	MaxAge        string `yaml:"max_age,omitempty"`        // Recreate persistent containers older than this (e.g., "7d", "12h")
+	DetachKeys    string `yaml:"detach_keys,omitempty"`    // Detach sequence for interactive sessions (e.g., "ctrl-x,x")
+	Entrypoint    string `yaml:"entrypoint,omitempty"`     // Custom entrypoint for images that need their own (skips addt init)
+	Platform      string `yaml:"platform,omitempty"`       // Target platform for builds and runs (e.g., "linux/amd64")
+	Init          *bool  `yaml:"init,omitempty"`           // Run an init process (tini) as PID 1 (default: true)
+	Name          string `yaml:"name,omitempty"`           // Fixed persistent container name instead of the generated one
+	EnvPrecedence string `yaml:"env_precedence,omitempty"` // env-wins (default) or config-wins for forwarded env vars
 }
 
 // VmSettings holds VM resource configuration (Podman machine, Docker Desktop)
@@ -50,6 +66,7 @@ type PortsSettings struct {
 	Expose             []string `yaml:"expose,omitempty"`
 	RangeStart         *int     `yaml:"range_start,omitempty"`
 	InjectSystemPrompt *bool    `yaml:"inject_system_prompt,omitempty"`
+	PromptTemplate     string   `yaml:"prompt_template,omitempty"` // text/template for the injected port prompt
 }
 
 // SSHSettings holds SSH forwarding configuration
@@ -58,6 +75,7 @@ type SSHSettings struct {
 	ForwardMode string   `yaml:"forward_mode,omitempty"`
 	AllowedKeys []string `yaml:"allowed_keys,omitempty"`
 	Dir         string   `yaml:"dir,omitempty"`
+	Dirs        []string `yaml:"dirs,omitempty"`
 }
 
 // GitHubSettings holds GitHub token forwarding configuration
@@ -74,6 +92,15 @@ type FirewallSettings struct {
 	Mode    string   `yaml:"mode,omitempty"`
 	Allowed []string `yaml:"allowed,omitempty"`
 	Denied  []string `yaml:"denied,omitempty"`
+	Presets []string `yaml:"presets,omitempty"` // Ecosystem allowlists, e.g. npm, pypi, go, github
+	// RequirePasta fails podman runs with the firewall when pasta is missing
+	RequirePasta *bool `yaml:"require_pasta,omitempty"`
+	// AllowNetworkOverride lets security.network_mode replace podman's pasta network
+	AllowNetworkOverride *bool `yaml:"allow_network_override,omitempty"`
+	// LogBlocked records blocked destinations to ~/.addt/firewall/blocked/<container>.log
+	LogBlocked *bool `yaml:"log_blocked,omitempty"`
+	// AsyncInit runs firewall init in the background instead of before the agent
+	AsyncInit *bool `yaml:"async_init,omitempty"`
 }
 
 // GPGSettings holds GPG forwarding configuration
@@ -85,24 +112,30 @@ type GPGSettings struct {
 
 // GitSettings holds git config forwarding configuration
 type GitSettings struct {
-	DisableHooks  *bool  `yaml:"disable_hooks,omitempty"`
-	ForwardConfig *bool  `yaml:"forward_config,omitempty"`
-	ConfigPath    string `yaml:"config_path,omitempty"`
+	DisableHooks   *bool  `yaml:"disable_hooks,omitempty"`
+	ForwardConfig  *bool  `yaml:"forward_config,omitempty"`
+	ConfigPath     string `yaml:"config_path,omitempty"`
+	ConfigReadonly *bool  `yaml:"config_readonly,omitempty"`
+	ConfigCopy     *bool  `yaml:"config_copy,omitempty"`
 }
 
 // LogSettings holds logging configuration
 type LogSettings struct {
-	Enabled  *bool  `yaml:"enabled,omitempty"`   // Enable command logging
-	Output   string `yaml:"output,omitempty"`    // Output target: stderr, stdout, file (default: stderr)
-	File     string `yaml:"file,omitempty"`      // Log file name (default: addt.log)
-	Dir      string `yaml:"dir,omitempty"`       // Log directory (default: ~/.addt/logs)
-	Level    string `yaml:"level,omitempty"`     // Log level: DEBUG, INFO, WARN, ERROR (default: INFO)
-	Modules  string `yaml:"modules,omitempty"`   // Comma-separated module filter (default: * for all)
-	Rotate   *bool  `yaml:"rotate,omitempty"`    // Enable log rotation (default: false)
-	MaxSize  string `yaml:"max_size,omitempty"`  // Max file size before rotating (e.g. "10m", default: 10m)
-	MaxFiles *int   `yaml:"max_files,omitempty"` // Number of rotated files to keep (default: 5)
+	Enabled          *bool  `yaml:"enabled,omitempty"`           // Enable command logging
+	Output           string `yaml:"output,omitempty"`            // Output target: stderr, stdout, file (default: stderr)
+	File             string `yaml:"file,omitempty"`              // Log file name (default: addt.log)
+	Dir              string `yaml:"dir,omitempty"`               // Log directory (default: ~/.addt/logs)
+	Level            string `yaml:"level,omitempty"`             // Log level: DEBUG, INFO, WARN, ERROR (default: INFO)
+	Modules          string `yaml:"modules,omitempty"`           // Comma-separated module filter (default: * for all)
+	Rotate           *bool  `yaml:"rotate,omitempty"`            // Enable log rotation (default: false)
+	MaxSize          string `yaml:"max_size,omitempty"`          // Max file size before rotating (e.g. "10m", default: 10m)
+	MaxFiles         *int   `yaml:"max_files,omitempty"`         // Number of rotated files to keep (default: 5)
+	CaptureContainer *bool  `yaml:"capture_container,omitempty"` // Tee non-interactive container output into the log (default: false)
 }
 
+// AuthMethods are the accepted values of auth.method, globally and per extension
+var AuthMethods = extensions.AuthMethods
+
 // AuthSettings holds authentication configuration
 type AuthSettings struct {
 	Autologin *bool  `yaml:"autologin,omitempty"` // Automatically handle authentication on first launch (default: true)
@@ -120,16 +153,28 @@ type TerminalSettings struct {
 	OSC *bool `yaml:"osc,omitempty"` // Forward terminal identification for OSC support (default: false)
 }
 
+// HistorySettings holds shell history persistence configuration
+type HistorySettings struct {
+	Dir string `yaml:"dir,omitempty"` // Where history files are kept (default: ~/.addt/history)
+}
+
+// HomeSettings holds container home directory configuration
+type HomeSettings struct {
+	PersistSubdirs []string `yaml:"persist_subdirs,omitempty"` // Home subdirs kept in per-workdir named volumes
+}
+
 // WorkdirSettings holds working directory configuration
 type WorkdirSettings struct {
 	Path      string `yaml:"path,omitempty"`      // Override working directory (default: current directory)
 	Automount *bool  `yaml:"automount,omitempty"` // Auto-mount working directory to /workspace
 	Readonly  *bool  `yaml:"readonly,omitempty"`  // Mount working directory as read-only
+	Overlay   *bool  `yaml:"overlay,omitempty"`   // Give the container a throwaway writable layer over the workdir
 	Autotrust *bool  `yaml:"autotrust,omitempty"` // Trust the /workspace directory on first launch (default: true)
 }
 
 // ProviderSettings holds provider selection configuration
 type ProviderSettings struct {
+	Name       string   `yaml:"name,omitempty"` // Provider to always use, skipping auto-detection
 	Autoselect []string `yaml:"autoselect,omitempty"`
 }
 
@@ -144,6 +189,9 @@ type GlobalConfig struct {
 	GitHub         *GitHubSettings    `yaml:"github,omitempty"`
 	EnvFileLoad    *bool              `yaml:"env_file_load,omitempty"`
 	EnvFile        string             `yaml:"env_file,omitempty"`
+	ForwardFiles   []string           `yaml:"forward_files,omitempty"` // host_path[:container_path][:ro|:rw]
+	Volumes        []string           `yaml:"volumes,omitempty"`       // source:target[:ro|:rw] host directories mounted into the container
+	EnvVars        []string           `yaml:"env_vars,omitempty"`      // host env vars forwarded into the container
 	GoVersion      string             `yaml:"go_version,omitempty"`
 	GPG            *GPGSettings       `yaml:"gpg,omitempty"`
 	Log            *LogSettings       `yaml:"log,omitempty"`
@@ -154,8 +202,12 @@ type GlobalConfig struct {
 	Terminal       *TerminalSettings  `yaml:"terminal,omitempty"`
 	TmuxForward    *bool              `yaml:"tmux_forward,omitempty"`
 	HistoryPersist *bool              `yaml:"history_persist,omitempty"` // Persist shell history between sessions
+	History        *HistorySettings   `yaml:"history,omitempty"`
+	Home           *HomeSettings      `yaml:"home,omitempty"`
 	UvVersion      string             `yaml:"uv_version,omitempty"`
 	Workdir        *WorkdirSettings   `yaml:"workdir,omitempty"`
+	Mode           string             `yaml:"mode,omitempty"`    // container or shell
+	Command        string             `yaml:"command,omitempty"` // agent command run in the container (default: extension entrypoint)
 	Auth           *AuthSettings      `yaml:"auth,omitempty"`
 	Config         *ConfigSettings    `yaml:"config,omitempty"`
 
@@ -167,6 +219,10 @@ type GlobalConfig struct {
 
 	// OpenTelemetry configuration
 	Otel *otel.Settings `yaml:"otel,omitempty"`
+
+	// Unknown top-level keys (from a newer addt version or a typo), kept so
+	// they survive a load/save round-trip instead of being silently dropped
+	Unknown map[string]yaml.Node `yaml:",inline"`
 }
 
 // Config holds all configuration options
@@ -183,19 +239,33 @@ type Config struct {
 	Ports                     []string
 	PortRangeStart            int
 	PortsInjectSystemPrompt   bool
+	PortsPromptTemplate       string
 	SSHForwardKeys            bool
 	SSHForwardMode            string
 	SSHAllowedKeys            []string
 	TmuxForward               bool
-	HistoryPersist            bool     // Persist shell history between sessions (default: false)
-	SSHDir                    string   // SSH directory path (default: ~/.ssh)
-	GitDisableHooks           bool     // Neutralize git hooks inside container (default: true)
-	GitForwardConfig          bool     // Forward .gitconfig to container (default: true)
-	GitConfigPath             string   // Custom .gitconfig file path
-	GPGForward                string   // "proxy", "agent", "keys", or "off"
-	GPGAllowedKeyIDs          []string // GPG key IDs allowed for signing
-	GPGDir                    string   // GPG directory path (default: ~/.gnupg)
+	HistoryPersist            bool                   // Persist shell history between sessions (default: false)
+	HistoryDir                string                 // Where history files are kept (default: ~/.addt/history)
+	HomePersistSubdirs        []string               // Home subdirs kept in per-workdir named volumes
+	SSHDir                    string                 // SSH directory path (default: ~/.ssh)
+	SSHDirs                   []string               // Extra SSH directories forwarded alongside SSHDir
+	ForwardFiles              []provider.ForwardFile // Extra host files forwarded into the container
+	Volumes                   []string               // Extra host directories to mount, source:target[:ro|:rw]
+	GitDisableHooks           bool                   // Neutralize git hooks inside container (default: true)
+	GitForwardConfig          bool                   // Forward .gitconfig to container (default: true)
+	GitConfigPath             string                 // Custom .gitconfig file path
+	GitConfigReadonly         bool                   // Mount .gitconfig read-only (default: true)
+	GitConfigCopy             bool                   // Deliver .gitconfig by value instead of a bind mount (default: false)
+	GPGForward                string                 // "proxy", "agent", "keys", or "off"
+	GPGAllowedKeyIDs          []string               // GPG key IDs allowed for signing
+	GPGDir                    string                 // GPG directory path (default: ~/.gnupg)
 	DockerDindMode            string
+	DockerForwardConfig       bool   // Mount host ~/.docker/config.json (default: false)
+	DockerConfigPath          string // Custom Docker CLI config.json path
+	DockerBuildTimeout        string // Kill image builds running longer than this (default: 60m, 0 = no limit)
+	DockerPullPolicy          string // Base image pulls: "always", "missing" (default) or "never"
+	DockerCpusetCPUs          string // Pin the container to these CPUs (e.g. "0-3,8")
+	DockerCpusetMems          string // Pin the container to these NUMA memory nodes (e.g. "0")
 	EnvFileLoad               bool
 	EnvFile                   string
 	LogEnabled                bool
@@ -207,14 +277,20 @@ type Config struct {
 	LogRotate                 bool   // Enable log rotation
 	LogMaxSize                string // Max file size before rotating (e.g. "10m")
 	LogMaxFiles               int    // Number of rotated files to keep
+	LogCaptureContainer       bool   // Tee non-interactive container output into the log
 	ImageName                 string
 	Persistent                bool                       // Enable persistent container mode
 	WorkdirAutomount          bool                       // Auto-mount working directory
 	WorkdirReadonly           bool                       // Mount working directory as read-only
+	WorkdirOverlay            bool                       // Discard container writes to the working directory
 	WorkdirAutotrust          bool                       // Trust the /workspace directory on first launch (default: true)
 	Workdir                   string                     // Override working directory (default: current directory)
 	FirewallEnabled           bool                       // Enable network firewall
 	FirewallMode              string                     // Firewall mode: strict, permissive, off
+	FirewallRequirePasta      bool                       // Fail podman firewall runs without pasta instead of warning
+	FirewallNetworkOverride   bool                       // Podman: security.network_mode replaces pasta with the firewall on
+	FirewallLogBlocked        bool                       // Log blocked destinations for "addt firewall log"
+	FirewallAsyncInit         bool                       // Don't wait for firewall init before starting the agent
 	GlobalFirewallAllowed     []string                   // Global allowed domains
 	GlobalFirewallDenied      []string                   // Global denied domains
 	ProjectFirewallAllowed    []string                   // Project allowed domains
@@ -239,10 +315,21 @@ type Config struct {
 	TerminalOSC               bool                       // Forward terminal identification for OSC support (default: false)
 	ContainerCPUs             string                     // Container CPU limit (e.g., "2", "0.5", "1.5")
 	ContainerMemory           string                     // Container memory limit (e.g., "512m", "2g", "4gb")
+	ContainerMaxAge           string                     // Recreate persistent containers older than this (empty = disabled)
+	ContainerDetachKeys       string                     // Detach sequence for interactive run/exec (empty = runtime default)
+	ContainerEntrypoint       string                     // Custom entrypoint replacing the image wrapper (empty = image entrypoint)
+	ContainerPlatform         string                     // Target platform for builds and runs (e.g., "linux/amd64"; empty = host)
+	ContainerInit             bool                       // Add --init to new interactive containers (default: true)
+	ContainerName             string                     // Persistent container name override (empty = generated)
+	ContainerEnvPrecedence    string                     // env-wins (default) or config-wins for forwarded env var values
 
 	// Security settings
 	Security security.Config
 
 	// OpenTelemetry settings
 	Otel otel.Config
+
+	// Sources maps config keys to the layer that supplied them; only
+	// populated when ADDT_EXPLAIN_CONFIG=true (addt run --explain-config)
+	Sources map[string]string
 }
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/jedi4ever/addt/provider"
+	"github.com/jedi4ever/addt/util"
+)
+
+// dockerConfigTarget is where the host Docker CLI config is mounted
+const dockerConfigTarget = "/home/addt/.docker/config.json"
+
+// dockerConfigSecretVar carries the Docker CLI config through the secrets
+// tmpfs when isolate_secrets is enabled; the entrypoint writes it to
+// /run/secrets/docker/config.json and points DOCKER_CONFIG there.
+const dockerConfigSecretVar = "ADDT_DOCKER_CONFIG_JSON"
+
+// resolveDockerConfigPath returns the host Docker CLI config.json path
+func resolveDockerConfigPath(cfg *provider.Config) string {
+	if cfg.DockerConfigPath != "" {
+		return util.ExpandTilde(cfg.DockerConfigPath)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".docker", "config.json")
+}
+
+// addDockerConfigForwarding forwards the host Docker CLI config so registry
+// logins carry over into the container. The file is mounted read-only, unless
+// isolate_secrets is on: its auth tokens then travel via the secrets tmpfs
+// instead of a bind mount of the host file.
+func addDockerConfigForwarding(spec *provider.RunSpec, cfg *provider.Config) {
+	if !cfg.DockerForwardConfig {
+		return
+	}
+
+	path := resolveDockerConfigPath(cfg)
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		envLogger.Debugf("Docker config not found at %s, skipping forwarding", path)
+		return
+	}
+
+	if !cfg.Security.IsolateSecrets {
+		spec.Volumes = append(spec.Volumes, provider.VolumeMount{
+			Source:   path,
+			Target:   dockerConfigTarget,
+			ReadOnly: true,
+		})
+		return
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		envLogger.Warning("failed to read Docker config %s: %v", path, err)
+		return
+	}
+	spec.Env[dockerConfigSecretVar] = string(data)
+	addCredentialVar(spec.Env, dockerConfigSecretVar)
+}
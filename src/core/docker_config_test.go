@@ -0,0 +1,86 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jedi4ever/addt/config/security"
+	"github.com/jedi4ever/addt/provider"
+)
+
+func writeDockerConfig(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"auths":{"registry.example.com":{"auth":"dG9rZW4="}}}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestAddDockerConfigForwarding_Disabled(t *testing.T) {
+	spec := &provider.RunSpec{Env: map[string]string{}}
+	cfg := &provider.Config{DockerConfigPath: writeDockerConfig(t)}
+
+	addDockerConfigForwarding(spec, cfg)
+
+	if len(spec.Volumes) != 0 || spec.Env[dockerConfigSecretVar] != "" {
+		t.Errorf("expected no forwarding when disabled, got volumes=%v env=%v", spec.Volumes, spec.Env)
+	}
+}
+
+func TestAddDockerConfigForwarding_MountsReadOnly(t *testing.T) {
+	path := writeDockerConfig(t)
+	spec := &provider.RunSpec{Env: map[string]string{}}
+	cfg := &provider.Config{DockerForwardConfig: true, DockerConfigPath: path}
+
+	addDockerConfigForwarding(spec, cfg)
+
+	if len(spec.Volumes) != 1 {
+		t.Fatalf("expected 1 volume, got %v", spec.Volumes)
+	}
+	vol := spec.Volumes[0]
+	if vol.Source != path || vol.Target != "/home/addt/.docker/config.json" || !vol.ReadOnly {
+		t.Errorf("volume = %+v, want %s -> /home/addt/.docker/config.json (ro)", vol, path)
+	}
+	if _, ok := spec.Env[dockerConfigSecretVar]; ok {
+		t.Error("config content should not be in env when secrets are not isolated")
+	}
+}
+
+func TestAddDockerConfigForwarding_IsolateSecrets(t *testing.T) {
+	path := writeDockerConfig(t)
+	spec := &provider.RunSpec{Env: map[string]string{"ADDT_CREDENTIAL_VARS": "CLAUDE_OAUTH_CREDENTIALS"}}
+	cfg := &provider.Config{
+		DockerForwardConfig: true,
+		DockerConfigPath:    path,
+		Security:            security.Config{IsolateSecrets: true},
+	}
+
+	addDockerConfigForwarding(spec, cfg)
+
+	if len(spec.Volumes) != 0 {
+		t.Errorf("host config must not be bind-mounted with isolate_secrets, got %v", spec.Volumes)
+	}
+	data, _ := os.ReadFile(path)
+	if spec.Env[dockerConfigSecretVar] != string(data) {
+		t.Errorf("%s = %q, want file content", dockerConfigSecretVar, spec.Env[dockerConfigSecretVar])
+	}
+	if got := spec.Env["ADDT_CREDENTIAL_VARS"]; got != "CLAUDE_OAUTH_CREDENTIALS,"+dockerConfigSecretVar {
+		t.Errorf("ADDT_CREDENTIAL_VARS = %q", got)
+	}
+}
+
+func TestAddDockerConfigForwarding_MissingFile(t *testing.T) {
+	spec := &provider.RunSpec{Env: map[string]string{}}
+	cfg := &provider.Config{
+		DockerForwardConfig: true,
+		DockerConfigPath:    filepath.Join(t.TempDir(), "missing.json"),
+	}
+
+	addDockerConfigForwarding(spec, cfg)
+
+	if len(spec.Volumes) != 0 || len(spec.Env) != 0 {
+		t.Errorf("expected nothing forwarded for a missing file, got volumes=%v env=%v", spec.Volumes, spec.Env)
+	}
+}
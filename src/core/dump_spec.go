@@ -0,0 +1,113 @@
+package core
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+
+	"github.com/jedi4ever/addt/provider"
+)
+
+// SpecDump is the JSON form of a resolved RunSpec written by --dump-spec.
+// Its field names are a stable contract for tooling. Secret env values and
+// security args are redacted as in --print-only-env; slices are never null.
+type SpecDump struct {
+	Name             string            `json:"name"`
+	Image            string            `json:"image"`
+	Args             []string          `json:"args"`
+	EntrypointArgs   []string          `json:"entrypoint_args"`
+	Workdir          string            `json:"workdir"`
+	Interactive      bool              `json:"interactive"`
+	Persistent       bool              `json:"persistent"`
+	Volumes          []VolumeDump      `json:"volumes"`
+	Mounts           []string          `json:"mounts"`
+	Ports            []PortDump        `json:"ports"`
+	Env              map[string]string `json:"env"`
+	SSHForwardKeys   bool              `json:"ssh_forward_keys"`
+	SSHForwardMode   string            `json:"ssh_forward_mode"`
+	SSHAllowedKeys   []string          `json:"ssh_allowed_keys"`
+	GPGForward       string            `json:"gpg_forward"`
+	GPGAllowedKeyIDs []string          `json:"gpg_allowed_key_ids"`
+	TmuxForward      bool              `json:"tmux_forward"`
+	HistoryPersist   bool              `json:"history_persist"`
+	DindMode         string            `json:"dind_mode"`
+	CPUs             string            `json:"cpus"`
+	Memory           string            `json:"memory"`
+	Security         []string          `json:"security"`
+}
+
+// VolumeDump is a mount in a SpecDump
+type VolumeDump struct {
+	Source   string `json:"source"`
+	Target   string `json:"target"`
+	ReadOnly bool   `json:"readonly"`
+	Overlay  bool   `json:"overlay"`
+}
+
+// PortDump is a port mapping in a SpecDump
+type PortDump struct {
+	Container int `json:"container"`
+	Host      int `json:"host"`
+}
+
+// NewSpecDump converts spec and the provider's security args to their dump
+// form, with mounts sorted by target like the env report
+func NewSpecDump(spec *provider.RunSpec, securityArgs []string) SpecDump {
+	d := SpecDump{
+		Name:             spec.Name,
+		Image:            spec.ImageName,
+		Args:             nonNil(spec.Args),
+		EntrypointArgs:   nonNil(spec.EntrypointArgs),
+		Workdir:          spec.WorkDir,
+		Interactive:      spec.Interactive,
+		Persistent:       spec.Persistent,
+		Volumes:          []VolumeDump{},
+		Mounts:           nonNil(spec.Mounts),
+		Ports:            []PortDump{},
+		Env:              map[string]string{},
+		SSHForwardKeys:   spec.SSHForwardKeys,
+		SSHForwardMode:   spec.SSHForwardMode,
+		SSHAllowedKeys:   nonNil(spec.SSHAllowedKeys),
+		GPGForward:       spec.GPGForward,
+		GPGAllowedKeyIDs: nonNil(spec.GPGAllowedKeyIDs),
+		TmuxForward:      spec.TmuxForward,
+		HistoryPersist:   spec.HistoryPersist,
+		DindMode:         spec.DockerDindMode,
+		CPUs:             spec.ContainerCPUs,
+		Memory:           spec.ContainerMemory,
+		Security:         nonNil(redactSecurityArgs(securityArgs)),
+	}
+	for _, v := range spec.Volumes {
+		d.Volumes = append(d.Volumes, VolumeDump{Source: v.Source, Target: v.Target, ReadOnly: v.ReadOnly, Overlay: v.Overlay})
+	}
+	sort.SliceStable(d.Volumes, func(i, j int) bool { return d.Volumes[i].Target < d.Volumes[j].Target })
+	for _, p := range spec.Ports {
+		d.Ports = append(d.Ports, PortDump{Container: p.Container, Host: p.Host})
+	}
+	credVars := credentialVarSet(spec.Env)
+	for k, v := range spec.Env {
+		d.Env[k] = redactEnvValue(k, v, credVars)
+	}
+	return d
+}
+
+// DumpSpec resolves the run options without starting a container and
+// writes them to w as indented JSON
+func (r *Runner) DumpSpec(w io.Writer, args []string, openShell bool) error {
+	spec := BuildRunOptions(r.provider, r.config, r.generateName(), args, openShell)
+	var secArgs []string
+	if sp, ok := r.provider.(provider.SecurityArgsProvider); ok {
+		secArgs = sp.SecurityArgs()
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(NewSpecDump(spec, secArgs))
+}
+
+// nonNil returns s, or an empty slice so it encodes as [] instead of null
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,73 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jedi4ever/addt/provider"
+)
+
+func TestDumpSpec_MatchesConstructedSpec(t *testing.T) {
+	t.Setenv("ADDT_DUMP_SPEC_TEST_TOKEN", "s3cret")
+	cfg := &provider.Config{
+		ImageName:        "test-image",
+		WorkdirAutomount: true,
+		Workdir:          t.TempDir(),
+		EnvVars:          []string{"ADDT_DUMP_SPEC_TEST_TOKEN"},
+		SSHForwardKeys:   true,
+		SSHForwardMode:   "proxy",
+		ContainerMemory:  "2g",
+	}
+	p := &launchTrackingProvider{}
+	runner := NewRunner(p, cfg)
+
+	var buf bytes.Buffer
+	if err := runner.DumpSpec(&buf, []string{"--help"}, false); err != nil {
+		t.Fatalf("DumpSpec() error = %v", err)
+	}
+	if p.launched {
+		t.Error("DumpSpec started a container")
+	}
+	if strings.Contains(buf.String(), "s3cret") || strings.Contains(buf.String(), "abc") {
+		t.Errorf("dump leaks a secret value:\n%s", buf.String())
+	}
+
+	var got SpecDump
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("dump is not valid JSON: %v\n%s", err, buf.String())
+	}
+	spec := BuildRunOptions(p, cfg, runner.generateName(), []string{"--help"}, false)
+	want := NewSpecDump(spec, p.SecurityArgs())
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("dumped spec = %+v\nwant %+v", got, want)
+	}
+
+	if got.Image != "test-image" || !reflect.DeepEqual(got.Args, []string{"--help"}) || got.Memory != "2g" {
+		t.Errorf("image/args/memory = %q/%v/%q", got.Image, got.Args, got.Memory)
+	}
+	if !got.SSHForwardKeys || got.SSHForwardMode != "proxy" {
+		t.Errorf("ssh forwarding = %v/%q, want true/proxy", got.SSHForwardKeys, got.SSHForwardMode)
+	}
+	if got.Env["ADDT_DUMP_SPEC_TEST_TOKEN"] != redactedValue {
+		t.Errorf("env ADDT_DUMP_SPEC_TEST_TOKEN = %q, want redacted", got.Env["ADDT_DUMP_SPEC_TEST_TOKEN"])
+	}
+	if len(got.Volumes) == 0 || got.Volumes[0].Target != "/workspace" {
+		t.Errorf("volumes = %+v, want the /workspace mount", got.Volumes)
+	}
+	if got.Security[len(got.Security)-1] != "-e API_TOKEN=<redacted>" {
+		t.Errorf("security = %v, want redacted -e value", got.Security)
+	}
+}
+
+func TestNewSpecDump_EmptySlicesEncodeAsArrays(t *testing.T) {
+	data, err := json.Marshal(NewSpecDump(&provider.RunSpec{Name: "addt-test"}, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "null") {
+		t.Errorf("dump contains null, want empty arrays/objects: %s", data)
+	}
+}
@@ -10,7 +10,6 @@ import (
 	"github.com/jedi4ever/addt/extensions"
 	"github.com/jedi4ever/addt/provider"
 	"github.com/jedi4ever/addt/util"
-	"github.com/jedi4ever/addt/util/terminal"
 )
 
 var envLogger = util.Log("env")
@@ -37,12 +36,18 @@ func BuildEnvironment(p provider.Provider, cfg *provider.Config) map[string]stri
 	// Add firewall configuration
 	addFirewallEnvVars(env, cfg)
 
+	// Add the commands allowed to receive isolated secrets
+	addSecretConsumersEnvVar(env, cfg)
+
 	// Add GitHub scope configuration
 	addGitHubScopeEnvVars(env, cfg)
 
 	// Add git hooks neutralization
 	addGitHooksEnvVars(env, cfg)
 
+	// Add .gitconfig write-back for a writable mount
+	addGitconfigEnvVars(env, cfg)
+
 	// Add command override
 	addCommandEnvVar(env, cfg)
 
@@ -66,12 +71,9 @@ func addExtensionEnvVars(env map[string]string, p provider.Provider, cfg *provid
 	extensionEnvVars := p.GetExtensionEnvVars(cfg.ImageName)
 	for _, varSpec := range extensionEnvVars {
 		varName, defaultValue := parseEnvVarSpec(varSpec)
-		if value := os.Getenv(varName); value != "" {
-			// Host has the var set, use it
+		// The host value wins over the default unless container.env_precedence is config-wins
+		if value := provider.ResolveEnvValue(os.Getenv(varName), defaultValue, cfg.ContainerEnvPrecedence); value != "" {
 			env[varName] = value
-		} else if defaultValue != "" {
-			// Use the default value from config
-			env[varName] = defaultValue
 		}
 	}
 
@@ -213,10 +215,13 @@ func addFlagEnvVars(env map[string]string, cfg *provider.Config, args []string)
 	}
 }
 
-// addUserEnvVars adds user-configured environment variables
+// addUserEnvVars adds the env_vars entries. "NAME" forwards the host value;
+// "NAME=value" also gives a config value, which fills in when the host
+// leaves NAME unset or, with container.env_precedence config-wins, replaces it.
 func addUserEnvVars(env map[string]string, cfg *provider.Config) {
-	for _, varName := range cfg.EnvVars {
-		if value := os.Getenv(varName); value != "" {
+	for _, varSpec := range cfg.EnvVars {
+		varName, configValue := parseEnvVarSpec(varSpec)
+		if value := provider.ResolveEnvValue(os.Getenv(varName), configValue, cfg.ContainerEnvPrecedence); value != "" {
 			env[varName] = value
 		}
 	}
@@ -256,8 +261,8 @@ func addTerminalEnvVars(env map[string]string, cfg *provider.Config) {
 		}
 	}
 
-	// Pass terminal size (critical for proper line handling in containers)
-	cols, lines := terminal.GetTerminalSize()
+	// Pass initial terminal size (critical for proper line handling in containers)
+	cols, lines := terminalSize()
 	env["COLUMNS"] = fmt.Sprintf("%d", cols)
 	env["LINES"] = fmt.Sprintf("%d", lines)
 }
@@ -267,6 +272,12 @@ func addFirewallEnvVars(env map[string]string, cfg *provider.Config) {
 	if cfg.FirewallEnabled {
 		env["ADDT_FIREWALL_ENABLED"] = "true"
 		env["ADDT_FIREWALL_MODE"] = cfg.FirewallMode
+		if cfg.FirewallLogBlocked {
+			env["ADDT_FIREWALL_LOG_BLOCKED"] = "true"
+		}
+		if cfg.FirewallAsyncInit {
+			env["ADDT_FIREWALL_ASYNC"] = "true"
+		}
 	}
 }
 
@@ -290,11 +301,15 @@ func addGitHubScopeEnvVars(env map[string]string, cfg *provider.Config) {
 // addExtensionConfigEnvVars passes per-extension config overrides to the container
 // These override defaults from extensions.json inside the container
 func addExtensionConfigEnvVars(env map[string]string, cfg *provider.Config) {
-	// Pass global workdir.autotrust setting
+	// Pass global workdir.autotrust setting; an explicit addt trust/untrust
+	// decision replaces it and the per-extension overrides below
 	env["ADDT_WORKDIR_AUTOTRUST"] = fmt.Sprintf("%v", cfg.WorkdirAutotrust)
+	if cfg.WorkdirTrusted != nil {
+		env["ADDT_WORKDIR_AUTOTRUST"] = fmt.Sprintf("%v", *cfg.WorkdirTrusted)
+	}
 
 	// Pass global config settings
-	env["ADDT_CONFIG_AUTOMOUNT"] = fmt.Sprintf("%v", cfg.ConfigAutomount)
+	env["ADDT_CONFIG_AUTOMOUNT"] = fmt.Sprintf("%v", cfg.ConfigAutomount && !cfg.NoConfigAutomount)
 	env["ADDT_CONFIG_READONLY"] = fmt.Sprintf("%v", cfg.ConfigReadonly)
 
 	// Pass global auth settings
@@ -308,7 +323,7 @@ func addExtensionConfigEnvVars(env map[string]string, cfg *provider.Config) {
 		extUpper := strings.ToUpper(strings.ReplaceAll(extName, "-", "_"))
 
 		// Pass config.automount override
-		if val, ok := cfg.ExtensionConfigAutomount[extName]; ok {
+		if val, ok := cfg.ExtensionConfigAutomount[extName]; ok && !cfg.NoConfigAutomount {
 			env[fmt.Sprintf("ADDT_%s_CONFIG_AUTOMOUNT", extUpper)] = fmt.Sprintf("%v", val)
 		}
 
@@ -318,7 +333,7 @@ func addExtensionConfigEnvVars(env map[string]string, cfg *provider.Config) {
 		}
 
 		// Pass workdir.autotrust override
-		if val, ok := cfg.ExtensionWorkdirAutotrust[extName]; ok {
+		if val, ok := cfg.ExtensionWorkdirAutotrust[extName]; ok && cfg.WorkdirTrusted == nil {
 			env[fmt.Sprintf("ADDT_%s_WORKDIR_AUTOTRUST", extUpper)] = fmt.Sprintf("%v", val)
 		}
 
@@ -18,12 +18,14 @@ func (m *mockEnvProvider) Exists(name string) bool                            {
 func (m *mockEnvProvider) IsRunning(name string) bool                         { return false }
 func (m *mockEnvProvider) Start(name string) error                            { return nil }
 func (m *mockEnvProvider) Stop(name string) error                             { return nil }
+func (m *mockEnvProvider) Restart(name string) error                          { return nil }
 func (m *mockEnvProvider) Remove(name string) error                           { return nil }
 func (m *mockEnvProvider) List() ([]provider.Environment, error)              { return nil, nil }
 func (m *mockEnvProvider) GeneratePersistentName() string                     { return "test-persistent" }
 func (m *mockEnvProvider) GenerateEphemeralName() string                      { return "test-ephemeral" }
 func (m *mockEnvProvider) GetStatus(cfg *provider.Config, name string) string { return "test" }
 func (m *mockEnvProvider) GetName() string                                    { return "mock" }
+func (m *mockEnvProvider) Stats(name string) (provider.Stats, error)          { return provider.Stats{}, nil }
 func (m *mockEnvProvider) GetExtensionEnvVars(imageName string) []string      { return nil }
 func (m *mockEnvProvider) DetermineImageName() string                         { return "test-image" }
 func (m *mockEnvProvider) BuildIfNeeded(rebuild bool, rebuildBase bool) error { return nil }
@@ -60,6 +62,45 @@ func TestBuildEnvironment_Firewall(t *testing.T) {
 	}
 }
 
+func TestBuildEnvironment_FirewallLogBlocked(t *testing.T) {
+	cfg := &provider.Config{FirewallEnabled: true, FirewallMode: "strict"}
+	if _, ok := BuildEnvironment(&mockEnvProvider{}, cfg)["ADDT_FIREWALL_LOG_BLOCKED"]; ok {
+		t.Error("ADDT_FIREWALL_LOG_BLOCKED should not be set unless firewall.log_blocked is on")
+	}
+
+	cfg.FirewallLogBlocked = true
+	if got := BuildEnvironment(&mockEnvProvider{}, cfg)["ADDT_FIREWALL_LOG_BLOCKED"]; got != "true" {
+		t.Errorf("ADDT_FIREWALL_LOG_BLOCKED = %q, want true", got)
+	}
+	opts := BuildRunOptions(&mockOptionsProvider{}, cfg, "test-container", nil, false)
+	if got := opts.Env["ADDT_FIREWALL_LOG_NAME"]; got != "test-container" {
+		t.Errorf("ADDT_FIREWALL_LOG_NAME = %q, want test-container", got)
+	}
+
+	// Nothing to log without the firewall
+	cfg.FirewallEnabled = false
+	if _, ok := BuildEnvironment(&mockEnvProvider{}, cfg)["ADDT_FIREWALL_LOG_BLOCKED"]; ok {
+		t.Error("ADDT_FIREWALL_LOG_BLOCKED should not be set with the firewall off")
+	}
+}
+
+func TestBuildEnvironment_FirewallAsync(t *testing.T) {
+	cfg := &provider.Config{FirewallEnabled: true, FirewallMode: "strict"}
+	if _, ok := BuildEnvironment(&mockEnvProvider{}, cfg)["ADDT_FIREWALL_ASYNC"]; ok {
+		t.Error("ADDT_FIREWALL_ASYNC should not be set by default")
+	}
+
+	cfg.FirewallAsyncInit = true
+	if got := BuildEnvironment(&mockEnvProvider{}, cfg)["ADDT_FIREWALL_ASYNC"]; got != "true" {
+		t.Errorf("ADDT_FIREWALL_ASYNC = %q, want true", got)
+	}
+
+	cfg.FirewallEnabled = false
+	if _, ok := BuildEnvironment(&mockEnvProvider{}, cfg)["ADDT_FIREWALL_ASYNC"]; ok {
+		t.Error("ADDT_FIREWALL_ASYNC should not be set with the firewall off")
+	}
+}
+
 func TestBuildEnvironment_FirewallDisabled(t *testing.T) {
 	cfg := &provider.Config{
 		FirewallEnabled: false,
@@ -72,6 +113,25 @@ func TestBuildEnvironment_FirewallDisabled(t *testing.T) {
 	}
 }
 
+func TestBuildEnvironment_WorkdirTrustDecision(t *testing.T) {
+	untrusted := false
+	cfg := &provider.Config{
+		Extensions:                "claude",
+		WorkdirAutotrust:          true,
+		WorkdirTrusted:            &untrusted,
+		ExtensionWorkdirAutotrust: map[string]bool{"claude": true},
+	}
+
+	env := BuildEnvironment(&mockEnvProvider{}, cfg)
+
+	if env["ADDT_WORKDIR_AUTOTRUST"] != "false" {
+		t.Errorf("ADDT_WORKDIR_AUTOTRUST = %q, want 'false' from addt untrust", env["ADDT_WORKDIR_AUTOTRUST"])
+	}
+	if _, ok := env["ADDT_CLAUDE_WORKDIR_AUTOTRUST"]; ok {
+		t.Error("per-extension autotrust override should not be passed with an explicit trust decision")
+	}
+}
+
 func TestBuildEnvironment_Command(t *testing.T) {
 	cfg := &provider.Config{
 		Command: "codex",
@@ -530,3 +590,70 @@ func TestParseEnvVarSpec(t *testing.T) {
 		})
 	}
 }
+
+func TestAddTerminalEnvVars_InitialSize(t *testing.T) {
+	// Scenario: not attached to a terminal (go test). COLUMNS/LINES are set
+	// once at start from the host env, falling back to 80x24. Live resizes are
+	// propagated by the runtime's TTY (-t), not by these vars.
+	cases := []struct {
+		name, columns, lines, wantColumns, wantLines string
+	}{
+		{"host env", "132", "50", "132", "50"},
+		{"fallback", "", "", "80", "24"},
+		{"invalid", "wide", "-3", "80", "24"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("COLUMNS", tc.columns)
+			t.Setenv("LINES", tc.lines)
+
+			env := make(map[string]string)
+			addTerminalEnvVars(env, &provider.Config{})
+
+			if env["COLUMNS"] != tc.wantColumns || env["LINES"] != tc.wantLines {
+				t.Errorf("COLUMNS/LINES = %s/%s, want %s/%s", env["COLUMNS"], env["LINES"], tc.wantColumns, tc.wantLines)
+			}
+		})
+	}
+}
+
+func TestBuildEnvironment_EnvVarsAllowlist(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "sk-test")
+	t.Setenv("GH_TOKEN", "gh-test")
+	cfg := &provider.Config{EnvVars: []string{"OPENAI_API_KEY"}}
+
+	env := BuildEnvironment(&mockEnvProvider{}, cfg)
+
+	if env["OPENAI_API_KEY"] != "sk-test" {
+		t.Errorf("OPENAI_API_KEY = %q, want forwarded", env["OPENAI_API_KEY"])
+	}
+	if _, ok := env["GH_TOKEN"]; ok {
+		t.Error("GH_TOKEN should not be forwarded when not in env_vars")
+	}
+}
+
+func TestBuildEnvironment_EnvPrecedence(t *testing.T) {
+	t.Setenv("NODE_ENV", "development")
+	t.Setenv("ADDT_TEST_UNSET_VAR", "")
+	cfg := &provider.Config{EnvVars: []string{"NODE_ENV=production", "ADDT_TEST_UNSET_VAR=fallback"}}
+
+	tests := []struct {
+		precedence string
+		want       string
+	}{
+		{"", "development"},
+		{provider.EnvPrecedenceEnvWins, "development"},
+		{provider.EnvPrecedenceConfigWins, "production"},
+	}
+	for _, tt := range tests {
+		cfg.ContainerEnvPrecedence = tt.precedence
+		env := BuildEnvironment(&mockEnvProvider{}, cfg)
+		if env["NODE_ENV"] != tt.want {
+			t.Errorf("precedence %q: NODE_ENV = %q, want %q", tt.precedence, env["NODE_ENV"], tt.want)
+		}
+		// The config value fills in for an unset host var either way
+		if env["ADDT_TEST_UNSET_VAR"] != "fallback" {
+			t.Errorf("precedence %q: ADDT_TEST_UNSET_VAR = %q, want fallback", tt.precedence, env["ADDT_TEST_UNSET_VAR"])
+		}
+	}
+}
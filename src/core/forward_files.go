@@ -0,0 +1,63 @@
+package core
+
+import (
+	"encoding/json"
+	"os"
+	"slices"
+
+	"github.com/jedi4ever/addt/provider"
+)
+
+// forwardFilesSecretVar carries sensitive forward_files through the secrets
+// tmpfs when isolate_secrets is enabled: a JSON object of container path to
+// file content that the entrypoint writes out read-only.
+const forwardFilesSecretVar = "ADDT_FORWARD_FILES_JSON"
+
+// addForwardFiles mounts the configured forward_files. Missing host files are
+// skipped with a warning. With isolate_secrets, sensitive regular files
+// (.netrc, credentials, ...) are copied in via the secrets tmpfs instead of
+// bind-mounting the host file. extra holds sensitive --mount sources
+// routed here by addMounts.
+func addForwardFiles(spec *provider.RunSpec, cfg *provider.Config, extra ...provider.ForwardFile) {
+	copied := make(map[string]string)
+	for _, f := range slices.Concat(cfg.ForwardFiles, extra) {
+		info, err := os.Stat(f.Source)
+		if err != nil {
+			envLogger.Warning("forward_files: %s not found, skipping", f.Source)
+			continue
+		}
+
+		if !forwardFileCopied(f, info, cfg.Security.IsolateSecrets) {
+			spec.Volumes = append(spec.Volumes, provider.VolumeMount{
+				Source:   f.Source,
+				Target:   f.Target,
+				ReadOnly: f.ReadOnly,
+			})
+			continue
+		}
+
+		data, err := os.ReadFile(f.Source)
+		if err != nil {
+			envLogger.Warning("forward_files: failed to read %s: %v", f.Source, err)
+			continue
+		}
+		copied[f.Target] = string(data)
+	}
+
+	if len(copied) == 0 {
+		return
+	}
+	data, err := json.Marshal(copied)
+	if err != nil {
+		envLogger.Warning("forward_files: failed to encode files: %v", err)
+		return
+	}
+	spec.Env[forwardFilesSecretVar] = string(data)
+	addCredentialVar(spec.Env, forwardFilesSecretVar)
+}
+
+// forwardFileCopied decides between the secrets copy path and a bind mount:
+// only sensitive regular files are copied, and only when secrets are isolated
+func forwardFileCopied(f provider.ForwardFile, info os.FileInfo, isolateSecrets bool) bool {
+	return isolateSecrets && info.Mode().IsRegular() && provider.IsSensitiveFile(f.Source)
+}
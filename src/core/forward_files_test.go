@@ -0,0 +1,79 @@
+package core
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jedi4ever/addt/config/security"
+	"github.com/jedi4ever/addt/provider"
+)
+
+// writeForwardFiles creates a .netrc and an .aws/config in a temp home
+func writeForwardFiles(t *testing.T) (netrc, awsConfig string) {
+	t.Helper()
+	home := t.TempDir()
+	netrc = filepath.Join(home, ".netrc")
+	awsConfig = filepath.Join(home, ".aws", "config")
+	if err := os.MkdirAll(filepath.Dir(awsConfig), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(netrc, []byte("machine example.com password s3cret\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(awsConfig, []byte("[default]\nregion = eu-west-1\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return netrc, awsConfig
+}
+
+func TestAddForwardFiles_MountsWithoutIsolation(t *testing.T) {
+	netrc, awsConfig := writeForwardFiles(t)
+	spec := &provider.RunSpec{Env: map[string]string{}}
+	cfg := &provider.Config{ForwardFiles: []provider.ForwardFile{
+		{Source: netrc, Target: "/home/addt/.netrc", ReadOnly: true},
+		{Source: awsConfig, Target: "/home/addt/.aws/config", ReadOnly: true},
+		{Source: filepath.Join(filepath.Dir(netrc), "missing"), Target: "/home/addt/missing", ReadOnly: true},
+	}}
+
+	addForwardFiles(spec, cfg)
+
+	if len(spec.Volumes) != 2 {
+		t.Fatalf("expected 2 volumes (missing file skipped), got %v", spec.Volumes)
+	}
+	if v := spec.Volumes[0]; v.Source != netrc || v.Target != "/home/addt/.netrc" || !v.ReadOnly {
+		t.Errorf("volume = %+v, want %s -> /home/addt/.netrc (ro)", v, netrc)
+	}
+	if _, ok := spec.Env[forwardFilesSecretVar]; ok {
+		t.Error("file content should not be in env when secrets are not isolated")
+	}
+}
+
+func TestAddForwardFiles_IsolateSecretsCopiesSensitive(t *testing.T) {
+	netrc, awsConfig := writeForwardFiles(t)
+	spec := &provider.RunSpec{Env: map[string]string{}}
+	cfg := &provider.Config{
+		ForwardFiles: []provider.ForwardFile{
+			{Source: netrc, Target: "/home/addt/.netrc", ReadOnly: true},
+			{Source: awsConfig, Target: "/home/addt/.aws/config", ReadOnly: true},
+		},
+		Security: security.Config{IsolateSecrets: true},
+	}
+
+	addForwardFiles(spec, cfg)
+
+	if len(spec.Volumes) != 1 || spec.Volumes[0].Source != awsConfig {
+		t.Errorf("expected only .aws/config mounted, got %v", spec.Volumes)
+	}
+	var files map[string]string
+	if err := json.Unmarshal([]byte(spec.Env[forwardFilesSecretVar]), &files); err != nil {
+		t.Fatalf("invalid %s: %v", forwardFilesSecretVar, err)
+	}
+	if files["/home/addt/.netrc"] != "machine example.com password s3cret\n" || len(files) != 1 {
+		t.Errorf("copied files = %v, want only .netrc", files)
+	}
+	if spec.Env["ADDT_CREDENTIAL_VARS"] != forwardFilesSecretVar {
+		t.Errorf("ADDT_CREDENTIAL_VARS = %q, want %s", spec.Env["ADDT_CREDENTIAL_VARS"], forwardFilesSecretVar)
+	}
+}
@@ -0,0 +1,55 @@
+package core
+
+import (
+	"os"
+
+	"github.com/jedi4ever/addt/provider"
+)
+
+// gitconfigSecretVar carries the host .gitconfig by value when
+// git.config_copy is on; the entrypoint writes it to ~/.gitconfig.
+const gitconfigSecretVar = "ADDT_GITCONFIG"
+
+// addGitconfigCopy delivers the host .gitconfig by value instead of a bind
+// mount, so agent edits inside the container never reach the host file.
+// It is listed as a credential var, so with isolate_secrets it travels via
+// the secrets tmpfs rather than a -e flag.
+func addGitconfigCopy(spec *provider.RunSpec, cfg *provider.Config) {
+	if !cfg.GitForwardConfig || !cfg.GitConfigCopy {
+		return
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return
+	}
+	path := provider.ResolveGitconfigPath(cfg, home)
+	if path == "" {
+		envLogger.Debugf("No .gitconfig to copy, skipping")
+		return
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		envLogger.Warning("failed to read git config %s: %v", path, err)
+		return
+	}
+	spec.Env[gitconfigSecretVar] = string(data)
+	addCredentialVar(spec.Env, gitconfigSecretVar)
+}
+
+// addGitconfigEnvVars asks the entrypoint to write git config changes back
+// to the host file when it is mounted writable (git.config_readonly=false)
+func addGitconfigEnvVars(env map[string]string, cfg *provider.Config) {
+	if cfg.GitForwardConfig && !cfg.GitConfigReadonly && !cfg.GitConfigCopy {
+		env["ADDT_GITCONFIG_WRITEBACK"] = "true"
+	}
+}
+
+// addCredentialVar lists name in ADDT_CREDENTIAL_VARS so the provider moves
+// it into the secrets file when isolate_secrets is on
+func addCredentialVar(env map[string]string, name string) {
+	if vars := env["ADDT_CREDENTIAL_VARS"]; vars != "" {
+		env["ADDT_CREDENTIAL_VARS"] = vars + "," + name
+	} else {
+		env["ADDT_CREDENTIAL_VARS"] = name
+	}
+}
@@ -0,0 +1,70 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jedi4ever/addt/provider"
+)
+
+func writeGitconfig(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "gitconfig")
+	if err := os.WriteFile(path, []byte("[user]\n\tname = Dev\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestAddGitconfigCopy(t *testing.T) {
+	path := writeGitconfig(t)
+	spec := &provider.RunSpec{Env: map[string]string{"ADDT_CREDENTIAL_VARS": "CLAUDE_OAUTH_CREDENTIALS"}}
+	cfg := &provider.Config{GitForwardConfig: true, GitConfigCopy: true, GitConfigPath: path}
+
+	addGitconfigCopy(spec, cfg)
+
+	if spec.Env[gitconfigSecretVar] != "[user]\n\tname = Dev\n" {
+		t.Errorf("%s = %q, want file content", gitconfigSecretVar, spec.Env[gitconfigSecretVar])
+	}
+	if got := spec.Env["ADDT_CREDENTIAL_VARS"]; got != "CLAUDE_OAUTH_CREDENTIALS,"+gitconfigSecretVar {
+		t.Errorf("ADDT_CREDENTIAL_VARS = %q", got)
+	}
+}
+
+func TestAddGitconfigCopy_Disabled(t *testing.T) {
+	path := writeGitconfig(t)
+	for _, cfg := range []*provider.Config{
+		{GitForwardConfig: true, GitConfigPath: path},
+		{GitForwardConfig: false, GitConfigCopy: true, GitConfigPath: path},
+		{GitForwardConfig: true, GitConfigCopy: true, GitConfigPath: filepath.Join(t.TempDir(), "missing")},
+	} {
+		spec := &provider.RunSpec{Env: map[string]string{}}
+		addGitconfigCopy(spec, cfg)
+		if len(spec.Env) != 0 {
+			t.Errorf("cfg %+v: expected no env, got %v", cfg, spec.Env)
+		}
+	}
+}
+
+func TestAddGitconfigEnvVars_Writeback(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  provider.Config
+		want bool
+	}{
+		{"readonly", provider.Config{GitForwardConfig: true, GitConfigReadonly: true}, false},
+		{"writable", provider.Config{GitForwardConfig: true}, true},
+		{"writable copy", provider.Config{GitForwardConfig: true, GitConfigCopy: true}, false},
+		{"not forwarded", provider.Config{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := map[string]string{}
+			addGitconfigEnvVars(env, &tt.cfg)
+			if got := env["ADDT_GITCONFIG_WRITEBACK"] == "true"; got != tt.want {
+				t.Errorf("ADDT_GITCONFIG_WRITEBACK set = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
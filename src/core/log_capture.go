@@ -0,0 +1,24 @@
+package core
+
+import (
+	"github.com/jedi4ever/addt/provider"
+	"github.com/jedi4ever/addt/util"
+)
+
+// captureContainerLog copies the output of a non-interactive run into the
+// addt log (module "container") when log.enabled and log.capture_container
+// are on. Interactive sessions are left alone: a TTY carries escape codes
+// and keystrokes, not log lines. Returns a func that logs any trailing
+// partial lines once the run is over.
+func captureContainerLog(cfg *provider.Config, spec *provider.RunSpec) func() {
+	if !cfg.LogEnabled || !cfg.LogCaptureContainer || spec.Interactive {
+		return func() {}
+	}
+	stdout := util.NewLogWriter("container", "")
+	stderr := util.NewLogWriter("container", "stderr: ")
+	spec.LogStdout, spec.LogStderr = stdout, stderr
+	return func() {
+		stdout.Flush()
+		stderr.Flush()
+	}
+}
@@ -0,0 +1,66 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jedi4ever/addt/provider"
+	"github.com/jedi4ever/addt/util"
+)
+
+func TestCaptureContainerLog_WritesOutputToLog(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "addt.log")
+	util.InitLogger(logFile, true)
+	defer util.InitLogger("", false)
+
+	var terminal bytes.Buffer
+	spec := &provider.RunSpec{Stdout: &terminal, Stderr: &terminal}
+	cfg := &provider.Config{LogEnabled: true, LogCaptureContainer: true}
+	flush := captureContainerLog(cfg, spec)
+
+	stdout, stderr := spec.OutputWriters()
+	fmt.Fprint(stdout, "build ok\nall tests passed\n")
+	fmt.Fprint(stderr, "warning: no newline")
+	flush()
+
+	if got := terminal.String(); got != "build ok\nall tests passed\nwarning: no newline" {
+		t.Errorf("terminal output = %q, want the container output unchanged", got)
+	}
+	content, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatalf("reading log file: %v", err)
+	}
+	for _, want := range []string{
+		"INFO [container] build ok",
+		"INFO [container] all tests passed",
+		"INFO [container] stderr: warning: no newline",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("log file missing %q:\n%s", want, content)
+		}
+	}
+}
+
+func TestCaptureContainerLog_Disabled(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  provider.Config
+		spec provider.RunSpec
+	}{
+		{"capture off", provider.Config{LogEnabled: true}, provider.RunSpec{}},
+		{"logging off", provider.Config{LogCaptureContainer: true}, provider.RunSpec{}},
+		{"interactive", provider.Config{LogEnabled: true, LogCaptureContainer: true}, provider.RunSpec{Interactive: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			captureContainerLog(&tt.cfg, &tt.spec)()
+			if tt.spec.LogStdout != nil || tt.spec.LogStderr != nil {
+				t.Error("captureContainerLog() set log writers, want none")
+			}
+		})
+	}
+}
@@ -1,8 +1,10 @@
 package core
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"os/exec"
 	"strings"
 	"time"
 )
@@ -19,3 +21,30 @@ func LogCommand(logFile, cwd, containerName string, args []string) {
 	fmt.Fprintf(f, "[%s] PWD: %s | Container: %s | Command: %s\n",
 		timestamp, cwd, containerName, strings.Join(args, " "))
 }
+
+// RunSummary describes a finished run for the end-of-run log line
+type RunSummary struct {
+	Duration  time.Duration
+	ExitCode  int
+	Image     string
+	Container string
+}
+
+// String formats the summary as space-separated key=value fields
+func (s RunSummary) String() string {
+	return fmt.Sprintf("run summary: duration=%s exit_code=%d image=%s container=%s",
+		s.Duration.Round(time.Millisecond), s.ExitCode, s.Image, s.Container)
+}
+
+// exitCodeFromError maps a provider error to a process exit code
+// (0 on success, the child's code for exit errors, 1 otherwise)
+func exitCodeFromError(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return 1
+}
@@ -0,0 +1,31 @@
+package core
+
+import (
+	"os"
+
+	"github.com/jedi4ever/addt/provider"
+)
+
+// addMounts passes the --mount values through to the runtime. With
+// isolate_secrets, a bind mount of a sensitive regular file (.netrc,
+// credentials, ...) is returned as a forward file instead, so it goes
+// through the secrets tmpfs like forward_files rather than a bind mount.
+func addMounts(spec *provider.RunSpec, cfg *provider.Config) []provider.ForwardFile {
+	var copied []provider.ForwardFile
+	for _, raw := range cfg.Mounts {
+		m, err := provider.ParseMountSpec(raw)
+		if err != nil {
+			envLogger.Warning("--mount: %v, skipping", err)
+			continue
+		}
+		if m.Type == "bind" && cfg.Security.IsolateSecrets {
+			f := provider.ForwardFile{Source: m.Source, Target: m.Target, ReadOnly: m.ReadOnly}
+			if info, err := os.Stat(m.Source); err == nil && forwardFileCopied(f, info, true) {
+				copied = append(copied, f)
+				continue
+			}
+		}
+		spec.Mounts = append(spec.Mounts, m.Raw)
+	}
+	return copied
+}
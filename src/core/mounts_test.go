@@ -0,0 +1,50 @@
+package core
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/jedi4ever/addt/config/security"
+	"github.com/jedi4ever/addt/provider"
+)
+
+func TestAddMounts_PassesThrough(t *testing.T) {
+	netrc, _ := writeForwardFiles(t)
+	mounts := []string{
+		"type=bind,source=" + netrc + ",target=/home/addt/.netrc,readonly",
+		"type=volume,source=cache,target=/cache",
+	}
+	spec := &provider.RunSpec{Env: map[string]string{}}
+	cfg := &provider.Config{Mounts: mounts}
+
+	if copied := addMounts(spec, cfg); len(copied) != 0 {
+		t.Errorf("copied = %v, want none without isolate_secrets", copied)
+	}
+	if !reflect.DeepEqual(spec.Mounts, mounts) {
+		t.Errorf("spec.Mounts = %v, want %v", spec.Mounts, mounts)
+	}
+}
+
+func TestAddMounts_IsolateSecretsRoutesSensitive(t *testing.T) {
+	netrc, awsConfig := writeForwardFiles(t)
+	awsMount := "type=bind,source=" + awsConfig + ",target=/home/addt/.aws/config"
+	spec := &provider.RunSpec{Env: map[string]string{}}
+	cfg := &provider.Config{
+		Mounts:   []string{"type=bind,source=" + netrc + ",target=/home/addt/.netrc,readonly", awsMount},
+		Security: security.Config{IsolateSecrets: true},
+	}
+
+	addForwardFiles(spec, cfg, addMounts(spec, cfg)...)
+
+	if !reflect.DeepEqual(spec.Mounts, []string{awsMount}) {
+		t.Errorf("spec.Mounts = %v, want only the .aws/config mount", spec.Mounts)
+	}
+	var files map[string]string
+	if err := json.Unmarshal([]byte(spec.Env[forwardFilesSecretVar]), &files); err != nil {
+		t.Fatalf("invalid %s: %v", forwardFilesSecretVar, err)
+	}
+	if _, ok := files["/home/addt/.netrc"]; !ok || len(files) != 1 {
+		t.Errorf("copied files = %v, want only .netrc", files)
+	}
+}
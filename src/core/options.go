@@ -51,9 +51,25 @@ func BuildRunOptions(p provider.Provider, cfg *provider.Config, name string, arg
 		ContainerCPUs:    cfg.ContainerCPUs,
 		ContainerMemory:  cfg.ContainerMemory,
 	}
+	// The firewall names its blocked-connection log after the container
+	if spec.Env["ADDT_FIREWALL_LOG_BLOCKED"] == "true" {
+		spec.Env["ADDT_FIREWALL_LOG_NAME"] = name
+	}
 	// Resolve flag → env var mappings (e.g., --yolo → ADDT_EXTENSION_CLAUDE_YOLO=true)
 	addFlagEnvVars(spec.Env, cfg, args)
 
+	// Forward host Docker CLI config (registry logins)
+	addDockerConfigForwarding(spec, cfg)
+
+	// Copy host .gitconfig by value (git.config_copy)
+	addGitconfigCopy(spec, cfg)
+
+	// Pass --mount values through; sensitive sources join forward_files
+	mountFiles := addMounts(spec, cfg)
+
+	// Forward extra host files (forward_files)
+	addForwardFiles(spec, cfg, mountFiles...)
+
 	optionsLogger.Debugf("RunSpec created: Name=%s, ImageName=%s, Interactive=%v, Persistent=%v, DockerDindMode=%s",
 		spec.Name, spec.ImageName, spec.Interactive, spec.Persistent, spec.DockerDindMode)
 
@@ -66,7 +82,8 @@ func BuildRunOptions(p provider.Provider, cfg *provider.Config, name string, arg
 		optionsLogger.Debug("Shell mode without args")
 	} else {
 		spec.Args = args
-		optionsLogger.Debugf("Run mode with args: %v", args)
+		spec.EntrypointArgs = cfg.EntrypointArgs
+		optionsLogger.Debugf("Run mode with args: %v, entrypoint args: %v", args, cfg.EntrypointArgs)
 	}
 
 	// Log command if enabled
@@ -77,18 +94,40 @@ func BuildRunOptions(p provider.Provider, cfg *provider.Config, name string, arg
 		optionsLogger.Debug("Command logging disabled")
 	}
 
-	// Load env file vars directly into spec.Env if enabled
-	if cfg.EnvFileLoad {
-		loadEnvFileVars(spec, cfg, cwd)
-	}
+	// Load env file vars directly into spec.Env
+	loadEnvFileVars(spec, cfg, cwd)
 
 	optionsLogger.Debugf("BuildRunOptions completed: spec.Args=%v, spec.Env count=%d", spec.Args, len(spec.Env))
 	return spec
 }
 
-// loadEnvFileVars reads the env file and adds its variables directly to spec.Env.
-// This ensures env file vars work regardless of IsolateSecrets mode.
+// loadEnvFileVars adds the variables of the configured env file (with
+// env_file_load on) and then of each --env-file to spec.Env, later files
+// overriding earlier ones. This ensures env file vars work regardless of
+// IsolateSecrets mode.
 func loadEnvFileVars(spec *provider.RunSpec, cfg *provider.Config, cwd string) {
+	if cfg.EnvFileLoad {
+		loadConfigEnvFile(spec, cfg, cwd)
+	}
+	for _, path := range cfg.EnvFiles {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(cwd, path)
+		}
+		vars, err := parseEnvFile(path)
+		if err != nil {
+			optionsLogger.Debugf("Failed to parse env file %s: %v", path, err)
+			continue
+		}
+		for k, v := range vars {
+			spec.Env[k] = v
+		}
+		optionsLogger.Debugf("Loaded %d vars from --env-file: %s", len(vars), path)
+	}
+}
+
+// loadConfigEnvFile adds the variables of the env_file setting (default
+// .env) to spec.Env
+func loadConfigEnvFile(spec *provider.RunSpec, cfg *provider.Config, cwd string) {
 	envFilePath := cfg.EnvFile
 	if envFilePath == "" {
 		envFilePath = ".env"
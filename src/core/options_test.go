@@ -1,6 +1,8 @@
 package core
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/jedi4ever/addt/provider"
@@ -17,12 +19,16 @@ func (m *mockOptionsProvider) Exists(name string) bool
 func (m *mockOptionsProvider) IsRunning(name string) bool                         { return false }
 func (m *mockOptionsProvider) Start(name string) error                            { return nil }
 func (m *mockOptionsProvider) Stop(name string) error                             { return nil }
+func (m *mockOptionsProvider) Restart(name string) error                          { return nil }
 func (m *mockOptionsProvider) Remove(name string) error                           { return nil }
 func (m *mockOptionsProvider) List() ([]provider.Environment, error)              { return nil, nil }
 func (m *mockOptionsProvider) GeneratePersistentName() string                     { return "test-persistent" }
 func (m *mockOptionsProvider) GenerateEphemeralName() string                      { return "test-ephemeral" }
 func (m *mockOptionsProvider) GetStatus(cfg *provider.Config, name string) string { return "test" }
 func (m *mockOptionsProvider) GetName() string                                    { return "mock" }
+func (m *mockOptionsProvider) Stats(name string) (provider.Stats, error) {
+	return provider.Stats{}, nil
+}
 func (m *mockOptionsProvider) GetExtensionEnvVars(imageName string) []string      { return nil }
 func (m *mockOptionsProvider) DetermineImageName() string                         { return "test-image" }
 func (m *mockOptionsProvider) BuildIfNeeded(rebuild bool, rebuildBase bool) error { return nil }
@@ -71,6 +77,26 @@ func TestBuildRunOptions_ShellMode(t *testing.T) {
 	}
 }
 
+func TestBuildRunOptions_EntrypointArgs(t *testing.T) {
+	cfg := &provider.Config{
+		ImageName:        "test-image",
+		WorkdirAutomount: true,
+		PortRangeStart:   30000,
+		EntrypointArgs:   []string{"--debug"},
+	}
+
+	opts := BuildRunOptions(&mockOptionsProvider{}, cfg, "test-container", []string{"--help"}, false)
+	if got := provider.CommandArgs(opts); len(got) != 2 || got[0] != "--debug" || got[1] != "--help" {
+		t.Errorf("CommandArgs() = %v, want [--debug --help]", got)
+	}
+
+	// The shell replaces the agent, so entrypoint args are left out
+	opts = BuildRunOptions(&mockOptionsProvider{}, cfg, "test-container", []string{}, true)
+	if len(opts.EntrypointArgs) != 0 {
+		t.Errorf("EntrypointArgs = %v, want empty for shell mode", opts.EntrypointArgs)
+	}
+}
+
 func TestBuildRunOptions_Persistent(t *testing.T) {
 	cfg := &provider.Config{
 		ImageName:        "test-image",
@@ -193,3 +219,29 @@ func TestBuildRunOptions_IncludesEnv(t *testing.T) {
 		t.Error("COLUMNS not set in env")
 	}
 }
+
+func TestBuildRunOptions_EnvFiles(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, ".env"), []byte("A=config\nB=config\nC=config\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "shared.env"), []byte("B=shared\nC=shared\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "local.env"), []byte("C=local\n"), 0644)
+
+	cfg := &provider.Config{
+		Workdir:     dir,
+		EnvFileLoad: true,
+		EnvFiles:    []string{filepath.Join(dir, "shared.env"), "local.env"},
+	}
+	opts := BuildRunOptions(&mockOptionsProvider{}, cfg, "test-container", nil, false)
+	for name, want := range map[string]string{"A": "config", "B": "shared", "C": "local"} {
+		if got := opts.Env[name]; got != want {
+			t.Errorf("Env[%s] = %q, want %q (later files win)", name, got, want)
+		}
+	}
+
+	// --env-file still applies with env_file_load off
+	cfg.EnvFileLoad = false
+	opts = BuildRunOptions(&mockOptionsProvider{}, cfg, "test-container", nil, false)
+	if _, ok := opts.Env["A"]; ok || opts.Env["C"] != "local" {
+		t.Errorf("Env = A:%q C:%q, want only the --env-file vars", opts.Env["A"], opts.Env["C"])
+	}
+}
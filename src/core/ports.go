@@ -3,6 +3,8 @@ package core
 import (
 	"fmt"
 	"net"
+	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -29,27 +31,87 @@ func FindAvailablePort(startPort int) int {
 	return port
 }
 
-// BuildPorts creates port mappings from the configuration
-// It finds available host ports starting from PortRangeStart
-func BuildPorts(cfg *provider.Config) []provider.PortMapping {
-	var portsList []provider.PortMapping
-	hostPort := cfg.PortRangeStart
+// ParsePortSpec parses a ports.expose entry: "3000" exposes container port
+// 3000 on an auto-allocated host port, "8080:3000" pins it to host port 8080
+// (host:container, as with docker -p). host is 0 when auto-allocated.
+func ParsePortSpec(entry string) (container, host int, err error) {
+	entry = strings.TrimSpace(entry)
+	hostPart, containerPart, explicit := strings.Cut(entry, ":")
+	if !explicit {
+		containerPart, hostPart = hostPart, ""
+	}
+	if container, err = parsePortNumber(containerPart); err != nil {
+		return 0, 0, fmt.Errorf("ports.expose %q: %w", entry, err)
+	}
+	if explicit {
+		if host, err = parsePortNumber(hostPart); err != nil {
+			return 0, 0, fmt.Errorf("ports.expose %q: host %w", entry, err)
+		}
+	}
+	return container, host, nil
+}
 
-	for _, containerPort := range cfg.Ports {
-		containerPort = strings.TrimSpace(containerPort)
-		hostPort = FindAvailablePort(hostPort)
+// parsePortNumber parses a TCP port between 1 and 65535
+func parsePortNumber(s string) (int, error) {
+	port, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || port < 1 || port > 65535 {
+		return 0, fmt.Errorf("port must be a number between 1 and 65535, got %q", s)
+	}
+	return port, nil
+}
 
-		// Parse container port as int
-		var containerPortInt int
-		fmt.Sscanf(containerPort, "%d", &containerPortInt)
+// AllocatePorts maps each ports.expose entry to a host port. Explicit host
+// ports are reserved before auto-allocation starts at PortRangeStart, so no
+// host port is handed out twice. Entries that can't be mapped (malformed, or
+// an explicit host port already claimed by an earlier entry) are skipped and
+// reported as errors.
+func AllocatePorts(cfg *provider.Config) ([]provider.PortMapping, []error) {
+	var errs []error
+	type entry struct{ container, host int }
+	entries := make([]entry, 0, len(cfg.Ports))
+	reserved := make(map[int]bool)
+	for _, spec := range cfg.Ports {
+		container, host, err := ParsePortSpec(spec)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if host != 0 {
+			if reserved[host] {
+				errs = append(errs, fmt.Errorf("ports.expose %q: host port %d is already mapped by another entry", strings.TrimSpace(spec), host))
+				continue
+			}
+			reserved[host] = true
+		}
+		entries = append(entries, entry{container, host})
+	}
 
-		portsList = append(portsList, provider.PortMapping{
-			Container: containerPortInt,
-			Host:      hostPort,
-		})
-		hostPort++
+	portsList := make([]provider.PortMapping, 0, len(entries))
+	hostPort := cfg.PortRangeStart
+	for _, e := range entries {
+		if e.host == 0 {
+			hostPort = FindAvailablePort(hostPort)
+			for reserved[hostPort] {
+				hostPort = FindAvailablePort(hostPort + 1)
+			}
+			e.host = hostPort
+			hostPort++
+		}
+		portsList = append(portsList, provider.PortMapping{Container: e.container, Host: e.host})
 	}
+	return portsList, errs
+}
 
+// BuildPorts creates port mappings from the configuration, warning about
+// ports.expose entries that were skipped
+func BuildPorts(cfg *provider.Config) []provider.PortMapping {
+	portsList, errs := AllocatePorts(cfg)
+	for _, err := range errs {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	if len(portsList) == 0 {
+		return nil
+	}
 	return portsList
 }
 
@@ -57,39 +119,24 @@ func BuildPorts(cfg *provider.Config) []provider.PortMapping {
 // Format: "containerPort:hostPort,containerPort:hostPort"
 // This is used for the ADDT_PORT_MAP environment variable
 func BuildPortMapString(cfg *provider.Config) string {
-	if len(cfg.Ports) == 0 {
-		return ""
-	}
-
-	var mappings []string
-	hostPort := cfg.PortRangeStart
-
-	for _, containerPort := range cfg.Ports {
-		containerPort = strings.TrimSpace(containerPort)
-		hostPort = FindAvailablePort(hostPort)
-		mappings = append(mappings, fmt.Sprintf("%s:%d", containerPort, hostPort))
-		hostPort++
-	}
-
-	return strings.Join(mappings, ",")
+	return joinPortMappings(cfg, "%d:%d")
 }
 
 // BuildPortDisplayString creates a display-friendly port mapping string
 // Format: "containerPort→hostPort,containerPort→hostPort"
 func BuildPortDisplayString(cfg *provider.Config) string {
+	return joinPortMappings(cfg, "%d→%d")
+}
+
+// joinPortMappings formats the allocated ports with format (container, host)
+func joinPortMappings(cfg *provider.Config, format string) string {
 	if len(cfg.Ports) == 0 {
 		return ""
 	}
-
-	var mappings []string
-	hostPort := cfg.PortRangeStart
-
-	for _, containerPort := range cfg.Ports {
-		containerPort = strings.TrimSpace(containerPort)
-		hostPort = FindAvailablePort(hostPort)
-		mappings = append(mappings, fmt.Sprintf("%s→%d", containerPort, hostPort))
-		hostPort++
+	portsList, _ := AllocatePorts(cfg)
+	mappings := make([]string, 0, len(portsList))
+	for _, port := range portsList {
+		mappings = append(mappings, fmt.Sprintf(format, port.Container, port.Host))
 	}
-
 	return strings.Join(mappings, ",")
 }
@@ -1,6 +1,9 @@
 package core
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/jedi4ever/addt/provider"
 )
 
@@ -18,7 +21,28 @@ func PortsInjectPrompt(env map[string]string, cfg *provider.Config) {
 	portMap := BuildPortMapString(cfg)
 	if portMap != "" {
 		env["ADDT_PORT_MAP"] = portMap
+		addPortPromptTemplate(env, cfg)
+	}
+}
+
+// addPortPromptTemplate renders ports.prompt_template into ADDT_PORT_PROMPT,
+// which the entrypoint uses instead of its built-in port wording. An invalid
+// template is reported and the built-in wording is kept.
+func addPortPromptTemplate(env map[string]string, cfg *provider.Config) {
+	if cfg.PortsPromptTemplate == "" {
+		return
+	}
+	portsList, _ := AllocatePorts(cfg)
+	prompt, err := provider.RenderPortPrompt(cfg.PortsPromptTemplate, provider.PortPromptData{
+		Ports:       portsList,
+		BindAddress: provider.PortBindAddress,
+		Provider:    cfg.Provider,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: invalid ports.prompt_template, using the default port prompt: %v\n", err)
+		return
 	}
+	env["ADDT_PORT_PROMPT"] = prompt
 }
 
 // BuildSystemPromptPortSection generates the port mapping section of the system prompt
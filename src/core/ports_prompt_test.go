@@ -1,6 +1,7 @@
 package core
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
@@ -168,3 +169,50 @@ func TestFormatPortMappingsForPrompt(t *testing.T) {
 		}
 	}
 }
+
+func TestPortsInjectPrompt_Template(t *testing.T) {
+	cfg := &provider.Config{
+		Ports:                   []string{"3000", "8080"},
+		PortRangeStart:          30000,
+		PortsInjectSystemPrompt: true,
+		Provider:                "orbstack",
+		PortsPromptTemplate:     "Ports on {{.Provider}}:\n{{range .Ports}}- {{.Container}} is https://{{$.BindAddress}}:{{.Host}}\n{{end}}",
+	}
+
+	env := make(map[string]string)
+	PortsInjectPrompt(env, cfg)
+
+	portsList, _ := AllocatePorts(cfg)
+	if len(portsList) != 2 {
+		t.Fatalf("AllocatePorts() = %v, want 2 mappings", portsList)
+	}
+	want := "Ports on orbstack:\n"
+	for _, port := range portsList {
+		want += fmt.Sprintf("- %d is https://127.0.0.1:%d\n", port.Container, port.Host)
+	}
+	if env["ADDT_PORT_PROMPT"] != want {
+		t.Errorf("ADDT_PORT_PROMPT = %q, want %q", env["ADDT_PORT_PROMPT"], want)
+	}
+	if env["ADDT_PORT_MAP"] == "" {
+		t.Error("ADDT_PORT_MAP should still be set with a template")
+	}
+}
+
+func TestPortsInjectPrompt_InvalidTemplate(t *testing.T) {
+	cfg := &provider.Config{
+		Ports:                   []string{"3000"},
+		PortRangeStart:          30000,
+		PortsInjectSystemPrompt: true,
+		PortsPromptTemplate:     "{{.Missing}}",
+	}
+
+	env := make(map[string]string)
+	PortsInjectPrompt(env, cfg)
+
+	if _, ok := env["ADDT_PORT_PROMPT"]; ok {
+		t.Error("ADDT_PORT_PROMPT should not be set for a template that fails to render")
+	}
+	if env["ADDT_PORT_MAP"] == "" {
+		t.Error("ADDT_PORT_MAP should remain for the built-in prompt")
+	}
+}
@@ -253,3 +253,76 @@ func TestBuildPortDisplayString_Format(t *testing.T) {
 		t.Errorf("Display string should use '→' not ':', got %q", display)
 	}
 }
+
+func TestParsePortSpec(t *testing.T) {
+	tests := []struct {
+		entry           string
+		container, host int
+		wantErr         bool
+	}{
+		{"3000", 3000, 0, false},
+		{" 8080:3000 ", 3000, 8080, false},
+		{"abc", 0, 0, true},
+		{"8080:", 0, 0, true},
+		{"70000:3000", 0, 0, true},
+	}
+	for _, tt := range tests {
+		container, host, err := ParsePortSpec(tt.entry)
+		if (err != nil) != tt.wantErr || container != tt.container || host != tt.host {
+			t.Errorf("ParsePortSpec(%q) = %d, %d, %v; want %d, %d, err=%v", tt.entry, container, host, err, tt.container, tt.host, tt.wantErr)
+		}
+	}
+}
+
+func TestAllocatePorts_ReservesExplicitHostPorts(t *testing.T) {
+	// The explicit host ports sit where auto-allocation starts
+	cfg := &provider.Config{
+		Ports:          []string{"3000", "59401:8080", "5173", "59400:9000"},
+		PortRangeStart: 59400,
+	}
+
+	ports, errs := AllocatePorts(cfg)
+	if len(errs) != 0 {
+		t.Fatalf("AllocatePorts() errors = %v", errs)
+	}
+	if len(ports) != 4 {
+		t.Fatalf("AllocatePorts() = %v, want 4 mappings", ports)
+	}
+
+	seen := make(map[int]int)
+	for _, p := range ports {
+		if other, ok := seen[p.Host]; ok {
+			t.Errorf("host port %d assigned to both container %d and %d", p.Host, other, p.Container)
+		}
+		seen[p.Host] = p.Container
+	}
+	if ports[1].Host != 59401 || ports[3].Host != 59400 {
+		t.Errorf("explicit mappings = %v, want host ports 59401 and 59400 kept", ports)
+	}
+	if ports[0].Host < 59402 || ports[2].Host < 59402 {
+		t.Errorf("auto-allocated ports = %d, %d, want them past the reserved 59400-59401", ports[0].Host, ports[2].Host)
+	}
+
+	// The prompt and status strings describe the same allocation
+	if got := BuildPortMapString(cfg); !strings.Contains(got, "8080:59401") || !strings.Contains(got, "9000:59400") {
+		t.Errorf("BuildPortMapString() = %q, want the explicit mappings", got)
+	}
+}
+
+func TestAllocatePorts_ExplicitCollision(t *testing.T) {
+	cfg := &provider.Config{
+		Ports:          []string{"8080:3000", "8080:4000", "5000"},
+		PortRangeStart: 59500,
+	}
+
+	ports, errs := AllocatePorts(cfg)
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "host port 8080 is already mapped") {
+		t.Errorf("AllocatePorts() errors = %v, want one collision on 8080", errs)
+	}
+	if len(ports) != 2 || ports[0].Container != 3000 || ports[0].Host != 8080 {
+		t.Errorf("AllocatePorts() = %v, want the first 8080 mapping kept and the duplicate skipped", ports)
+	}
+	if ports[1].Host == 8080 {
+		t.Errorf("auto-allocated port reused host port 8080")
+	}
+}
@@ -0,0 +1,142 @@
+package core
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/jedi4ever/addt/provider"
+)
+
+// redactedValue replaces the value of sensitive environment variables
+const redactedValue = "<redacted>"
+
+// sensitiveEnvMarkers are name fragments that mark an env var as a secret
+var sensitiveEnvMarkers = []string{"TOKEN", "SECRET", "PASSWORD", "PASSWD", "KEY", "CREDENTIAL", "AUTH", "COOKIE", "SESSION"}
+
+// isSensitiveEnvVar reports whether an env var name looks like it holds a secret
+func isSensitiveEnvVar(name string) bool {
+	upper := strings.ToUpper(name)
+	for _, marker := range sensitiveEnvMarkers {
+		if strings.Contains(upper, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// credentialVarSet returns the names listed in env's ADDT_CREDENTIAL_VARS,
+// which hold secrets whatever their name looks like
+func credentialVarSet(env map[string]string) map[string]bool {
+	set := make(map[string]bool)
+	for _, name := range strings.Split(env["ADDT_CREDENTIAL_VARS"], ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			set[name] = true
+		}
+	}
+	return set
+}
+
+// redactEnvValue hides the value of sensitive env vars and of the listed
+// credential vars. Empty and boolean values are kept visible since they only
+// toggle features.
+func redactEnvValue(name, value string, credVars map[string]bool) string {
+	if value == "" || value == "true" || value == "false" {
+		return value
+	}
+	if isSensitiveEnvVar(name) || credVars[name] {
+		return redactedValue
+	}
+	return value
+}
+
+// sortedEnvKeys returns the env var names in stable order
+func sortedEnvKeys(env map[string]string) []string {
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// PrintOnlyEnv resolves the run options without starting a container and
+// writes the redacted environment, mounts and security flags to w
+func (r *Runner) PrintOnlyEnv(w io.Writer, args []string, openShell bool) {
+	name := r.generateName()
+	spec := BuildRunOptions(r.provider, r.config, name, args, openShell)
+
+	var secArgs []string
+	if sp, ok := r.provider.(provider.SecurityArgsProvider); ok {
+		secArgs = sp.SecurityArgs()
+	}
+	WriteEnvReport(w, spec, secArgs)
+}
+
+// WriteEnvReport writes a CI-friendly dump of a run spec: sections in fixed
+// order, env vars and mounts sorted, secret values redacted
+func WriteEnvReport(w io.Writer, spec *provider.RunSpec, securityArgs []string) {
+	fmt.Fprintln(w, "[container]")
+	fmt.Fprintf(w, "name=%s\n", spec.Name)
+	fmt.Fprintf(w, "image=%s\n", spec.ImageName)
+	fmt.Fprintf(w, "workdir=%s\n", spec.WorkDir)
+	fmt.Fprintf(w, "persistent=%t\n", spec.Persistent)
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "[env]")
+	credVars := credentialVarSet(spec.Env)
+	for _, k := range sortedEnvKeys(spec.Env) {
+		fmt.Fprintf(w, "%s=%s\n", k, redactEnvValue(k, spec.Env[k], credVars))
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "[mounts]")
+	mounts := append([]provider.VolumeMount(nil), spec.Volumes...)
+	sort.SliceStable(mounts, func(i, j int) bool { return mounts[i].Target < mounts[j].Target })
+	for _, m := range mounts {
+		mode := "rw"
+		if m.ReadOnly {
+			mode = "ro"
+		}
+		fmt.Fprintf(w, "%s:%s:%s\n", m.Source, m.Target, mode)
+	}
+	for _, m := range spec.Mounts {
+		fmt.Fprintln(w, m)
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "[ports]")
+	for _, p := range spec.Ports {
+		fmt.Fprintf(w, "%d:%d\n", p.Host, p.Container)
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "[security]")
+	for _, arg := range redactSecurityArgs(securityArgs) {
+		fmt.Fprintln(w, arg)
+	}
+}
+
+// redactSecurityArgs joins each security flag with its value, one entry
+// per flag, with secret values redacted
+func redactSecurityArgs(securityArgs []string) []string {
+	var out []string
+	for i := 0; i < len(securityArgs); i++ {
+		arg := securityArgs[i]
+		if strings.HasPrefix(arg, "-") && i+1 < len(securityArgs) && !strings.HasPrefix(securityArgs[i+1], "-") {
+			arg += " " + redactSecurityValue(securityArgs[i+1])
+			i++
+		}
+		out = append(out, arg)
+	}
+	return out
+}
+
+// redactSecurityValue redacts secret values passed as -e NAME=value
+func redactSecurityValue(value string) string {
+	if name, v, ok := strings.Cut(value, "="); ok && isSensitiveEnvVar(name) {
+		return name + "=" + redactEnvValue(name, v, nil)
+	}
+	return value
+}
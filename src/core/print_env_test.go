@@ -0,0 +1,124 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jedi4ever/addt/provider"
+)
+
+// launchTrackingProvider records whether a container was started and reports fixed security flags
+type launchTrackingProvider struct {
+	mockOptionsProvider
+	launched bool
+}
+
+func (m *launchTrackingProvider) Run(spec *provider.RunSpec) error {
+	m.launched = true
+	return errors.New("container must not be started")
+}
+
+func (m *launchTrackingProvider) Shell(spec *provider.RunSpec) error {
+	m.launched = true
+	return errors.New("container must not be started")
+}
+
+func (m *launchTrackingProvider) SecurityArgs() []string {
+	return []string{"--pids-limit", "200", "--cap-drop", "ALL", "--read-only", "-e", "API_TOKEN=abc"}
+}
+
+func TestPrintOnlyEnv_PrintsSectionsWithoutLaunching(t *testing.T) {
+	t.Setenv("ADDT_PRINT_ENV_TEST_TOKEN", "s3cret")
+	cfg := &provider.Config{
+		ImageName:        "test-image",
+		WorkdirAutomount: true,
+		Workdir:          t.TempDir(),
+		PortRangeStart:   30000,
+		EnvVars:          []string{"ADDT_PRINT_ENV_TEST_TOKEN"},
+	}
+	p := &launchTrackingProvider{}
+
+	var buf bytes.Buffer
+	NewRunner(p, cfg).PrintOnlyEnv(&buf, []string{"--help"}, false)
+	out := buf.String()
+
+	if p.launched {
+		t.Error("PrintOnlyEnv started a container")
+	}
+
+	sections := []string{"[container]", "[env]", "[mounts]", "[ports]", "[security]"}
+	last := -1
+	for _, s := range sections {
+		idx := strings.Index(out, s)
+		if idx < 0 {
+			t.Fatalf("output missing section %s:\n%s", s, out)
+		}
+		if idx < last {
+			t.Errorf("section %s out of order", s)
+		}
+		last = idx
+	}
+
+	for _, want := range []string{
+		"image=test-image",
+		"ADDT_PRINT_ENV_TEST_TOKEN=<redacted>",
+		":/workspace:rw",
+		"--cap-drop ALL",
+		"--read-only",
+		"-e API_TOKEN=<redacted>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "s3cret") || strings.Contains(out, "abc") {
+		t.Errorf("output leaks a secret value:\n%s", out)
+	}
+}
+
+func TestWriteEnvReport_SortedEnv(t *testing.T) {
+	spec := &provider.RunSpec{Env: map[string]string{"ZED": "1", "ALPHA": "2", "GH_TOKEN": "x", "SSH_FORWARD_KEYS": "true"}}
+
+	var buf bytes.Buffer
+	WriteEnvReport(&buf, spec, nil)
+	out := buf.String()
+
+	if strings.Index(out, "ALPHA=2") > strings.Index(out, "ZED=1") {
+		t.Errorf("env vars not sorted:\n%s", out)
+	}
+	if !strings.Contains(out, "GH_TOKEN=<redacted>") {
+		t.Errorf("GH_TOKEN not redacted:\n%s", out)
+	}
+	if !strings.Contains(out, "SSH_FORWARD_KEYS=true") {
+		t.Errorf("boolean toggle should stay visible:\n%s", out)
+	}
+}
+
+func TestWriteEnvReport_RedactsCredentialVars(t *testing.T) {
+	spec := &provider.RunSpec{Env: map[string]string{
+		"ADDT_DOCKER_CONFIG_JSON": `{"auths":{"ghcr.io":{"auth":"c2VjcmV0"}}}`,
+		"ADDT_FORWARD_FILES_JSON": `[{"path":".npmrc","content":"//registry/:_token=abc"}]`,
+		"ADDT_CREDENTIAL_VARS":    "ADDT_DOCKER_CONFIG_JSON,ADDT_FORWARD_FILES_JSON",
+	}}
+
+	var buf bytes.Buffer
+	WriteEnvReport(&buf, spec, nil)
+	out := buf.String()
+
+	for _, want := range []string{"ADDT_DOCKER_CONFIG_JSON=<redacted>", "ADDT_FORWARD_FILES_JSON=<redacted>"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "c2VjcmV0") || strings.Contains(out, "_token=abc") {
+		t.Errorf("output leaks a credential value:\n%s", out)
+	}
+	d := NewSpecDump(spec, nil)
+	for _, name := range []string{"ADDT_DOCKER_CONFIG_JSON", "ADDT_FORWARD_FILES_JSON"} {
+		if d.Env[name] != redactedValue {
+			t.Errorf("dump Env[%s] = %q, want %q", name, d.Env[name], redactedValue)
+		}
+	}
+}
@@ -2,6 +2,8 @@ package core
 
 import (
 	"fmt"
+	"io"
+	"time"
 
 	"github.com/jedi4ever/addt/provider"
 	"github.com/jedi4ever/addt/util"
@@ -13,6 +15,10 @@ var runnerLogger = util.Log("runner")
 type Runner struct {
 	provider provider.Provider
 	config   *provider.Config
+	stdout   io.Writer     // container stdout destination (nil = terminal)
+	stderr   io.Writer     // container stderr destination (nil = terminal)
+	timeout  time.Duration // host-side run deadline (0 = none)
+	record   io.Writer     // session transcript (nil = not recorded)
 }
 
 // NewRunner creates a new runner
@@ -23,6 +29,26 @@ func NewRunner(p provider.Provider, cfg *provider.Config) *Runner {
 	}
 }
 
+// SetOutput routes container stdout and stderr to the given writers.
+// A nil writer keeps that stream on the terminal. Redirecting either
+// stream disables the TTY so stdout and stderr stay separate.
+func (r *Runner) SetOutput(stdout, stderr io.Writer) {
+	r.stdout = stdout
+	r.stderr = stderr
+}
+
+// SetTimeout gives the run a host-side deadline. When it passes, the
+// provider kills the runtime CLI and tears down the container.
+func (r *Runner) SetTimeout(timeout time.Duration) {
+	r.timeout = timeout
+}
+
+// SetRecord mirrors the session output to w as a transcript. Unlike
+// SetOutput, the terminal and TTY are kept.
+func (r *Runner) SetRecord(w io.Writer) {
+	r.record = w
+}
+
 // Run executes the container with the configured extension
 func (r *Runner) Run(args []string) error {
 	runnerLogger.Debugf("Runner.Run called with args: %v", args)
@@ -48,31 +74,52 @@ func (r *Runner) execute(args []string, openShell bool) error {
 	// Build run options
 	runnerLogger.Debug("Building run options")
 	opts := BuildRunOptions(r.provider, r.config, name, args, openShell)
+	if r.stdout != nil || r.stderr != nil {
+		// A TTY merges stderr into stdout, so redirected runs are non-interactive
+		opts.Stdout, opts.Stderr = r.stdout, r.stderr
+		opts.Interactive = false
+	}
+	opts.Timeout = r.timeout
+	opts.Record = r.record
+	flushContainerLog := captureContainerLog(r.config, opts)
 	runnerLogger.Debugf("Run options: Name=%s, ImageName=%s, Args=%v, Interactive=%v, Persistent=%v",
 		opts.Name, opts.ImageName, opts.Args, opts.Interactive, opts.Persistent)
 
+	provider.WarnPlatformEmulation(r.config)
+
 	// Display status
 	runnerLogger.Debug("Displaying status")
 	DisplayStatus(r.provider, r.config, name)
 
 	// Execute via provider
+	start := time.Now()
+	var err error
 	if openShell {
 		runnerLogger.Debug("Calling provider.Shell")
-		err := r.provider.Shell(opts)
+		err = r.provider.Shell(opts)
 		if err != nil {
 			runnerLogger.Errorf("Provider.Shell failed: %v", err)
 		} else {
 			runnerLogger.Debug("Provider.Shell completed successfully")
 		}
-		return err
-	}
-	runnerLogger.Debug("Calling provider.Run")
-	err := r.provider.Run(opts)
-	if err != nil {
-		runnerLogger.Errorf("Provider.Run failed: %v", err)
 	} else {
-		runnerLogger.Debug("Provider.Run completed successfully")
+		runnerLogger.Debug("Calling provider.Run")
+		err = r.provider.Run(opts)
+		if err != nil {
+			runnerLogger.Errorf("Provider.Run failed: %v", err)
+		} else {
+			runnerLogger.Debug("Provider.Run completed successfully")
+		}
 	}
+
+	flushContainerLog()
+
+	runnerLogger.Info("%s", RunSummary{
+		Duration:  time.Since(start),
+		ExitCode:  exitCodeFromError(err),
+		Image:     opts.ImageName,
+		Container: name,
+	})
 	return err
 }
 
@@ -0,0 +1,106 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jedi4ever/addt/provider"
+	"github.com/jedi4ever/addt/util"
+)
+
+func TestRunSummary_String(t *testing.T) {
+	s := RunSummary{
+		Duration:  1500 * time.Millisecond,
+		ExitCode:  2,
+		Image:     "addt:claude",
+		Container: "addt-ephemeral-1",
+	}
+
+	got := s.String()
+	want := "run summary: duration=1.5s exit_code=2 image=addt:claude container=addt-ephemeral-1"
+	if got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestExitCodeFromError(t *testing.T) {
+	if got := exitCodeFromError(nil); got != 0 {
+		t.Errorf("exitCodeFromError(nil) = %d, want 0", got)
+	}
+	if got := exitCodeFromError(errors.New("boom")); got != 1 {
+		t.Errorf("exitCodeFromError(generic) = %d, want 1", got)
+	}
+
+	err := exec.Command("sh", "-c", "exit 3").Run()
+	if got := exitCodeFromError(err); got != 3 {
+		t.Errorf("exitCodeFromError(exit 3) = %d, want 3", got)
+	}
+}
+
+func TestRunner_LogsRunSummary(t *testing.T) {
+	oldLevel, hadLevel := os.LookupEnv("ADDT_LOG_LEVEL")
+	os.Unsetenv("ADDT_LOG_LEVEL")
+	logDir := t.TempDir()
+	defer func() {
+		if hadLevel {
+			os.Setenv("ADDT_LOG_LEVEL", oldLevel)
+		}
+		util.InitLoggerFull("", "", "stderr", false, "INFO", "*", false, "10m", 5)
+	}()
+	util.InitLoggerFull("run.log", logDir, "file", true, "INFO", "runner", false, "10m", 5)
+
+	cfg := &provider.Config{ImageName: "test-image", PortRangeStart: 30000}
+	if err := NewRunner(&mockOptionsProvider{}, cfg).Run(nil); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(logDir, "run.log"))
+	if err != nil {
+		t.Fatalf("failed to read log: %v", err)
+	}
+	log := string(content)
+
+	for _, field := range []string{"INFO", "run summary:", "duration=", "exit_code=0", "image=test-image", "container=test-ephemeral"} {
+		if !strings.Contains(log, field) {
+			t.Errorf("run summary missing %q\nGot: %s", field, log)
+		}
+	}
+}
+
+// specCapturingProvider records the RunSpec passed to Run
+type specCapturingProvider struct {
+	mockOptionsProvider
+	spec *provider.RunSpec
+}
+
+func (m *specCapturingProvider) Run(spec *provider.RunSpec) error {
+	m.spec = spec
+	return nil
+}
+
+func TestRunner_SetOutputRedirectsAndDisablesTTY(t *testing.T) {
+	cfg := &provider.Config{ImageName: "test-image", PortRangeStart: 30000}
+	p := &specCapturingProvider{}
+	r := NewRunner(p, cfg)
+
+	var stdout strings.Builder
+	r.SetOutput(&stdout, nil)
+	if err := r.Run(nil); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if p.spec.Stdout != &stdout {
+		t.Error("spec.Stdout should be the redirected writer")
+	}
+	if p.spec.Stderr != nil {
+		t.Error("spec.Stderr should stay on the terminal")
+	}
+	if p.spec.Interactive {
+		t.Error("redirected runs must not allocate a TTY")
+	}
+}
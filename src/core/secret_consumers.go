@@ -0,0 +1,17 @@
+package core
+
+import (
+	"strings"
+
+	"github.com/jedi4ever/addt/provider"
+)
+
+// addSecretConsumersEnvVar tells the entrypoint which commands may receive
+// the isolated secrets (security.secret_consumers); it holds them back from
+// every other process. Without isolate_secrets the secrets are plain env
+// vars, so the list has no effect.
+func addSecretConsumersEnvVar(env map[string]string, cfg *provider.Config) {
+	if cfg.Security.IsolateSecrets && len(cfg.Security.SecretConsumers) > 0 {
+		env["ADDT_SECRET_CONSUMERS"] = strings.Join(cfg.Security.SecretConsumers, ",")
+	}
+}
@@ -0,0 +1,26 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/jedi4ever/addt/config/security"
+	"github.com/jedi4ever/addt/provider"
+)
+
+func TestBuildEnvironment_SecretConsumers(t *testing.T) {
+	cfg := &provider.Config{Security: security.DefaultConfig()}
+	if _, ok := BuildEnvironment(&mockEnvProvider{}, cfg)["ADDT_SECRET_CONSUMERS"]; ok {
+		t.Error("ADDT_SECRET_CONSUMERS should not be set without security.secret_consumers")
+	}
+
+	security.ApplySettings(&cfg.Security, &security.Settings{SecretConsumers: []string{"claude", "codex"}})
+	if got := BuildEnvironment(&mockEnvProvider{}, cfg)["ADDT_SECRET_CONSUMERS"]; got != "claude,codex" {
+		t.Errorf("ADDT_SECRET_CONSUMERS = %q, want claude,codex", got)
+	}
+
+	// Without isolation the secrets are plain env vars; there is nothing to hold back
+	cfg.Security.IsolateSecrets = false
+	if _, ok := BuildEnvironment(&mockEnvProvider{}, cfg)["ADDT_SECRET_CONSUMERS"]; ok {
+		t.Error("ADDT_SECRET_CONSUMERS should not be set with isolate_secrets off")
+	}
+}
@@ -0,0 +1,59 @@
+package core
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/jedi4ever/addt/provider"
+)
+
+// Statuses reported by addt secrets check
+const (
+	SecretClassified = "classified" // moved into the secrets file with security.isolate_secrets
+	SecretForwarded  = "forwarded"  // passed to the container as a plain env var
+	SecretDenied     = "denied"     // set on the host and looks secret, but not forwarded
+)
+
+// SecretCheck is the classification of one env var. It never holds the value.
+type SecretCheck struct {
+	Name   string
+	Status string
+}
+
+// CheckSecrets resolves the run environment and classifies each variable
+// the way the providers' isolate_secrets does. Host vars that look like
+// secrets but aren't forwarded are reported as denied. addt's own ADDT_*
+// settings are left out.
+func CheckSecrets(p provider.Provider, cfg *provider.Config) []SecretCheck {
+	env := BuildEnvironment(p, cfg)
+	secret := make(map[string]bool)
+	for _, name := range provider.SecretVarNames(p.GetExtensionEnvVars(cfg.ImageName), env) {
+		secret[name] = env[name] != ""
+	}
+
+	var checks []SecretCheck
+	for _, name := range sortedEnvKeys(env) {
+		if strings.HasPrefix(name, "ADDT_") {
+			continue
+		}
+		status := SecretForwarded
+		if secret[name] {
+			status = SecretClassified
+		}
+		checks = append(checks, SecretCheck{Name: name, Status: status})
+	}
+
+	var denied []string
+	for _, kv := range os.Environ() {
+		name, _, _ := strings.Cut(kv, "=")
+		if _, forwarded := env[name]; !forwarded && isSensitiveEnvVar(name) && !strings.HasPrefix(name, "ADDT_") {
+			denied = append(denied, name)
+		}
+	}
+	sort.Strings(denied)
+	for _, name := range denied {
+		checks = append(checks, SecretCheck{Name: name, Status: SecretDenied})
+	}
+	return checks
+}
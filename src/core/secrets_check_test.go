@@ -0,0 +1,60 @@
+package core
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jedi4ever/addt/provider"
+)
+
+// secretsCheckProvider declares one credential and one defaulted setting
+type secretsCheckProvider struct {
+	mockEnvProvider
+}
+
+func (m *secretsCheckProvider) GetExtensionEnvVars(imageName string) []string {
+	return []string{"CHECK_TEST_API_KEY", "CHECK_TEST_SETTING=1"}
+}
+
+func TestCheckSecrets_Classification(t *testing.T) {
+	t.Setenv("CHECK_TEST_API_KEY", "sk-secret")
+	t.Setenv("CHECK_TEST_EDITOR", "vim")
+	t.Setenv("CHECK_TEST_HOST_TOKEN", "ghp-host-only")
+	cfg := &provider.Config{
+		Extensions: "no-such-extension",
+		ImageName:  "test-image",
+		EnvVars:    []string{"CHECK_TEST_EDITOR"},
+	}
+
+	statuses := make(map[string]string)
+	for _, c := range CheckSecrets(&secretsCheckProvider{}, cfg) {
+		statuses[c.Name] = c.Status
+	}
+
+	want := map[string]string{
+		"CHECK_TEST_API_KEY":    SecretClassified,
+		"CHECK_TEST_SETTING":    SecretForwarded,
+		"CHECK_TEST_EDITOR":     SecretForwarded,
+		"CHECK_TEST_HOST_TOKEN": SecretDenied,
+	}
+	for name, status := range want {
+		if statuses[name] != status {
+			t.Errorf("%s = %q, want %s", name, statuses[name], status)
+		}
+	}
+	for name := range statuses {
+		if strings.HasPrefix(name, "ADDT_") {
+			t.Errorf("report includes addt setting %s", name)
+		}
+	}
+}
+
+func TestSecretVarNames_CredentialVars(t *testing.T) {
+	env := map[string]string{"ADDT_CREDENTIAL_VARS": "CLAUDE_OAUTH_CREDENTIALS, OTHER_CRED"}
+	got := provider.SecretVarNames([]string{"ANTHROPIC_API_KEY", "DISABLE_AUTOUPDATER=1"}, env)
+	want := []string{"ANTHROPIC_API_KEY", "CLAUDE_OAUTH_CREDENTIALS", "OTHER_CRED"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SecretVarNames() = %v, want %v", got, want)
+	}
+}
@@ -50,6 +50,8 @@ func SecurityPostureLine(cfg *provider.Config) (string, bool) {
 	// Workdir
 	if !cfg.WorkdirAutomount {
 		parts = append(parts, "workdir:none")
+	} else if cfg.WorkdirOverlay {
+		parts = append(parts, "workdir:overlay")
 	} else if cfg.WorkdirReadonly {
 		parts = append(parts, "workdir:ro")
 	} else {
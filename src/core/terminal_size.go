@@ -0,0 +1,35 @@
+package core
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/jedi4ever/addt/util/terminal"
+)
+
+// terminalSize returns the initial COLUMNS/LINES for the container. On a
+// terminal the live window size wins; otherwise (pipes, CI) the host's own
+// COLUMNS/LINES are honoured before falling back to 80x24. Later resizes
+// reach the container through the runtime's TTY, not these values.
+func terminalSize() (cols, lines int) {
+	cols, lines = terminal.GetTerminalSize()
+	if terminal.IsTerminal() {
+		return cols, lines
+	}
+	if n, ok := positiveEnvInt("COLUMNS"); ok {
+		cols = n
+	}
+	if n, ok := positiveEnvInt("LINES"); ok {
+		lines = n
+	}
+	return cols, lines
+}
+
+// positiveEnvInt parses a positive integer from the named host env var
+func positiveEnvInt(name string) (int, bool) {
+	n, err := strconv.Atoi(os.Getenv(name))
+	if err != nil || n <= 0 {
+		return 0, false
+	}
+	return n, true
+}
@@ -1,6 +1,8 @@
 package core
 
 import (
+	"os"
+
 	"github.com/jedi4ever/addt/provider"
 )
 
@@ -12,10 +14,35 @@ func BuildVolumes(cfg *provider.Config, cwd string) []provider.VolumeMount {
 	if cfg.WorkdirAutomount {
 		volumes = append(volumes, provider.VolumeMount{
 			Source:   cwd,
-			Target:   "/workspace",
+			Target:   provider.WorkdirTarget(cfg),
 			ReadOnly: cfg.WorkdirReadonly,
+			Overlay:  cfg.WorkdirOverlay,
 		})
 	}
 
+	return append(volumes, configVolumes(cfg, cwd)...)
+}
+
+// configVolumes returns the extra mounts from the volumes setting, with
+// relative sources taken from cwd. Invalid entries and missing sources are
+// skipped with a warning.
+func configVolumes(cfg *provider.Config, cwd string) []provider.VolumeMount {
+	homeDir, _ := os.UserHomeDir()
+	var volumes []provider.VolumeMount
+	for _, spec := range cfg.Volumes {
+		if spec == "" {
+			continue
+		}
+		v, err := provider.ParseVolumeSpec(spec, homeDir, cwd)
+		if err != nil {
+			envLogger.Warning("%v, skipping", err)
+			continue
+		}
+		if _, err := os.Stat(v.Source); err != nil {
+			envLogger.Warning("volumes: %s not found, skipping", v.Source)
+			continue
+		}
+		volumes = append(volumes, v)
+	}
 	return volumes
 }
@@ -1,6 +1,9 @@
 package core
 
 import (
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
 
 	"github.com/jedi4ever/addt/provider"
@@ -67,3 +70,44 @@ func TestBuildVolumes_DifferentPaths(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildVolumes_Overlay(t *testing.T) {
+	cfg := &provider.Config{
+		WorkdirAutomount: true,
+		WorkdirReadonly:  true,
+		WorkdirOverlay:   true,
+	}
+
+	volumes := BuildVolumes(cfg, "/home/user/project")
+
+	if len(volumes) != 1 || !volumes[0].Overlay || !volumes[0].ReadOnly {
+		t.Errorf("BuildVolumes() = %+v, want a read-only overlay workdir mount", volumes)
+	}
+}
+
+func TestBuildVolumes_ConfigVolumes(t *testing.T) {
+	workdir := t.TempDir()
+	shared := t.TempDir()
+	if err := os.Mkdir(filepath.Join(workdir, "fixtures"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &provider.Config{
+		WorkdirAutomount: true,
+		Volumes: []string{
+			shared + ":/cache",
+			"fixtures:/fixtures:ro",
+			"missing:/missing",
+			"not-a-spec",
+		},
+	}
+
+	volumes := BuildVolumes(cfg, workdir)
+	want := []provider.VolumeMount{
+		{Source: workdir, Target: "/workspace"},
+		{Source: shared, Target: "/cache"},
+		{Source: filepath.Join(workdir, "fixtures"), Target: "/fixtures", ReadOnly: true},
+	}
+	if !reflect.DeepEqual(volumes, want) {
+		t.Errorf("BuildVolumes() = %+v, want %+v", volumes, want)
+	}
+}
@@ -81,6 +81,10 @@ func (e Entrypoint) Args() []string {
 	return e[1:]
 }
 
+// AuthMethods are the accepted values of auth.method. config.AuthMethods
+// refers to this list, since config imports this package.
+var AuthMethods = []string{"native", "env", "auto"}
+
 // ExtensionAuthConfig holds auth settings in extension config.yaml
 type ExtensionAuthConfig struct {
 	Autologin bool   `yaml:"autologin" json:"autologin"` // Automatically handle authentication on first launch
@@ -94,6 +98,13 @@ type ExtensionCfgSection struct {
 	Mounts    []ExtensionMount `yaml:"mounts" json:"mounts,omitempty"`
 }
 
+// ExtensionFirewall holds the firewall: section in extension config.yaml,
+// the extension layer's default allow and deny rules
+type ExtensionFirewall struct {
+	Allowed []string `yaml:"allowed" json:"allowed,omitempty"`
+	Denied  []string `yaml:"denied" json:"denied,omitempty"`
+}
+
 // ExtensionConfig represents the config.yaml structure for extension source files
 // Used when reading extension configs from embedded filesystem or local ~/.addt/extensions/
 type ExtensionConfig struct {
@@ -103,7 +114,9 @@ type ExtensionConfig struct {
 	DefaultVersion   string              `yaml:"default_version" json:"default_version,omitempty"`
 	Auth             ExtensionAuthConfig `yaml:"auth" json:"auth"`
 	Config           ExtensionCfgSection `yaml:"config" json:"config"`
+	Firewall         ExtensionFirewall   `yaml:"firewall" json:"firewall"`
 	Dependencies     []string            `yaml:"dependencies" json:"dependencies,omitempty"`
+	RequiresHost     []string            `yaml:"requires_host" json:"requires_host,omitempty"` // Host commands the extension needs, e.g. gh; checked by addt doctor
 	EnvVars          []string            `yaml:"env_vars" json:"env_vars,omitempty"`
 	OtelVars         []string            `yaml:"otel_vars" json:"otel_vars,omitempty"` // OpenTelemetry env vars; supports "VAR" or "VAR=default"
 	Flags            []ExtensionFlag     `yaml:"flags" json:"flags,omitempty"`
@@ -0,0 +1,209 @@
+package extensions
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/jedi4ever/addt/config/security"
+	"gopkg.in/yaml.v3"
+)
+
+// ValidationIssue is a problem found in an extension config.yaml
+type ValidationIssue struct {
+	Line    int    // 1-based line in config.yaml (0 if unknown)
+	Field   string // Dotted field path, e.g. "flags[0].env_var"
+	Message string
+}
+
+// String formats the issue with its line context
+func (i ValidationIssue) String() string {
+	if i.Line > 0 {
+		return fmt.Sprintf("line %d: %s: %s", i.Line, i.Field, i.Message)
+	}
+	return fmt.Sprintf("%s: %s", i.Field, i.Message)
+}
+
+// knownConfigKeys are the top-level keys understood in an extension config.yaml
+var knownConfigKeys = map[string]bool{
+	"name": true, "description": true, "entrypoint": true, "default_version": true,
+	"auth": true, "config": true, "dependencies": true, "env_vars": true,
+	"otel_vars": true, "flags": true, "credential_script": true, "requires_host": true,
+	"firewall": true,
+}
+
+// movedConfigKeys are legacy top-level keys and where they live now
+var movedConfigKeys = map[string]string{
+	"mounts": "config.mounts",
+}
+
+var extensionNamePattern = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
+// ValidateConfigFile validates an extension config.yaml. path may be the file
+// itself or the extension directory containing it.
+func ValidateConfigFile(path string) ([]ValidationIssue, error) {
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, "config.yaml")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ValidateConfig(data), nil
+}
+
+// ValidateConfig checks extension config.yaml content for missing required
+// fields, malformed flags, env var entries, mounts and firewall rules.
+func ValidateConfig(data []byte) []ValidationIssue {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return []ValidationIssue{{Field: "yaml", Message: err.Error()}}
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return []ValidationIssue{{Line: 1, Field: "yaml", Message: "expected a mapping at the top level"}}
+	}
+	root := doc.Content[0]
+
+	var cfg ExtensionConfig
+	if err := root.Decode(&cfg); err != nil {
+		return []ValidationIssue{{Line: root.Line, Field: "yaml", Message: err.Error()}}
+	}
+
+	v := &validator{root: root}
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key := root.Content[i]
+		if moved, ok := movedConfigKeys[key.Value]; ok {
+			v.add(key.Line, key.Value, fmt.Sprintf("deprecated top-level field, move it under %s", moved))
+		} else if !knownConfigKeys[key.Value] {
+			v.add(key.Line, key.Value, "unknown field")
+		}
+	}
+
+	switch {
+	case cfg.Name == "":
+		v.add(v.line("name"), "name", "required")
+	case !extensionNamePattern.MatchString(cfg.Name):
+		v.add(v.line("name"), "name", "must contain only lowercase letters, numbers, hyphens, and underscores")
+	}
+	if cfg.DefaultVersion == "" {
+		v.add(v.line("default_version"), "default_version", "required")
+	}
+	if cfg.Entrypoint.Command() == "" {
+		v.add(v.line("entrypoint"), "entrypoint", "required")
+	}
+
+	if m := cfg.Auth.Method; m != "" && !slices.Contains(AuthMethods, m) {
+		v.add(v.line("auth", "method"), "auth.method", fmt.Sprintf("must be one of %s, got %q", strings.Join(AuthMethods, ", "), m))
+	}
+
+	for i, flag := range cfg.Flags {
+		field := fmt.Sprintf("flags[%d]", i)
+		line := v.itemLine(i, "flags")
+		if flag.Flag == "" {
+			v.add(line, field+".flag", "required")
+		} else if !strings.HasPrefix(flag.Flag, "--") {
+			v.add(line, field+".flag", fmt.Sprintf("must start with --, got %q", flag.Flag))
+		}
+		if flag.EnvVar == "" {
+			v.add(line, field+".env_var", "required")
+		} else if !isValidEnvVarName(flag.EnvVar) {
+			v.add(line, field+".env_var", fmt.Sprintf("invalid env var name %q", flag.EnvVar))
+		}
+		if flag.Description == "" {
+			v.add(line, field+".description", "required")
+		}
+	}
+
+	v.checkEnvList("env_vars", cfg.EnvVars)
+	v.checkEnvList("otel_vars", cfg.OtelVars)
+	v.checkFirewallList("allowed", cfg.Firewall.Allowed)
+	v.checkFirewallList("denied", cfg.Firewall.Denied)
+
+	for i, mount := range cfg.Config.Mounts {
+		field := fmt.Sprintf("config.mounts[%d]", i)
+		line := v.itemLine(i, "config", "mounts")
+		if mount.Source == "" {
+			v.add(line, field+".source", "required")
+		}
+		if mount.Target == "" {
+			v.add(line, field+".target", "required")
+		} else if !strings.HasPrefix(mount.Target, "/") {
+			v.add(line, field+".target", fmt.Sprintf("must be an absolute path, got %q", mount.Target))
+		}
+	}
+
+	return v.issues
+}
+
+// validator collects issues and resolves line numbers from the YAML tree
+type validator struct {
+	root   *yaml.Node
+	issues []ValidationIssue
+}
+
+func (v *validator) add(line int, field, message string) {
+	v.issues = append(v.issues, ValidationIssue{Line: line, Field: field, Message: message})
+}
+
+// node walks a key path through nested mappings
+func (v *validator) node(path ...string) *yaml.Node {
+	n := v.root
+	for _, key := range path {
+		if n.Kind != yaml.MappingNode {
+			return nil
+		}
+		var next *yaml.Node
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if n.Content[i].Value == key {
+				next = n.Content[i+1]
+				break
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		n = next
+	}
+	return n
+}
+
+// line returns the line of the value at path, falling back to the top of the file
+func (v *validator) line(path ...string) int {
+	if n := v.node(path...); n != nil {
+		return n.Line
+	}
+	return 1
+}
+
+// itemLine returns the line of the i-th item of the sequence at path
+func (v *validator) itemLine(i int, path ...string) int {
+	n := v.node(path...)
+	if n == nil || n.Kind != yaml.SequenceNode || i >= len(n.Content) {
+		return v.line(path...)
+	}
+	return n.Content[i].Line
+}
+
+// checkEnvList validates "VAR" or "VAR=default" entries
+func (v *validator) checkEnvList(key string, entries []string) {
+	for i, entry := range entries {
+		name, _, _ := strings.Cut(entry, "=")
+		if !isValidEnvVarName(name) {
+			v.add(v.itemLine(i, key), fmt.Sprintf("%s[%d]", key, i), fmt.Sprintf("expected VAR or VAR=default, got %q", entry))
+		}
+	}
+}
+
+// checkFirewallList validates firewall.<key> entries: domains, IP addresses
+// or CIDR ranges
+func (v *validator) checkFirewallList(key string, hosts []string) {
+	for i, host := range hosts {
+		if err := security.ValidateFirewallHost(host); err != nil {
+			v.add(v.itemLine(i, "firewall", key), fmt.Sprintf("firewall.%s[%d]", key, i), err.Error())
+		}
+	}
+}
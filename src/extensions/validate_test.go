@@ -0,0 +1,182 @@
+package extensions
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const validExtensionConfig = `name: myext
+description: My extension
+entrypoint: myext
+default_version: latest
+auth:
+  method: env
+config:
+  mounts:
+    - source: ~/.myext
+      target: /home/addt/.myext
+env_vars:
+  - MYEXT_API_KEY
+  - MYEXT_MODE=fast
+flags:
+  - flag: "--yolo"
+    description: "Skip confirmations"
+    env_var: ADDT_EXTENSION_MYEXT_YOLO
+requires_host:
+  - gh
+firewall:
+  allowed:
+    - api.myext.dev
+    - 10.0.0.0/8
+`
+
+func TestValidateConfig_Valid(t *testing.T) {
+	if issues := ValidateConfig([]byte(validExtensionConfig)); len(issues) != 0 {
+		t.Errorf("expected no issues, got %v", issues)
+	}
+}
+
+func TestExtensionConfig_RequiresHost(t *testing.T) {
+	var cfg ExtensionConfig
+	if err := yaml.Unmarshal([]byte(validExtensionConfig), &cfg); err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.RequiresHost) != 1 || cfg.RequiresHost[0] != "gh" {
+		t.Errorf("RequiresHost = %v, want [gh]", cfg.RequiresHost)
+	}
+}
+
+func TestValidateConfig_EmbeddedExtensions(t *testing.T) {
+	entries, err := fs.ReadDir(FS, ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		data, err := FS.ReadFile(entry.Name() + "/config.yaml")
+		if err != nil {
+			continue
+		}
+		if issues := ValidateConfig(data); len(issues) != 0 {
+			t.Errorf("%s: expected no issues, got %v", entry.Name(), issues)
+		}
+	}
+}
+
+func TestValidateConfig_ExperimentalExtensions(t *testing.T) {
+	configs, err := filepath.Glob("../extensions_experimental/*/config.yaml")
+	if err != nil || len(configs) == 0 {
+		t.Fatalf("no experimental extension configs found: %v", err)
+	}
+	for _, path := range configs {
+		issues, err := ValidateConfigFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(issues) != 0 {
+			t.Errorf("%s: expected no issues, got %v", path, issues)
+		}
+	}
+}
+
+func TestValidateConfig_Malformed(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want []string // issue strings expected in the output
+	}{
+		{
+			name: "missing required fields",
+			yaml: "description: nothing else\n",
+			want: []string{"name: required", "default_version: required", "entrypoint: required"},
+		},
+		{
+			name: "bad name",
+			yaml: "name: My Ext\nentrypoint: x\ndefault_version: latest\n",
+			want: []string{"line 1: name: must contain only lowercase"},
+		},
+		{
+			name: "incomplete flag",
+			yaml: "name: x\nentrypoint: x\ndefault_version: latest\nflags:\n  - flag: yolo\n    env_var: 1BAD\n",
+			want: []string{
+				`line 5: flags[0].flag: must start with --, got "yolo"`,
+				`line 5: flags[0].env_var: invalid env var name "1BAD"`,
+				"line 5: flags[0].description: required",
+			},
+		},
+		{
+			name: "bad env var entry",
+			yaml: "name: x\nentrypoint: x\ndefault_version: latest\nenv_vars:\n  - GOOD\n  - BAD-NAME=1\n",
+			want: []string{`line 6: env_vars[1]: expected VAR or VAR=default, got "BAD-NAME=1"`},
+		},
+		{
+			name: "relative mount target and bad auth method",
+			yaml: "name: x\nentrypoint: x\ndefault_version: latest\nauth:\n  method: magic\nconfig:\n  mounts:\n    - source: ~/.x\n      target: .x\n",
+			want: []string{
+				`line 5: auth.method: must be one of native, env, auto, got "magic"`,
+				`line 8: config.mounts[0].target: must be an absolute path, got ".x"`,
+			},
+		},
+		{
+			name: "unknown field",
+			yaml: "name: x\nentrypoint: x\ndefault_version: latest\nnetwork:\n  allowed: [example.com]\n",
+			want: []string{"line 4: network: unknown field"},
+		},
+		{
+			name: "legacy top-level mounts",
+			yaml: "name: x\nentrypoint: x\ndefault_version: latest\nmounts:\n  - source: ~/.x\n    target: /home/addt/.x\n",
+			want: []string{"line 4: mounts: deprecated top-level field, move it under config.mounts"},
+		},
+		{
+			name: "bad firewall rules",
+			yaml: "name: x\nentrypoint: x\ndefault_version: latest\nfirewall:\n  allowed:\n    - api.x.dev\n    - https://x.dev\n  denied: [10.0.0.0/33]\n",
+			want: []string{
+				`line 7: firewall.allowed[1]: invalid CIDR "https://x.dev"`,
+				`line 8: firewall.denied[0]: invalid CIDR "10.0.0.0/33"`,
+			},
+		},
+		{
+			name: "invalid yaml",
+			yaml: "name: [unclosed\n",
+			want: []string{"yaml: "},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, issue := range ValidateConfig([]byte(tt.yaml)) {
+				got = append(got, issue.String())
+			}
+			joined := strings.Join(got, "\n")
+			for _, want := range tt.want {
+				if !strings.Contains(joined, want) {
+					t.Errorf("expected issue %q, got:\n%s", want, joined)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateConfigFile_Directory(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(validExtensionConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	issues, err := ValidateConfigFile(dir)
+	if err != nil {
+		t.Fatalf("ValidateConfigFile() error = %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("expected no issues, got %v", issues)
+	}
+
+	if _, err := ValidateConfigFile(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
@@ -0,0 +1,108 @@
+package applecontainer
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/jedi4ever/addt/config/security"
+	"github.com/jedi4ever/addt/provider"
+	"github.com/jedi4ever/addt/util"
+)
+
+// containerBinary is Apple's container CLI (macOS 15+)
+const containerBinary = "container"
+
+// goos is the host OS, overridable in tests
+var goos = runtime.GOOS
+
+var logger = util.Log("applecontainer")
+
+// AppleContainerProvider implements the Provider interface for Apple's
+// container CLI. Each container runs in its own lightweight VM, so the
+// Docker images are reused as-is but namespace-level hardening flags
+// (capabilities, seccomp, pids limits, ...) have no equivalent.
+type AppleContainerProvider struct {
+	config                 *provider.Config
+	embeddedDockerfile     []byte
+	embeddedDockerfileBase []byte
+	embeddedEntrypoint     []byte
+	embeddedInitFirewall   []byte
+	embeddedInstallSh      []byte
+	embeddedExtensions     embed.FS
+}
+
+// NewAppleContainerProvider creates a new Apple container provider
+func NewAppleContainerProvider(cfg *provider.Config, dockerfile, dockerfileBase, entrypoint, initFirewall, installSh []byte, extensions embed.FS) (provider.Provider, error) {
+	return &AppleContainerProvider{
+		config:                 cfg,
+		embeddedDockerfile:     dockerfile,
+		embeddedDockerfileBase: dockerfileBase,
+		embeddedEntrypoint:     entrypoint,
+		embeddedInitFirewall:   initFirewall,
+		embeddedInstallSh:      installSh,
+		embeddedExtensions:     extensions,
+	}, nil
+}
+
+// Initialize initializes the Apple container provider
+func (p *AppleContainerProvider) Initialize(cfg *provider.Config) error {
+	p.config = cfg
+
+	// Clean up stale temp directories from previous runs
+	security.CleanupAll()
+
+	return p.CheckPrerequisites()
+}
+
+// GetName returns the provider name
+func (p *AppleContainerProvider) GetName() string {
+	return "applecontainer"
+}
+
+// CheckPrerequisites verifies the container CLI is installed and its system service is running
+func (p *AppleContainerProvider) CheckPrerequisites() error {
+	if goos != "darwin" {
+		return provider.Tag(provider.ErrRuntimeNotFound, fmt.Errorf("Apple container is only available on macOS"))
+	}
+
+	if _, err := exec.LookPath(containerBinary); err != nil {
+		return provider.Tag(provider.ErrRuntimeNotFound, fmt.Errorf("Apple container CLI is not installed. Install it from: https://github.com/apple/container/releases"))
+	}
+
+	if err := p.containerCmd("system", "status").Run(); err != nil {
+		return provider.Tag(provider.ErrRuntimeNotRunning, fmt.Errorf("Apple container system service is not running. Start it with: container system start"))
+	}
+
+	return nil
+}
+
+// containerCmd creates an exec.Cmd for the container CLI
+func (p *AppleContainerProvider) containerCmd(args ...string) *exec.Cmd {
+	return exec.Command(containerBinary, args...)
+}
+
+// Cleanup has nothing to release: no host-side proxies are started
+func (p *AppleContainerProvider) Cleanup() error {
+	return nil
+}
+
+// executeCommand runs the container CLI attached to the terminal, sending
+// output to the spec's writers
+func (p *AppleContainerProvider) executeCommand(args []string, spec *provider.RunSpec) error {
+	logger.Debugf("Executing: container %v", args)
+	runCtx, cancel := spec.RunContext()
+	defer cancel()
+	cmd := exec.CommandContext(runCtx, containerBinary, args...)
+	provider.CancelOnTimeout(cmd, p, spec)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout, cmd.Stderr = spec.OutputWriters()
+	err := provider.RunCommand(cmd, spec, spec != nil && spec.Interactive)
+	if runCtx.Err() == context.DeadlineExceeded {
+		return provider.TimeoutError(spec)
+	}
+	return err
+}
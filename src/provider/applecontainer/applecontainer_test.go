@@ -0,0 +1,97 @@
+package applecontainer
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jedi4ever/addt/provider"
+)
+
+// installStubContainer puts a container CLI stub with the given shell body on PATH
+func installStubContainer(t *testing.T, body string) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "container"), []byte("#!/bin/sh\n"+body), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir)
+}
+
+// onDarwin pretends the host is macOS for the duration of the test
+func onDarwin(t *testing.T) {
+	t.Helper()
+	orig := goos
+	goos = "darwin"
+	t.Cleanup(func() { goos = orig })
+}
+
+func TestCheckPrerequisites_NotMacOS(t *testing.T) {
+	orig := goos
+	goos = "linux"
+	defer func() { goos = orig }()
+	installStubContainer(t, "exit 0\n")
+
+	p := &AppleContainerProvider{config: &provider.Config{}}
+	if err := p.CheckPrerequisites(); !errors.Is(err, provider.ErrRuntimeNotFound) {
+		t.Errorf("CheckPrerequisites() = %v, want ErrRuntimeNotFound", err)
+	}
+}
+
+func TestCheckPrerequisites_RuntimeNotFound(t *testing.T) {
+	onDarwin(t)
+	t.Setenv("PATH", t.TempDir())
+
+	p := &AppleContainerProvider{config: &provider.Config{}}
+	if err := p.CheckPrerequisites(); !errors.Is(err, provider.ErrRuntimeNotFound) {
+		t.Errorf("CheckPrerequisites() = %v, want ErrRuntimeNotFound", err)
+	}
+}
+
+func TestCheckPrerequisites_SystemNotRunning(t *testing.T) {
+	onDarwin(t)
+	installStubContainer(t, "if [ \"$1 $2\" = \"system status\" ]; then exit 1; fi\n")
+
+	p := &AppleContainerProvider{config: &provider.Config{}}
+	if err := p.CheckPrerequisites(); !errors.Is(err, provider.ErrRuntimeNotRunning) {
+		t.Errorf("CheckPrerequisites() = %v, want ErrRuntimeNotRunning", err)
+	}
+}
+
+func TestCheckPrerequisites_Ready(t *testing.T) {
+	onDarwin(t)
+	installStubContainer(t, "exit 0\n")
+
+	p := &AppleContainerProvider{config: &provider.Config{}}
+	if err := p.CheckPrerequisites(); err != nil {
+		t.Errorf("CheckPrerequisites() = %v, want nil", err)
+	}
+}
+
+func TestListAndIsRunning(t *testing.T) {
+	// PATH only holds the stub, so stick to shell builtins
+	installStubContainer(t, `echo '[{"status":"running","configuration":{"id":"addt-persistent-app-1234abcd"}},
+ {"status":"stopped","configuration":{"id":"addt-persistent-lib-deadbeef"}},
+ {"status":"running","configuration":{"id":"unrelated"}}]'
+`)
+
+	p := &AppleContainerProvider{config: &provider.Config{}}
+	envs, err := p.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(envs) != 2 || envs[0].Name != "addt-persistent-app-1234abcd" || envs[1].Status != "stopped" {
+		t.Errorf("List() = %+v, want the two addt-persistent containers", envs)
+	}
+
+	if !p.IsRunning("addt-persistent-app-1234abcd") {
+		t.Error("IsRunning(app) = false, want true")
+	}
+	if p.IsRunning("addt-persistent-lib-deadbeef") {
+		t.Error("IsRunning(lib) = true, want false for a stopped container")
+	}
+	if !p.Exists("addt-persistent-lib-deadbeef") || p.Exists("missing") {
+		t.Error("Exists() should report stopped containers and reject unknown names")
+	}
+}
@@ -0,0 +1,221 @@
+package applecontainer
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jedi4ever/addt/provider"
+	"github.com/jedi4ever/addt/util"
+)
+
+// BuildIfNeeded ensures the base and extension images are present
+func (p *AppleContainerProvider) BuildIfNeeded(rebuild bool, rebuildBase bool) error {
+	if rebuildBase {
+		fmt.Printf("Rebuilding base image %s...\n", p.GetBaseImageName())
+		if err := p.BuildBaseImage(); err != nil {
+			return err
+		}
+	}
+
+	if rebuild || !p.ImageExists(p.config.ImageName) {
+		return p.BuildImage()
+	}
+	return nil
+}
+
+// DetermineImageName returns the image tag for the configured extensions.
+// Versions are used as configured (dist-tags are not resolved against npm),
+// so use --rebuild to pick up a new "latest".
+func (p *AppleContainerProvider) DetermineImageName() string {
+	exts := p.sortedExtensions()
+	if len(exts) == 0 {
+		return fmt.Sprintf("addt:v%s_base-%s", p.config.AddtVersion, p.assetsHash())
+	}
+
+	var tagParts []string
+	for _, ext := range exts {
+		tagParts = append(tagParts, fmt.Sprintf("%s-%s", ext, p.extensionVersion(ext)))
+	}
+	return fmt.Sprintf("addt:v%s_%s-%s-%s", p.config.AddtVersion, strings.Join(tagParts, "_"), p.assetsHash(), p.extAssetsHash())
+}
+
+// extensionVersion returns the configured version, defaulting to "stable" for claude
+func (p *AppleContainerProvider) extensionVersion(ext string) string {
+	if v, ok := p.config.ExtensionVersions[ext]; ok {
+		return v
+	}
+	if ext == "claude" {
+		return "stable"
+	}
+	return "latest"
+}
+
+// GetBaseImageName returns the base image name for the current config
+func (p *AppleContainerProvider) GetBaseImageName() string {
+	return fmt.Sprintf("addt-base:v%s-node%s-go%s-uv%s-uid%s-%s",
+		p.config.AddtVersion, p.config.NodeVersion, p.config.GoVersion, p.config.UvVersion, util.CurrentUser().Uid, p.assetsHash())
+}
+
+// ImageExists checks if an image exists in the container image store
+func (p *AppleContainerProvider) ImageExists(imageName string) bool {
+	return p.containerCmd("image", "inspect", imageName).Run() == nil
+}
+
+// assetsHash returns a short hash of the base image assets
+func (p *AppleContainerProvider) assetsHash() string {
+	h := sha256.New()
+	h.Write(p.embeddedDockerfileBase)
+	h.Write(p.embeddedEntrypoint)
+	h.Write(p.embeddedInitFirewall)
+	return fmt.Sprintf("%x", h.Sum(nil))[:8]
+}
+
+// extAssetsHash returns a short hash of the embedded extension layer assets
+func (p *AppleContainerProvider) extAssetsHash() string {
+	h := sha256.New()
+	h.Write(p.embeddedDockerfile)
+	h.Write(p.embeddedInstallSh)
+	fs.WalkDir(p.embeddedExtensions, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		content, err := p.embeddedExtensions.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		h.Write([]byte(path))
+		h.Write(content)
+		return nil
+	})
+	return fmt.Sprintf("%x", h.Sum(nil))[:8]
+}
+
+// BuildBaseImage builds the base image (Node, Go, UV, system packages)
+func (p *AppleContainerProvider) BuildBaseImage() error {
+	baseImageName := p.GetBaseImageName()
+	u := util.CurrentUser()
+
+	buildDir, err := p.writeBuildContext()
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(buildDir)
+
+	args := []string{
+		"build",
+		"--build-arg", fmt.Sprintf("NODE_VERSION=%s", p.config.NodeVersion),
+		"--build-arg", fmt.Sprintf("GO_VERSION=%s", p.config.GoVersion),
+		"--build-arg", fmt.Sprintf("UV_VERSION=%s", p.config.UvVersion),
+		"--build-arg", fmt.Sprintf("USER_ID=%s", u.Uid),
+		"--build-arg", fmt.Sprintf("GROUP_ID=%s", u.Gid),
+		"--build-arg", "USERNAME=addt",
+		"-t", baseImageName,
+		"-f", filepath.Join(buildDir, "Dockerfile.base"),
+		buildDir,
+	}
+	return p.runBuild(baseImageName, args, "base image")
+}
+
+// BuildImage builds the extension image on top of the base image
+func (p *AppleContainerProvider) BuildImage() error {
+	baseImageName := p.GetBaseImageName()
+	if p.ImageExists(baseImageName) {
+		util.PrintCacheHit(baseImageName)
+	} else if err := p.BuildBaseImage(); err != nil {
+		return fmt.Errorf("failed to ensure base image: %w", err)
+	}
+
+	buildDir, err := p.writeBuildContext()
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(buildDir)
+
+	var versionPairs []string
+	for _, ext := range p.sortedExtensions() {
+		versionPairs = append(versionPairs, fmt.Sprintf("%s:%s", ext, p.extensionVersion(ext)))
+	}
+
+	args := []string{"build"}
+	if p.config.NoCache {
+		args = append(args, "--no-cache")
+	}
+	args = append(args,
+		"--build-arg", fmt.Sprintf("BASE_IMAGE=%s", baseImageName),
+		"--build-arg", fmt.Sprintf("ADDT_EXTENSIONS=%s", p.config.Extensions),
+		"--build-arg", fmt.Sprintf("EXTENSION_VERSIONS=%s", strings.Join(versionPairs, ",")),
+		"-t", p.config.ImageName,
+		"-f", filepath.Join(buildDir, "Dockerfile"),
+		buildDir,
+	)
+	return p.runBuild(p.config.ImageName, args, "image")
+}
+
+// runBuild runs "container build" with progress output and the configured timeout
+func (p *AppleContainerProvider) runBuild(imageName string, args []string, what string) error {
+	startTime := time.Now()
+	util.PrintBuildStart(imageName)
+	if err := util.RunBuildCommandWithTimeout(containerBinary, args, os.Environ(), provider.BuildTimeout(p.config)); err != nil {
+		util.PrintError(fmt.Sprintf("Failed to build %s: %v", what, err))
+		return provider.Tag(provider.ErrImageBuildFailed, fmt.Errorf("failed to build %s: %w", what, err))
+	}
+	util.PrintBuildComplete(imageName, time.Since(startTime))
+	return nil
+}
+
+// writeBuildContext writes the embedded Docker assets and extensions to a temp
+// build directory. The caller removes it.
+func (p *AppleContainerProvider) writeBuildContext() (string, error) {
+	buildDir, err := os.MkdirTemp("", "addt-build-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp build directory: %w", err)
+	}
+
+	files := []struct {
+		name    string
+		content []byte
+		mode    os.FileMode
+	}{
+		{"Dockerfile.base", p.embeddedDockerfileBase, 0644},
+		{"Dockerfile", p.embeddedDockerfile, 0644},
+		{"docker-entrypoint.sh", p.embeddedEntrypoint, 0755},
+		{"init-firewall.sh", p.embeddedInitFirewall, 0755},
+		{"install.sh", p.embeddedInstallSh, 0755},
+	}
+	for _, f := range files {
+		if err := os.WriteFile(filepath.Join(buildDir, f.name), f.content, f.mode); err != nil {
+			os.RemoveAll(buildDir)
+			return "", fmt.Errorf("failed to write %s: %w", f.name, err)
+		}
+	}
+
+	extensionsDir := filepath.Join(buildDir, "extensions")
+	if err := os.MkdirAll(extensionsDir, 0755); err != nil {
+		os.RemoveAll(buildDir)
+		return "", fmt.Errorf("failed to create extensions directory: %w", err)
+	}
+	err = fs.WalkDir(p.embeddedExtensions, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || path == "." || path == "embed.go" || path == "go.mod" {
+			return err
+		}
+		dest := filepath.Join(extensionsDir, path)
+		if d.IsDir() {
+			return os.MkdirAll(dest, 0755)
+		}
+		content, err := p.embeddedExtensions.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(dest, content, 0755)
+	})
+	if err != nil {
+		os.RemoveAll(buildDir)
+		return "", fmt.Errorf("failed to write extensions: %w", err)
+	}
+	return buildDir, nil
+}
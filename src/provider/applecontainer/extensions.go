@@ -0,0 +1,89 @@
+package applecontainer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jedi4ever/addt/extensions"
+)
+
+// GetExtensionMetadata reads all extension metadata from the image
+func (p *AppleContainerProvider) GetExtensionMetadata(imageName string) map[string]extensions.ExtensionMetadata {
+	output, err := p.containerCmd("run", "--rm", "--entrypoint", "cat", imageName,
+		"/home/addt/.addt/extensions.json").Output()
+	if err != nil {
+		// Images without extensions have no metadata; not an error
+		return nil
+	}
+
+	var config extensions.ExtensionsJSONConfig
+	if err := json.Unmarshal(output, &config); err != nil {
+		return nil
+	}
+	return config.Extensions
+}
+
+// GetExtensionEnvVars returns all unique environment variables (env_vars and
+// otel_vars) needed by the installed extensions
+func (p *AppleContainerProvider) GetExtensionEnvVars(imageName string) []string {
+	seen := make(map[string]bool)
+	var envVars []string
+	for _, ext := range p.GetExtensionMetadata(imageName) {
+		for _, list := range [][]string{ext.EnvVars, ext.OtelVars} {
+			for _, v := range list {
+				if !seen[v] {
+					seen[v] = true
+					envVars = append(envVars, v)
+				}
+			}
+		}
+	}
+	return envVars
+}
+
+// AddExtensionMounts adds the config mounts of extensions that have automount
+// enabled, honouring the per-extension and global automount/readonly settings
+func (p *AppleContainerProvider) AddExtensionMounts(args []string, imageName, homeDir string) []string {
+	if p.config.NoConfigAutomount {
+		return args
+	}
+	for extName, ext := range p.GetExtensionMetadata(imageName) {
+		if ext.Config == nil {
+			continue
+		}
+
+		automount := ext.Config.Automount != nil && *ext.Config.Automount
+		if enabled, ok := p.config.ExtensionConfigAutomount[extName]; ok {
+			automount = enabled
+		}
+		if !automount {
+			continue
+		}
+
+		readonly := (ext.Config.Readonly != nil && *ext.Config.Readonly) || p.config.ConfigReadonly
+		if ro, ok := p.config.ExtensionConfigReadonly[extName]; ok {
+			readonly = ro
+		}
+		suffix := ""
+		if readonly {
+			suffix = ":ro"
+		}
+
+		for _, mount := range ext.Config.Mounts {
+			source := mount.Source
+			if strings.HasPrefix(source, "~/") {
+				source = filepath.Join(homeDir, source[2:])
+			}
+			if _, err := os.Stat(source); os.IsNotExist(err) {
+				// Create missing directories; skip missing files (e.g. ~/.claude.json on a fresh install)
+				if strings.Contains(filepath.Base(source), ".") || os.MkdirAll(source, 0755) != nil {
+					continue
+				}
+			}
+			args = append(args, "-v", source+":"+mount.Target+suffix)
+		}
+	}
+	return args
+}
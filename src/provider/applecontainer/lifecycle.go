@@ -0,0 +1,197 @@
+package applecontainer
+
+import (
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jedi4ever/addt/provider"
+	"github.com/jedi4ever/addt/util"
+)
+
+// containerInfo is the subset of "container ls --format json" output addt uses
+type containerInfo struct {
+	Status        string `json:"status"`
+	Configuration struct {
+		ID string `json:"id"`
+	} `json:"configuration"`
+}
+
+// listContainers returns all containers known to the container CLI
+func (p *AppleContainerProvider) listContainers() ([]containerInfo, error) {
+	output, err := p.containerCmd("ls", "--all", "--format", "json").Output()
+	if err != nil {
+		return nil, err
+	}
+	var containers []containerInfo
+	if err := json.Unmarshal(output, &containers); err != nil {
+		return nil, fmt.Errorf("failed to parse container list: %w", err)
+	}
+	return containers, nil
+}
+
+// findContainer looks up a container by name
+func (p *AppleContainerProvider) findContainer(name string) (containerInfo, bool) {
+	containers, err := p.listContainers()
+	if err != nil {
+		return containerInfo{}, false
+	}
+	for _, c := range containers {
+		if c.Configuration.ID == name {
+			return c, true
+		}
+	}
+	return containerInfo{}, false
+}
+
+// Exists checks if a container exists (running or stopped)
+func (p *AppleContainerProvider) Exists(name string) bool {
+	_, ok := p.findContainer(name)
+	return ok
+}
+
+// IsRunning checks if a container is currently running
+func (p *AppleContainerProvider) IsRunning(name string) bool {
+	c, ok := p.findContainer(name)
+	return ok && c.Status == "running"
+}
+
+// Stats is not supported: the container CLI has no stats command
+func (p *AppleContainerProvider) Stats(name string) (provider.Stats, error) {
+	return provider.Stats{}, fmt.Errorf("stats for %s: %w", name, provider.ErrUnsupported)
+}
+
+// Start starts a stopped container
+func (p *AppleContainerProvider) Start(name string) error {
+	return util.SimpleSpinnerRun(fmt.Sprintf("Starting container %s", name), p.containerCmd("start", name))
+}
+
+// Stop stops a running container
+func (p *AppleContainerProvider) Stop(name string) error {
+	return util.SimpleSpinnerRun(fmt.Sprintf("Stopping container %s", name), p.containerCmd("stop", name))
+}
+
+// Restart stops and starts a container; the container CLI has no restart command
+func (p *AppleContainerProvider) Restart(name string) error {
+	if err := p.Stop(name); err != nil {
+		return err
+	}
+	return p.Start(name)
+}
+
+// Remove removes a container
+func (p *AppleContainerProvider) Remove(name string) error {
+	return util.SimpleSpinnerRun(fmt.Sprintf("Removing container %s", name), p.containerCmd("delete", "--force", name))
+}
+
+// List lists all persistent addt containers. The container CLI doesn't
+// report creation times, so CreatedAt is left empty.
+func (p *AppleContainerProvider) List() ([]provider.Environment, error) {
+	containers, err := p.listContainers()
+	if err != nil {
+		return nil, err
+	}
+	var envs []provider.Environment
+	for _, c := range containers {
+		if strings.HasPrefix(c.Configuration.ID, "addt-persistent-") || (p.config.ContainerName != "" && c.Configuration.ID == p.config.ContainerName) {
+			envs = append(envs, provider.Environment{Name: c.Configuration.ID, Status: c.Status})
+		}
+	}
+	return envs, nil
+}
+
+// GeneratePersistentName returns the container.name override, or
+// addt-persistent-<dirname>-<hash>, where the hash covers workdir +
+// extensions so each combination gets its own container
+func (p *AppleContainerProvider) GeneratePersistentName() string {
+	if p.config.ContainerName != "" {
+		return p.config.ContainerName
+	}
+	workdir := p.config.Workdir
+	if workdir == "" {
+		var err error
+		if workdir, err = os.Getwd(); err != nil {
+			workdir = "/tmp"
+		}
+	}
+
+	dirname := strings.ToLower(filepath.Base(workdir))
+	dirname = strings.Trim(regexp.MustCompile(`[^a-z0-9-]+`).ReplaceAllString(dirname, "-"), "-")
+	if len(dirname) > 20 {
+		dirname = dirname[:20]
+	}
+
+	hash := md5.Sum([]byte(workdir + "|" + strings.Join(p.sortedExtensions(), ",")))
+	return fmt.Sprintf("addt-persistent-%s-%x", dirname, hash[:4])
+}
+
+// GenerateEphemeralName generates a unique ephemeral container name
+func (p *AppleContainerProvider) GenerateEphemeralName() string {
+	return fmt.Sprintf("addt-%s-%d", time.Now().Format("20060102-150405"), os.Getpid())
+}
+
+// GetStatus returns a status string for display
+func (p *AppleContainerProvider) GetStatus(cfg *provider.Config, envName string) string {
+	parts := []string{"applecontainer"}
+
+	var res []string
+	if cfg.ContainerCPUs != "" {
+		res = append(res, fmt.Sprintf("cpu:%s", cfg.ContainerCPUs))
+	}
+	if cfg.ContainerMemory != "" {
+		res = append(res, fmt.Sprintf("mem:%s", cfg.ContainerMemory))
+	}
+	if len(res) > 0 {
+		parts = append(parts, strings.Join(res, " "))
+	}
+
+	workdir := cfg.Workdir
+	if workdir == "" {
+		workdir, _ = os.Getwd()
+	}
+	switch {
+	case !cfg.WorkdirAutomount:
+		parts = append(parts, "[not mounted]")
+	case cfg.WorkdirReadonly || cfg.WorkdirOverlay:
+		parts = append(parts, fmt.Sprintf("%s [RO]", workdir))
+	default:
+		parts = append(parts, fmt.Sprintf("%s [RW]", workdir))
+	}
+
+	if cfg.SSHForwardKeys && cfg.SSHForwardMode == "agent" {
+		parts = append(parts, "SSH:agent")
+	}
+	if cfg.Persistent {
+		parts = append(parts, "Persistent")
+	}
+
+	return strings.Join(parts, " | ")
+}
+
+// sortedExtensions returns the configured extensions, trimmed and sorted
+func (p *AppleContainerProvider) sortedExtensions() []string {
+	var exts []string
+	for _, ext := range strings.Split(p.config.Extensions, ",") {
+		if ext = strings.TrimSpace(ext); ext != "" {
+			exts = append(exts, ext)
+		}
+	}
+	sort.Strings(exts)
+	return exts
+}
+
+// sortedKeys returns the keys of m in a stable order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,190 @@
+package applecontainer
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jedi4ever/addt/provider"
+	"github.com/jedi4ever/addt/util"
+)
+
+const entrypointPath = "/usr/local/bin/docker-entrypoint.sh"
+
+// Run runs a new container, or execs into an existing persistent one
+func (p *AppleContainerProvider) Run(spec *provider.RunSpec) error {
+	return p.run(spec, nil)
+}
+
+// Shell opens a bash shell through the entrypoint so extension setup still runs
+func (p *AppleContainerProvider) Shell(spec *provider.RunSpec) error {
+	fmt.Println("Opening bash shell in container...")
+	return p.run(spec, []string{"-e", "ADDT_COMMAND=/bin/bash"})
+}
+
+// run starts the container for spec. extraExecEnv is passed to the
+// entrypoint when it runs through "container exec".
+func (p *AppleContainerProvider) run(spec *provider.RunSpec, extraExecEnv []string) error {
+	if err := p.checkFirewall(); err != nil {
+		return err
+	}
+	p.warnIgnoredSettings()
+
+	homeDir := util.CurrentUser().HomeDir
+
+	if spec.Persistent {
+		if !p.Exists(spec.Name) {
+			fmt.Printf("Creating new persistent container: %s\n", spec.Name)
+			args := append(p.buildRunArgs(spec, homeDir, true), "--entrypoint", "sleep", spec.ImageName, "infinity")
+			logger.Debugf("Starting persistent container: container %v", args)
+			if output, err := p.containerCmd(args...).CombinedOutput(); err != nil {
+				return provider.Tag(provider.ErrContainerStartFailed, fmt.Errorf("failed to start persistent container: %w\n%s", err, string(output)))
+			}
+		} else if !p.IsRunning(spec.Name) {
+			fmt.Printf("Starting persistent container: %s\n", spec.Name)
+			if err := p.Start(spec.Name); err != nil {
+				return provider.Tag(provider.ErrContainerStartFailed, err)
+			}
+		} else {
+			fmt.Printf("Found existing persistent container: %s\n", spec.Name)
+		}
+		return p.executeCommand(buildExecArgs(spec, extraExecEnv), spec)
+	}
+
+	args := p.buildRunArgs(spec, homeDir, false)
+	if extraExecEnv != nil {
+		args = append(args, extraExecEnv...)
+	}
+	args = append(args, provider.EntrypointEnvArgs(spec)...)
+	args = append(args, spec.ImageName)
+	args = append(args, provider.CommandArgs(spec)...)
+	return p.executeCommand(args, spec)
+}
+
+// buildRunArgs builds "container run" arguments (without the image) for spec.
+// Detached runs are used for persistent containers that are exec'd into.
+func (p *AppleContainerProvider) buildRunArgs(spec *provider.RunSpec, homeDir string, detached bool) []string {
+	args := []string{"run", "--name", spec.Name}
+	switch {
+	case detached:
+		args = append(args, "-d")
+	case spec.Interactive:
+		args = append(args, "--rm", "-i", "-t")
+	default:
+		args = append(args, "--rm", "-i")
+	}
+	args = append(args, provider.WorkdirArgs(p.config)...)
+
+	for _, vol := range spec.Volumes {
+		args = append(args, "-v", provider.VolumeArg(vol, false))
+	}
+	args = append(args, provider.MountArgs(spec.Mounts)...)
+	if homeDir != "" {
+		args = p.AddExtensionMounts(args, spec.ImageName, homeDir)
+		args = append(args, provider.GitconfigMountArgs(p.config, homeDir, "addt")...)
+	}
+
+	// The container CLI forwards the host SSH agent natively
+	if spec.SSHForwardKeys && spec.SSHForwardMode == "agent" {
+		args = append(args, "--ssh")
+	}
+
+	// Bind to localhost only to avoid exposing dev ports to the network
+	for _, port := range spec.Ports {
+		args = append(args, "-p", fmt.Sprintf("127.0.0.1:%d:%d", port.Host, port.Container))
+	}
+
+	for _, k := range sortedKeys(spec.Env) {
+		args = append(args, "-e", fmt.Sprintf("%s=%s", k, spec.Env[k]))
+	}
+
+	if spec.ContainerCPUs != "" {
+		args = append(args, "--cpus", spec.ContainerCPUs)
+	}
+	if spec.ContainerMemory != "" {
+		args = append(args, "--memory", spec.ContainerMemory)
+	}
+
+	// security.mount_readonly: no host file is writable from the container
+	if p.config.Security.MountReadonly {
+		args = provider.ReadOnlyMountArgs(args)
+	}
+	return append(args, p.SecurityArgs()...)
+}
+
+// buildExecArgs builds "container exec" arguments that run the entrypoint
+// in an existing persistent container
+func buildExecArgs(spec *provider.RunSpec, extraEnv []string) []string {
+	args := []string{"exec"}
+	if spec.Interactive {
+		args = append(args, "-i", "-t")
+	} else {
+		args = append(args, "-i")
+	}
+	args = append(args, extraEnv...)
+	args = append(args, provider.TerminalSizeArgs(spec.Env)...)
+	args = append(args, provider.EntrypointEnvArgs(spec)...)
+	args = append(args, spec.Name, entrypointPath)
+	return append(args, provider.CommandArgs(spec)...)
+}
+
+// SecurityArgs returns the security flags a new container would be started with.
+// Only the time limit maps onto the container CLI; see ignoredSettings.
+func (p *AppleContainerProvider) SecurityArgs() []string {
+	var args []string
+	if p.config.Security.TimeLimit > 0 {
+		args = append(args, "-e", fmt.Sprintf("ADDT_TIME_LIMIT_SECONDS=%d", p.config.Security.TimeLimit*60))
+	}
+	return args
+}
+
+// checkFirewall refuses runs with firewall.enabled: the container CLI can't
+// grant the iptables capabilities the firewall rules need, and a run that
+// looks firewalled but isn't is worse than a failed one.
+func (p *AppleContainerProvider) checkFirewall() error {
+	if p.config.FirewallEnabled {
+		return fmt.Errorf("firewall not supported on the Apple container provider; disable it with --no-firewall or firewall.enabled false: %w", provider.ErrUnsupported)
+	}
+	return nil
+}
+
+// ignoredSettings returns the explicitly enabled settings the container CLI
+// can't honour. The default hardening flags (pids/ulimits, capabilities,
+// no-new-privileges, secret isolation) are replaced by per-container VM
+// isolation and aren't reported.
+func ignoredSettings(cfg *provider.Config) []string {
+	sec := cfg.Security
+	var ignored []string
+	add := func(enabled bool, name string) {
+		if enabled {
+			ignored = append(ignored, name)
+		}
+	}
+	add(cfg.DockerDindMode != "" && cfg.DockerDindMode != "off" && cfg.DockerDindMode != "false", "docker.dind")
+	add(cfg.SSHForwardKeys && cfg.SSHForwardMode != "agent", "ssh.forward_mode="+cfg.SSHForwardMode)
+	add(cfg.GPGForward != "" && cfg.GPGForward != "off" && cfg.GPGForward != "false", "gpg.forward")
+	add(cfg.TmuxForward, "tmux.forward")
+	add(cfg.HistoryPersist, "history.persist")
+	add(len(cfg.HomePersistSubdirs) > 0, "home.persist_subdirs")
+	add(sec.ReadOnlyRootfs, "security.read_only_rootfs")
+	add(sec.SeccompProfile != "" && sec.SeccompProfile != "default", "security.seccomp_profile")
+	add(sec.NetworkMode != "" && sec.NetworkMode != "bridge", "security.network_mode")
+	add(sec.DisableIPC, "security.disable_ipc")
+	add(sec.UserNamespace != "", "security.user_namespace")
+	add(sec.DisableDevices, "security.disable_devices")
+	add(sec.MemorySwap != "", "security.memory_swap")
+	add(len(sec.Ulimits) > 0, "security.ulimits")
+	add(cfg.ContainerDetachKeys != "", "container.detach_keys")
+	add(cfg.ContainerEntrypoint != "", "container.entrypoint")
+	add(cfg.ContainerPlatform != "", "container.platform")
+	add(provider.PullPolicy(cfg) != provider.PullMissing, "docker.pull_policy")
+	add(cfg.DockerCpusetCPUs != "" || cfg.DockerCpusetMems != "", "docker.cpuset_cpus/cpuset_mems")
+	return ignored
+}
+
+// warnIgnoredSettings tells the user which enabled settings have no effect
+func (p *AppleContainerProvider) warnIgnoredSettings() {
+	if ignored := ignoredSettings(p.config); len(ignored) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: not supported by Apple container, ignoring: %s\n", strings.Join(ignored, ", "))
+	}
+}
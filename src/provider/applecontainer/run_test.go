@@ -0,0 +1,99 @@
+package applecontainer
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jedi4ever/addt/config/security"
+	"github.com/jedi4ever/addt/provider"
+)
+
+func TestBuildRunArgs(t *testing.T) {
+	p := &AppleContainerProvider{config: &provider.Config{
+		Security: security.Config{TimeLimit: 5, PidsLimit: 200, CapDrop: []string{"ALL"}},
+	}}
+	spec := &provider.RunSpec{
+		Name:            "addt-test",
+		ImageName:       "addt:test",
+		Interactive:     true,
+		Volumes:         []provider.VolumeMount{{Source: "/src", Target: "/workspace"}, {Source: "/cfg", Target: "/cfg", ReadOnly: true}},
+		Ports:           []provider.PortMapping{{Container: 3000, Host: 30000}},
+		Env:             map[string]string{"B": "2", "A": "1"},
+		SSHForwardKeys:  true,
+		SSHForwardMode:  "agent",
+		ContainerCPUs:   "2",
+		ContainerMemory: "4g",
+	}
+
+	// Empty homeDir skips the image lookups for extension mounts
+	got := p.buildRunArgs(spec, "", false)
+	want := []string{
+		"run", "--name", "addt-test", "--rm", "-i", "-t",
+		"-v", "/src:/workspace", "-v", "/cfg:/cfg:ro",
+		"--ssh",
+		"-p", "127.0.0.1:30000:3000",
+		"-e", "A=1", "-e", "B=2",
+		"--cpus", "2", "--memory", "4g",
+		"-e", "ADDT_TIME_LIMIT_SECONDS=300",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("buildRunArgs() =\n  %v\nwant\n  %v", got, want)
+	}
+}
+
+func TestBuildRunArgs_Detached(t *testing.T) {
+	p := &AppleContainerProvider{config: &provider.Config{}}
+	spec := &provider.RunSpec{Name: "addt-persistent-x", Interactive: true}
+
+	got := p.buildRunArgs(spec, "", true)
+	want := []string{"run", "--name", "addt-persistent-x", "-d"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("buildRunArgs(detached) = %v, want %v", got, want)
+	}
+}
+
+func TestBuildExecArgs(t *testing.T) {
+	spec := &provider.RunSpec{Name: "addt-persistent-x", Args: []string{"--help"}}
+
+	got := buildExecArgs(spec, []string{"-e", "ADDT_COMMAND=/bin/bash"})
+	want := []string{"exec", "-i", "-e", "ADDT_COMMAND=/bin/bash", "addt-persistent-x", entrypointPath, "--help"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("buildExecArgs() = %v, want %v", got, want)
+	}
+}
+
+func TestBuildExecArgs_EntrypointArgs(t *testing.T) {
+	spec := &provider.RunSpec{Name: "addt-persistent-x", Args: []string{"--help"}, EntrypointArgs: []string{"--debug"}}
+
+	got := buildExecArgs(spec, nil)
+	want := []string{"exec", "-i", "-e", "ADDT_ENTRYPOINT_ARGS=1", "addt-persistent-x", entrypointPath, "--debug", "--help"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("buildExecArgs() = %v, want %v", got, want)
+	}
+}
+
+func TestIgnoredSettings(t *testing.T) {
+	// Default hardening is covered by VM isolation and not reported
+	cfg := &provider.Config{Security: security.DefaultConfig(), SSHForwardKeys: true, SSHForwardMode: "agent"}
+	if got := ignoredSettings(cfg); len(got) != 0 {
+		t.Errorf("ignoredSettings(defaults) = %v, want none", got)
+	}
+
+	cfg.FirewallEnabled = true
+	cfg.SSHForwardMode = "proxy"
+	cfg.Security.ReadOnlyRootfs = true
+	cfg.Security.NetworkMode = "none"
+	want := []string{"ssh.forward_mode=proxy", "security.read_only_rootfs", "security.network_mode"}
+	if got := ignoredSettings(cfg); !reflect.DeepEqual(got, want) {
+		t.Errorf("ignoredSettings() = %v, want %v", got, want)
+	}
+}
+
+func TestRun_FirewallUnsupported(t *testing.T) {
+	p := &AppleContainerProvider{config: &provider.Config{FirewallEnabled: true}}
+	err := p.Run(&provider.RunSpec{Name: "addt-test", ImageName: "addt:test"})
+	if !errors.Is(err, provider.ErrUnsupported) {
+		t.Fatalf("Run() error = %v, want ErrUnsupported", err)
+	}
+}
@@ -0,0 +1,23 @@
+package provider
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultBuildTimeout applies when docker.build_timeout is unset
+const DefaultBuildTimeout = 60 * time.Minute
+
+// BuildTimeout returns the image build timeout from docker.build_timeout.
+// "0" disables the timeout; an invalid value falls back to the default.
+func BuildTimeout(cfg *Config) time.Duration {
+	if cfg == nil || cfg.DockerBuildTimeout == "" {
+		return DefaultBuildTimeout
+	}
+	d, err := time.ParseDuration(cfg.DockerBuildTimeout)
+	if err != nil || d < 0 {
+		fmt.Printf("Warning: invalid docker.build_timeout %q, using %s\n", cfg.DockerBuildTimeout, DefaultBuildTimeout)
+		return DefaultBuildTimeout
+	}
+	return d
+}
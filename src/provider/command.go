@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"os"
 	"os/exec"
 	"strings"
@@ -16,6 +17,14 @@ func DockerCmd(context string, args ...string) *exec.Cmd {
 	return cmd
 }
 
+// DockerCmdContext is DockerCmd bound to ctx: the docker CLI is killed
+// when ctx is done.
+func DockerCmdContext(ctx context.Context, dockerContext string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	cmd.Env = append(os.Environ(), "DOCKER_CONTEXT="+dockerContext)
+	return cmd
+}
+
 var (
 	dockerContexts     []string
 	dockerContextsOnce sync.Once
@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ParseVolumeSpec parses a volumes entry, source:target[:ro|:rw]. The
+// source is tilde-expanded against homeDir and a relative source is taken
+// from workdir; the target must be an absolute container path or start with
+// ~/ (the agent's home). Volumes are read-write unless they end in ":ro".
+func ParseVolumeSpec(spec, homeDir, workdir string) (VolumeMount, error) {
+	parts := strings.Split(strings.TrimSpace(spec), ":")
+	var v VolumeMount
+
+	if n := len(parts); n > 2 && (parts[n-1] == "ro" || parts[n-1] == "rw") {
+		v.ReadOnly = parts[n-1] == "ro"
+		parts = parts[:n-1]
+	}
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return VolumeMount{}, fmt.Errorf("volumes %q: expected source:target[:ro|:rw]", spec)
+	}
+
+	v.Source = expandHome(parts[0], homeDir)
+	if !filepath.IsAbs(v.Source) {
+		v.Source = filepath.Join(workdir, v.Source)
+	}
+	v.Source = filepath.Clean(v.Source)
+
+	v.Target = parts[1]
+	if strings.HasPrefix(v.Target, "~/") {
+		v.Target = path.Join(containerHome, v.Target[2:])
+	}
+	if !path.IsAbs(v.Target) {
+		return VolumeMount{}, fmt.Errorf("volumes %q: container path must be absolute or start with ~/", spec)
+	}
+	v.Target = path.Clean(v.Target)
+	return v, nil
+}
@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseVolumeSpec(t *testing.T) {
+	tests := []struct {
+		spec string
+		want VolumeMount
+	}{
+		{"/data/cache:/cache", VolumeMount{Source: "/data/cache", Target: "/cache"}},
+		{"/data/cache:/cache:ro", VolumeMount{Source: "/data/cache", Target: "/cache", ReadOnly: true}},
+		{"/data/cache:/cache:rw", VolumeMount{Source: "/data/cache", Target: "/cache"}},
+		{"~/.cache/pip:~/.cache/pip", VolumeMount{Source: "/home/me/.cache/pip", Target: "/home/addt/.cache/pip"}},
+		{"../shared:/shared:ro", VolumeMount{Source: "/work/shared", Target: "/shared", ReadOnly: true}},
+		{"fixtures:/fixtures", VolumeMount{Source: "/work/project/fixtures", Target: "/fixtures"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseVolumeSpec(tt.spec, "/home/me", "/work/project")
+		if err != nil {
+			t.Errorf("ParseVolumeSpec(%q) error = %v", tt.spec, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseVolumeSpec(%q) = %+v, want %+v", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestParseVolumeSpec_Invalid(t *testing.T) {
+	for _, spec := range []string{"/data", "/data:", ":/cache", "/data:cache", "/a:/b:/c", "/data:ro"} {
+		if _, err := ParseVolumeSpec(spec, "/home/me", "/work"); err == nil || !strings.Contains(err.Error(), "volumes") {
+			t.Errorf("ParseVolumeSpec(%q) error = %v, want a volumes error", spec, err)
+		}
+	}
+}
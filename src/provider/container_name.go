@@ -0,0 +1,19 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// containerNamePattern is the name constraint of docker and podman
+var containerNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]+$`)
+
+// ValidateContainerName checks a container.name override against the
+// runtime's naming rules: a letter or digit, then letters, digits, "_", "."
+// or "-", at least two characters in total
+func ValidateContainerName(name string) error {
+	if !containerNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid container name %q: must match %s", name, containerNamePattern)
+	}
+	return nil
+}
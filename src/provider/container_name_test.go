@@ -0,0 +1,16 @@
+package provider
+
+import "testing"
+
+func TestValidateContainerName(t *testing.T) {
+	for _, name := range []string{"myproject", "addt-persistent-app", "App_1.dev", "a1"} {
+		if err := ValidateContainerName(name); err != nil {
+			t.Errorf("ValidateContainerName(%q) error = %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "a", "-leading", ".hidden", "has space", "slash/name", "colon:name", "ünïcode"} {
+		if err := ValidateContainerName(name); err == nil {
+			t.Errorf("ValidateContainerName(%q) expected an error", name)
+		}
+	}
+}
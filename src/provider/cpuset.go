@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ValidateCpuset checks a docker.cpuset_cpus/cpuset_mems value: a comma
+// separated list of numbers and ascending ranges, e.g. "0-3,8". Empty means
+// no pinning.
+func ValidateCpuset(value string) error {
+	if value == "" {
+		return nil
+	}
+	for _, part := range strings.Split(value, ",") {
+		lo, hi, isRange := strings.Cut(part, "-")
+		first, err := strconv.Atoi(lo)
+		if err != nil || first < 0 || lo != strconv.Itoa(first) {
+			return fmt.Errorf("invalid cpuset %q: want numbers and ranges like 0-3,8", value)
+		}
+		if !isRange {
+			continue
+		}
+		last, err := strconv.Atoi(hi)
+		if err != nil || hi != strconv.Itoa(last) || last < first {
+			return fmt.Errorf("invalid cpuset %q: range %s must be low-high", value, part)
+		}
+	}
+	return nil
+}
+
+// CpusetArgs returns the --cpuset-cpus/--cpuset-mems flags pinning the
+// container to docker.cpuset_cpus and docker.cpuset_mems. Values set
+// through the environment are passed as-is; the runtime rejects bad ones.
+func CpusetArgs(cfg *Config) []string {
+	var args []string
+	if cfg.DockerCpusetCPUs != "" {
+		args = append(args, "--cpuset-cpus", cfg.DockerCpusetCPUs)
+	}
+	if cfg.DockerCpusetMems != "" {
+		args = append(args, "--cpuset-mems", cfg.DockerCpusetMems)
+	}
+	return args
+}
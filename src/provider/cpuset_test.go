@@ -0,0 +1,30 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidateCpuset(t *testing.T) {
+	for _, valid := range []string{"", "0", "0-3", "0-3,8", "1,3,5-7", "4-4"} {
+		if err := ValidateCpuset(valid); err != nil {
+			t.Errorf("ValidateCpuset(%q) error = %v", valid, err)
+		}
+	}
+	for _, invalid := range []string{"a", "0-", "-1", "3-1", "0,,1", "0-3,", " 0", "0-3-5", "+1"} {
+		if err := ValidateCpuset(invalid); err == nil {
+			t.Errorf("ValidateCpuset(%q) expected error", invalid)
+		}
+	}
+}
+
+func TestCpusetArgs(t *testing.T) {
+	if got := CpusetArgs(&Config{}); got != nil {
+		t.Errorf("CpusetArgs(unset) = %v, want nil", got)
+	}
+	got := CpusetArgs(&Config{DockerCpusetCPUs: "0-3,8", DockerCpusetMems: "0"})
+	want := []string{"--cpuset-cpus", "0-3,8", "--cpuset-mems", "0"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CpusetArgs() = %v, want %v", got, want)
+	}
+}
@@ -47,7 +47,7 @@ func (p *DaytonaProvider) GetName() string {
 func (p *DaytonaProvider) CheckPrerequisites() error {
 	// Check Daytona is installed
 	if _, err := exec.LookPath("daytona"); err != nil {
-		return fmt.Errorf("Daytona is not installed. Please install Daytona from: https://github.com/daytonaio/daytona")
+		return provider.Tag(provider.ErrRuntimeNotFound, fmt.Errorf("Daytona is not installed. Please install Daytona from: https://github.com/daytonaio/daytona"))
 	}
 
 	// Check if user is logged in (Daytona v0.138+ uses cloud-based authentication)
@@ -77,6 +77,11 @@ func (p *DaytonaProvider) IsRunning(name string) bool {
 	return p.Exists(name)
 }
 
+// Stats is not supported for Daytona workspaces
+func (p *DaytonaProvider) Stats(name string) (provider.Stats, error) {
+	return provider.Stats{}, fmt.Errorf("stats for %s: %w", name, provider.ErrUnsupported)
+}
+
 // Start starts a stopped workspace (no-op for Daytona)
 func (p *DaytonaProvider) Start(name string) error {
 	// Daytona workspaces don't need explicit start
@@ -91,6 +96,11 @@ func (p *DaytonaProvider) Stop(name string) error {
 	return cmd.Run()
 }
 
+// Restart is not supported: workspaces have no restart command
+func (p *DaytonaProvider) Restart(name string) error {
+	return fmt.Errorf("restart %s: %w", name, provider.ErrUnsupported)
+}
+
 // Remove removes a workspace
 func (p *DaytonaProvider) Remove(name string) error {
 	cmd := exec.Command("daytona", "delete", name, "-y")
@@ -130,6 +140,9 @@ func (p *DaytonaProvider) List() ([]provider.Environment, error) {
 
 // Run runs a command in a workspace
 func (p *DaytonaProvider) Run(spec *provider.RunSpec) error {
+	if err := p.checkFirewall(); err != nil {
+		return err
+	}
 	workspaceName := spec.Name
 
 	// Check if workspace exists
@@ -246,6 +259,9 @@ func (p *DaytonaProvider) Run(spec *provider.RunSpec) error {
 
 // Shell opens a shell in a workspace
 func (p *DaytonaProvider) Shell(spec *provider.RunSpec) error {
+	if err := p.checkFirewall(); err != nil {
+		return err
+	}
 	workspaceName := spec.Name
 
 	// Check if workspace exists
@@ -408,8 +424,12 @@ func (p *DaytonaProvider) GetStatus(cfg *provider.Config, envName string) string
 	return status
 }
 
-// GeneratePersistentName generates a sandbox name for persistent mode
+// GeneratePersistentName generates a sandbox name for persistent mode,
+// unless container.name pins one
 func (p *DaytonaProvider) GeneratePersistentName() string {
+	if p.config.ContainerName != "" {
+		return p.config.ContainerName
+	}
 	// Use configured workdir or fall back to current directory
 	workdir := p.config.Workdir
 	if workdir == "" {
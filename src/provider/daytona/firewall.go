@@ -0,0 +1,17 @@
+package daytona
+
+import (
+	"fmt"
+
+	"github.com/jedi4ever/addt/provider"
+)
+
+// checkFirewall refuses runs with firewall.enabled: sandboxes run on
+// Daytona's infrastructure, where addt can't apply its iptables rules.
+// Failing is safer than a run that looks firewalled but isn't.
+func (p *DaytonaProvider) checkFirewall() error {
+	if p.config != nil && p.config.FirewallEnabled {
+		return fmt.Errorf("firewall not supported on daytona sandboxes; disable it with --no-firewall or firewall.enabled false: %w", provider.ErrUnsupported)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package daytona
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jedi4ever/addt/provider"
+)
+
+func TestRunAndShell_FirewallUnsupported(t *testing.T) {
+	p := &DaytonaProvider{config: &provider.Config{FirewallEnabled: true}}
+	spec := &provider.RunSpec{Name: "addt-test"}
+
+	for name, run := range map[string]func(*provider.RunSpec) error{"Run": p.Run, "Shell": p.Shell} {
+		err := run(spec)
+		if !errors.Is(err, provider.ErrUnsupported) {
+			t.Errorf("%s() error = %v, want ErrUnsupported", name, err)
+		}
+		if err != nil && !strings.Contains(err.Error(), "firewall not supported on daytona sandboxes") {
+			t.Errorf("%s() error = %q, want it to explain the firewall is unsupported", name, err)
+		}
+	}
+}
+
+func TestCheckFirewall_Disabled(t *testing.T) {
+	p := &DaytonaProvider{config: &provider.Config{}}
+	if err := p.checkFirewall(); err != nil {
+		t.Errorf("checkFirewall() error = %v, want nil", err)
+	}
+}
@@ -0,0 +1,12 @@
+package provider
+
+// DetachKeysArgs returns the --detach-keys flag for interactive run/exec
+// commands, or nil when container.detach_keys is unset and the runtime
+// default (Ctrl-P Ctrl-Q) applies. The sequence uses the runtime's own
+// format, e.g. "ctrl-x,x".
+func DetachKeysArgs(cfg *Config) []string {
+	if cfg.ContainerDetachKeys == "" {
+		return nil
+	}
+	return []string{"--detach-keys", cfg.ContainerDetachKeys}
+}
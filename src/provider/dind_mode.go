@@ -0,0 +1,26 @@
+package provider
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// DinD modes for docker.dind.mode
+const (
+	DindHost     = "host"
+	DindIsolated = "isolated"
+	DindOff      = "off"
+)
+
+// DindModes lists the accepted docker.dind.mode values
+var DindModes = []string{DindHost, DindIsolated, DindOff}
+
+// ValidateDindMode checks a DinD mode: host shares the host daemon socket,
+// isolated runs a private daemon in the container, off disables both
+func ValidateDindMode(mode string) error {
+	if !slices.Contains(DindModes, mode) {
+		return fmt.Errorf("expected one of %s, got %q", strings.Join(DindModes, ", "), mode)
+	}
+	return nil
+}
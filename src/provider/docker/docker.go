@@ -1,6 +1,7 @@
 package docker
 
 import (
+	"context"
 	"embed"
 	"fmt"
 	"os"
@@ -64,13 +65,13 @@ func (p *DockerProvider) GetName() string {
 func (p *DockerProvider) CheckPrerequisites() error {
 	// Check Docker is installed
 	if _, err := exec.LookPath("docker"); err != nil {
-		return fmt.Errorf("Docker is not installed. Please install Docker from: https://docs.docker.com/get-docker/")
+		return provider.Tag(provider.ErrRuntimeNotFound, fmt.Errorf("Docker is not installed. Please install Docker from: https://docs.docker.com/get-docker/"))
 	}
 
 	// Check Docker daemon is running
 	cmd := p.dockerCmd("info")
 	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("Docker daemon is not running. Please start Docker and try again")
+		return provider.Tag(provider.ErrRuntimeNotRunning, fmt.Errorf("Docker daemon is not running. Please start Docker and try again"))
 	}
 
 	return nil
@@ -85,6 +86,11 @@ func (p *DockerProvider) dockerCmd(args ...string) *exec.Cmd {
 	return provider.DockerCmd(p.dockerContext, args...)
 }
 
+// dockerCmdContext is dockerCmd bound to ctx (used for --timeout).
+func (p *DockerProvider) dockerCmdContext(ctx context.Context, args ...string) *exec.Cmd {
+	return provider.DockerCmdContext(ctx, p.dockerContext, args...)
+}
+
 // dockerEnv returns the environment slice for Docker commands in this context.
 func (p *DockerProvider) dockerEnv() []string {
 	return append(os.Environ(), "DOCKER_CONTEXT="+p.dockerContext)
@@ -1,13 +1,14 @@
 package docker
 
 import (
+	"context"
 	"fmt"
 	"os"
-	"os/user"
 	"path/filepath"
 	"time"
 
 	"github.com/jedi4ever/addt/assets"
+	"github.com/jedi4ever/addt/config/security"
 	"github.com/jedi4ever/addt/provider"
 	"github.com/jedi4ever/addt/util"
 )
@@ -23,10 +24,7 @@ type containerContext struct {
 
 // setupContainerContext prepares common container context and checks for existing containers
 func (p *DockerProvider) setupContainerContext(spec *provider.RunSpec) (*containerContext, error) {
-	currentUser, err := user.Current()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get current user: %w", err)
-	}
+	currentUser := util.CurrentUser()
 
 	ctx := &containerContext{
 		homeDir:              currentUser.HomeDir,
@@ -35,7 +33,15 @@ func (p *DockerProvider) setupContainerContext(spec *provider.RunSpec) (*contain
 	}
 
 	// Check if we should use existing container
-	if spec.Persistent && p.Exists(spec.Name) {
+	exists := spec.Persistent && p.Exists(spec.Name)
+	if exists {
+		removed, err := provider.RemoveExpired(p, p.config, p.dockerCmd, spec.Name)
+		if err != nil {
+			return nil, err
+		}
+		exists = !removed
+	}
+	if exists {
 		fmt.Printf("Found existing persistent container: %s\n", spec.Name)
 		if p.IsRunning(spec.Name) {
 			fmt.Println("Container is running, connecting...")
@@ -78,12 +84,16 @@ func (p *DockerProvider) buildBaseDockerArgs(spec *provider.RunSpec, ctx *contai
 		} else {
 			dockerArgs = []string{"run", "--rm", "--name", spec.Name}
 		}
+		dockerArgs = append(dockerArgs, provider.PlatformArgs(p.config)...)
+		dockerArgs = append(dockerArgs, provider.RunPullArgs(p.config)...)
+		dockerArgs = append(dockerArgs, provider.WorkdirArgs(p.config)...)
 	}
 
 	// Interactive mode
 	if spec.Interactive {
 		dockerArgs = append(dockerArgs, "-it")
-		if !ctx.useExistingContainer {
+		dockerArgs = append(dockerArgs, provider.DetachKeysArgs(p.config)...)
+		if !ctx.useExistingContainer && p.config.ContainerInit {
 			dockerArgs = append(dockerArgs, "--init")
 		}
 	} else {
@@ -99,28 +109,15 @@ func (p *DockerProvider) addContainerVolumesAndEnv(dockerArgs []string, spec *pr
 	cleanup := func() {}
 	// Add volumes
 	for _, vol := range spec.Volumes {
-		mount := fmt.Sprintf("%s:%s", vol.Source, vol.Target)
-		if vol.ReadOnly {
-			mount += ":ro"
-		}
-		dockerArgs = append(dockerArgs, "-v", mount)
+		dockerArgs = append(dockerArgs, "-v", provider.VolumeArg(vol, false))
 	}
+	dockerArgs = append(dockerArgs, provider.MountArgs(spec.Mounts)...)
 
 	// Add extension mounts
 	dockerArgs = p.AddExtensionMounts(dockerArgs, spec.ImageName, ctx.homeDir)
 
 	// Mount .gitconfig (if forwarding enabled)
-	if p.config.GitForwardConfig {
-		gitconfigPath := p.config.GitConfigPath
-		if gitconfigPath == "" {
-			gitconfigPath = filepath.Join(ctx.homeDir, ".gitconfig")
-		} else {
-			gitconfigPath = util.ExpandTilde(gitconfigPath)
-		}
-		if _, err := os.Stat(gitconfigPath); err == nil {
-			dockerArgs = append(dockerArgs, "-v", fmt.Sprintf("%s:/home/%s/.gitconfig.host:ro", gitconfigPath, ctx.username))
-		}
-	}
+	dockerArgs = append(dockerArgs, provider.GitconfigMountArgs(p.config, ctx.homeDir, ctx.username)...)
 
 	// Note: Claude config mounts (~/.claude, ~/.claude.json) are now handled
 	// by the claude extension via AddExtensionMounts above.
@@ -130,21 +127,11 @@ func (p *DockerProvider) addContainerVolumesAndEnv(dockerArgs []string, spec *pr
 	// so they go through the same -e mechanism as other env vars.
 
 	// SSH forwarding
-	sshDir := p.config.SSHDir
-	if sshDir == "" {
-		sshDir = filepath.Join(ctx.homeDir, ".ssh")
-	} else {
-		sshDir = util.ExpandTilde(sshDir)
-	}
-	dockerArgs = append(dockerArgs, p.HandleSSHForwarding(spec.SSHForwardKeys, spec.SSHForwardMode, sshDir, ctx.username, spec.SSHAllowedKeys)...)
+	sshDir := provider.SSHDir(p.config, ctx.homeDir)
+	dockerArgs = append(dockerArgs, p.HandleSSHForwarding(spec.SSHForwardKeys, spec.SSHForwardMode, sshDir, ctx.username, spec.SSHAllowedKeys, provider.ExtraSSHDirs(p.config)...)...)
 
 	// GPG forwarding
-	gpgDir := p.config.GPGDir
-	if gpgDir == "" {
-		gpgDir = filepath.Join(ctx.homeDir, ".gnupg")
-	} else {
-		gpgDir = util.ExpandTilde(gpgDir)
-	}
+	gpgDir := provider.GPGDir(p.config, ctx.homeDir)
 	dockerArgs = append(dockerArgs, p.HandleGPGForwarding(spec.GPGForward, gpgDir, ctx.username, spec.GPGAllowedKeyIDs)...)
 
 	// Tmux forwarding
@@ -152,24 +139,20 @@ func (p *DockerProvider) addContainerVolumesAndEnv(dockerArgs []string, spec *pr
 
 	// History persistence
 	dockerArgs = append(dockerArgs, p.HandleHistoryPersist(spec.HistoryPersist, spec.WorkDir, ctx.username)...)
+	dockerArgs = append(dockerArgs, provider.HomePersistArgs(p.config, spec.WorkDir, ctx.username)...)
 
 	// Firewall configuration
 	if p.config.FirewallEnabled {
 		// Start as root so entrypoint can apply iptables rules without sudo,
 		// then drop to addt via gosu (compatible with no-new-privileges)
 		dockerArgs = append(dockerArgs, "--user", "root")
-		// Capabilities for the root phase (dropped after gosu switches to addt):
-		// NET_ADMIN: iptables/nftables rules
-		// DAC_OVERRIDE: create dirs/files in addt's home
-		// CHOWN: fix file ownership
-		// SETUID/SETGID: gosu needs these to switch from root to addt
-		dockerArgs = append(dockerArgs, "--cap-add", "NET_ADMIN")
-		dockerArgs = append(dockerArgs, "--cap-add", "DAC_OVERRIDE")
-		dockerArgs = append(dockerArgs, "--cap-add", "CHOWN")
-		dockerArgs = append(dockerArgs, "--cap-add", "SETUID")
-		dockerArgs = append(dockerArgs, "--cap-add", "SETGID")
+		// Capabilities for the root phase (dropped after gosu switches to addt)
+		for _, cap := range security.FirewallCaps {
+			dockerArgs = append(dockerArgs, "--cap-add", cap)
+		}
 
 		// Mount firewall config directory
+		provider.EnsureFirewallLogDir(p.config)
 		addtHome := util.GetAddtHome()
 		if addtHome != "" {
 			firewallConfigDir := filepath.Join(addtHome, "firewall")
@@ -196,7 +179,7 @@ func (p *DockerProvider) addContainerVolumesAndEnv(dockerArgs []string, spec *pr
 	// Handle isolate_secrets: add tmpfs mount for secrets
 	// Secrets will be copied via docker cp after container starts
 	if p.config.Security.IsolateSecrets {
-		dockerArgs = p.addTmpfsSecretsMount(dockerArgs)
+		dockerArgs = provider.SecretsTmpfsArgs(dockerArgs)
 	}
 
 	// Handle OTEL: add host alias so container can reach host's OTEL collector
@@ -216,22 +199,31 @@ func (p *DockerProvider) addContainerVolumesAndEnv(dockerArgs []string, spec *pr
 	if spec.ContainerMemory != "" {
 		dockerArgs = append(dockerArgs, "--memory", spec.ContainerMemory)
 	}
+	dockerArgs = append(dockerArgs, provider.CpusetArgs(p.config)...)
 
 	// Add security settings
 	dockerArgs = p.addSecuritySettings(dockerArgs)
 
+	// security.mount_readonly: no host file is writable from the container
+	if p.config.Security.MountReadonly {
+		dockerArgs = provider.ReadOnlyMountArgs(dockerArgs)
+	}
+
 	return dockerArgs, cleanup
 }
 
-// executeDockerCommand runs the docker command with standard I/O
-func (p *DockerProvider) executeDockerCommand(dockerArgs []string) error {
+// executeDockerCommand runs the docker command, sending output to the
+// spec's writers (the terminal unless redirected)
+func (p *DockerProvider) executeDockerCommand(dockerArgs []string, spec *provider.RunSpec) error {
 	dockerLogger.Debugf("Executing: docker %v", dockerArgs)
-	cmd := p.dockerCmd(dockerArgs...)
+	runCtx, cancel := spec.RunContext()
+	defer cancel()
+	cmd := p.dockerCmdContext(runCtx, dockerArgs...)
+	provider.CancelOnTimeout(cmd, p, spec)
 
 	// Check if -it flag is present (fully interactive mode)
 	hasItFlag := false
 	hasIFlag := false
-	isAttach := false
 	for _, arg := range dockerArgs {
 		if arg == "-it" {
 			hasItFlag = true
@@ -240,12 +232,8 @@ func (p *DockerProvider) executeDockerCommand(dockerArgs []string) error {
 		if arg == "-i" {
 			hasIFlag = true
 		}
-		if arg == "attach" {
-			isAttach = true
-			dockerLogger.Debug("Detected attach command")
-		}
 	}
-	dockerLogger.Debugf("Flag check: hasItFlag=%v, hasIFlag=%v, isAttach=%v", hasItFlag, hasIFlag, isAttach)
+	dockerLogger.Debugf("Flag check: hasItFlag=%v, hasIFlag=%v", hasItFlag, hasIFlag)
 
 	if hasItFlag {
 		// Fully interactive: connect to terminal stdin
@@ -256,20 +244,18 @@ func (p *DockerProvider) executeDockerCommand(dockerArgs []string) error {
 		// This allows commands like "addt run claude" to receive input
 		cmd.Stdin = os.Stdin
 		dockerLogger.Debug("Connecting stdin to terminal (interactive mode with -i)")
-	} else if isAttach {
-		// Attach command: connect stdin (container was started with -i, so attach inherits it)
-		cmd.Stdin = os.Stdin
-		dockerLogger.Debug("Connecting stdin to terminal (attach command)")
 	} else {
 		// No -i flag: don't connect stdin
 		cmd.Stdin = nil
 		dockerLogger.Debug("Not connecting stdin (no -i flag)")
 	}
 
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	cmd.Stdout, cmd.Stderr = spec.OutputWriters()
 	dockerLogger.Debug("Starting docker command execution")
-	err := cmd.Run()
+	err := provider.RunCommand(cmd, spec, hasItFlag)
+	if runCtx.Err() == context.DeadlineExceeded {
+		return provider.TimeoutError(spec)
+	}
 	if err != nil {
 		dockerLogger.Debugf("Docker command failed: %v", err)
 	} else {
@@ -280,22 +266,18 @@ func (p *DockerProvider) executeDockerCommand(dockerArgs []string) error {
 
 // Run runs a new container
 func (p *DockerProvider) Run(spec *provider.RunSpec) error {
+	// A custom entrypoint can't apply the firewall
+	if err := provider.CheckCustomEntrypoint(p.config); err != nil {
+		return err
+	}
+
 	ctx, err := p.setupContainerContext(spec)
 	if err != nil {
 		return err
 	}
 
 	// Prepare secrets if enabled (before building args so we can filter env)
-	var secretsJSON string
-	if p.config.Security.IsolateSecrets && !ctx.useExistingContainer {
-		json, secretVarNames, err := p.prepareSecretsJSON(spec.ImageName, spec.Env)
-		if err == nil && json != "" {
-			secretsJSON = json
-			p.filterSecretEnvVars(spec.Env, secretVarNames)
-			// ADDT_CREDENTIAL_VARS is no longer needed — secrets are in the file
-			delete(spec.Env, "ADDT_CREDENTIAL_VARS")
-		}
-	}
+	secretsJSON := provider.IsolateSecrets(p.config, spec, ctx.useExistingContainer, p.GetExtensionEnvVars)
 
 	dockerArgs := p.buildBaseDockerArgs(spec, ctx)
 
@@ -308,10 +290,17 @@ func (p *DockerProvider) Run(spec *provider.RunSpec) error {
 
 	// Handle existing container
 	if ctx.useExistingContainer {
+		dockerArgs = append(dockerArgs, provider.TerminalSizeArgs(spec.Env)...)
+		dockerArgs = append(dockerArgs, provider.EntrypointEnvArgs(spec)...)
 		dockerArgs = append(dockerArgs, spec.Name)
-		dockerArgs = append(dockerArgs, "/usr/local/bin/docker-entrypoint.sh")
-		dockerArgs = append(dockerArgs, spec.Args...)
-		return p.executeDockerCommand(dockerArgs)
+		dockerArgs = append(dockerArgs, provider.EntrypointCommand(p.config, "/usr/local/bin/docker-entrypoint.sh"))
+		dockerArgs = append(dockerArgs, provider.CommandArgs(spec)...)
+		return p.executeDockerCommand(dockerArgs, spec)
+	}
+
+	// Custom entrypoint: run it directly, without the keep-alive or secrets wrapping
+	if args := provider.CustomEntrypointArgs(p.config, spec); args != nil {
+		return p.executeDockerCommand(append(dockerArgs, args...), spec)
 	}
 
 	// New persistent container: detached keep-alive + exec entrypoint
@@ -319,109 +308,77 @@ func (p *DockerProvider) Run(spec *provider.RunSpec) error {
 		return p.runPersistent(dockerArgs, spec, secretsJSON)
 	}
 
-	// New container with secrets: use two-step process
-	// 1. Start container detached with wait script
-	// 2. Copy secrets via docker cp
-	// 3. Signal container to continue and attach
+	// New container with secrets: start detached with sleep, copy secrets
+	// via docker cp, then exec the entrypoint
 	if secretsJSON != "" {
 		return p.runWithSecrets(dockerArgs, spec, secretsJSON)
 	}
 
 	// Normal run without secrets
+	dockerArgs = append(dockerArgs, provider.EntrypointEnvArgs(spec)...)
 	dockerArgs = append(dockerArgs, spec.ImageName)
-	dockerArgs = append(dockerArgs, spec.Args...)
-	return p.executeDockerCommand(dockerArgs)
+	dockerArgs = append(dockerArgs, provider.CommandArgs(spec)...)
+	return p.executeDockerCommand(dockerArgs, spec)
 }
 
 // runPersistent creates a persistent container with sleep infinity as PID 1,
 // then execs the entrypoint. This ensures the container stays alive after
 // the agent exits, so subsequent runs can reuse it via docker exec.
 func (p *DockerProvider) runPersistent(baseArgs []string, spec *provider.RunSpec, secretsJSON string) error {
-	// Strip interactive/init flags — not needed for detached sleep process
-	var runArgs []string
-	needsTTY := false
-	needsStdin := false
-	for _, arg := range baseArgs {
-		switch arg {
-		case "-it":
-			needsTTY = true
-			needsStdin = true
-		case "-i":
-			needsStdin = true
-		case "-t", "--init":
-			// not needed for detached sleep process
-		default:
-			runArgs = append(runArgs, arg)
-		}
-	}
-
-	// Start container detached with sleep as keep-alive PID 1
-	runArgs = append(runArgs, "-d", "--entrypoint", "sleep", spec.ImageName, "infinity")
+	// Start detached with sleep as keep-alive; -i/-t move to the entrypoint exec
+	runArgs, needsTTY, needsStdin := provider.KeepAliveRunArgs(baseArgs, spec.ImageName)
 	dockerLogger.Debugf("Starting persistent container: docker %v", runArgs)
 
 	cmd := p.dockerCmd(runArgs...)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("failed to start persistent container: %w\n%s", err, string(output))
+		return provider.Tag(provider.ErrContainerStartFailed, fmt.Errorf("failed to start persistent container: %w\n%s", err, string(output)))
 	}
 
-	// Copy secrets if needed
+	// Copy secrets if needed, once the keep-alive container runs
 	if secretsJSON != "" {
+		if err := provider.WaitSecretsReady(p.config, p.dockerCmd, spec.Name); err != nil {
+			p.dockerCmd("rm", "-f", spec.Name).Run()
+			return provider.Tag(provider.ErrContainerStartFailed, err)
+		}
 		dockerLogger.Debug("Copying secrets to persistent container")
 		if err := p.copySecretsToContainer(spec.Name, secretsJSON); err != nil {
 			dockerLogger.Debugf("Failed to copy secrets, cleaning up container %s", spec.Name)
 			p.dockerCmd("rm", "-f", spec.Name).Run()
-			return fmt.Errorf("failed to copy secrets: %w", err)
+			return provider.Tag(provider.ErrSecretsCopyFailed, fmt.Errorf("failed to copy secrets: %w", err))
 		}
 	}
 
 	// Exec entrypoint as root so the root phase (chown secrets, firewall, DinD)
 	// runs before dropping to addt via gosu.
-	execArgs := []string{"exec", "--user", "root"}
-	if needsTTY {
-		execArgs = append(execArgs, "-it")
-	} else if needsStdin {
-		execArgs = append(execArgs, "-i")
-	}
+	execArgs := provider.EntrypointExecArgs(p.config, []string{"exec", "--user", "root"}, needsTTY, needsStdin)
+	execArgs = append(execArgs, provider.EntrypointEnvArgs(spec)...)
 	execArgs = append(execArgs, spec.Name, "/usr/local/bin/docker-entrypoint.sh")
-	execArgs = append(execArgs, spec.Args...)
+	execArgs = append(execArgs, provider.CommandArgs(spec)...)
 
 	dockerLogger.Debugf("Executing entrypoint in persistent container: docker %v", execArgs)
-	return p.executeDockerCommand(execArgs)
+	return p.executeDockerCommand(execArgs, spec)
 }
 
 // runWithSecrets starts a container, copies secrets, then execs the entrypoint.
-// Uses a simple approach: start with sleep, copy secrets, exec entrypoint.
-// Entrypoint output goes directly to terminal via exec (no attach needed).
+// Uses a simple approach: start with sleep, wait until it is ready, copy
+// secrets, exec entrypoint. Entrypoint output goes directly to terminal via
+// exec (no attach needed).
 func (p *DockerProvider) runWithSecrets(baseArgs []string, spec *provider.RunSpec, secretsJSON string) error {
-	// Strip interactive flags from run args — they'll be added to exec instead.
-	// The detached sleep process doesn't need stdin or TTY.
-	// Track -i vs -it separately: Docker requires a real TTY for -it.
-	var runArgs []string
-	needsTTY := false
-	needsStdin := false
-	for _, arg := range baseArgs {
-		switch arg {
-		case "-it":
-			needsTTY = true
-			needsStdin = true
-		case "-i":
-			needsStdin = true
-		case "-t", "--init":
-			// not needed for detached sleep process
-		default:
-			runArgs = append(runArgs, arg)
-		}
-	}
-
-	// Start container detached with sleep as keep-alive
-	runArgs = append(runArgs, "-d", "--entrypoint", "sleep", spec.ImageName, "infinity")
+	// Start detached with sleep as keep-alive; -i/-t move to the entrypoint exec
+	runArgs, needsTTY, needsStdin := provider.KeepAliveRunArgs(baseArgs, spec.ImageName)
 	dockerLogger.Debugf("Starting detached container: docker %v", runArgs)
 
 	cmd := p.dockerCmd(runArgs...)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("failed to start container: %w\n%s", err, string(output))
+		return provider.Tag(provider.ErrContainerStartFailed, fmt.Errorf("failed to start container: %w\n%s", err, string(output)))
+	}
+
+	// Wait for the keep-alive container before copying into its tmpfs
+	if err := provider.WaitSecretsReady(p.config, p.dockerCmd, spec.Name); err != nil {
+		p.dockerCmd("rm", "-f", spec.Name).Run()
+		return provider.Tag(provider.ErrContainerStartFailed, err)
 	}
 
 	// Copy secrets to container tmpfs
@@ -429,28 +386,24 @@ func (p *DockerProvider) runWithSecrets(baseArgs []string, spec *provider.RunSpe
 	if err := p.copySecretsToContainer(spec.Name, secretsJSON); err != nil {
 		dockerLogger.Debugf("Failed to copy secrets, cleaning up container %s", spec.Name)
 		p.dockerCmd("rm", "-f", spec.Name).Run()
-		return fmt.Errorf("failed to copy secrets: %w", err)
+		return provider.Tag(provider.ErrSecretsCopyFailed, fmt.Errorf("failed to copy secrets: %w", err))
 	}
 
 	// Exec entrypoint as root so the root phase (chown secrets, firewall, DinD)
 	// runs before dropping to addt via gosu.
-	execArgs := []string{"exec", "--user", "root"}
-	if needsTTY {
-		execArgs = append(execArgs, "-it")
-	} else if needsStdin {
-		execArgs = append(execArgs, "-i")
-	}
+	execArgs := provider.EntrypointExecArgs(p.config, []string{"exec", "--user", "root"}, needsTTY, needsStdin)
+	execArgs = append(execArgs, provider.EntrypointEnvArgs(spec)...)
 	execArgs = append(execArgs, spec.Name, "/usr/local/bin/docker-entrypoint.sh")
-	execArgs = append(execArgs, spec.Args...)
+	execArgs = append(execArgs, provider.CommandArgs(spec)...)
 
 	dockerLogger.Debugf("Executing entrypoint: docker %v", execArgs)
-	execErr := p.executeDockerCommand(execArgs)
+	execErr := p.executeDockerCommand(execArgs, spec)
 
 	// On failure, dump container logs for debugging
 	if execErr != nil {
 		dockerLogger.Debugf("Entrypoint failed, fetching container logs for %s", spec.Name)
-		if logsOutput, err := p.dockerCmd("logs", spec.Name).CombinedOutput(); err == nil && len(logsOutput) > 0 {
-			dockerLogger.Debugf("Container logs:\n%s", string(logsOutput))
+		if logs := provider.EntrypointLog(p.config, p.dockerCmd("logs", spec.Name)); logs != "" {
+			dockerLogger.Debugf("Container logs:\n%s", logs)
 		}
 	}
 
@@ -484,6 +437,7 @@ func (p *DockerProvider) Shell(spec *provider.RunSpec) error {
 	if ctx.useExistingContainer {
 		// Run through entrypoint so init (socat, firewall, DinD) works
 		dockerArgs = append(dockerArgs, "-e", "ADDT_COMMAND=/bin/bash")
+		dockerArgs = append(dockerArgs, provider.TerminalSizeArgs(spec.Env)...)
 		dockerArgs = append(dockerArgs, spec.Name, "/usr/local/bin/docker-entrypoint.sh")
 		dockerArgs = append(dockerArgs, spec.Args...)
 	} else if spec.Persistent {
@@ -530,53 +484,35 @@ exec gosu addt /bin/bash "$@"
 		}
 	}
 
-	return p.executeDockerCommand(dockerArgs)
+	return p.executeDockerCommand(dockerArgs, spec)
 }
 
 // shellPersistent creates a persistent container with sleep infinity as PID 1,
 // then execs the entrypoint with ADDT_COMMAND=/bin/bash for shell access.
 func (p *DockerProvider) shellPersistent(baseArgs []string, spec *provider.RunSpec, ctx *containerContext) error {
-	// Strip interactive/init flags — not needed for detached sleep process
-	var runArgs []string
-	needsTTY := false
-	needsStdin := false
-	for _, arg := range baseArgs {
-		switch arg {
-		case "-it":
-			needsTTY = true
-			needsStdin = true
-		case "-i":
-			needsStdin = true
-		case "-t", "--init":
-			// not needed for detached sleep process
-		default:
-			runArgs = append(runArgs, arg)
-		}
-	}
-
-	// Start container detached with sleep as keep-alive PID 1
-	runArgs = append(runArgs, "-d", "--entrypoint", "sleep", spec.ImageName, "infinity")
+	// Start detached with sleep as keep-alive; -i/-t move to the entrypoint exec
+	runArgs, needsTTY, needsStdin := provider.KeepAliveRunArgs(baseArgs, spec.ImageName)
 	dockerLogger.Debugf("Starting persistent container for shell: docker %v", runArgs)
 
 	cmd := p.dockerCmd(runArgs...)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("failed to start persistent container: %w\n%s", err, string(output))
+		return provider.Tag(provider.ErrContainerStartFailed, fmt.Errorf("failed to start persistent container: %w\n%s", err, string(output)))
 	}
 
 	// Exec entrypoint as root so the root phase runs before dropping to addt via gosu.
-	execArgs := []string{"exec", "--user", "root"}
-	if needsTTY {
-		execArgs = append(execArgs, "-it")
-	} else if needsStdin {
-		execArgs = append(execArgs, "-i")
-	}
+	execArgs := provider.EntrypointExecArgs(p.config, []string{"exec", "--user", "root"}, needsTTY, needsStdin)
 	execArgs = append(execArgs, "-e", "ADDT_COMMAND=/bin/bash")
 	execArgs = append(execArgs, spec.Name, "/usr/local/bin/docker-entrypoint.sh")
 	execArgs = append(execArgs, spec.Args...)
 
 	dockerLogger.Debugf("Executing shell in persistent container: docker %v", execArgs)
-	return p.executeDockerCommand(execArgs)
+	return p.executeDockerCommand(execArgs, spec)
+}
+
+// SecurityArgs returns the security flags a new container would be started with
+func (p *DockerProvider) SecurityArgs() []string {
+	return p.addSecuritySettings(nil)
 }
 
 // addSecuritySettings adds container security hardening options
@@ -588,19 +524,17 @@ func (p *DockerProvider) addSecuritySettings(dockerArgs []string) []string {
 		dockerArgs = append(dockerArgs, "--pids-limit", fmt.Sprintf("%d", sec.PidsLimit))
 	}
 
-	// Ulimits
-	if sec.UlimitNofile != "" {
-		dockerArgs = append(dockerArgs, "--ulimit", "nofile="+sec.UlimitNofile)
-	}
-	if sec.UlimitNproc != "" {
-		dockerArgs = append(dockerArgs, "--ulimit", "nproc="+sec.UlimitNproc)
-	}
+	// Ulimits (ulimit_nofile/ulimit_nproc plus the security.ulimits map)
+	dockerArgs = append(dockerArgs, security.UlimitArgs(sec)...)
 
 	// Privilege escalation prevention
 	if sec.NoNewPrivileges {
 		dockerArgs = append(dockerArgs, "--security-opt", "no-new-privileges")
 	}
 
+	// The firewall root phase drops to addt via gosu, which needs SETUID/SETGID
+	provider.WarnDroppedGosuCaps(p.config)
+
 	// Drop capabilities
 	for _, cap := range sec.CapDrop {
 		dockerArgs = append(dockerArgs, "--cap-drop", cap)
@@ -619,10 +553,8 @@ func (p *DockerProvider) addSecuritySettings(dockerArgs []string) []string {
 		dockerArgs = append(dockerArgs, "--tmpfs", "/var/tmp:rw,noexec,nosuid,size=128m")
 		// Home dir needs exec (npm installs executables there) and uid/gid
 		// so the non-root container user owns the tmpfs (Docker supports uid/gid)
-		homeOpts := fmt.Sprintf("/home/addt:rw,exec,nosuid,size=%s", sec.TmpfsHomeSize)
-		if u, err := user.Current(); err == nil {
-			homeOpts = fmt.Sprintf("/home/addt:rw,exec,nosuid,uid=%s,gid=%s,size=%s", u.Uid, u.Gid, sec.TmpfsHomeSize)
-		}
+		u := util.CurrentUser()
+		homeOpts := fmt.Sprintf("/home/addt:rw,exec,nosuid,uid=%s,gid=%s,size=%s", u.Uid, u.Gid, sec.TmpfsHomeSize)
 		dockerArgs = append(dockerArgs, "--tmpfs", homeOpts)
 	}
 
@@ -632,9 +564,9 @@ func (p *DockerProvider) addSecuritySettings(dockerArgs []string) []string {
 		case "unconfined":
 			dockerArgs = append(dockerArgs, "--security-opt", "seccomp=unconfined")
 		case "restrictive":
-			// Write embedded restrictive profile to temp file with restrictive permissions
-			profilePath := filepath.Join(os.TempDir(), "addt-seccomp-restrictive.json")
-			if err := os.WriteFile(profilePath, assets.SeccompRestrictive, 0600); err == nil {
+			// Write embedded restrictive profile to a private file named by its
+			// content hash, so a profile from another addt version is never reused
+			if profilePath, err := provider.WriteSeccompProfile(assets.SeccompRestrictive); err == nil {
 				dockerArgs = append(dockerArgs, "--security-opt", "seccomp="+profilePath)
 			}
 		case "default":
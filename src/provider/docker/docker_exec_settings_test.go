@@ -0,0 +1,187 @@
+package docker
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jedi4ever/addt/config/security"
+	"github.com/jedi4ever/addt/provider"
+)
+
+func TestAddSecuritySettings_FirewallKeepsGosuCaps(t *testing.T) {
+	p := &DockerProvider{
+		config: &provider.Config{
+			FirewallEnabled: true,
+			Security:        security.DefaultConfig(),
+		},
+	}
+
+	args := p.addSecuritySettings(nil)
+
+	// no-new-privileges must coexist with the caps gosu needs
+	assertContains(t, args, "no-new-privileges")
+	assertContains(t, args, "SETUID")
+	assertContains(t, args, "SETGID")
+}
+
+func TestAddSecuritySettings_MergedCaps(t *testing.T) {
+	sec := security.DefaultConfig()
+	security.MergeCaps(&sec, []string{"SYS_PTRACE"}, []string{"CHOWN"})
+	p := &DockerProvider{
+		config: &provider.Config{Security: sec},
+	}
+
+	args := p.addSecuritySettings(nil)
+
+	assertArgPair(t, args, "--cap-add", "SYS_PTRACE")
+	assertArgPair(t, args, "--cap-drop", "CHOWN")
+	assertArgPair(t, args, "--cap-drop", "ALL")
+	for i := 0; i+1 < len(args); i++ {
+		if args[i] == "--cap-add" && args[i+1] == "CHOWN" {
+			t.Error("CHOWN should not be added back after --drop-cap CHOWN")
+		}
+	}
+}
+
+func assertArgPair(t *testing.T, args []string, flag, value string) {
+	t.Helper()
+	for i := 0; i+1 < len(args); i++ {
+		if args[i] == flag && args[i+1] == value {
+			return
+		}
+	}
+	t.Errorf("expected %s %s in args %v", flag, value, args)
+}
+
+func TestAddSecuritySettings_Ulimits(t *testing.T) {
+	sec := security.DefaultConfig()
+	sec.Ulimits = map[string]string{"memlock": "-1:-1", "core": "0:0", "nofile": "1024:2048"}
+	p := &DockerProvider{
+		config: &provider.Config{Security: sec},
+	}
+
+	got := argValues(p.addSecuritySettings(nil), "--ulimit")
+	want := []string{"core=0:0", "memlock=-1:-1", "nofile=1024:2048", "nproc=256:512"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("--ulimit args = %v, want %v (map entries win over ulimit_nofile)", got, want)
+	}
+}
+
+func TestAddSecuritySettings_TmpfsSizes(t *testing.T) {
+	sec := security.DefaultConfig()
+	sec.ReadOnlyRootfs = true
+	sec.TmpfsTmpSize = "4g"
+	sec.TmpfsHomeSize = "1g"
+	p := &DockerProvider{config: &provider.Config{Security: sec}}
+
+	args := strings.Join(p.addSecuritySettings(nil), " ")
+
+	if !strings.Contains(args, "--tmpfs /tmp:rw,noexec,nosuid,size=4g") {
+		t.Errorf("args = %s, want /tmp tmpfs of 4g", args)
+	}
+	if !strings.Contains(args, ",size=1g") || !strings.Contains(args, "/home/addt:") {
+		t.Errorf("args = %s, want /home/addt tmpfs of 1g", args)
+	}
+}
+
+func TestAddContainerVolumesAndEnv_MountReadonly(t *testing.T) {
+	sec := security.DefaultConfig()
+	sec.MountReadonly = true
+	p := &DockerProvider{config: &provider.Config{Security: sec}}
+	spec := &provider.RunSpec{
+		Name: "test-container",
+		Volumes: []provider.VolumeMount{
+			{Source: "/home/me/project", Target: "/workspace"},
+			{Source: "/home/me/data", Target: "/data", ReadOnly: true},
+		},
+	}
+	ctx := &containerContext{homeDir: t.TempDir(), username: "addt"}
+
+	args, cleanup := p.addContainerVolumesAndEnv(nil, spec, ctx)
+	defer cleanup()
+
+	mounts := 0
+	for i := 0; i+1 < len(args); i++ {
+		if args[i] != "-v" || !strings.HasPrefix(args[i+1], "/") {
+			continue
+		}
+		mounts++
+		if !strings.HasSuffix(args[i+1], ":ro") {
+			t.Errorf("bind mount %q is not read-only", args[i+1])
+		}
+	}
+	if mounts < 2 {
+		t.Errorf("expected at least 2 bind mounts, got %d in %v", mounts, args)
+	}
+}
+
+func TestAddContainerVolumesAndEnv_Mounts(t *testing.T) {
+	p := &DockerProvider{config: &provider.Config{Security: security.DefaultConfig()}}
+	mount := "type=bind,source=/home/me/data,target=/data,readonly,bind-propagation=rslave"
+	spec := &provider.RunSpec{Name: "test-container", Mounts: []string{mount}}
+	ctx := &containerContext{homeDir: t.TempDir(), username: "addt"}
+
+	args, cleanup := p.addContainerVolumesAndEnv(nil, spec, ctx)
+	defer cleanup()
+
+	if !strings.Contains(strings.Join(args, " "), "--mount "+mount) {
+		t.Errorf("args = %v, want --mount %s intact", args, mount)
+	}
+}
+
+func TestAddContainerVolumesAndEnv_Cpuset(t *testing.T) {
+	p := &DockerProvider{config: &provider.Config{
+		Security:         security.DefaultConfig(),
+		DockerCpusetCPUs: "0-3,8",
+		DockerCpusetMems: "0",
+	}}
+	ctx := &containerContext{homeDir: t.TempDir(), username: "addt"}
+
+	args, cleanup := p.addContainerVolumesAndEnv(nil, &provider.RunSpec{Name: "test-container"}, ctx)
+	defer cleanup()
+	assertArgPair(t, args, "--cpuset-cpus", "0-3,8")
+	assertArgPair(t, args, "--cpuset-mems", "0")
+
+	p.config.DockerCpusetCPUs, p.config.DockerCpusetMems = "", ""
+	args, cleanup2 := p.addContainerVolumesAndEnv(nil, &provider.RunSpec{Name: "test-container"}, ctx)
+	defer cleanup2()
+	assertNotContains(t, args, "--cpuset-cpus")
+	assertNotContains(t, args, "--cpuset-mems")
+}
+
+func TestAddContainerVolumesAndEnv_DindModes(t *testing.T) {
+	_, sockErr := os.Stat("/var/run/docker.sock")
+	for _, tc := range []struct {
+		mode       string
+		privileged bool
+		socket     bool
+	}{
+		{"isolated", true, false},
+		{"host", false, sockErr == nil},
+		{"off", false, false},
+	} {
+		t.Run(tc.mode, func(t *testing.T) {
+			p := &DockerProvider{config: &provider.Config{Security: security.DefaultConfig()}}
+			spec := &provider.RunSpec{Name: "test-container", DockerDindMode: tc.mode}
+			ctx := &containerContext{homeDir: t.TempDir(), username: "addt"}
+
+			args, cleanup := p.addContainerVolumesAndEnv(nil, spec, ctx)
+			defer cleanup()
+
+			if got := containsArg(args, "--privileged"); got != tc.privileged {
+				t.Errorf("--privileged = %v, want %v in %v", got, tc.privileged, args)
+			}
+			if got := containsVolume(args, "addt-docker-test-container:/var/lib/docker"); got != tc.privileged {
+				t.Errorf("dockerd volume = %v, want %v in %v", got, tc.privileged, args)
+			}
+			if got := containsArg(args, "root"); got != tc.privileged {
+				t.Errorf("--user root = %v, want %v in %v", got, tc.privileged, args)
+			}
+			if got := containsVolume(args, "/var/run/docker.sock:/var/run/docker.sock"); got != tc.socket {
+				t.Errorf("socket mount = %v, want %v in %v", got, tc.socket, args)
+			}
+		})
+	}
+}
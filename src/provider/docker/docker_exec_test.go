@@ -8,7 +8,7 @@ import (
 
 func TestBuildBaseDockerArgs_NewPersistent(t *testing.T) {
 	p := &DockerProvider{
-		config: &provider.Config{},
+		config: &provider.Config{ContainerInit: true},
 	}
 	spec := &provider.RunSpec{
 		Name:        "test-container",
@@ -30,6 +30,21 @@ func TestBuildBaseDockerArgs_NewPersistent(t *testing.T) {
 	assertNotContains(t, args, "--rm")
 }
 
+func TestBuildBaseDockerArgs_InitDisabled(t *testing.T) {
+	p := &DockerProvider{
+		config: &provider.Config{ContainerInit: false},
+	}
+	spec := &provider.RunSpec{
+		Name:        "test-container",
+		Interactive: true,
+	}
+
+	args := p.buildBaseDockerArgs(spec, &containerContext{})
+
+	assertContains(t, args, "-it")
+	assertNotContains(t, args, "--init")
+}
+
 func TestBuildBaseDockerArgs_ExistingPersistent(t *testing.T) {
 	p := &DockerProvider{
 		config: &provider.Config{},
@@ -52,6 +67,25 @@ func TestBuildBaseDockerArgs_ExistingPersistent(t *testing.T) {
 	assertNotContains(t, args, "--rm")
 }
 
+func TestBuildBaseDockerArgs_DetachKeys(t *testing.T) {
+	p := &DockerProvider{
+		config: &provider.Config{ContainerDetachKeys: "ctrl-x,x"},
+	}
+	ctx := &containerContext{useExistingContainer: true}
+
+	args := p.buildBaseDockerArgs(&provider.RunSpec{Name: "test-container", Interactive: true}, ctx)
+	assertArgPair(t, args, "--detach-keys", "ctrl-x,x")
+
+	// Without a TTY there is nothing to detach from
+	args = p.buildBaseDockerArgs(&provider.RunSpec{Name: "test-container"}, ctx)
+	assertNotContains(t, args, "--detach-keys")
+
+	// Unset keeps the runtime default
+	p.config.ContainerDetachKeys = ""
+	args = p.buildBaseDockerArgs(&provider.RunSpec{Name: "test-container", Interactive: true}, ctx)
+	assertNotContains(t, args, "--detach-keys")
+}
+
 func TestBuildBaseDockerArgs_Ephemeral(t *testing.T) {
 	p := &DockerProvider{
 		config: &provider.Config{},
@@ -75,7 +109,7 @@ func TestBuildBaseDockerArgs_Ephemeral(t *testing.T) {
 }
 
 func TestStripInteractiveFlags(t *testing.T) {
-	// Test the flag stripping logic used in runPersistent/shellPersistent
+	// Test the flag stripping used in runPersistent/runWithSecrets/shellPersistent
 	tests := []struct {
 		name            string
 		input           []string
@@ -110,20 +144,8 @@ func TestStripInteractiveFlags(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			var gotArgs []string
-			gotInteractive := false
-			for _, arg := range tt.input {
-				switch arg {
-				case "-it":
-					gotInteractive = true
-				case "-i":
-					gotInteractive = true
-				case "-t", "--init":
-					// stripped
-				default:
-					gotArgs = append(gotArgs, arg)
-				}
-			}
+			runArgs, _, gotInteractive := provider.KeepAliveRunArgs(tt.input, "img")
+			gotArgs := runArgs[:len(runArgs)-5] // drop -d --entrypoint sleep img infinity
 
 			if gotInteractive != tt.wantInteractive {
 				t.Errorf("interactive = %v, want %v", gotInteractive, tt.wantInteractive)
@@ -194,3 +216,20 @@ func assertSliceEqual(t *testing.T, got, want []string) {
 		}
 	}
 }
+
+func TestBuildBaseDockerArgs_WorkdirTarget(t *testing.T) {
+	p := &DockerProvider{
+		config: &provider.Config{WorkdirTarget: "/src/app"},
+	}
+
+	args := p.buildBaseDockerArgs(&provider.RunSpec{Name: "test-container"}, &containerContext{})
+	assertArgPair(t, args, "-w", "/src/app")
+
+	// Exec inherits the working directory of the container
+	args = p.buildBaseDockerArgs(&provider.RunSpec{Name: "test-container"}, &containerContext{useExistingContainer: true})
+	assertNotContains(t, args, "-w")
+
+	p.config.WorkdirTarget = ""
+	args = p.buildBaseDockerArgs(&provider.RunSpec{Name: "test-container"}, &containerContext{})
+	assertNotContains(t, args, "-w")
+}
@@ -35,7 +35,7 @@ func (p *DockerProvider) GetStatus(cfg *provider.Config, envName string) string
 		workdir, _ = os.Getwd()
 	}
 	if cfg.WorkdirAutomount {
-		if cfg.WorkdirReadonly {
+		if cfg.WorkdirReadonly || cfg.WorkdirOverlay {
 			parts = append(parts, fmt.Sprintf("%s [RO]", workdir))
 		} else {
 			parts = append(parts, fmt.Sprintf("%s [RW]", workdir))
@@ -0,0 +1,41 @@
+package docker
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/jedi4ever/addt/provider"
+)
+
+func TestRun_CustomEntrypointBypassesWrapping(t *testing.T) {
+	callLog := installRecordingDocker(t)
+
+	cfg := &provider.Config{ContainerEntrypoint: "/opt/custom-entrypoint.sh"}
+	cfg.Security.IsolateSecrets = true
+	p := &DockerProvider{config: cfg}
+	spec := &provider.RunSpec{
+		Name:       "addt-entrypoint-test",
+		ImageName:  "addt:test",
+		Persistent: true,
+		Args:       []string{"--help"},
+		Env:        map[string]string{"ANTHROPIC_API_KEY": "secret"},
+		Stdout:     &bytes.Buffer{},
+		Stderr:     &bytes.Buffer{},
+	}
+	if err := p.Run(spec); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	data, _ := os.ReadFile(callLog)
+	calls := string(data)
+	if !strings.Contains(calls, "--entrypoint /opt/custom-entrypoint.sh addt:test --help") {
+		t.Errorf("custom entrypoint not passed to docker run, calls:\n%s", calls)
+	}
+	for _, wrapped := range []string{"sleep", "docker-entrypoint.sh", "exec ", "cp "} {
+		if strings.Contains(calls, wrapped) {
+			t.Errorf("calls contain %q, want the default wrapping bypassed:\n%s", wrapped, calls)
+		}
+	}
+}
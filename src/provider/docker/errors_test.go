@@ -0,0 +1,97 @@
+package docker
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jedi4ever/addt/config/security"
+	"github.com/jedi4ever/addt/provider"
+)
+
+// installFailingDocker puts a docker stub on PATH that exits 1 for the
+// given subcommand and succeeds otherwise
+func installFailingDocker(t *testing.T, failing string) {
+	t.Helper()
+	dir := t.TempDir()
+	script := "#!/bin/sh\nif [ \"$1\" = \"" + failing + "\" ]; then echo boom >&2; exit 1; fi\n" +
+		"if [ \"$3\" = \"{{.State.Status}}\" ]; then echo running; fi\n"
+	if err := os.WriteFile(filepath.Join(dir, "docker"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir)
+}
+
+func TestCheckPrerequisites_RuntimeNotFound(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+
+	p := &DockerProvider{config: &provider.Config{}}
+	if err := p.CheckPrerequisites(); !errors.Is(err, provider.ErrRuntimeNotFound) {
+		t.Errorf("CheckPrerequisites() = %v, want ErrRuntimeNotFound", err)
+	}
+}
+
+func TestCheckPrerequisites_RuntimeNotRunning(t *testing.T) {
+	installFailingDocker(t, "info")
+
+	p := &DockerProvider{config: &provider.Config{}}
+	if err := p.CheckPrerequisites(); !errors.Is(err, provider.ErrRuntimeNotRunning) {
+		t.Errorf("CheckPrerequisites() = %v, want ErrRuntimeNotRunning", err)
+	}
+}
+
+func TestBuildBaseImage_ImageBuildFailed(t *testing.T) {
+	installFailingDocker(t, "build")
+
+	p := &DockerProvider{config: &provider.Config{NodeVersion: "22", GoVersion: "1.24", UvVersion: "0.5"}}
+	if err := p.BuildBaseImage(); !errors.Is(err, provider.ErrImageBuildFailed) {
+		t.Errorf("BuildBaseImage() = %v, want ErrImageBuildFailed", err)
+	}
+}
+
+func TestRunWithSecrets_ContainerStartFailed(t *testing.T) {
+	installFailingDocker(t, "run")
+
+	p := &DockerProvider{config: &provider.Config{}}
+	spec := &provider.RunSpec{Name: "addt-test", ImageName: "addt-test:latest"}
+	err := p.runWithSecrets([]string{"run", "--rm"}, spec, `{"A":"b"}`)
+	if !errors.Is(err, provider.ErrContainerStartFailed) {
+		t.Errorf("runWithSecrets() = %v, want ErrContainerStartFailed", err)
+	}
+}
+
+func TestRunWithSecrets_SecretsCopyFailed(t *testing.T) {
+	installFailingDocker(t, "exec")
+
+	p := &DockerProvider{config: &provider.Config{}}
+	spec := &provider.RunSpec{Name: "addt-test", ImageName: "addt-test:latest"}
+	err := p.runWithSecrets([]string{"run", "--rm"}, spec, `{"A":"b"}`)
+	if !errors.Is(err, provider.ErrSecretsCopyFailed) {
+		t.Errorf("runWithSecrets() = %v, want ErrSecretsCopyFailed", err)
+	}
+	var exitErr interface{ ExitCode() int }
+	if !errors.As(err, &exitErr) {
+		t.Error("errors.As should still reach the docker exit error")
+	}
+}
+
+func TestRunWithSecrets_ContainerNotReady(t *testing.T) {
+	dir := t.TempDir()
+	script := "#!/bin/sh\ncase \"$1\" in\n  inspect) echo exited ;;\n  logs) echo 'keep-alive died' ;;\nesac\n"
+	if err := os.WriteFile(filepath.Join(dir, "docker"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir)
+
+	p := &DockerProvider{config: &provider.Config{Security: security.Config{FetchEntrypointLog: true}}}
+	spec := &provider.RunSpec{Name: "addt-test", ImageName: "addt-test:latest"}
+	err := p.runWithSecrets([]string{"run", "--rm"}, spec, `{"A":"b"}`)
+	if !errors.Is(err, provider.ErrContainerStartFailed) {
+		t.Errorf("runWithSecrets() = %v, want ErrContainerStartFailed", err)
+	}
+	if err == nil || !strings.Contains(err.Error(), "container is exited") || !strings.Contains(err.Error(), "keep-alive died") {
+		t.Errorf("runWithSecrets() = %v, want the state and container logs", err)
+	}
+}
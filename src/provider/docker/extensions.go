@@ -68,6 +68,9 @@ func (p *DockerProvider) GetExtensionMountsWithNames(imageName string) []extensi
 
 // AddExtensionMounts adds extension mount volumes to docker args
 func (p *DockerProvider) AddExtensionMounts(dockerArgs []string, imageName, homeDir string) []string {
+	if p.config.NoConfigAutomount {
+		return dockerArgs
+	}
 	extMounts := p.GetExtensionMountsWithNames(imageName)
 	for _, extMount := range extMounts {
 		// Determine if mount should be enabled based on mounts.automount and explicit config
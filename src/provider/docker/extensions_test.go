@@ -0,0 +1,24 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/jedi4ever/addt/provider"
+)
+
+func TestAddExtensionMounts_NoConfigAutomount(t *testing.T) {
+	p := &DockerProvider{
+		config: &provider.Config{
+			ConfigAutomount:          true,
+			ExtensionConfigAutomount: map[string]bool{"claude": true},
+			NoConfigAutomount:        true,
+		},
+	}
+
+	args := p.AddExtensionMounts([]string{"run"}, "addt:test", "/home/user")
+
+	assertNotContains(t, args, "-v")
+	if len(args) != 1 {
+		t.Errorf("AddExtensionMounts() = %v, want [run]", args)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/jedi4ever/addt/provider"
 )
 
 func TestHandleGPGForwarding_Disabled(t *testing.T) {
@@ -107,3 +109,18 @@ func TestHandleGPGForwarding_InvalidMode(t *testing.T) {
 		t.Errorf("HandleGPGForwarding(\"invalid\") returned %v, want empty", args)
 	}
 }
+
+func TestHandleGPGForwarding_ConfiguredDir(t *testing.T) {
+	p := &DockerProvider{tempDirs: []string{}}
+	gpgDir := t.TempDir()
+	home := t.TempDir()
+
+	// gpg.dir replaces ~/.gnupg as the forwarded directory
+	dir := provider.GPGDir(&provider.Config{GPGDir: gpgDir}, home)
+	args := p.HandleGPGForwarding("keys", dir, "addt", nil)
+
+	want := gpgDir + ":/home/addt/.gnupg:ro"
+	if len(args) < 2 || args[1] != want {
+		t.Errorf("HandleGPGForwarding() = %v, want mount %q", args, want)
+	}
+}
@@ -0,0 +1,22 @@
+package docker
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SaveImage writes imageName to a tarball at path (docker save)
+func (p *DockerProvider) SaveImage(imageName, path string) error {
+	if output, err := p.dockerCmd("save", "-o", path, imageName).CombinedOutput(); err != nil {
+		return fmt.Errorf("docker save %s: %w: %s", imageName, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// LoadImage imports the images in the tarball at path (docker load)
+func (p *DockerProvider) LoadImage(path string) error {
+	if output, err := p.dockerCmd("load", "-i", path).CombinedOutput(); err != nil {
+		return fmt.Errorf("docker load %s: %w: %s", path, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
@@ -5,13 +5,13 @@ import (
 	"io"
 	"io/fs"
 	"os"
-	"os/user"
 	"path/filepath"
 	"regexp"
 	"strings"
 	"time"
 
 	"github.com/jedi4ever/addt/extensions"
+	"github.com/jedi4ever/addt/provider"
 	"github.com/jedi4ever/addt/util"
 )
 
@@ -20,6 +20,12 @@ func (p *DockerProvider) BuildBaseImage() error {
 	baseImageName := p.GetBaseImageName()
 	startTime := time.Now()
 
+	// docker build has no flag to forbid pulls, so enforce never up front
+	fromArgs := map[string]string{"NODE_VERSION": p.config.NodeVersion}
+	if err := provider.CheckNeverPull(p.config, p.embeddedDockerfileBase, fromArgs, p.ImageExists); err != nil {
+		return err
+	}
+
 	util.PrintBuildStart(baseImageName)
 	util.PrintInfo("This may take a few minutes on first build...")
 
@@ -49,16 +55,15 @@ func (p *DockerProvider) BuildBaseImage() error {
 	}
 
 	// Get current user info
-	currentUser, err := user.Current()
-	if err != nil {
-		return fmt.Errorf("failed to get current user: %w", err)
-	}
+	currentUser := util.CurrentUser()
 	uid := currentUser.Uid
 	gid := currentUser.Gid
 
 	// Build docker command for base image
-	args := []string{
-		"build",
+	args := []string{"build"}
+	args = append(args, provider.PlatformArgs(p.config)...)
+	args = append(args, provider.BasePullArgs(p.config, false)...)
+	args = append(args,
 		"--build-arg", fmt.Sprintf("NODE_VERSION=%s", p.config.NodeVersion),
 		"--build-arg", fmt.Sprintf("GO_VERSION=%s", p.config.GoVersion),
 		"--build-arg", fmt.Sprintf("UV_VERSION=%s", p.config.UvVersion),
@@ -68,12 +73,12 @@ func (p *DockerProvider) BuildBaseImage() error {
 		"-t", baseImageName,
 		"-f", dockerfilePath,
 		buildDir,
-	}
+	)
 
 	// Run build with progress indication (using provider's Docker context)
 	if err := util.RunBuildCommandWithEnv("docker", args, p.dockerEnv()); err != nil {
 		util.PrintError(fmt.Sprintf("Failed to build base image: %v", err))
-		return fmt.Errorf("failed to build base Docker image: %w", err)
+		return provider.Tag(provider.ErrImageBuildFailed, fmt.Errorf("failed to build base Docker image: %w", err))
 	}
 
 	elapsed := time.Since(startTime)
@@ -198,6 +203,7 @@ func (p *DockerProvider) BuildImage(embeddedDockerfile, embeddedEntrypoint []byt
 
 	// Build docker command - use base image and only pass extension args
 	args := []string{"build"}
+	args = append(args, provider.PlatformArgs(p.config)...)
 
 	// Add --no-cache if requested
 	if p.config.NoCache {
@@ -213,10 +219,11 @@ func (p *DockerProvider) BuildImage(embeddedDockerfile, embeddedEntrypoint []byt
 		scriptDir,
 	)
 
-	// Run build with progress indication (using provider's Docker context)
-	if err := util.RunBuildCommandWithEnv("docker", args, p.dockerEnv()); err != nil {
+	// Run build with progress indication (using provider's Docker context),
+	// killing it if extension installs hang past docker.build_timeout
+	if err := util.RunBuildCommandWithTimeout("docker", args, p.dockerEnv(), provider.BuildTimeout(p.config)); err != nil {
 		util.PrintError(fmt.Sprintf("Failed to build image: %v", err))
-		return fmt.Errorf("failed to build Docker image: %w", err)
+		return provider.Tag(provider.ErrImageBuildFailed, fmt.Errorf("failed to build Docker image: %w", err))
 	}
 
 	elapsed := time.Since(startTime)
@@ -270,7 +277,9 @@ func (p *DockerProvider) detectToolVersions(imageName string) map[string]string
 
 	for name, cmdArgs := range tools {
 		spinner.UpdateMessage(fmt.Sprintf("Detecting %s version...", name))
-		args := append([]string{"run", "--rm", "--entrypoint", cmdArgs[0], imageName}, cmdArgs[1:]...)
+		args := append([]string{"run", "--rm"}, provider.PlatformArgs(p.config)...)
+		args = append(args, "--entrypoint", cmdArgs[0], imageName)
+		args = append(args, cmdArgs[1:]...)
 		cmd := p.dockerCmd(args...)
 		output, err := cmd.Output()
 		if err == nil {
@@ -308,7 +317,9 @@ func (p *DockerProvider) addVersionLabels(cfg interface{}, versions map[string]s
 	tmpFile.Close()
 
 	// Build with labels
-	cmd := p.dockerCmd("build", "-f", tmpFile.Name(), "-t", imageName, ".")
+	labelArgs := append([]string{"build"}, provider.PlatformArgs(p.config)...)
+	labelArgs = append(labelArgs, "-f", tmpFile.Name(), "-t", imageName, ".")
+	cmd := p.dockerCmd(labelArgs...)
 	if err := cmd.Run(); err != nil {
 		fmt.Printf("Warning: failed to add version labels: %v\n", err)
 	}
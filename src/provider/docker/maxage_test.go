@@ -0,0 +1,76 @@
+package docker
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jedi4ever/addt/provider"
+)
+
+// installFakeDocker puts a docker stub on PATH that reports a running container
+// created at created and logs every invocation to the returned file
+func installFakeDocker(t *testing.T, name string, created time.Time) string {
+	t.Helper()
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "docker.log")
+	script := `#!/bin/sh
+echo "$*" >> "` + logFile + `"
+case "$1" in
+  ps) echo "` + name + `" ;;
+  inspect) echo "` + created.UTC().Format(time.RFC3339Nano) + `" ;;
+esac
+`
+	if err := os.WriteFile(filepath.Join(dir, "docker"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return logFile
+}
+
+func dockerCalls(t *testing.T, logFile string) string {
+	t.Helper()
+	data, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestSetupContainerContext_MaxAgeRecreatesOldContainer(t *testing.T) {
+	name := "addt-persistent-test-12345678"
+	logFile := installFakeDocker(t, name, time.Now().Add(-48*time.Hour))
+
+	p := &DockerProvider{config: &provider.Config{ContainerMaxAge: "1d"}}
+	ctx, err := p.setupContainerContext(&provider.RunSpec{Name: name, Persistent: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ctx.useExistingContainer {
+		t.Error("expired container should not be reused")
+	}
+	if calls := dockerCalls(t, logFile); !strings.Contains(calls, "rm -f "+name) {
+		t.Errorf("expected expired container to be removed, docker calls:\n%s", calls)
+	}
+}
+
+func TestSetupContainerContext_MaxAgeKeepsFreshContainer(t *testing.T) {
+	name := "addt-persistent-test-12345678"
+	logFile := installFakeDocker(t, name, time.Now().Add(-time.Hour))
+
+	p := &DockerProvider{config: &provider.Config{ContainerMaxAge: "1d"}}
+	ctx, err := p.setupContainerContext(&provider.RunSpec{Name: name, Persistent: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !ctx.useExistingContainer {
+		t.Error("fresh running container should be reused")
+	}
+	if calls := dockerCalls(t, logFile); strings.Contains(calls, "rm -f") {
+		t.Errorf("fresh container should not be removed, docker calls:\n%s", calls)
+	}
+}
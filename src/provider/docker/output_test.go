@@ -0,0 +1,44 @@
+package docker
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jedi4ever/addt/provider"
+)
+
+func TestExecuteDockerCommand_HonorsOutputWriters(t *testing.T) {
+	dir := t.TempDir()
+	script := "#!/bin/sh\necho agent output\necho agent log >&2\n"
+	if err := os.WriteFile(filepath.Join(dir, "docker"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	var stdout, stderr bytes.Buffer
+	spec := &provider.RunSpec{Stdout: &stdout, Stderr: &stderr}
+	p := &DockerProvider{config: &provider.Config{}}
+
+	if err := p.executeDockerCommand([]string{"run", "--rm", "-i", "test-image"}, spec); err != nil {
+		t.Fatalf("executeDockerCommand() error = %v", err)
+	}
+	if got := stdout.String(); got != "agent output\n" {
+		t.Errorf("stdout = %q, want %q", got, "agent output\n")
+	}
+	if got := stderr.String(); got != "agent log\n" {
+		t.Errorf("stderr = %q, want %q", got, "agent log\n")
+	}
+}
+
+func TestRunSpecOutputWriters_DefaultsToTerminal(t *testing.T) {
+	var buf bytes.Buffer
+	stdout, stderr := (&provider.RunSpec{Stderr: &buf}).OutputWriters()
+	if stdout != os.Stdout {
+		t.Error("stdout should default to os.Stdout")
+	}
+	if stderr != &buf {
+		t.Error("stderr should use the configured writer")
+	}
+}
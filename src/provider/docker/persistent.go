@@ -35,6 +35,11 @@ func (p *DockerProvider) IsRunning(name string) bool {
 	return strings.TrimSpace(string(output)) == name
 }
 
+// Stats samples the CPU, memory and network usage of a running container
+func (p *DockerProvider) Stats(name string) (provider.Stats, error) {
+	return provider.RuntimeStats(p.dockerCmd(), name)
+}
+
 // Start starts a stopped container
 func (p *DockerProvider) Start(name string) error {
 	cmd := p.dockerCmd("start", name)
@@ -47,6 +52,12 @@ func (p *DockerProvider) Stop(name string) error {
 	return util.SimpleSpinnerRun(fmt.Sprintf("Stopping container %s", name), cmd)
 }
 
+// Restart stops and starts a container, keeping its filesystem
+func (p *DockerProvider) Restart(name string) error {
+	cmd := p.dockerCmd("restart", name)
+	return util.SimpleSpinnerRun(fmt.Sprintf("Restarting container %s", name), cmd)
+}
+
 // Remove removes a container
 func (p *DockerProvider) Remove(name string) error {
 	cmd := p.dockerCmd("rm", "-f", name)
@@ -55,8 +66,13 @@ func (p *DockerProvider) Remove(name string) error {
 
 // List lists all persistent addt containers
 func (p *DockerProvider) List() ([]provider.Environment, error) {
-	cmd := p.dockerCmd("ps", "-a", "--filter", "name=^addt-persistent-",
-		"--format", "{{.Names}}\t{{.Status}}\t{{.CreatedAt}}")
+	args := []string{"ps", "-a", "--filter", "name=^addt-persistent-"}
+	if p.config.ContainerName != "" {
+		// Filters on the same key match either name
+		args = append(args, "--filter", "name=^"+regexp.QuoteMeta(p.config.ContainerName)+"$")
+	}
+	args = append(args, "--format", "{{.Names}}\t{{.Status}}\t{{.CreatedAt}}")
+	cmd := p.dockerCmd(args...)
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, err
@@ -141,8 +157,11 @@ func (p *DockerProvider) GenerateEphemeralName() string {
 	return fmt.Sprintf("addt-%s-%d", time.Now().Format("20060102-150405"), os.Getpid())
 }
 
-// GeneratePersistentName is an alias for GenerateContainerName to implement Provider interface
+// GeneratePersistentName returns the container.name override, or GenerateContainerName
 func (p *DockerProvider) GeneratePersistentName() string {
+	if p.config.ContainerName != "" {
+		return p.config.ContainerName
+	}
 	return p.GenerateContainerName()
 }
 
@@ -290,3 +290,17 @@ func TestIsPersistentAndEphemeral_MutuallyExclusive(t *testing.T) {
 		}
 	}
 }
+
+func TestGeneratePersistentName_ContainerNameOverride(t *testing.T) {
+	prov := createPersistentUnitProvider("/home/user/project/sub", "claude")
+	prov.config.ContainerName = "myproject"
+
+	if got := prov.GeneratePersistentName(); got != "myproject" {
+		t.Errorf("GeneratePersistentName() = %q, want container.name override", got)
+	}
+
+	prov.config.ContainerName = ""
+	if got := prov.GeneratePersistentName(); got != prov.GenerateContainerName() {
+		t.Errorf("GeneratePersistentName() = %q, want generated name without override", got)
+	}
+}
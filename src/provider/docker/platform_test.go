@@ -0,0 +1,65 @@
+package docker
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jedi4ever/addt/provider"
+)
+
+// installRecordingDocker puts a docker stub on PATH that succeeds and
+// appends each invocation's arguments to the returned log file
+func installRecordingDocker(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	argLog := filepath.Join(dir, "args.log")
+	script := "#!/bin/sh\necho \"$@\" >> " + argLog + "\n" +
+		"if [ \"$3\" = \"{{.State.Status}}\" ]; then echo running; fi\n"
+	if err := os.WriteFile(filepath.Join(dir, "docker"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return argLog
+}
+
+func TestBuildBaseDockerArgs_Platform(t *testing.T) {
+	p := &DockerProvider{
+		config: &provider.Config{ContainerPlatform: "linux/amd64"},
+	}
+
+	args := p.buildBaseDockerArgs(&provider.RunSpec{Name: "test-container"}, &containerContext{})
+	assertArgPair(t, args, "--platform", "linux/amd64")
+
+	// Exec into an existing container takes no platform
+	args = p.buildBaseDockerArgs(&provider.RunSpec{Name: "test-container"}, &containerContext{useExistingContainer: true})
+	assertNotContains(t, args, "--platform")
+
+	p.config.ContainerPlatform = ""
+	args = p.buildBaseDockerArgs(&provider.RunSpec{Name: "test-container"}, &containerContext{})
+	assertNotContains(t, args, "--platform")
+}
+
+func TestBuildBaseImage_Platform(t *testing.T) {
+	argLog := installRecordingDocker(t)
+
+	p := &DockerProvider{
+		config: &provider.Config{ContainerPlatform: "linux/amd64", AddtVersion: "0.0.0-test", NodeVersion: "22"},
+	}
+	if err := p.BuildBaseImage(); err != nil {
+		t.Fatalf("BuildBaseImage() = %v", err)
+	}
+
+	data, err := os.ReadFile(argLog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	build := string(data)
+	if !strings.HasPrefix(build, "build --platform linux/amd64 ") {
+		t.Errorf("build args = %q, want --platform linux/amd64", build)
+	}
+	if !strings.Contains(build, "-linux-amd64 ") {
+		t.Errorf("build args = %q, want the image tag suffixed with the platform", build)
+	}
+}
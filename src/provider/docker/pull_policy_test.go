@@ -0,0 +1,69 @@
+package docker
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jedi4ever/addt/provider"
+)
+
+// installImagelessDocker puts a docker stub on PATH that has no local
+// images and records every other invocation to the returned log file
+func installImagelessDocker(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	argLog := filepath.Join(dir, "args.log")
+	script := "#!/bin/sh\nif [ \"$1\" = \"image\" ]; then exit 1; fi\necho \"$@\" >> " + argLog + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "docker"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return argLog
+}
+
+func newPullPolicyProvider(policy string) *DockerProvider {
+	return &DockerProvider{
+		config:                 &provider.Config{DockerPullPolicy: policy, AddtVersion: "0.0.0-test", NodeVersion: "22"},
+		embeddedDockerfileBase: []byte("ARG NODE_VERSION\nFROM node:${NODE_VERSION}-slim\n"),
+	}
+}
+
+func TestBuildBaseImage_PullNever(t *testing.T) {
+	argLog := installImagelessDocker(t)
+
+	err := newPullPolicyProvider("never").BuildBaseImage()
+	if !errors.Is(err, provider.ErrImageBuildFailed) || !strings.Contains(err.Error(), "node:22-slim") {
+		t.Fatalf("BuildBaseImage() = %v, want the missing base image reported", err)
+	}
+	if data, _ := os.ReadFile(argLog); strings.Contains(string(data), "build") {
+		t.Errorf("docker calls = %q, want no build that could pull", data)
+	}
+}
+
+func TestBuildBaseImage_PullAlways(t *testing.T) {
+	argLog := installImagelessDocker(t)
+
+	if err := newPullPolicyProvider("always").BuildBaseImage(); err != nil {
+		t.Fatalf("BuildBaseImage() = %v", err)
+	}
+	data, err := os.ReadFile(argLog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "build --pull ") {
+		t.Errorf("build args = %q, want --pull to force a fresh base layer", data)
+	}
+}
+
+func TestBuildBaseDockerArgs_PullPolicy(t *testing.T) {
+	p := &DockerProvider{config: &provider.Config{DockerPullPolicy: "never"}}
+	args := p.buildBaseDockerArgs(&provider.RunSpec{Name: "test-container"}, &containerContext{})
+	assertArgPair(t, args, "--pull", "never")
+
+	p.config.DockerPullPolicy = "always"
+	args = p.buildBaseDockerArgs(&provider.RunSpec{Name: "test-container"}, &containerContext{})
+	assertNotContains(t, args, "--pull")
+}
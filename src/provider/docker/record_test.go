@@ -0,0 +1,52 @@
+package docker
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jedi4ever/addt/provider"
+)
+
+// installEchoDocker puts a docker stub on PATH that prints to stdout and stderr
+func installEchoDocker(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	script := "#!/bin/sh\necho agent output\necho agent warning >&2\n"
+	if err := os.WriteFile(filepath.Join(dir, "docker"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestExecuteDockerCommand_Record(t *testing.T) {
+	installEchoDocker(t)
+
+	transcript, err := os.Create(filepath.Join(t.TempDir(), "session.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer transcript.Close()
+
+	var stdout, stderr bytes.Buffer
+	p := &DockerProvider{config: &provider.Config{}}
+	spec := &provider.RunSpec{Name: "addt-test", Stdout: &stdout, Stderr: &stderr, Record: transcript}
+	if err := p.executeDockerCommand([]string{"run", "--rm", "addt:test"}, spec); err != nil {
+		t.Fatalf("executeDockerCommand() = %v", err)
+	}
+
+	data, err := os.ReadFile(transcript.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"agent output", "agent warning"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("transcript = %q, missing %q", data, want)
+		}
+	}
+	if stdout.String() != "agent output\n" || stderr.String() != "agent warning\n" {
+		t.Errorf("stdout=%q stderr=%q, want output still delivered to its destination", stdout.String(), stderr.String())
+	}
+}
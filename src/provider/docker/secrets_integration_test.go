@@ -29,19 +29,6 @@ func checkDockerForSecrets(t *testing.T) {
 func TestIsolateSecrets_Integration_EnvVarsNotPassed(t *testing.T) {
 	checkDockerForSecrets(t)
 
-	// Create a provider with isolate_secrets enabled
-	secCfg := security.DefaultConfig()
-	secCfg.IsolateSecrets = true
-
-	cfg := &provider.Config{
-		Security: secCfg,
-	}
-
-	prov := &DockerProvider{
-		config:   cfg,
-		tempDirs: []string{},
-	}
-
 	// Simulate env with secrets
 	env := map[string]string{
 		"ANTHROPIC_API_KEY": "sk-ant-test-key-12345",
@@ -53,7 +40,7 @@ func TestIsolateSecrets_Integration_EnvVarsNotPassed(t *testing.T) {
 	secretVarNames := []string{"ANTHROPIC_API_KEY"}
 
 	// Filter the secret env vars from the env map
-	prov.filterSecretEnvVars(env, secretVarNames)
+	provider.FilterSecretEnvVars(env, secretVarNames)
 
 	// Verify ANTHROPIC_API_KEY was removed from env
 	if _, exists := env["ANTHROPIC_API_KEY"]; exists {
@@ -499,7 +486,7 @@ func TestIsolateSecrets_Integration_RealEntrypoint(t *testing.T) {
 	}
 }
 
-// TestPrepareSecretsJSON tests the prepareSecretsJSON function
+// TestPrepareSecretsJSON tests provider.SecretsJSON with the extension env vars
 func TestPrepareSecretsJSON(t *testing.T) {
 	secCfg := security.DefaultConfig()
 	secCfg.IsolateSecrets = true
@@ -521,9 +508,9 @@ func TestPrepareSecretsJSON(t *testing.T) {
 		"ADDT_CREDENTIAL_VARS": "ANTHROPIC_API_KEY,GH_TOKEN",
 	}
 
-	jsonStr, secretVarNames, err := prov.prepareSecretsJSON("addt-test", env)
+	jsonStr, secretVarNames, err := provider.SecretsJSON(prov.GetExtensionEnvVars("addt-test"), env)
 	if err != nil {
-		t.Fatalf("prepareSecretsJSON failed: %v", err)
+		t.Fatalf("SecretsJSON failed: %v", err)
 	}
 
 	// Verify JSON contains secrets
@@ -3,56 +3,14 @@ package docker
 import (
 	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"os"
+	"strings"
 	"testing"
-)
-
-func TestFilterSecretEnvVars(t *testing.T) {
-	p := &DockerProvider{}
-
-	env := map[string]string{
-		"ANTHROPIC_API_KEY": "secret-key",
-		"GH_TOKEN":          "github-token",
-		"TERM":              "xterm-256color",
-		"HOME":              "/home/user",
-	}
-
-	secretVarNames := []string{"ANTHROPIC_API_KEY", "GH_TOKEN"}
-
-	p.filterSecretEnvVars(env, secretVarNames)
-
-	// Secret vars should be removed
-	if _, exists := env["ANTHROPIC_API_KEY"]; exists {
-		t.Error("ANTHROPIC_API_KEY should be removed")
-	}
-	if _, exists := env["GH_TOKEN"]; exists {
-		t.Error("GH_TOKEN should be removed")
-	}
-
-	// Non-secret vars should remain
-	if env["TERM"] != "xterm-256color" {
-		t.Errorf("TERM = %q, want \"xterm-256color\"", env["TERM"])
-	}
-	if env["HOME"] != "/home/user" {
-		t.Errorf("HOME = %q, want \"/home/user\"", env["HOME"])
-	}
-}
 
-func TestAddTmpfsSecretsMount(t *testing.T) {
-	p := &DockerProvider{}
-
-	args := []string{"-it"}
-	result := p.addTmpfsSecretsMount(args)
-
-	if len(result) != 3 {
-		t.Errorf("Expected 3 args, got %d: %v", len(result), result)
-	}
-	if result[1] != "--tmpfs" {
-		t.Errorf("Expected --tmpfs flag, got %s", result[1])
-	}
-	if result[2] != "/run/secrets:size=1m,mode=0777" {
-		t.Errorf("Expected tmpfs mount arg, got %s", result[2])
-	}
-}
+	"github.com/jedi4ever/addt/config/security"
+	"github.com/jedi4ever/addt/provider"
+)
 
 func TestPrepareSecrets(t *testing.T) {
 	// Test that secrets are properly encoded as base64 JSON
@@ -89,3 +47,89 @@ func TestPrepareSecrets(t *testing.T) {
 			decodedSecrets["GH_TOKEN"], secrets["GH_TOKEN"])
 	}
 }
+
+// containerCalls runs spec through p.Run and returns the recorded docker
+// run/exec calls for the container (extension metadata reads are skipped)
+func containerCalls(t *testing.T, p *DockerProvider, spec *provider.RunSpec) []string {
+	t.Helper()
+	argLog := installRecordingDocker(t)
+	if err := p.Run(spec); err != nil {
+		t.Fatalf("Run() = %v", err)
+	}
+	data, err := os.ReadFile(argLog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var calls []string
+	for _, call := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if (strings.HasPrefix(call, "run ") || strings.HasPrefix(call, "exec ")) && strings.Contains(call, spec.Name) {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+func TestRun_IsolateSecretsWithoutSecretsUsesPlainRun(t *testing.T) {
+	p := &DockerProvider{
+		config: &provider.Config{Security: security.Config{IsolateSecrets: true}},
+	}
+	spec := &provider.RunSpec{
+		Name:      "addt-test",
+		ImageName: "addt:test",
+		Env:       map[string]string{"TERM": "xterm-256color"},
+	}
+
+	calls := containerCalls(t, p, spec)
+	if len(calls) != 1 || !strings.HasPrefix(calls[0], "run ") || !strings.HasSuffix(calls[0], " addt:test") {
+		t.Fatalf("container calls = %q, want a single plain run of addt:test", calls)
+	}
+	if strings.Contains(calls[0], "--entrypoint sleep") {
+		t.Errorf("run = %q, want the normal entrypoint without secrets to copy", calls[0])
+	}
+}
+
+func TestRun_IsolateSecretsWithSecretsUsesTwoStepFlow(t *testing.T) {
+	p := &DockerProvider{
+		config: &provider.Config{Security: security.Config{IsolateSecrets: true}},
+	}
+	spec := &provider.RunSpec{
+		Name:      "addt-test",
+		ImageName: "addt:test",
+		Env:       map[string]string{"ADDT_CREDENTIAL_VARS": "MY_TOKEN", "MY_TOKEN": "s3cret"},
+	}
+
+	calls := containerCalls(t, p, spec)
+	if len(calls) < 2 || !strings.Contains(calls[0], "--entrypoint sleep") {
+		t.Fatalf("container calls = %q, want a detached start followed by exec", calls)
+	}
+	if strings.Contains(calls[0], "s3cret") {
+		t.Errorf("run = %q, secret value leaked into the run args", calls[0])
+	}
+}
+
+func TestRun_CustomEntrypointKeepsSecretsInEnv(t *testing.T) {
+	p := &DockerProvider{
+		config: &provider.Config{Security: security.Config{IsolateSecrets: true}, ContainerEntrypoint: "/opt/run.sh"},
+	}
+	spec := &provider.RunSpec{
+		Name:      "addt-test",
+		ImageName: "addt:test",
+		Env:       map[string]string{"ADDT_CREDENTIAL_VARS": "MY_TOKEN", "MY_TOKEN": "s3cret"},
+	}
+
+	calls := containerCalls(t, p, spec)
+	if len(calls) != 1 || !strings.Contains(calls[0], "--entrypoint /opt/run.sh") {
+		t.Fatalf("container calls = %q, want a single run of the custom entrypoint", calls)
+	}
+	if spec.Env["MY_TOKEN"] != "s3cret" {
+		t.Error("secret was dropped from the env instead of passed to the custom entrypoint")
+	}
+}
+
+func TestRun_CustomEntrypointWithFirewall(t *testing.T) {
+	p := &DockerProvider{config: &provider.Config{ContainerEntrypoint: "/opt/run.sh", FirewallEnabled: true}}
+	spec := &provider.RunSpec{Name: "addt-test", ImageName: "addt:test"}
+	if err := p.Run(spec); !errors.Is(err, provider.ErrUnsupported) {
+		t.Errorf("Run() = %v, want ErrUnsupported", err)
+	}
+}
@@ -0,0 +1,101 @@
+package docker
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/jedi4ever/addt/config/security"
+	"github.com/jedi4ever/addt/provider"
+)
+
+// argValues returns every value following flag in args
+func argValues(args []string, flag string) []string {
+	var values []string
+	for i := 0; i+1 < len(args); i++ {
+		if args[i] == flag {
+			values = append(values, args[i+1])
+		}
+	}
+	return values
+}
+
+// uniqueSorted dedupes and sorts values for set comparison
+func uniqueSorted(values []string) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestSecurityExplain_MatchesAddSecuritySettings(t *testing.T) {
+	sec := security.DefaultConfig()
+	sec.ReadOnlyRootfs = true
+	sec.DisableDevices = true
+	sec.DisableIPC = true
+	sec.NetworkMode = "none"
+	sec.SeccompProfile = "unconfined"
+	security.MergeCaps(&sec, []string{"SYS_PTRACE"}, nil)
+
+	p := &DockerProvider{
+		config: &provider.Config{FirewallEnabled: true, Security: sec},
+	}
+	args := p.addSecuritySettings(nil)
+	// The firewall path adds its caps alongside the security settings
+	for _, c := range security.FirewallCaps {
+		args = append(args, "--cap-add", c)
+	}
+
+	posture := security.Explain(sec, true)
+
+	if got, want := uniqueSorted(argValues(args, "--cap-add")), uniqueSorted(posture.CapAdd); !reflect.DeepEqual(got, want) {
+		t.Errorf("cap-add args %v, posture %v", got, want)
+	}
+	if got, want := uniqueSorted(argValues(args, "--cap-drop")), uniqueSorted(posture.CapDrop); !reflect.DeepEqual(got, want) {
+		t.Errorf("cap-drop args %v, posture %v", got, want)
+	}
+	if got := argValues(args, "--pids-limit"); len(got) != 1 || got[0] != fmt.Sprint(posture.PidsLimit) {
+		t.Errorf("pids-limit args %v, posture %d", got, posture.PidsLimit)
+	}
+	ulimits := argValues(args, "--ulimit")
+	assertContains(t, ulimits, "nofile="+posture.UlimitNofile)
+	assertContains(t, ulimits, "nproc="+posture.UlimitNproc)
+
+	secOpts := argValues(args, "--security-opt")
+	if posture.NoNewPrivileges {
+		assertContains(t, secOpts, "no-new-privileges")
+	}
+	assertContains(t, secOpts, "seccomp="+posture.SeccompProfile)
+
+	if got := argValues(args, "--network"); len(got) != 1 || got[0] != posture.NetworkMode {
+		t.Errorf("network args %v, posture %q", got, posture.NetworkMode)
+	}
+	if posture.DisableIPC {
+		assertArgPair(t, args, "--ipc", "none")
+	}
+	if posture.ReadOnlyRootfs {
+		assertContains(t, args, "--read-only")
+	}
+
+	// Every tmpfs mount emitted is described in the posture
+	for _, mount := range argValues(args, "--tmpfs") {
+		path, _, _ := strings.Cut(mount, ":")
+		found := false
+		for _, described := range posture.Tmpfs {
+			if strings.HasPrefix(described, path+" ") {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("tmpfs %s not in posture %v", path, posture.Tmpfs)
+		}
+	}
+}
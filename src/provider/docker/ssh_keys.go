@@ -5,8 +5,14 @@ import (
 	"os"
 )
 
-// handleSSHKeysForwarding mounts the entire SSH directory read-only
-func (p *DockerProvider) handleSSHKeysForwarding(sshDir, username string) []string {
+// handleSSHKeysForwarding mounts the entire SSH directory read-only. Extra
+// directories can't share the ~/.ssh mount point, so with extraDirs the keys
+// that pass allowedKeys are merged into a temp directory instead.
+func (p *DockerProvider) handleSSHKeysForwarding(sshDir, username string, allowedKeys, extraDirs []string) []string {
+	if len(extraDirs) > 0 {
+		return p.mountMergedSSHDir(append([]string{sshDir}, extraDirs...), username, allowedKeys, true)
+	}
+
 	var args []string
 
 	if _, err := os.Stat(sshDir); err == nil {
@@ -67,3 +67,34 @@ func TestHandleSSHForwarding_AllowedKeys_IgnoredForKeysMode(t *testing.T) {
 		t.Errorf("HandleSSHForwarding(\"keys\") with allowedKeys should still mount .ssh, got %v", args)
 	}
 }
+
+func TestHandleSSHForwarding_Keys_ExtraDirs(t *testing.T) {
+	p := &DockerProvider{}
+	defer func() {
+		for _, dir := range p.tempDirs {
+			os.RemoveAll(dir)
+		}
+	}()
+
+	sshDir := t.TempDir()
+	workDir := t.TempDir()
+	os.WriteFile(filepath.Join(sshDir, "id_rsa"), []byte("private"), 0600)
+	os.WriteFile(filepath.Join(workDir, "id_work_github"), []byte("private"), 0600)
+
+	args := p.HandleSSHForwarding(true, "keys", sshDir, "testuser", []string{"github"}, workDir)
+
+	// Directories are merged into one temp dir instead of mounted directly
+	if len(p.tempDirs) != 1 {
+		t.Fatalf("expected a merged temp dir, got %v (args %v)", p.tempDirs, args)
+	}
+	merged := p.tempDirs[0]
+	if !containsVolume(args, merged+":/home/testuser/.ssh:ro") {
+		t.Errorf("HandleSSHForwarding(\"keys\") missing merged mount, got %v", args)
+	}
+	if _, err := os.Stat(filepath.Join(merged, "id_work_github")); err != nil {
+		t.Error("key from the extra dir matching allowed_keys should be forwarded")
+	}
+	if _, err := os.Stat(filepath.Join(merged, "id_rsa")); err == nil {
+		t.Error("key not matching allowed_keys should be filtered out")
+	}
+}
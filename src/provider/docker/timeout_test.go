@@ -0,0 +1,62 @@
+package docker
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jedi4ever/addt/provider"
+)
+
+// installHangingDocker puts a docker stub on PATH whose "run" only
+// finishes when its container is removed, and which records "rm" calls to
+// the returned log file
+func installHangingDocker(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	rmLog := filepath.Join(dir, "rm.log")
+	pidFile := filepath.Join(dir, "run.pid")
+	script := "#!/bin/sh\nif [ \"$1\" = \"rm\" ]; then echo \"$@\" >> " + rmLog + "; kill $(cat " + pidFile + "); exit 0; fi\necho $$ > " + pidFile + "\nexec sleep 30\n"
+	if err := os.WriteFile(filepath.Join(dir, "docker"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return rmLog
+}
+
+func TestExecuteDockerCommand_HostTimeout(t *testing.T) {
+	rmLog := installHangingDocker(t)
+
+	p := &DockerProvider{config: &provider.Config{}}
+	spec := &provider.RunSpec{Name: "addt-timeout-test", Timeout: 200 * time.Millisecond, Stdout: &bytes.Buffer{}, Stderr: &bytes.Buffer{}}
+
+	start := time.Now()
+	err := p.executeDockerCommand([]string{"run", "--rm", "--name", spec.Name, "addt:test"}, spec)
+	if !errors.Is(err, provider.ErrRunTimeout) {
+		t.Fatalf("executeDockerCommand() = %v, want ErrRunTimeout", err)
+	}
+	// The CLI exits because its container is gone, not through the
+	// WaitDelay kill that would skip its terminal restore
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("run took %s, want the CLI to exit once its container is removed", elapsed)
+	}
+
+	data, _ := os.ReadFile(rmLog)
+	if !strings.Contains(string(data), "rm -f addt-timeout-test") {
+		t.Errorf("ephemeral container was not removed, docker rm calls: %q", data)
+	}
+}
+
+func TestExecuteDockerCommand_NoTimeout(t *testing.T) {
+	installFailingDocker(t, "none")
+
+	p := &DockerProvider{config: &provider.Config{}}
+	spec := &provider.RunSpec{Name: "addt-test", Stdout: &bytes.Buffer{}, Stderr: &bytes.Buffer{}}
+	if err := p.executeDockerCommand([]string{"run", "--rm", "addt:test"}, spec); err != nil {
+		t.Errorf("executeDockerCommand() = %v, want nil without a timeout", err)
+	}
+}
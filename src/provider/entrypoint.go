@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jedi4ever/addt/config/security"
+)
+
+// EntrypointCommand returns the command exec'd in an existing container to
+// start the agent: container.entrypoint when set, otherwise wrapper, the
+// image's entrypoint script
+func EntrypointCommand(cfg *Config, wrapper string) string {
+	if cfg.ContainerEntrypoint != "" {
+		return cfg.ContainerEntrypoint
+	}
+	return wrapper
+}
+
+// CheckCustomEntrypoint refuses container.entrypoint with the firewall:
+// the container starts as root with the firewall capabilities, and only the
+// built-in entrypoint applies the rules and drops to addt via gosu.
+func CheckCustomEntrypoint(cfg *Config) error {
+	if cfg.ContainerEntrypoint != "" && cfg.FirewallEnabled {
+		return fmt.Errorf("container.entrypoint can't be used with the firewall, which only the built-in entrypoint applies; unset one of them: %w", ErrUnsupported)
+	}
+	return nil
+}
+
+// WarnDroppedGosuCaps warns when security.cap_drop removes a capability
+// the firewall's root phase needs for gosu to drop to the addt user
+func WarnDroppedGosuCaps(cfg *Config) {
+	if !cfg.FirewallEnabled {
+		return
+	}
+	if dropped := security.DroppedGosuCaps(cfg.Security.CapDrop, cfg.Security.CapAdd); len(dropped) > 0 {
+		fmt.Printf("Warning: security.cap_drop removes %s, which the firewall needs for gosu to switch to the addt user; the container may fail to start\n", strings.Join(dropped, ", "))
+	}
+}
+
+// CustomEntrypointArgs returns "--entrypoint <path> <image> [args...]" to
+// start a new container with container.entrypoint, or nil when unset. A
+// custom entrypoint runs directly: the keep-alive and secrets-copy wrapping
+// is skipped, and the image's security, firewall and secrets init only runs
+// if the custom entrypoint calls it.
+func CustomEntrypointArgs(cfg *Config, spec *RunSpec) []string {
+	if cfg.ContainerEntrypoint == "" {
+		return nil
+	}
+	args := append(EntrypointEnvArgs(spec), "--entrypoint", cfg.ContainerEntrypoint, spec.ImageName)
+	return append(args, CommandArgs(spec)...)
+}
+
+// EntrypointArgsEnv is the variable telling the entrypoint how many of its
+// leading args are --entrypoint-arg values rather than agent args
+const EntrypointArgsEnv = "ADDT_ENTRYPOINT_ARGS"
+
+// EntrypointEnvArgs returns "-e ADDT_ENTRYPOINT_ARGS=<n>" for the run or
+// exec that starts the entrypoint, or nil without entrypoint args. It is
+// passed per invocation rather than in spec.Env so a reused persistent
+// container never keeps the count of an earlier run.
+func EntrypointEnvArgs(spec *RunSpec) []string {
+	if len(spec.EntrypointArgs) == 0 {
+		return nil
+	}
+	return []string{"-e", fmt.Sprintf("%s=%d", EntrypointArgsEnv, len(spec.EntrypointArgs))}
+}
+
+// CommandArgs returns the args passed to the entrypoint: the
+// --entrypoint-arg values first, then the agent args
+func CommandArgs(spec *RunSpec) []string {
+	return slices.Concat(spec.EntrypointArgs, spec.Args)
+}
@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"os/exec"
+	"regexp"
+)
+
+// redactedLogValue replaces credentials scrubbed from container logs
+const redactedLogValue = "<redacted>"
+
+// Credential-looking tokens in container logs: assignments to secret-named
+// variables (ANTHROPIC_API_KEY=..., "token": "..."), bearer/basic auth
+// headers and the well-known prefixed token formats (Anthropic/OpenAI keys,
+// GitHub and GitLab tokens, Slack tokens, AWS access key IDs).
+var (
+	secretAssignmentPattern = regexp.MustCompile(`(?i)([A-Z0-9_]*(?:TOKEN|SECRET|PASSWORD|PASSWD|API_?KEY|CREDENTIAL|AUTH|COOKIE|SESSION)[A-Z0-9_]*"?\s*[=:]\s*"?)([^\s"',]+)`)
+	authHeaderPattern       = regexp.MustCompile(`(?i)\b(Bearer|Basic)\s+[A-Za-z0-9._~+/=-]{8,}`)
+	tokenPattern            = regexp.MustCompile(`\b(?:sk-[A-Za-z0-9_-]{16,}|gh[pousr]_[A-Za-z0-9]{20,}|github_pat_[A-Za-z0-9_]{20,}|glpat-[A-Za-z0-9_-]{20,}|xox[abprs]-[A-Za-z0-9-]{10,}|AKIA[0-9A-Z]{16})`)
+)
+
+// RedactLog scrubs credential-looking tokens from container log output
+func RedactLog(log string) string {
+	log = authHeaderPattern.ReplaceAllString(log, "${1} "+redactedLogValue)
+	log = secretAssignmentPattern.ReplaceAllString(log, "${1}"+redactedLogValue)
+	return tokenPattern.ReplaceAllString(log, redactedLogValue)
+}
+
+// EntrypointLog runs cmd (e.g. "docker logs <name>") to fetch the output of a
+// failed entrypoint, which includes its debug log. It returns "" when
+// security.fetch_entrypoint_log is off or there is nothing to show, and
+// scrubs credentials unless security.redact_entrypoint_log is off.
+func EntrypointLog(cfg *Config, cmd *exec.Cmd) string {
+	if !cfg.Security.FetchEntrypointLog {
+		return ""
+	}
+	output, err := cmd.CombinedOutput()
+	if err != nil || len(output) == 0 {
+		return ""
+	}
+	if cfg.Security.RedactEntrypointLog {
+		return RedactLog(string(output))
+	}
+	return string(output)
+}
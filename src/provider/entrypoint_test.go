@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestEntrypointCommand_Default(t *testing.T) {
+	const wrapper = "/usr/local/bin/docker-entrypoint.sh"
+	if got := EntrypointCommand(&Config{}, wrapper); got != wrapper {
+		t.Errorf("EntrypointCommand() = %q, want %q", got, wrapper)
+	}
+	if got := CustomEntrypointArgs(&Config{}, &RunSpec{}); got != nil {
+		t.Errorf("CustomEntrypointArgs() = %v, want nil", got)
+	}
+}
+
+func TestEntrypointCommand_Custom(t *testing.T) {
+	cfg := &Config{ContainerEntrypoint: "/opt/run.sh"}
+	if got := EntrypointCommand(cfg, "/usr/local/bin/docker-entrypoint.sh"); got != "/opt/run.sh" {
+		t.Errorf("EntrypointCommand() = %q, want /opt/run.sh", got)
+	}
+}
+
+func TestCommandArgs_EntrypointArgsFirst(t *testing.T) {
+	spec := &RunSpec{Args: []string{"--model", "opus"}, EntrypointArgs: []string{"--debug"}}
+	want := []string{"--debug", "--model", "opus"}
+	if got := CommandArgs(spec); !reflect.DeepEqual(got, want) {
+		t.Errorf("CommandArgs() = %v, want %v", got, want)
+	}
+	if got := EntrypointEnvArgs(spec); !reflect.DeepEqual(got, []string{"-e", "ADDT_ENTRYPOINT_ARGS=1"}) {
+		t.Errorf("EntrypointEnvArgs() = %v", got)
+	}
+}
+
+func TestCommandArgs_NoEntrypointArgs(t *testing.T) {
+	spec := &RunSpec{Args: []string{"--help"}}
+	if got := CommandArgs(spec); !reflect.DeepEqual(got, []string{"--help"}) {
+		t.Errorf("CommandArgs() = %v, want [--help]", got)
+	}
+	if got := EntrypointEnvArgs(spec); got != nil {
+		t.Errorf("EntrypointEnvArgs() = %v, want nil", got)
+	}
+}
+
+func TestCustomEntrypointArgs_EntrypointArgs(t *testing.T) {
+	cfg := &Config{ContainerEntrypoint: "/opt/run.sh"}
+	spec := &RunSpec{ImageName: "img", Args: []string{"task"}, EntrypointArgs: []string{"--debug"}}
+	want := []string{"-e", "ADDT_ENTRYPOINT_ARGS=1", "--entrypoint", "/opt/run.sh", "img", "--debug", "task"}
+	if got := CustomEntrypointArgs(cfg, spec); !reflect.DeepEqual(got, want) {
+		t.Errorf("CustomEntrypointArgs() = %v, want %v", got, want)
+	}
+}
+
+func TestCheckCustomEntrypoint(t *testing.T) {
+	if err := CheckCustomEntrypoint(&Config{ContainerEntrypoint: "/opt/run.sh"}); err != nil {
+		t.Errorf("custom entrypoint without firewall = %v, want nil", err)
+	}
+	if err := CheckCustomEntrypoint(&Config{FirewallEnabled: true}); err != nil {
+		t.Errorf("firewall with built-in entrypoint = %v, want nil", err)
+	}
+	err := CheckCustomEntrypoint(&Config{ContainerEntrypoint: "/opt/run.sh", FirewallEnabled: true})
+	if !errors.Is(err, ErrUnsupported) {
+		t.Errorf("custom entrypoint with firewall = %v, want ErrUnsupported", err)
+	}
+}
@@ -0,0 +1,23 @@
+package provider
+
+// container.env_precedence values: which side wins when a forwarded env var
+// is set on the host and also given a value in config
+const (
+	EnvPrecedenceEnvWins    = "env-wins"    // the host value wins, config fills gaps (default)
+	EnvPrecedenceConfigWins = "config-wins" // the config value wins, the host fills gaps
+)
+
+// EnvPrecedences lists the valid container.env_precedence values
+var EnvPrecedences = []string{EnvPrecedenceEnvWins, EnvPrecedenceConfigWins}
+
+// ResolveEnvValue picks the value of a forwarded env var from its host value
+// and its config value ("" = unset) under precedence
+func ResolveEnvValue(hostValue, configValue, precedence string) string {
+	if precedence == EnvPrecedenceConfigWins && configValue != "" {
+		return configValue
+	}
+	if hostValue != "" {
+		return hostValue
+	}
+	return configValue
+}
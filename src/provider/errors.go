@@ -0,0 +1,33 @@
+package provider
+
+import "errors"
+
+// Failure kinds returned by providers. Match them with errors.Is; the
+// underlying error (and its message) stays reachable via errors.As.
+var (
+	ErrRuntimeNotFound      = errors.New("container runtime not found")
+	ErrRuntimeNotRunning    = errors.New("container runtime not running")
+	ErrImageBuildFailed     = errors.New("image build failed")
+	ErrSecretsCopyFailed    = errors.New("copying secrets to container failed")
+	ErrContainerStartFailed = errors.New("container failed to start")
+	ErrRunTimeout           = errors.New("run timed out")
+	ErrUnsupported          = errors.New("not supported by this provider")
+)
+
+// kindError tags an error with a failure kind without changing its message
+type kindError struct {
+	kind error
+	err  error
+}
+
+func (e *kindError) Error() string   { return e.err.Error() }
+func (e *kindError) Unwrap() []error { return []error{e.kind, e.err} }
+
+// Tag marks err as a failure of the given kind so errors.Is(err, kind)
+// matches. Returns nil if err is nil.
+func Tag(kind, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &kindError{kind: kind, err: err}
+}
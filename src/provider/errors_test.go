@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type detailError struct{ detail string }
+
+func (e *detailError) Error() string { return e.detail }
+
+func TestTag_KeepsMessageAndMatchesKind(t *testing.T) {
+	inner := &detailError{detail: "exit status 1"}
+	err := Tag(ErrImageBuildFailed, fmt.Errorf("failed to build Docker image: %w", inner))
+
+	if err.Error() != "failed to build Docker image: exit status 1" {
+		t.Errorf("Error() = %q, want the original message", err.Error())
+	}
+	if !errors.Is(err, ErrImageBuildFailed) {
+		t.Error("errors.Is(err, ErrImageBuildFailed) = false")
+	}
+	if errors.Is(err, ErrSecretsCopyFailed) {
+		t.Error("errors.Is(err, ErrSecretsCopyFailed) = true, want false")
+	}
+	var detail *detailError
+	if !errors.As(err, &detail) || detail != inner {
+		t.Error("errors.As should reach the wrapped error")
+	}
+}
+
+func TestTag_Nil(t *testing.T) {
+	if err := Tag(ErrRuntimeNotFound, nil); err != nil {
+		t.Errorf("Tag(kind, nil) = %v, want nil", err)
+	}
+}
@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/jedi4ever/addt/util"
+)
+
+// FirewallBlockedLogDir returns the host directory holding the
+// firewall.log_blocked logs, ~/.addt/firewall/blocked. The firewall config
+// directory is mounted into the container, so the entrypoint writes there.
+func FirewallBlockedLogDir() string {
+	addtHome := util.GetAddtHome()
+	if addtHome == "" {
+		return ""
+	}
+	return filepath.Join(addtHome, "firewall", "blocked")
+}
+
+// FirewallBlockedLogPath returns the blocked-connection log of a container
+func FirewallBlockedLogPath(name string) string {
+	dir := FirewallBlockedLogDir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, name+".log")
+}
+
+// EnsureFirewallLogDir creates the blocked log directory with
+// firewall.log_blocked on, so the firewall config directory exists to be
+// mounted even before any firewall rule was written
+func EnsureFirewallLogDir(cfg *Config) {
+	if !cfg.FirewallEnabled || !cfg.FirewallLogBlocked {
+		return
+	}
+	if dir := FirewallBlockedLogDir(); dir != "" {
+		os.MkdirAll(dir, 0755)
+	}
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// containerHome is the agent user's home directory inside the container
+const containerHome = "/home/addt"
+
+// ForwardFile is a host file forwarded into the container (forward_files)
+type ForwardFile struct {
+	Source   string // Absolute host path
+	Target   string // Absolute container path
+	ReadOnly bool
+}
+
+// ParseForwardFile parses a forward_files entry, host_path[:container_path][:ro|:rw].
+// The host path is tilde-expanded against homeDir. Without a container path,
+// files under homeDir land at the same place under /home/addt and other files
+// keep their path. Entries are read-only unless they end in ":rw".
+func ParseForwardFile(spec, homeDir string) (ForwardFile, error) {
+	parts := strings.Split(strings.TrimSpace(spec), ":")
+	f := ForwardFile{ReadOnly: true}
+
+	if n := len(parts); n > 1 && (parts[n-1] == "ro" || parts[n-1] == "rw") {
+		f.ReadOnly = parts[n-1] == "ro"
+		parts = parts[:n-1]
+	}
+	if len(parts) > 2 {
+		return ForwardFile{}, fmt.Errorf("forward_files %q: expected host_path[:container_path][:ro|:rw]", spec)
+	}
+
+	f.Source = expandHome(parts[0], homeDir)
+	if !filepath.IsAbs(f.Source) {
+		return ForwardFile{}, fmt.Errorf("forward_files %q: host path must be absolute or start with ~/", spec)
+	}
+	f.Source = filepath.Clean(f.Source)
+
+	if len(parts) == 2 && parts[1] != "" {
+		f.Target = parts[1]
+		if strings.HasPrefix(f.Target, "~/") {
+			f.Target = path.Join(containerHome, f.Target[2:])
+		}
+		if !path.IsAbs(f.Target) {
+			return ForwardFile{}, fmt.Errorf("forward_files %q: container path must be absolute or start with ~/", spec)
+		}
+		f.Target = path.Clean(f.Target)
+	} else {
+		f.Target = defaultForwardTarget(f.Source, homeDir)
+	}
+	return f, nil
+}
+
+// IsSensitiveFile reports whether a forwarded file likely holds credentials
+// (.netrc, .npmrc, .pypirc, .git-credentials, */credentials, ...). With
+// isolate_secrets these are copied into the secrets tmpfs instead of mounted.
+func IsSensitiveFile(p string) bool {
+	base := strings.ToLower(filepath.Base(p))
+	switch base {
+	case ".netrc", "_netrc", ".npmrc", ".pypirc", ".git-credentials":
+		return true
+	}
+	return strings.Contains(base, "credentials")
+}
+
+// expandHome expands a leading ~/ against homeDir
+func expandHome(p, homeDir string) string {
+	if strings.HasPrefix(p, "~/") && homeDir != "" {
+		return filepath.Join(homeDir, p[2:])
+	}
+	return p
+}
+
+// defaultForwardTarget maps a host path under homeDir into /home/addt
+func defaultForwardTarget(source, homeDir string) string {
+	if homeDir != "" {
+		if rel, err := filepath.Rel(homeDir, source); err == nil && rel != "." && !strings.HasPrefix(rel, "..") {
+			return path.Join(containerHome, filepath.ToSlash(rel))
+		}
+	}
+	return filepath.ToSlash(source)
+}
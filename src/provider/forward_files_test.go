@@ -0,0 +1,54 @@
+package provider
+
+import "testing"
+
+func TestParseForwardFile(t *testing.T) {
+	home := "/Users/alice"
+	tests := []struct {
+		spec string
+		want ForwardFile
+	}{
+		{"~/.netrc", ForwardFile{Source: "/Users/alice/.netrc", Target: "/home/addt/.netrc", ReadOnly: true}},
+		{"~/.aws/config", ForwardFile{Source: "/Users/alice/.aws/config", Target: "/home/addt/.aws/config", ReadOnly: true}},
+		{"/etc/hosts.extra", ForwardFile{Source: "/etc/hosts.extra", Target: "/etc/hosts.extra", ReadOnly: true}},
+		{"~/.npmrc:ro", ForwardFile{Source: "/Users/alice/.npmrc", Target: "/home/addt/.npmrc", ReadOnly: true}},
+		{"~/.npmrc:rw", ForwardFile{Source: "/Users/alice/.npmrc", Target: "/home/addt/.npmrc", ReadOnly: false}},
+		{"~/work/.npmrc:~/.npmrc", ForwardFile{Source: "/Users/alice/work/.npmrc", Target: "/home/addt/.npmrc", ReadOnly: true}},
+		{"/tmp/ca.pem:/etc/ssl/ca.pem:rw", ForwardFile{Source: "/tmp/ca.pem", Target: "/etc/ssl/ca.pem", ReadOnly: false}},
+		{" ~/.netrc ", ForwardFile{Source: "/Users/alice/.netrc", Target: "/home/addt/.netrc", ReadOnly: true}},
+	}
+	for _, tt := range tests {
+		got, err := ParseForwardFile(tt.spec, home)
+		if err != nil {
+			t.Errorf("ParseForwardFile(%q) error = %v", tt.spec, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseForwardFile(%q) = %+v, want %+v", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestParseForwardFile_Invalid(t *testing.T) {
+	for _, spec := range []string{"", ".netrc", "~/.netrc:etc/netrc", "/a:/b:/c", "/a:/b:/c:ro"} {
+		if _, err := ParseForwardFile(spec, "/Users/alice"); err == nil {
+			t.Errorf("ParseForwardFile(%q) expected an error", spec)
+		}
+	}
+}
+
+func TestIsSensitiveFile(t *testing.T) {
+	for path, want := range map[string]bool{
+		"/Users/alice/.netrc":             true,
+		"/Users/alice/.aws/credentials":   true,
+		"/Users/alice/.git-credentials":   true,
+		"/Users/alice/.npmrc":             true,
+		"/Users/alice/.aws/config":        false,
+		"/Users/alice/.config/gh/hosts":   false,
+		"/Users/alice/gcloud/CREDENTIALS": true,
+	} {
+		if got := IsSensitiveFile(path); got != want {
+			t.Errorf("IsSensitiveFile(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jedi4ever/addt/util"
+)
+
+// ResolveGitconfigPath returns the host .gitconfig to forward, or "" when the
+// file does not exist. git.config_path overrides ~/.gitconfig.
+func ResolveGitconfigPath(cfg *Config, homeDir string) string {
+	gitconfigPath := cfg.GitConfigPath
+	if gitconfigPath == "" {
+		gitconfigPath = filepath.Join(homeDir, ".gitconfig")
+	} else {
+		gitconfigPath = util.ExpandTilde(gitconfigPath)
+	}
+	if info, err := os.Stat(gitconfigPath); err != nil || info.IsDir() {
+		return ""
+	}
+	return gitconfigPath
+}
+
+// GitconfigMountArgs returns the volume args that mount the host .gitconfig
+// for username, or nil when git.forward_config is off, git.config_copy
+// delivers it by value instead, or the file does not exist. The mount is
+// read-only unless git.config_readonly is false.
+func GitconfigMountArgs(cfg *Config, homeDir, username string) []string {
+	if !cfg.GitForwardConfig || cfg.GitConfigCopy {
+		return nil
+	}
+	gitconfigPath := ResolveGitconfigPath(cfg, homeDir)
+	if gitconfigPath == "" {
+		return nil
+	}
+	mount := fmt.Sprintf("%s:/home/%s/.gitconfig.host", gitconfigPath, username)
+	if cfg.GitConfigReadonly {
+		mount += ":ro"
+	}
+	return []string{"-v", mount}
+}
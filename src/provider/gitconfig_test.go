@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestGitconfigMountArgs(t *testing.T) {
+	home := t.TempDir()
+	custom := filepath.Join(t.TempDir(), "work.gitconfig")
+	for _, path := range []string{filepath.Join(home, ".gitconfig"), custom} {
+		if err := os.WriteFile(path, []byte("[user]\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		cfg  Config
+		want []string
+	}{
+		{"disabled", Config{GitForwardConfig: false, GitConfigReadonly: true}, nil},
+		{"default path", Config{GitForwardConfig: true, GitConfigReadonly: true}, []string{"-v", filepath.Join(home, ".gitconfig") + ":/home/addt/.gitconfig.host:ro"}},
+		{"custom path", Config{GitForwardConfig: true, GitConfigReadonly: true, GitConfigPath: custom}, []string{"-v", custom + ":/home/addt/.gitconfig.host:ro"}},
+		{"missing file", Config{GitForwardConfig: true, GitConfigReadonly: true, GitConfigPath: filepath.Join(home, "nope")}, nil},
+		{"writable", Config{GitForwardConfig: true, GitConfigReadonly: false}, []string{"-v", filepath.Join(home, ".gitconfig") + ":/home/addt/.gitconfig.host"}},
+		{"copy mode", Config{GitForwardConfig: true, GitConfigReadonly: true, GitConfigCopy: true}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GitconfigMountArgs(&tt.cfg, home, "addt"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GitconfigMountArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
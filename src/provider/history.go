@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jedi4ever/addt/util"
+)
+
+// HistoryDir returns the directory holding a workdir's shell history for a
+// provider: <history.dir>/<provider>/<workdir-key>, with history.dir
+// defaulting to ~/.addt/history. The key is the workdir hash the trust store
+// uses, so the same workdir always maps to the same directory.
+func HistoryDir(cfg *Config, providerName, workdir string) (string, error) {
+	base := util.ExpandTilde(cfg.HistoryDir)
+	if base == "" {
+		addtHome := util.GetAddtHome()
+		if addtHome == "" {
+			return "", fmt.Errorf("failed to determine addt home directory")
+		}
+		base = filepath.Join(addtHome, "history")
+	}
+	return filepath.Join(base, providerName, util.WorkdirKey(workdir)), nil
+}
+
+// HistoryMountArgs creates the workdir's history files and returns the -v
+// flags mounting them as ~/.bash_history and ~/.zsh_history. The files are
+// created by the host user, whose UID the image gives the addt user.
+func HistoryMountArgs(cfg *Config, providerName, workdir, username string) []string {
+	historyDir, err := HistoryDir(cfg, providerName, workdir)
+	if err == nil {
+		migrateLegacyHistoryDir(cfg, historyDir, workdir)
+		err = os.MkdirAll(historyDir, 0700)
+	}
+	if err != nil {
+		fmt.Printf("Warning: failed to create history directory: %v\n", err)
+		return nil
+	}
+
+	var args []string
+	homeInContainer := fmt.Sprintf("/home/%s", username)
+	for _, name := range []string{"bash_history", "zsh_history"} {
+		path := filepath.Join(historyDir, name)
+		if err := touchFile(path); err == nil {
+			args = append(args, "-v", fmt.Sprintf("%s:%s/.%s", path, homeInContainer, name))
+		}
+	}
+	return args
+}
+
+// migrateLegacyHistoryDir moves history kept by older releases at
+// ~/.addt/history/<workdir-key> into the per-provider location, so
+// upgrading doesn't lose it. The first provider to run claims it.
+func migrateLegacyHistoryDir(cfg *Config, historyDir, workdir string) {
+	if cfg.HistoryDir != "" {
+		return
+	}
+	legacy := filepath.Join(filepath.Dir(filepath.Dir(historyDir)), util.WorkdirKey(workdir))
+	if _, err := os.Stat(historyDir); !os.IsNotExist(err) {
+		return
+	}
+	if info, err := os.Stat(legacy); err != nil || !info.IsDir() {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(historyDir), 0700); err == nil {
+		os.Rename(legacy, historyDir)
+	}
+}
+
+// touchFile creates an empty, owner-only file if it doesn't exist
+func touchFile(path string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
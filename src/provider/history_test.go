@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHistoryDir_StablePerWorkdir(t *testing.T) {
+	base := t.TempDir()
+	cfg := &Config{HistoryDir: base}
+	work := t.TempDir()
+
+	first, err := HistoryDir(cfg, "docker", work)
+	if err != nil {
+		t.Fatalf("HistoryDir: %v", err)
+	}
+	second, _ := HistoryDir(cfg, "docker", work)
+	if first != second {
+		t.Errorf("same workdir gave %q and %q", first, second)
+	}
+	if filepath.Dir(filepath.Dir(first)) != base {
+		t.Errorf("HistoryDir = %q, want it under %q", first, base)
+	}
+
+	// A relative spelling of the same directory maps to the same path
+	wd, _ := os.Getwd()
+	defer os.Chdir(wd)
+	os.Chdir(filepath.Dir(work))
+	relative, _ := HistoryDir(cfg, "docker", filepath.Base(work))
+	if relative != first {
+		t.Errorf("relative workdir gave %q, want %q", relative, first)
+	}
+}
+
+func TestHistoryDir_DiffersAcrossWorkdirsAndProviders(t *testing.T) {
+	cfg := &Config{HistoryDir: t.TempDir()}
+
+	a, _ := HistoryDir(cfg, "docker", "/projects/a")
+	b, _ := HistoryDir(cfg, "docker", "/projects/b")
+	if a == b {
+		t.Errorf("different workdirs share history dir %q", a)
+	}
+	podman, _ := HistoryDir(cfg, "podman", "/projects/a")
+	if a == podman {
+		t.Errorf("different providers share history dir %q", a)
+	}
+}
+
+func TestHistoryDir_DefaultsUnderAddtHome(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("ADDT_HOME", home)
+
+	got, err := HistoryDir(&Config{}, "docker", "/projects/a")
+	if err != nil {
+		t.Fatalf("HistoryDir: %v", err)
+	}
+	if want := filepath.Join(home, "history", "docker"); filepath.Dir(got) != want {
+		t.Errorf("HistoryDir = %q, want it under %q", got, want)
+	}
+}
+
+func TestHistoryMountArgs_CreatesFiles(t *testing.T) {
+	cfg := &Config{HistoryDir: t.TempDir()}
+
+	args := HistoryMountArgs(cfg, "docker", "/projects/a", "addt")
+	if len(args) != 4 {
+		t.Fatalf("HistoryMountArgs = %v, want two -v mounts", args)
+	}
+	dir, _ := HistoryDir(cfg, "docker", "/projects/a")
+	for i, name := range []string{"bash_history", "zsh_history"} {
+		path := filepath.Join(dir, name)
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("%s not created: %v", name, err)
+		}
+		if info.Mode().Perm() != 0600 {
+			t.Errorf("%s mode = %v, want 0600", name, info.Mode().Perm())
+		}
+		if want := path + ":/home/addt/." + name; args[2*i+1] != want {
+			t.Errorf("mount = %q, want %q", args[2*i+1], want)
+		}
+	}
+}
+
+func TestHistoryMountArgs_MigratesLegacyDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("ADDT_HOME", home)
+
+	dir, _ := HistoryDir(&Config{}, "docker", "/projects/a")
+	legacy := filepath.Join(home, "history", filepath.Base(dir))
+	os.MkdirAll(legacy, 0700)
+	os.WriteFile(filepath.Join(legacy, "bash_history"), []byte("ls\n"), 0600)
+
+	HistoryMountArgs(&Config{}, "docker", "/projects/a", "addt")
+
+	data, err := os.ReadFile(filepath.Join(dir, "bash_history"))
+	if err != nil || string(data) != "ls\n" {
+		t.Errorf("migrated history = %q, %v; want the legacy content", data, err)
+	}
+	if _, err := os.Stat(legacy); !os.IsNotExist(err) {
+		t.Errorf("legacy dir still present after migration")
+	}
+}
@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/jedi4ever/addt/util"
+)
+
+// volumeNameUnsafe matches the characters a home subdir can't carry into a
+// volume name
+var volumeNameUnsafe = regexp.MustCompile(`[^a-zA-Z0-9_.-]+`)
+
+// ValidateHomeSubdir checks a home.persist_subdirs entry: a clean path
+// relative to the container home (e.g. ".cache", ".config/gh") that stays
+// inside it
+func ValidateHomeSubdir(subdir string) error {
+	switch {
+	case subdir == "" || subdir == ".":
+		return fmt.Errorf("home subdir must not be empty")
+	case strings.HasPrefix(subdir, "/") || strings.HasPrefix(subdir, "~"):
+		return fmt.Errorf("home subdir %q must be relative to the home directory", subdir)
+	case path.Clean(subdir) != subdir || subdir == ".." || strings.HasPrefix(subdir, "../"):
+		return fmt.Errorf("home subdir %q must be a clean path inside the home directory", subdir)
+	case strings.ContainsAny(subdir, ", :"):
+		return fmt.Errorf("home subdir %q must not contain commas, spaces or colons", subdir)
+	}
+	return nil
+}
+
+// HomeVolumeName returns the named volume persisting a home subdir for a
+// workdir: addt-home-<workdir-key>-<subdir>. The key is the workdir hash
+// the trust store uses, so projects don't share caches.
+func HomeVolumeName(workdir, subdir string) string {
+	name := strings.Trim(volumeNameUnsafe.ReplaceAllString(subdir, "-"), "-.")
+	return fmt.Sprintf("addt-home-%s-%s", util.WorkdirKey(workdir), name)
+}
+
+// HomePersistArgs returns the -v flags mounting a named volume over each
+// home.persist_subdirs entry, so those survive between runs while the rest
+// of the home stays ephemeral. Invalid entries are skipped. The subdirs are
+// also passed as ADDT_HOME_PERSIST_SUBDIRS for the entrypoint to hand the
+// new, root-owned volumes to the container user.
+func HomePersistArgs(cfg *Config, workdir, username string) []string {
+	var args, subdirs []string
+	for _, subdir := range cfg.HomePersistSubdirs {
+		if err := ValidateHomeSubdir(subdir); err != nil {
+			fmt.Printf("Warning: %v, skipping\n", err)
+			continue
+		}
+		args = append(args, "-v", fmt.Sprintf("%s:/home/%s/%s", HomeVolumeName(workdir, subdir), username, subdir))
+		subdirs = append(subdirs, subdir)
+	}
+	if len(subdirs) > 0 {
+		args = append(args, "-e", "ADDT_HOME_PERSIST_SUBDIRS="+strings.Join(subdirs, ","))
+	}
+	return args
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jedi4ever/addt/util"
+)
+
+func TestHomePersistArgs_VolumePerSubdir(t *testing.T) {
+	cfg := &Config{HomePersistSubdirs: []string{".cache", ".config/gh"}}
+	key := util.WorkdirKey("/home/user/project")
+
+	got := HomePersistArgs(cfg, "/home/user/project", "addt")
+	want := []string{
+		"-v", "addt-home-" + key + "-cache:/home/addt/.cache",
+		"-v", "addt-home-" + key + "-config-gh:/home/addt/.config/gh",
+		"-e", "ADDT_HOME_PERSIST_SUBDIRS=.cache,.config/gh",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("HomePersistArgs() = %v, want %v", got, want)
+	}
+}
+
+func TestHomePersistArgs_None(t *testing.T) {
+	if got := HomePersistArgs(&Config{}, "/home/user/project", "addt"); got != nil {
+		t.Errorf("HomePersistArgs() = %v, want nil", got)
+	}
+}
+
+func TestHomePersistArgs_SkipsInvalid(t *testing.T) {
+	cfg := &Config{HomePersistSubdirs: []string{"../.ssh", ".cache"}}
+	got := HomePersistArgs(cfg, "/home/user/project", "addt")
+	if len(got) != 4 || !strings.HasSuffix(got[1], ":/home/addt/.cache") {
+		t.Errorf("HomePersistArgs() = %v, want only the .cache volume", got)
+	}
+}
+
+func TestHomeVolumeName_PerWorkdir(t *testing.T) {
+	a := HomeVolumeName("/home/user/a", ".cache")
+	b := HomeVolumeName("/home/user/b", ".cache")
+	if a == b {
+		t.Errorf("HomeVolumeName() = %q for both workdirs, want per-workdir volumes", a)
+	}
+}
+
+func TestValidateHomeSubdir(t *testing.T) {
+	for _, subdir := range []string{".cache", ".config/gh", "go/pkg"} {
+		if err := ValidateHomeSubdir(subdir); err != nil {
+			t.Errorf("ValidateHomeSubdir(%q) = %v, want nil", subdir, err)
+		}
+	}
+	for _, subdir := range []string{"", ".", "..", "../x", "/abs", "~/x", "a//b", "a/", "a b", "a:b"} {
+		if err := ValidateHomeSubdir(subdir); err == nil {
+			t.Errorf("ValidateHomeSubdir(%q) expected error", subdir)
+		}
+	}
+}
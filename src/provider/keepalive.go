@@ -0,0 +1,38 @@
+package provider
+
+// KeepAliveRunArgs turns the run args of a new container into a detached
+// start with "sleep infinity" as PID 1, used for persistent containers and
+// for secrets that are copied in before the entrypoint runs. The -i/-it/-t
+// and --init flags are dropped; tty and stdin report which the entrypoint
+// exec should take over.
+func KeepAliveRunArgs(baseArgs []string, imageName string) (runArgs []string, tty, stdin bool) {
+	for _, arg := range baseArgs {
+		switch arg {
+		case "-it":
+			tty, stdin = true, true
+		case "-i":
+			stdin = true
+		case "-t", "--init":
+			// not needed for detached sleep process
+		default:
+			runArgs = append(runArgs, arg)
+		}
+	}
+	runArgs = append(runArgs, "-d", "--entrypoint", "sleep", imageName, "infinity")
+	return runArgs, tty, stdin
+}
+
+// EntrypointExecArgs returns prefix (e.g. "exec", "--user", "root") with the
+// interactive flags for the exec that runs the entrypoint in a keep-alive
+// container. Callers append the container name, entrypoint and its args.
+// Docker needs a real TTY for -it, so a stdin-only run gets -i.
+func EntrypointExecArgs(cfg *Config, prefix []string, tty, stdin bool) []string {
+	execArgs := append([]string{}, prefix...)
+	if tty {
+		execArgs = append(execArgs, "-it")
+		execArgs = append(execArgs, DetachKeysArgs(cfg)...)
+	} else if stdin {
+		execArgs = append(execArgs, "-i")
+	}
+	return execArgs
+}
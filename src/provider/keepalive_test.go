@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestKeepAliveRunArgs(t *testing.T) {
+	tests := []struct {
+		name      string
+		base      []string
+		wantArgs  []string
+		wantTTY   bool
+		wantStdin bool
+	}{
+		{"interactive tty", []string{"run", "--rm", "-it", "--init", "--name", "c"}, []string{"run", "--rm", "--name", "c"}, true, true},
+		{"stdin only", []string{"run", "-i", "--name", "c"}, []string{"run", "--name", "c"}, false, true},
+		{"tty only", []string{"run", "-t", "--name", "c"}, []string{"run", "--name", "c"}, false, false},
+		{"no interactive flags", []string{"run", "--name", "c", "-v", "/src:/dst"}, []string{"run", "--name", "c", "-v", "/src:/dst"}, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, tty, stdin := KeepAliveRunArgs(tt.base, "img:latest")
+			want := append(tt.wantArgs, "-d", "--entrypoint", "sleep", "img:latest", "infinity")
+			if !slices.Equal(args, want) {
+				t.Errorf("args = %v, want %v", args, want)
+			}
+			if tty != tt.wantTTY || stdin != tt.wantStdin {
+				t.Errorf("tty, stdin = %v, %v, want %v, %v", tty, stdin, tt.wantTTY, tt.wantStdin)
+			}
+		})
+	}
+}
+
+// Docker and OrbStack exec the entrypoint as root, podman as the image
+// user; both get the same interactive flags
+func TestEntrypointExecArgs_DockerAndPodman(t *testing.T) {
+	cfg := &Config{ContainerDetachKeys: "ctrl-x,x"}
+	base := []string{"run", "--rm", "-it", "--name", "c"}
+	_, tty, stdin := KeepAliveRunArgs(base, "img")
+
+	docker := EntrypointExecArgs(cfg, []string{"exec", "--user", "root"}, tty, stdin)
+	podman := EntrypointExecArgs(cfg, []string{"exec"}, tty, stdin)
+	if want := []string{"exec", "--user", "root", "-it", "--detach-keys", "ctrl-x,x"}; !slices.Equal(docker, want) {
+		t.Errorf("docker exec args = %v, want %v", docker, want)
+	}
+	if want := []string{"exec", "-it", "--detach-keys", "ctrl-x,x"}; !slices.Equal(podman, want) {
+		t.Errorf("podman exec args = %v, want %v", podman, want)
+	}
+	if !slices.Equal(docker[3:], podman[1:]) {
+		t.Errorf("interactive flags differ: docker %v, podman %v", docker[3:], podman[1:])
+	}
+
+	if got := EntrypointExecArgs(cfg, []string{"exec"}, false, true); !slices.Equal(got, []string{"exec", "-i"}) {
+		t.Errorf("stdin-only exec args = %v, want [exec -i]", got)
+	}
+	if got := EntrypointExecArgs(cfg, []string{"exec"}, false, false); !slices.Equal(got, []string{"exec"}) {
+		t.Errorf("non-interactive exec args = %v, want [exec]", got)
+	}
+}
@@ -0,0 +1,25 @@
+package provider
+
+import (
+	"path/filepath"
+
+	"github.com/jedi4ever/addt/util"
+)
+
+// SSHDir returns the SSH directory to forward keys from: ssh.dir, or
+// ~/.ssh under homeDir when unset
+func SSHDir(cfg *Config, homeDir string) string {
+	if cfg.SSHDir == "" {
+		return filepath.Join(homeDir, ".ssh")
+	}
+	return util.ExpandTilde(cfg.SSHDir)
+}
+
+// GPGDir returns the GPG home to forward keys from: gpg.dir, or ~/.gnupg
+// under homeDir when unset
+func GPGDir(cfg *Config, homeDir string) string {
+	if cfg.GPGDir == "" {
+		return filepath.Join(homeDir, ".gnupg")
+	}
+	return util.ExpandTilde(cfg.GPGDir)
+}
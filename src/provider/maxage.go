@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// containerCreatedLayouts are the timestamp formats returned by
+// `docker inspect --format {{.Created}}` and `podman inspect --format {{.Created}}`
+var containerCreatedLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999 -0700 MST",
+}
+
+// ParseMaxAge parses a container.max_age value. Accepts Go durations
+// ("12h", "90m") and whole days ("7d"). Empty or "0" disables the check
+// and returns 0.
+func ParseMaxAge(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || s == "0" {
+		return 0, nil
+	}
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid max age %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid max age %q", s)
+	}
+	return d, nil
+}
+
+// ParseContainerCreated parses a container creation timestamp
+func ParseContainerCreated(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range containerCreatedLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized container creation time %q", s)
+}
+
+// ContainerExpired reports whether a container created at created is older
+// than maxAge at now. A zero maxAge never expires.
+func ContainerExpired(created time.Time, maxAge time.Duration, now time.Time) bool {
+	return maxAge > 0 && now.Sub(created) > maxAge
+}
+
+// ContainerCreatedAt returns the creation time of a container, inspected
+// through the runtime command
+func ContainerCreatedAt(runtime func(args ...string) *exec.Cmd, name string) (time.Time, error) {
+	output, err := runtime("inspect", "--format", "{{.Created}}", name).Output()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return ParseContainerCreated(string(output))
+}
+
+// RemoveExpired removes the persistent container name when it is older than
+// container.max_age and reports whether it did. A max age or creation time
+// that can't be read is warned about and leaves the container alone.
+func RemoveExpired(p Provider, cfg *Config, runtime func(args ...string) *exec.Cmd, name string) (bool, error) {
+	maxAge, err := ParseMaxAge(cfg.ContainerMaxAge)
+	if err != nil {
+		fmt.Printf("Warning: ignoring container.max_age: %v\n", err)
+		return false, nil
+	}
+	if maxAge == 0 {
+		return false, nil
+	}
+	created, err := ContainerCreatedAt(runtime, name)
+	if err != nil {
+		fmt.Printf("Warning: could not determine age of container %s: %v\n", name, err)
+		return false, nil
+	}
+	if !ContainerExpired(created, maxAge, time.Now()) {
+		return false, nil
+	}
+	fmt.Printf("Persistent container %s is older than container.max_age (%s), recreating...\n", name, cfg.ContainerMaxAge)
+	if err := p.Remove(name); err != nil {
+		return false, fmt.Errorf("failed to remove expired container %s: %w", name, err)
+	}
+	return true, nil
+}
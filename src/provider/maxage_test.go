@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"errors"
+	"os/exec"
+	"testing"
+	"time"
+)
+
+func TestParseMaxAge(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"0", 0, false},
+		{"12h", 12 * time.Hour, false},
+		{"90m", 90 * time.Minute, false},
+		{"7d", 7 * 24 * time.Hour, false},
+		{"xd", 0, true},
+		{"-1h", 0, true},
+		{"soon", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseMaxAge(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseMaxAge(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseMaxAge(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseContainerCreated(t *testing.T) {
+	want := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, in := range []string{
+		"2026-01-02T03:04:05.000000000Z",
+		"2026-01-02 03:04:05 +0000 UTC",
+	} {
+		got, err := ParseContainerCreated(in)
+		if err != nil {
+			t.Errorf("ParseContainerCreated(%q) error: %v", in, err)
+			continue
+		}
+		if !got.Equal(want) {
+			t.Errorf("ParseContainerCreated(%q) = %v, want %v", in, got, want)
+		}
+	}
+	if _, err := ParseContainerCreated("yesterday"); err == nil {
+		t.Error("expected error for unparseable timestamp")
+	}
+}
+
+func TestContainerExpired(t *testing.T) {
+	now := time.Now()
+	if !ContainerExpired(now.Add(-48*time.Hour), 24*time.Hour, now) {
+		t.Error("container older than max age should be expired")
+	}
+	if ContainerExpired(now.Add(-time.Hour), 24*time.Hour, now) {
+		t.Error("fresh container should not be expired")
+	}
+	if ContainerExpired(now.Add(-48*time.Hour), 0, now) {
+		t.Error("zero max age should never expire")
+	}
+}
+
+// removeFailingProvider is a Provider whose Remove always fails
+type removeFailingProvider struct {
+	Provider
+	removed []string
+}
+
+func (p *removeFailingProvider) Remove(name string) error {
+	p.removed = append(p.removed, name)
+	return errors.New("rm failed")
+}
+
+func TestRemoveExpired(t *testing.T) {
+	oldContainer := func(args ...string) *exec.Cmd {
+		return exec.Command("echo", "2020-01-01T00:00:00Z")
+	}
+
+	p := &removeFailingProvider{}
+	removed, err := RemoveExpired(p, &Config{ContainerMaxAge: "1h"}, oldContainer, "addt-persistent-x")
+	if err == nil || removed {
+		t.Fatalf("RemoveExpired() = %v, %v; want the Remove error", removed, err)
+	}
+	if len(p.removed) != 1 || p.removed[0] != "addt-persistent-x" {
+		t.Errorf("Remove calls = %v, want [addt-persistent-x]", p.removed)
+	}
+
+	p = &removeFailingProvider{}
+	removed, err = RemoveExpired(p, &Config{}, oldContainer, "addt-persistent-x")
+	if err != nil || removed || len(p.removed) != 0 {
+		t.Errorf("RemoveExpired() without max_age = %v, %v, removes %v; want no removal", removed, err, p.removed)
+	}
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// MountTypes are the --mount types addt passes through
+var MountTypes = []string{"bind", "volume", "tmpfs"}
+
+// MountSpec is a docker-style --mount value, parsed only far enough to
+// validate it and to find sensitive bind sources. The runtime gets Raw.
+type MountSpec struct {
+	Raw      string
+	Type     string
+	Source   string
+	Target   string
+	ReadOnly bool
+}
+
+// ParseMountSpec parses "type=bind,source=/a,target=/b[,readonly]". Keys
+// the runtime understands beyond these are kept in Raw untouched. type and
+// target are required, as is source for bind mounts.
+func ParseMountSpec(s string) (MountSpec, error) {
+	m := MountSpec{Raw: s}
+	for _, field := range strings.Split(s, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(field), "=")
+		switch strings.ToLower(key) {
+		case "type":
+			m.Type = value
+		case "source", "src":
+			m.Source = value
+		case "target", "destination", "dst":
+			m.Target = value
+		case "readonly", "ro":
+			m.ReadOnly = value == "" || value == "true" || value == "1"
+		}
+	}
+	switch {
+	case m.Type == "":
+		return m, fmt.Errorf("mount %q: type is required (%s)", s, strings.Join(MountTypes, ", "))
+	case !slices.Contains(MountTypes, m.Type):
+		return m, fmt.Errorf("mount %q: unknown type %q, expected one of %s", s, m.Type, strings.Join(MountTypes, ", "))
+	case m.Target == "":
+		return m, fmt.Errorf("mount %q: target is required", s)
+	case m.Type == "bind" && m.Source == "":
+		return m, fmt.Errorf("mount %q: source is required for bind mounts", s)
+	}
+	return m, nil
+}
+
+// readOnlyMountSpec adds readonly to a bind --mount value, for
+// security.mount_readonly. Volumes and tmpfs are left alone, like named
+// volumes among the -v flags.
+func readOnlyMountSpec(s string) string {
+	m, err := ParseMountSpec(s)
+	if err != nil || m.Type != "bind" || m.ReadOnly {
+		return s
+	}
+	return s + ",readonly"
+}
+
+// MountArgs returns a --mount flag for each value, unchanged
+func MountArgs(mounts []string) []string {
+	var args []string
+	for _, m := range mounts {
+		args = append(args, "--mount", m)
+	}
+	return args
+}
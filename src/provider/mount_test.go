@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMountSpec(t *testing.T) {
+	m, err := ParseMountSpec("type=bind,source=/data,target=/mnt/data,readonly,bind-propagation=rslave")
+	if err != nil {
+		t.Fatalf("ParseMountSpec() error = %v", err)
+	}
+	if m.Type != "bind" || m.Source != "/data" || m.Target != "/mnt/data" || !m.ReadOnly {
+		t.Errorf("ParseMountSpec() = %+v", m)
+	}
+
+	for _, ok := range []string{
+		"type=volume,src=cache,dst=/cache",
+		"type=volume,target=/scratch",
+		"type=tmpfs,destination=/run/cache,tmpfs-size=64m",
+	} {
+		if _, err := ParseMountSpec(ok); err != nil {
+			t.Errorf("ParseMountSpec(%q) error = %v", ok, err)
+		}
+	}
+	for _, bad := range []string{
+		"type=bind,source=/data",
+		"source=/data,target=/data",
+		"type=nfs,source=/data,target=/data",
+		"type=bind,target=/data",
+	} {
+		if _, err := ParseMountSpec(bad); err == nil {
+			t.Errorf("ParseMountSpec(%q) expected error", bad)
+		}
+	}
+}
+
+func TestMountArgs(t *testing.T) {
+	spec := "type=bind,source=/data,target=/data,readonly"
+	if got, want := MountArgs([]string{spec}), []string{"--mount", spec}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MountArgs() = %v, want %v", got, want)
+	}
+}
+
+func TestReadOnlyMountArgs_MountFlag(t *testing.T) {
+	args := []string{
+		"--mount", "type=bind,source=/data,target=/data",
+		"--mount", "type=bind,source=/ro,target=/ro,readonly",
+		"--mount", "type=volume,source=cache,target=/cache",
+	}
+	want := []string{
+		"--mount", "type=bind,source=/data,target=/data,readonly",
+		"--mount", "type=bind,source=/ro,target=/ro,readonly",
+		"--mount", "type=volume,source=cache,target=/cache",
+	}
+	if got := ReadOnlyMountArgs(args); !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadOnlyMountArgs() = %v, want %v", got, want)
+	}
+}
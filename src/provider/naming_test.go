@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// legacyNaming matches leftovers of the dclaude -> addt rename: the old
+// binary and env prefix, and the old "claude" container user, which the
+// images no longer create (it is "addt", home /home/addt)
+var legacyNaming = regexp.MustCompile(`(?i)dclaude|/home/claude\b|--user[ =]claude\b|-u claude\b|\bclaude:claude\b|(?:user|username)\s*:?=\s*"claude"`)
+
+// TestNoLegacyNaming keeps the provider packages and the image assets
+// consistent, so forwarding code doesn't silently target the old names
+func TestNoLegacyNaming(t *testing.T) {
+	for _, root := range []string{".", filepath.Join("..", "assets")} {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || strings.HasSuffix(path, "naming_test.go") {
+				return err
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			for i, line := range strings.Split(string(data), "\n") {
+				if m := legacyNaming.FindString(line); m != "" {
+					t.Errorf("%s:%d: legacy name %q, use addt/ADDT_ and the addt user", path, i+1, m)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("walking %s: %v", root, err)
+		}
+	}
+}
@@ -6,12 +6,12 @@ import (
 	"hash"
 	"io/fs"
 	"os"
-	"os/user"
 	"path/filepath"
 	"strings"
 
 	profilecmd "github.com/jedi4ever/addt/cmd/profile"
 	"github.com/jedi4ever/addt/extensions"
+	"github.com/jedi4ever/addt/provider"
 	"github.com/jedi4ever/addt/util"
 )
 
@@ -142,10 +142,7 @@ func (p *OrbStackProvider) extAssetsHash() string {
 
 // GetBaseImageName returns the base image name for the current config
 func (p *OrbStackProvider) GetBaseImageName() string {
-	currentUser, err := user.Current()
-	if err != nil {
-		return "addt-base:latest"
-	}
+	currentUser := util.CurrentUser()
 	return fmt.Sprintf("addt-base:v%s-node%s-go%s-uv%s-uid%s-%s",
-		p.config.AddtVersion, p.config.NodeVersion, p.config.GoVersion, p.config.UvVersion, currentUser.Uid, p.assetsHash())
+		p.config.AddtVersion, p.config.NodeVersion, p.config.GoVersion, p.config.UvVersion, currentUser.Uid, p.assetsHash()) + provider.PlatformTag(p.config)
 }
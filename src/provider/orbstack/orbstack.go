@@ -1,6 +1,7 @@
 package orbstack
 
 import (
+	"context"
 	"embed"
 	"fmt"
 	"os"
@@ -59,27 +60,27 @@ func (p *OrbStackProvider) GetName() string {
 func (p *OrbStackProvider) CheckPrerequisites() error {
 	// OrbStack is macOS-only
 	if runtime.GOOS != "darwin" {
-		return fmt.Errorf("OrbStack is only available on macOS")
+		return provider.Tag(provider.ErrRuntimeNotFound, fmt.Errorf("OrbStack is only available on macOS"))
 	}
 
 	// Check orbctl is installed
 	if _, err := exec.LookPath("orbctl"); err != nil {
-		return fmt.Errorf("OrbStack is not installed. Please install OrbStack from: https://orbstack.dev/")
+		return provider.Tag(provider.ErrRuntimeNotFound, fmt.Errorf("OrbStack is not installed. Please install OrbStack from: https://orbstack.dev/"))
 	}
 
 	// Check OrbStack is running
 	cmd := exec.Command("orbctl", "status")
 	output, err := cmd.Output()
 	if err != nil {
-		return fmt.Errorf("OrbStack is not running. Please start OrbStack and try again")
+		return provider.Tag(provider.ErrRuntimeNotRunning, fmt.Errorf("OrbStack is not running. Please start OrbStack and try again"))
 	}
 	if status := string(output); len(status) > 0 && status != "Running\n" && status != "Running\r\n" {
-		return fmt.Errorf("OrbStack is not running (status: %s). Please start OrbStack and try again", status)
+		return provider.Tag(provider.ErrRuntimeNotRunning, fmt.Errorf("OrbStack is not running (status: %s). Please start OrbStack and try again", status))
 	}
 
 	// Check Docker CLI is available (OrbStack provides Docker compatibility)
 	if _, err := exec.LookPath("docker"); err != nil {
-		return fmt.Errorf("Docker CLI is not available. OrbStack should provide this - try reinstalling OrbStack")
+		return provider.Tag(provider.ErrRuntimeNotFound, fmt.Errorf("Docker CLI is not available. OrbStack should provide this - try reinstalling OrbStack"))
 	}
 
 	return nil
@@ -94,6 +95,11 @@ func (p *OrbStackProvider) dockerCmd(args ...string) *exec.Cmd {
 	return provider.DockerCmd("orbstack", args...)
 }
 
+// dockerCmdContext is dockerCmd bound to ctx (used for --timeout).
+func (p *OrbStackProvider) dockerCmdContext(ctx context.Context, args ...string) *exec.Cmd {
+	return provider.DockerCmdContext(ctx, "orbstack", args...)
+}
+
 // dockerEnv returns the environment slice for Docker commands in the orbstack context.
 func (p *OrbStackProvider) dockerEnv() []string {
 	return append(os.Environ(), "DOCKER_CONTEXT=orbstack")
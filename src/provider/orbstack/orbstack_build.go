@@ -6,6 +6,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/jedi4ever/addt/provider"
 	"github.com/jedi4ever/addt/util"
 )
 
@@ -68,7 +69,7 @@ func (p *OrbStackProvider) DetermineImageName() string {
 
 	// Handle base image case (no extensions)
 	if len(validExts) == 0 {
-		return fmt.Sprintf("addt:v%s_base-%s", p.config.AddtVersion, p.assetsHash())
+		return fmt.Sprintf("addt:v%s_base-%s", p.config.AddtVersion, p.assetsHash()) + provider.PlatformTag(p.config)
 	}
 
 	// Check if all extensions have explicit versions (not dist-tags)
@@ -102,7 +103,7 @@ func (p *OrbStackProvider) DetermineImageName() string {
 	// Prefix with addt version, base hash, and extension hash so images are rebuilt when assets change
 	baseHash := p.assetsHash()
 	extHash := p.extAssetsHash()
-	imageName := fmt.Sprintf("addt:v%s_%s-%s-%s", p.config.AddtVersion, tag, baseHash, extHash)
+	imageName := fmt.Sprintf("addt:v%s_%s-%s-%s", p.config.AddtVersion, tag, baseHash, extHash) + provider.PlatformTag(p.config)
 	logger := util.Log("orbstack-build")
 	logger.Debugf("assetsHash=%s extAssetsHash=%s imageName=%s", baseHash, extHash, imageName)
 	return imageName
@@ -3,12 +3,13 @@ package orbstack
 import (
 	"testing"
 
+	"github.com/jedi4ever/addt/config/security"
 	"github.com/jedi4ever/addt/provider"
 )
 
 func TestBuildBaseDockerArgs_NewPersistent(t *testing.T) {
 	p := &OrbStackProvider{
-		config: &provider.Config{},
+		config: &provider.Config{ContainerInit: true},
 	}
 	spec := &provider.RunSpec{
 		Name:        "test-container",
@@ -30,6 +31,21 @@ func TestBuildBaseDockerArgs_NewPersistent(t *testing.T) {
 	assertNotContains(t, args, "--rm")
 }
 
+func TestBuildBaseDockerArgs_InitDisabled(t *testing.T) {
+	p := &OrbStackProvider{
+		config: &provider.Config{ContainerInit: false},
+	}
+	spec := &provider.RunSpec{
+		Name:        "test-container",
+		Interactive: true,
+	}
+
+	args := p.buildBaseDockerArgs(spec, &containerContext{})
+
+	assertContains(t, args, "-it")
+	assertNotContains(t, args, "--init")
+}
+
 func TestBuildBaseDockerArgs_ExistingPersistent(t *testing.T) {
 	p := &OrbStackProvider{
 		config: &provider.Config{},
@@ -75,7 +91,7 @@ func TestBuildBaseDockerArgs_Ephemeral(t *testing.T) {
 }
 
 func TestStripInteractiveFlags(t *testing.T) {
-	// Test the flag stripping logic used in runPersistent/shellPersistent
+	// Test the flag stripping used in runPersistent/runWithSecrets/shellPersistent
 	tests := []struct {
 		name            string
 		input           []string
@@ -110,20 +126,8 @@ func TestStripInteractiveFlags(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			var gotArgs []string
-			gotInteractive := false
-			for _, arg := range tt.input {
-				switch arg {
-				case "-it":
-					gotInteractive = true
-				case "-i":
-					gotInteractive = true
-				case "-t", "--init":
-					// stripped
-				default:
-					gotArgs = append(gotArgs, arg)
-				}
-			}
+			runArgs, _, gotInteractive := provider.KeepAliveRunArgs(tt.input, "img")
+			gotArgs := runArgs[:len(runArgs)-5] // drop -d --entrypoint sleep img infinity
 
 			if gotInteractive != tt.wantInteractive {
 				t.Errorf("interactive = %v, want %v", gotInteractive, tt.wantInteractive)
@@ -194,3 +198,33 @@ func assertSliceEqual(t *testing.T, got, want []string) {
 		}
 	}
 }
+
+func TestAddContainerVolumesAndEnv_FirewallCaps(t *testing.T) {
+	p := &OrbStackProvider{config: &provider.Config{FirewallEnabled: true, Security: security.DefaultConfig()}}
+	spec := &provider.RunSpec{Name: "test-container"}
+	ctx := &containerContext{homeDir: t.TempDir(), username: "addt"}
+
+	args, cleanup := p.addContainerVolumesAndEnv(nil, spec, ctx)
+	defer cleanup()
+
+	// The entrypoint applies the rules as root, then drops to addt via gosu
+	assertArgPair(t, args, "--user", "root")
+	for _, c := range security.FirewallCaps {
+		assertArgPair(t, args, "--cap-add", c)
+	}
+
+	p.config.FirewallEnabled = false
+	args, cleanup = p.addContainerVolumesAndEnv(nil, spec, ctx)
+	defer cleanup()
+	assertNotContains(t, args, "NET_ADMIN")
+}
+
+func assertArgPair(t *testing.T, args []string, flag, value string) {
+	t.Helper()
+	for i := 0; i+1 < len(args); i++ {
+		if args[i] == flag && args[i+1] == value {
+			return
+		}
+	}
+	t.Errorf("expected %s %s in %v", flag, value, args)
+}
@@ -3,56 +3,15 @@ package orbstack
 import (
 	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
-)
-
-func TestFilterSecretEnvVars(t *testing.T) {
-	p := &OrbStackProvider{}
-
-	env := map[string]string{
-		"ANTHROPIC_API_KEY": "secret-key",
-		"GH_TOKEN":          "github-token",
-		"TERM":              "xterm-256color",
-		"HOME":              "/home/user",
-	}
-
-	secretVarNames := []string{"ANTHROPIC_API_KEY", "GH_TOKEN"}
-
-	p.filterSecretEnvVars(env, secretVarNames)
-
-	// Secret vars should be removed
-	if _, exists := env["ANTHROPIC_API_KEY"]; exists {
-		t.Error("ANTHROPIC_API_KEY should be removed")
-	}
-	if _, exists := env["GH_TOKEN"]; exists {
-		t.Error("GH_TOKEN should be removed")
-	}
-
-	// Non-secret vars should remain
-	if env["TERM"] != "xterm-256color" {
-		t.Errorf("TERM = %q, want \"xterm-256color\"", env["TERM"])
-	}
-	if env["HOME"] != "/home/user" {
-		t.Errorf("HOME = %q, want \"/home/user\"", env["HOME"])
-	}
-}
 
-func TestAddTmpfsSecretsMount(t *testing.T) {
-	p := &OrbStackProvider{}
-
-	args := []string{"-it"}
-	result := p.addTmpfsSecretsMount(args)
-
-	if len(result) != 3 {
-		t.Errorf("Expected 3 args, got %d: %v", len(result), result)
-	}
-	if result[1] != "--tmpfs" {
-		t.Errorf("Expected --tmpfs flag, got %s", result[1])
-	}
-	if result[2] != "/run/secrets:size=1m,mode=0777" {
-		t.Errorf("Expected tmpfs mount arg, got %s", result[2])
-	}
-}
+	"github.com/jedi4ever/addt/config/security"
+	"github.com/jedi4ever/addt/provider"
+)
 
 func TestPrepareSecrets(t *testing.T) {
 	// Test that secrets are properly encoded as base64 JSON
@@ -89,3 +48,22 @@ func TestPrepareSecrets(t *testing.T) {
 			decodedSecrets["GH_TOKEN"], secrets["GH_TOKEN"])
 	}
 }
+
+func TestRunWithSecrets_ContainerNotReady(t *testing.T) {
+	dir := t.TempDir()
+	script := "#!/bin/sh\ncase \"$1\" in\n  inspect) echo exited ;;\n  logs) echo 'keep-alive died' ;;\nesac\n"
+	if err := os.WriteFile(filepath.Join(dir, "docker"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir)
+
+	p := &OrbStackProvider{config: &provider.Config{Security: security.Config{FetchEntrypointLog: true}}}
+	spec := &provider.RunSpec{Name: "addt-test", ImageName: "addt-test:latest"}
+	err := p.runWithSecrets([]string{"run", "--rm"}, spec, `{"A":"b"}`)
+	if !errors.Is(err, provider.ErrContainerStartFailed) {
+		t.Errorf("runWithSecrets() = %v, want ErrContainerStartFailed", err)
+	}
+	if err == nil || !strings.Contains(err.Error(), "container is exited") || !strings.Contains(err.Error(), "keep-alive died") {
+		t.Errorf("runWithSecrets() = %v, want the state and container logs", err)
+	}
+}
@@ -9,7 +9,7 @@ import (
 )
 
 // handleSSHProxyForwarding creates a filtered SSH agent proxy
-func (p *OrbStackProvider) handleSSHProxyForwarding(sshDir, username string, allowedKeys []string) []string {
+func (p *OrbStackProvider) handleSSHProxyForwarding(sshDir, username string, allowedKeys, extraDirs []string) []string {
 	var args []string
 
 	sshAuthSock := os.Getenv("SSH_AUTH_SOCK")
@@ -21,7 +21,7 @@ func (p *OrbStackProvider) handleSSHProxyForwarding(sshDir, username string, all
 	// On macOS, Docker Desktop runs containers in a VM and can't mount Unix sockets.
 	// Use TCP mode: proxy listens on TCP, container connects via socat.
 	if runtime.GOOS == "darwin" {
-		return p.handleSSHProxyForwardingTCP(sshAuthSock, sshDir, username, allowedKeys)
+		return p.handleSSHProxyForwardingTCP(sshAuthSock, sshDir, username, allowedKeys, extraDirs)
 	}
 
 	// Linux: use Unix socket (can be mounted directly)
@@ -41,7 +41,7 @@ func (p *OrbStackProvider) handleSSHProxyForwarding(sshDir, username string, all
 	proxySocket := proxy.SocketPath()
 	args = append(args, "-v", fmt.Sprintf("%s:/ssh-agent", proxySocket))
 	args = append(args, "-e", "SSH_AUTH_SOCK=/ssh-agent")
-	args = append(args, p.mountSafeSSHFiles(sshDir, username)...)
+	args = append(args, p.mountSafeSSHFiles(sshDir, username, extraDirs...)...)
 
 	if len(allowedKeys) > 0 {
 		fmt.Printf("SSH proxy active: only keys matching %v are accessible\n", allowedKeys)
@@ -54,7 +54,7 @@ func (p *OrbStackProvider) handleSSHProxyForwarding(sshDir, username string, all
 
 // handleSSHProxyForwardingTCP creates a TCP-based SSH agent proxy for macOS.
 // The proxy listens on a TCP port on the host; the container connects via socat.
-func (p *OrbStackProvider) handleSSHProxyForwardingTCP(sshAuthSock, sshDir, username string, allowedKeys []string) []string {
+func (p *OrbStackProvider) handleSSHProxyForwardingTCP(sshAuthSock, sshDir, username string, allowedKeys, extraDirs []string) []string {
 	var args []string
 
 	proxy, err := security.NewSSHProxyAgentTCP(sshAuthSock, allowedKeys)
@@ -83,7 +83,7 @@ func (p *OrbStackProvider) handleSSHProxyForwardingTCP(sshAuthSock, sshDir, user
 	args = append(args, "-e", fmt.Sprintf("ADDT_SSH_PROXY_PORT=%d", proxy.TCPPort()))
 
 	// Mount safe SSH files only (config, known_hosts, public keys)
-	args = append(args, p.mountSafeSSHFiles(sshDir, username)...)
+	args = append(args, p.mountSafeSSHFiles(sshDir, username, extraDirs...)...)
 
 	if len(allowedKeys) > 0 {
 		fmt.Printf("SSH proxy active (TCP): only keys matching %v are accessible\n", allowedKeys)
@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+)
+
+// hostArch is the host architecture, overridable in tests
+var hostArch = runtime.GOARCH
+
+// PlatformArgs returns the --platform flag for container.platform, or nil
+// when unset and the runtime picks the host platform. Builds and runs get
+// the same flag so the image and container agree.
+func PlatformArgs(cfg *Config) []string {
+	if cfg.ContainerPlatform == "" {
+		return nil
+	}
+	return []string{"--platform", cfg.ContainerPlatform}
+}
+
+// PlatformTag returns the image tag suffix for container.platform, so
+// images built for another platform don't collide with native ones.
+// Returns "" when unset.
+func PlatformTag(cfg *Config) string {
+	if cfg.ContainerPlatform == "" {
+		return ""
+	}
+	return "-" + strings.ReplaceAll(cfg.ContainerPlatform, "/", "-")
+}
+
+// PlatformArch returns the architecture of an os/arch[/variant] platform,
+// normalized to Go's names (x86_64 -> amd64, aarch64 -> arm64)
+func PlatformArch(platform string) string {
+	parts := strings.Split(platform, "/")
+	if len(parts) < 2 {
+		return ""
+	}
+	switch arch := strings.ToLower(parts[1]); arch {
+	case "x86_64", "x86-64":
+		return "amd64"
+	case "aarch64":
+		return "arm64"
+	default:
+		return arch
+	}
+}
+
+// WarnPlatformEmulation warns when container.platform targets another
+// architecture than the host, since the runtime then emulates every
+// instruction (QEMU or Rosetta) and builds and agents run much slower.
+func WarnPlatformEmulation(cfg *Config) {
+	if cfg.ContainerPlatform == "" {
+		return
+	}
+	if arch := PlatformArch(cfg.ContainerPlatform); arch != "" && arch != hostArch {
+		fmt.Fprintf(os.Stderr, "Warning: container.platform %s differs from the host architecture (%s); the container runs under emulation and will be slower\n",
+			cfg.ContainerPlatform, hostArch)
+	}
+}
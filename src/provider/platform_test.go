@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPlatformArgs(t *testing.T) {
+	if args := PlatformArgs(&Config{}); args != nil {
+		t.Errorf("PlatformArgs() = %v, want nil when unset", args)
+	}
+	args := PlatformArgs(&Config{ContainerPlatform: "linux/amd64"})
+	if want := []string{"--platform", "linux/amd64"}; !reflect.DeepEqual(args, want) {
+		t.Errorf("PlatformArgs() = %v, want %v", args, want)
+	}
+}
+
+func TestPlatformTag(t *testing.T) {
+	tests := map[string]string{
+		"":             "",
+		"linux/amd64":  "-linux-amd64",
+		"linux/arm/v7": "-linux-arm-v7",
+	}
+	for platform, want := range tests {
+		if got := PlatformTag(&Config{ContainerPlatform: platform}); got != want {
+			t.Errorf("PlatformTag(%q) = %q, want %q", platform, got, want)
+		}
+	}
+}
+
+func TestPlatformArch(t *testing.T) {
+	tests := map[string]string{
+		"linux/amd64":   "amd64",
+		"linux/x86_64":  "amd64",
+		"linux/aarch64": "arm64",
+		"linux/arm/v7":  "arm",
+		"amd64":         "",
+	}
+	for platform, want := range tests {
+		if got := PlatformArch(platform); got != want {
+			t.Errorf("PlatformArch(%q) = %q, want %q", platform, got, want)
+		}
+	}
+}
+
+// captureStderr returns what fn writes to os.Stderr
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	orig := os.Stderr
+	os.Stderr = w
+	fn()
+	os.Stderr = orig
+	w.Close()
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+	return buf.String()
+}
+
+func TestWarnPlatformEmulation(t *testing.T) {
+	orig := hostArch
+	hostArch = "arm64"
+	defer func() { hostArch = orig }()
+
+	out := captureStderr(t, func() { WarnPlatformEmulation(&Config{ContainerPlatform: "linux/amd64"}) })
+	if !strings.Contains(out, "emulation") {
+		t.Errorf("expected an emulation warning for linux/amd64 on arm64, got %q", out)
+	}
+
+	for _, platform := range []string{"", "linux/arm64", "linux/aarch64"} {
+		out := captureStderr(t, func() { WarnPlatformEmulation(&Config{ContainerPlatform: platform}) })
+		if out != "" {
+			t.Errorf("WarnPlatformEmulation(%q) printed %q, want nothing on a native platform", platform, out)
+		}
+	}
+}
@@ -69,6 +69,9 @@ func (p *PodmanProvider) GetExtensionMountsWithNames(imageName string) []extensi
 
 // AddExtensionMounts adds extension mount volumes to podman args
 func (p *PodmanProvider) AddExtensionMounts(podmanArgs []string, imageName, homeDir string) []string {
+	if p.config.NoConfigAutomount {
+		return podmanArgs
+	}
 	extMounts := p.GetExtensionMountsWithNames(imageName)
 	for _, extMount := range extMounts {
 		// Determine if mount should be enabled based on mounts.automount and explicit config
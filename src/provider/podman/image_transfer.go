@@ -0,0 +1,25 @@
+package podman
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// SaveImage writes imageName to a docker-archive tarball at path (podman
+// save), so the file also loads with docker
+func (p *PodmanProvider) SaveImage(imageName, path string) error {
+	cmd := exec.Command("podman", "save", "--format", "docker-archive", "-o", path, imageName)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("podman save %s: %w: %s", imageName, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// LoadImage imports the images in the tarball at path (podman load)
+func (p *PodmanProvider) LoadImage(path string) error {
+	if output, err := exec.Command("podman", "load", "-i", path).CombinedOutput(); err != nil {
+		return fmt.Errorf("podman load %s: %w: %s", path, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
@@ -7,12 +7,12 @@ import (
 	"io/fs"
 	"os"
 	"os/exec"
-	"os/user"
 	"path/filepath"
 	"strings"
 
 	profilecmd "github.com/jedi4ever/addt/cmd/profile"
 	"github.com/jedi4ever/addt/extensions"
+	"github.com/jedi4ever/addt/provider"
 	"github.com/jedi4ever/addt/util"
 )
 
@@ -143,10 +143,7 @@ func (p *PodmanProvider) extAssetsHash() string {
 
 // GetBaseImageName returns the base image name for the current config
 func (p *PodmanProvider) GetBaseImageName() string {
-	currentUser, err := user.Current()
-	if err != nil {
-		return "addt-base:latest"
-	}
+	currentUser := util.CurrentUser()
 	return fmt.Sprintf("addt-base:v%s-node%s-go%s-uv%s-uid%s-%s",
-		p.config.AddtVersion, p.config.NodeVersion, p.config.GoVersion, p.config.UvVersion, currentUser.Uid, p.assetsHash())
+		p.config.AddtVersion, p.config.NodeVersion, p.config.GoVersion, p.config.UvVersion, currentUser.Uid, p.assetsHash()) + provider.PlatformTag(p.config)
 }
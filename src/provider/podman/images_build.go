@@ -6,13 +6,13 @@ import (
 	"io/fs"
 	"os"
 	"os/exec"
-	"os/user"
 	"path/filepath"
 	"regexp"
 	"strings"
 	"time"
 
 	"github.com/jedi4ever/addt/extensions"
+	"github.com/jedi4ever/addt/provider"
 	"github.com/jedi4ever/addt/util"
 )
 
@@ -50,16 +50,15 @@ func (p *PodmanProvider) BuildBaseImage() error {
 	}
 
 	// Get current user info
-	currentUser, err := user.Current()
-	if err != nil {
-		return fmt.Errorf("failed to get current user: %w", err)
-	}
+	currentUser := util.CurrentUser()
 	uid := currentUser.Uid
 	gid := currentUser.Gid
 
 	// Build podman command for base image
-	args := []string{
-		"build",
+	args := []string{"build"}
+	args = append(args, provider.PlatformArgs(p.config)...)
+	args = append(args, provider.BasePullArgs(p.config, true)...)
+	args = append(args,
 		"--build-arg", fmt.Sprintf("NODE_VERSION=%s", p.config.NodeVersion),
 		"--build-arg", fmt.Sprintf("GO_VERSION=%s", p.config.GoVersion),
 		"--build-arg", fmt.Sprintf("UV_VERSION=%s", p.config.UvVersion),
@@ -69,12 +68,12 @@ func (p *PodmanProvider) BuildBaseImage() error {
 		"-t", baseImageName,
 		"-f", dockerfilePath,
 		buildDir,
-	}
+	)
 
 	// Run build with progress indication
 	if err := util.RunBuildCommand("podman", args); err != nil {
 		util.PrintError(fmt.Sprintf("Failed to build base image: %v", err))
-		return fmt.Errorf("failed to build base Podman image: %w", err)
+		return provider.Tag(provider.ErrImageBuildFailed, fmt.Errorf("failed to build base Podman image: %w", err))
 	}
 
 	elapsed := time.Since(startTime)
@@ -199,6 +198,7 @@ func (p *PodmanProvider) BuildImage(embeddedDockerfile, embeddedEntrypoint []byt
 
 	// Build podman command - use base image and only pass extension args
 	args := []string{"build"}
+	args = append(args, provider.PlatformArgs(p.config)...)
 
 	// Add --no-cache if requested
 	if p.config.NoCache {
@@ -214,10 +214,11 @@ func (p *PodmanProvider) BuildImage(embeddedDockerfile, embeddedEntrypoint []byt
 		scriptDir,
 	)
 
-	// Run build with progress indication
-	if err := util.RunBuildCommand("podman", args); err != nil {
+	// Run build with progress indication, killing it if extension
+	// installs hang past docker.build_timeout
+	if err := util.RunBuildCommandWithTimeout("podman", args, nil, provider.BuildTimeout(p.config)); err != nil {
 		util.PrintError(fmt.Sprintf("Failed to build image: %v", err))
-		return fmt.Errorf("failed to build Podman image: %w", err)
+		return provider.Tag(provider.ErrImageBuildFailed, fmt.Errorf("failed to build Podman image: %w", err))
 	}
 
 	elapsed := time.Since(startTime)
@@ -271,7 +272,9 @@ func (p *PodmanProvider) detectToolVersions(imageName string) map[string]string
 
 	for name, cmdArgs := range tools {
 		spinner.UpdateMessage(fmt.Sprintf("Detecting %s version...", name))
-		args := append([]string{"run", "--rm", "--entrypoint", cmdArgs[0], imageName}, cmdArgs[1:]...)
+		args := append([]string{"run", "--rm"}, provider.PlatformArgs(p.config)...)
+		args = append(args, "--entrypoint", cmdArgs[0], imageName)
+		args = append(args, cmdArgs[1:]...)
 		cmd := exec.Command("podman", args...)
 		output, err := cmd.Output()
 		if err == nil {
@@ -309,7 +312,9 @@ func (p *PodmanProvider) addVersionLabels(cfg interface{}, versions map[string]s
 	tmpFile.Close()
 
 	// Build with labels
-	cmd := exec.Command("podman", "build", "-f", tmpFile.Name(), "-t", imageName, ".")
+	labelArgs := append([]string{"build"}, provider.PlatformArgs(p.config)...)
+	labelArgs = append(labelArgs, "-f", tmpFile.Name(), "-t", imageName, ".")
+	cmd := exec.Command("podman", labelArgs...)
 	if err := cmd.Run(); err != nil {
 		fmt.Printf("Warning: failed to add version labels: %v\n", err)
 	}
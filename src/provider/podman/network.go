@@ -3,6 +3,7 @@ package podman
 import (
 	"fmt"
 	"net"
+	"os"
 )
 
 // getHostGatewayIP detects the host's IP address that is reachable from containers.
@@ -25,3 +26,24 @@ func getHostGatewayIP() (string, error) {
 
 	return localAddr.IP.String(), nil
 }
+
+// networkOverride reports whether security.network_mode replaces the
+// firewall's pasta network (firewall.allow_network_override)
+func (p *PodmanProvider) networkOverride() bool {
+	return p.config.FirewallEnabled && p.config.FirewallNetworkOverride && p.config.Security.NetworkMode != ""
+}
+
+// networkModeArgs returns "--network <security.network_mode>". The firewall
+// runs on its own pasta network, so with the firewall on the mode is
+// ignored with a warning unless firewall.allow_network_override forces it.
+func (p *PodmanProvider) networkModeArgs() []string {
+	mode := p.config.Security.NetworkMode
+	if mode == "" {
+		return nil
+	}
+	if p.config.FirewallEnabled && !p.config.FirewallNetworkOverride {
+		fmt.Fprintf(os.Stderr, "Warning: security.network_mode=%s is ignored because the firewall is enabled: podman runs the firewall on its pasta network so the iptables rules can filter traffic. Set firewall.allow_network_override to use network_mode anyway (the firewall then filters only what that network allows, and none has no network to filter), or disable the firewall.\n", mode)
+		return nil
+	}
+	return []string{"--network", mode}
+}
@@ -2,7 +2,11 @@ package podman
 
 import (
 	"net"
+	"reflect"
 	"testing"
+
+	"github.com/jedi4ever/addt/config/security"
+	"github.com/jedi4ever/addt/provider"
 )
 
 func TestGetHostGatewayIP(t *testing.T) {
@@ -24,3 +28,36 @@ func TestGetHostGatewayIP(t *testing.T) {
 		t.Fatalf("expected non-loopback IP, got %s", ip)
 	}
 }
+
+func TestNetworkModeArgs_FirewallOverride(t *testing.T) {
+	orig := lookPath
+	defer func() { lookPath = orig }()
+	lookPath = func(string) (string, error) { return "/usr/bin/pasta", nil }
+
+	cfg := &provider.Config{Security: security.DefaultConfig()}
+	cfg.Security.NetworkMode = "none"
+	p := &PodmanProvider{config: cfg}
+	if got := p.networkModeArgs(); !reflect.DeepEqual(got, []string{"--network", "none"}) {
+		t.Errorf("without firewall networkModeArgs() = %v, want [--network none]", got)
+	}
+
+	// The firewall keeps pasta and ignores network_mode with a warning
+	cfg.FirewallEnabled = true
+	if got := p.networkModeArgs(); got != nil {
+		t.Errorf("with firewall networkModeArgs() = %v, want nil", got)
+	}
+	if p.networkOverride() {
+		t.Error("networkOverride() = true without firewall.allow_network_override")
+	}
+
+	cfg.FirewallNetworkOverride = true
+	if got := p.networkModeArgs(); !reflect.DeepEqual(got, []string{"--network", "none"}) {
+		t.Errorf("with override networkModeArgs() = %v, want [--network none]", got)
+	}
+	if !p.networkOverride() {
+		t.Error("networkOverride() = false with firewall.allow_network_override")
+	}
+	if err := p.checkFirewallNetwork(); err != nil {
+		t.Errorf("checkFirewallNetwork() with override = %v", err)
+	}
+}
@@ -36,6 +36,11 @@ func (p *PodmanProvider) IsRunning(name string) bool {
 	return strings.TrimSpace(string(output)) == name
 }
 
+// Stats samples the CPU, memory and network usage of a running container
+func (p *PodmanProvider) Stats(name string) (provider.Stats, error) {
+	return provider.RuntimeStats(p.podmanCmd(), name)
+}
+
 // Start starts a stopped container
 func (p *PodmanProvider) Start(name string) error {
 	cmd := exec.Command("podman", "start", name)
@@ -48,6 +53,12 @@ func (p *PodmanProvider) Stop(name string) error {
 	return util.SimpleSpinnerRun(fmt.Sprintf("Stopping container %s", name), cmd)
 }
 
+// Restart stops and starts a container, keeping its filesystem
+func (p *PodmanProvider) Restart(name string) error {
+	cmd := exec.Command("podman", "restart", name)
+	return util.SimpleSpinnerRun(fmt.Sprintf("Restarting container %s", name), cmd)
+}
+
 // Remove removes a container
 func (p *PodmanProvider) Remove(name string) error {
 	cmd := exec.Command("podman", "rm", "-f", name)
@@ -56,8 +67,13 @@ func (p *PodmanProvider) Remove(name string) error {
 
 // List lists all persistent addt containers
 func (p *PodmanProvider) List() ([]provider.Environment, error) {
-	cmd := exec.Command("podman", "ps", "-a", "--filter", "name=^addt-persistent-",
-		"--format", "{{.Names}}\t{{.Status}}\t{{.CreatedAt}}")
+	args := []string{"ps", "-a", "--filter", "name=^addt-persistent-"}
+	if p.config.ContainerName != "" {
+		// Filters on the same key match either name
+		args = append(args, "--filter", "name=^"+regexp.QuoteMeta(p.config.ContainerName)+"$")
+	}
+	args = append(args, "--format", "{{.Names}}\t{{.Status}}\t{{.CreatedAt}}")
+	cmd := exec.Command("podman", args...)
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, err
@@ -142,8 +158,11 @@ func (p *PodmanProvider) GenerateEphemeralName() string {
 	return fmt.Sprintf("addt-%s-%d", time.Now().Format("20060102-150405"), os.Getpid())
 }
 
-// GeneratePersistentName is an alias for GenerateContainerName to implement Provider interface
+// GeneratePersistentName returns the container.name override, or GenerateContainerName
 func (p *PodmanProvider) GeneratePersistentName() string {
+	if p.config.ContainerName != "" {
+		return p.config.ContainerName
+	}
 	return p.GenerateContainerName()
 }
 
@@ -58,28 +58,52 @@ func (p *PodmanProvider) GetName() string {
 func (p *PodmanProvider) CheckPrerequisites() error {
 	// Check Podman is installed
 	if _, err := exec.LookPath("podman"); err != nil {
-		return fmt.Errorf("Podman is not installed. Please install Podman from: https://podman.io/getting-started/installation")
+		return provider.Tag(provider.ErrRuntimeNotFound, fmt.Errorf("Podman is not installed. Please install Podman from: https://podman.io/getting-started/installation"))
 	}
 
 	// Verify Podman works (no daemon needed unlike Docker)
 	cmd := exec.Command("podman", "version")
 	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("Podman is not working properly: %w", err)
+		return provider.Tag(provider.ErrRuntimeNotRunning, fmt.Errorf("Podman is not working properly: %w", err))
 	}
 
 	return nil
 }
 
+// lookPath finds host binaries; tests stub it to simulate a missing pasta
+var lookPath = exec.LookPath
+
 // CheckPastaAvailable checks if pasta is available for network namespaces
 func (p *PodmanProvider) CheckPastaAvailable() bool {
-	_, err := exec.LookPath("pasta")
+	_, err := lookPath("pasta")
 	return err == nil
 }
 
+// checkFirewallNetwork warns when the firewall is enabled but pasta is
+// missing: podman then uses its default rootless network, where the
+// in-container iptables rules may not filter all traffic. With
+// firewall.require_pasta it fails instead. pasta isn't needed when
+// firewall.allow_network_override uses security.network_mode.
+func (p *PodmanProvider) checkFirewallNetwork() error {
+	if !p.config.FirewallEnabled || p.networkOverride() || p.CheckPastaAvailable() {
+		return nil
+	}
+	if p.config.FirewallRequirePasta {
+		return fmt.Errorf("firewall is enabled but pasta is not installed and firewall.require_pasta is set; install pasta (passt package) or unset firewall.require_pasta")
+	}
+	fmt.Fprintln(os.Stderr, "Warning: firewall is enabled but pasta is not installed; podman falls back to its default network and the firewall may not filter all traffic. Install pasta (passt package), or set firewall.require_pasta to fail instead.")
+	return nil
+}
+
 // Container lifecycle methods (Exists, IsRunning, Start, Stop, Remove, List)
 // and name generation (GenerateContainerName, GenerateEphemeralName, GeneratePersistentName)
 // are defined in persistent.go
 
+// podmanCmd creates an exec.Cmd for podman
+func (p *PodmanProvider) podmanCmd(args ...string) *exec.Cmd {
+	return exec.Command("podman", args...)
+}
+
 // Cleanup removes temporary directories and stops proxies
 func (p *PodmanProvider) Cleanup() error {
 	// Stop SSH proxy if running
@@ -1,14 +1,15 @@
 package podman
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
-	"os/user"
 	"path/filepath"
 	"time"
 
 	"github.com/jedi4ever/addt/assets"
+	"github.com/jedi4ever/addt/config/security"
 	"github.com/jedi4ever/addt/provider"
 	"github.com/jedi4ever/addt/util"
 )
@@ -24,10 +25,7 @@ type containerContext struct {
 
 // setupContainerContext prepares common container context and checks for existing containers
 func (p *PodmanProvider) setupContainerContext(spec *provider.RunSpec) (*containerContext, error) {
-	currentUser, err := user.Current()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get current user: %w", err)
-	}
+	currentUser := util.CurrentUser()
 
 	ctx := &containerContext{
 		homeDir:              currentUser.HomeDir,
@@ -36,7 +34,15 @@ func (p *PodmanProvider) setupContainerContext(spec *provider.RunSpec) (*contain
 	}
 
 	// Check if we should use existing container
-	if spec.Persistent && p.Exists(spec.Name) {
+	exists := spec.Persistent && p.Exists(spec.Name)
+	if exists {
+		removed, err := provider.RemoveExpired(p, p.config, p.podmanCmd, spec.Name)
+		if err != nil {
+			return nil, err
+		}
+		exists = !removed
+	}
+	if exists {
 		fmt.Printf("Found existing persistent container: %s\n", spec.Name)
 		if p.IsRunning(spec.Name) {
 			fmt.Println("Container is running, connecting...")
@@ -64,6 +70,12 @@ func (p *PodmanProvider) setupContainerContext(spec *provider.RunSpec) (*contain
 		fmt.Printf("Creating new persistent container: %s\n", spec.Name)
 	}
 
+	if !ctx.useExistingContainer {
+		if err := p.checkFirewallNetwork(); err != nil {
+			return nil, err
+		}
+	}
+
 	return ctx, nil
 }
 
@@ -82,13 +94,17 @@ func (p *PodmanProvider) buildBasePodmanArgs(spec *provider.RunSpec, ctx *contai
 		} else {
 			podmanArgs = []string{"run", "--rm", "--name", spec.Name}
 		}
+		podmanArgs = append(podmanArgs, provider.PlatformArgs(p.config)...)
+		podmanArgs = append(podmanArgs, provider.RunPullArgs(p.config)...)
+		podmanArgs = append(podmanArgs, provider.WorkdirArgs(p.config)...)
 	}
 
 	// Interactive mode
 	if spec.Interactive {
 		podmanArgs = append(podmanArgs, "-it")
+		podmanArgs = append(podmanArgs, provider.DetachKeysArgs(p.config)...)
 		podmanLogger.Debug("Added -it flag (interactive mode)")
-		if !ctx.useExistingContainer {
+		if !ctx.useExistingContainer && p.config.ContainerInit {
 			podmanArgs = append(podmanArgs, "--init")
 		}
 	} else {
@@ -108,48 +124,25 @@ func (p *PodmanProvider) addContainerVolumesAndEnv(podmanArgs []string, spec *pr
 
 	// Add volumes
 	for _, vol := range spec.Volumes {
-		mount := fmt.Sprintf("%s:%s", vol.Source, vol.Target)
-		if vol.ReadOnly {
-			mount += ":ro"
-		}
-		podmanArgs = append(podmanArgs, "-v", mount)
+		podmanArgs = append(podmanArgs, "-v", provider.VolumeArg(vol, true))
 	}
+	podmanArgs = append(podmanArgs, provider.MountArgs(spec.Mounts)...)
 
 	// Add extension mounts
 	podmanArgs = p.AddExtensionMounts(podmanArgs, spec.ImageName, ctx.homeDir)
 
 	// Mount .gitconfig (if forwarding enabled)
-	if p.config.GitForwardConfig {
-		gitconfigPath := p.config.GitConfigPath
-		if gitconfigPath == "" {
-			gitconfigPath = filepath.Join(ctx.homeDir, ".gitconfig")
-		} else {
-			gitconfigPath = util.ExpandTilde(gitconfigPath)
-		}
-		if _, err := os.Stat(gitconfigPath); err == nil {
-			podmanArgs = append(podmanArgs, "-v", fmt.Sprintf("%s:/home/%s/.gitconfig.host:ro", gitconfigPath, ctx.username))
-		}
-	}
+	podmanArgs = append(podmanArgs, provider.GitconfigMountArgs(p.config, ctx.homeDir, ctx.username)...)
 
 	// Env file vars are loaded into spec.Env by BuildRunOptions (see core/options.go)
 	// so they go through the same -e mechanism as other env vars.
 
 	// SSH forwarding
-	sshDir := p.config.SSHDir
-	if sshDir == "" {
-		sshDir = filepath.Join(ctx.homeDir, ".ssh")
-	} else {
-		sshDir = util.ExpandTilde(sshDir)
-	}
-	podmanArgs = append(podmanArgs, p.HandleSSHForwarding(spec.SSHForwardKeys, spec.SSHForwardMode, sshDir, ctx.username, spec.SSHAllowedKeys)...)
+	sshDir := provider.SSHDir(p.config, ctx.homeDir)
+	podmanArgs = append(podmanArgs, p.HandleSSHForwarding(spec.SSHForwardKeys, spec.SSHForwardMode, sshDir, ctx.username, spec.SSHAllowedKeys, provider.ExtraSSHDirs(p.config)...)...)
 
 	// GPG forwarding
-	gpgDir := p.config.GPGDir
-	if gpgDir == "" {
-		gpgDir = filepath.Join(ctx.homeDir, ".gnupg")
-	} else {
-		gpgDir = util.ExpandTilde(gpgDir)
-	}
+	gpgDir := provider.GPGDir(p.config, ctx.homeDir)
 	podmanArgs = append(podmanArgs, p.HandleGPGForwarding(spec.GPGForward, gpgDir, ctx.username, spec.GPGAllowedKeyIDs)...)
 
 	// Tmux forwarding
@@ -157,6 +150,7 @@ func (p *PodmanProvider) addContainerVolumesAndEnv(podmanArgs []string, spec *pr
 
 	// History persistence
 	podmanArgs = append(podmanArgs, p.HandleHistoryPersist(spec.HistoryPersist, spec.WorkDir, ctx.username)...)
+	podmanArgs = append(podmanArgs, provider.HomePersistArgs(p.config, spec.WorkDir, ctx.username)...)
 
 	// Firewall configuration with pasta network backend
 	if p.config.FirewallEnabled {
@@ -166,22 +160,18 @@ func (p *PodmanProvider) addContainerVolumesAndEnv(podmanArgs []string, spec *pr
 
 		// Use pasta network backend for better firewall support in rootless mode
 		// pasta handles network namespaces efficiently and supports filtering
-		if p.CheckPastaAvailable() {
+		// unless firewall.allow_network_override uses security.network_mode
+		if !p.networkOverride() && p.CheckPastaAvailable() {
 			podmanArgs = append(podmanArgs, "--network=pasta")
 		}
 
-		// Capabilities for the root phase (dropped after gosu switches to addt):
-		// NET_ADMIN: iptables/nftables rules
-		// DAC_OVERRIDE: create dirs/files in addt's home
-		// CHOWN: fix file ownership
-		// SETUID/SETGID: gosu needs these to switch from root to addt
-		podmanArgs = append(podmanArgs, "--cap-add", "NET_ADMIN")
-		podmanArgs = append(podmanArgs, "--cap-add", "DAC_OVERRIDE")
-		podmanArgs = append(podmanArgs, "--cap-add", "CHOWN")
-		podmanArgs = append(podmanArgs, "--cap-add", "SETUID")
-		podmanArgs = append(podmanArgs, "--cap-add", "SETGID")
+		// Capabilities for the root phase (dropped after gosu switches to addt)
+		for _, cap := range security.FirewallCaps {
+			podmanArgs = append(podmanArgs, "--cap-add", cap)
+		}
 
 		// Mount firewall config directory
+		provider.EnsureFirewallLogDir(p.config)
 		addtHome := util.GetAddtHome()
 		if addtHome != "" {
 			firewallConfigDir := filepath.Join(addtHome, "firewall")
@@ -207,7 +197,7 @@ func (p *PodmanProvider) addContainerVolumesAndEnv(podmanArgs []string, spec *pr
 	// Handle isolate_secrets: add tmpfs mount for secrets
 	// Secrets are copied via podman cp after container starts (see runWithSecrets)
 	if p.config.Security.IsolateSecrets {
-		podmanArgs = p.addTmpfsSecretsMount(podmanArgs)
+		podmanArgs = provider.SecretsTmpfsArgs(podmanArgs)
 	}
 
 	// Handle OTEL: add host alias so container can reach host's OTEL collector
@@ -232,23 +222,34 @@ func (p *PodmanProvider) addContainerVolumesAndEnv(podmanArgs []string, spec *pr
 	if spec.ContainerMemory != "" {
 		podmanArgs = append(podmanArgs, "--memory", spec.ContainerMemory)
 	}
+	podmanArgs = append(podmanArgs, provider.CpusetArgs(p.config)...)
 
 	// Add security settings
 	podmanArgs = p.addSecuritySettings(podmanArgs)
 
+	// security.mount_readonly: no host file is writable from the container
+	if p.config.Security.MountReadonly {
+		podmanArgs = provider.ReadOnlyMountArgs(podmanArgs)
+	}
+
 	return podmanArgs, cleanup
 }
 
-// executePodmanCommand runs the podman command with standard I/O
-func (p *PodmanProvider) executePodmanCommand(podmanArgs []string) error {
+// executePodmanCommand runs the podman command, sending output to the
+// spec's writers (the terminal unless redirected)
+func (p *PodmanProvider) executePodmanCommand(podmanArgs []string, spec *provider.RunSpec) error {
 	podmanLogger.Debugf("Executing: podman %v", podmanArgs)
-	cmd := exec.Command("podman", podmanArgs...)
+	runCtx, cancel := spec.RunContext()
+	defer cancel()
+	cmd := exec.CommandContext(runCtx, "podman", podmanArgs...)
+	provider.CancelOnTimeout(cmd, p, spec)
 
 	// Connect stdin if -it or -i flag is present
-	hasInteractive := false
+	hasInteractive, hasTTY := false, false
 	for _, arg := range podmanArgs {
 		if arg == "-it" || arg == "-i" {
 			hasInteractive = true
+			hasTTY = arg == "-it"
 			break
 		}
 	}
@@ -257,9 +258,11 @@ func (p *PodmanProvider) executePodmanCommand(podmanArgs []string) error {
 		cmd.Stdin = os.Stdin
 	}
 
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	err := cmd.Run()
+	cmd.Stdout, cmd.Stderr = spec.OutputWriters()
+	err := provider.RunCommand(cmd, spec, hasTTY)
+	if runCtx.Err() == context.DeadlineExceeded {
+		return provider.TimeoutError(spec)
+	}
 	if err != nil {
 		podmanLogger.Debugf("Podman command failed: %v", err)
 	}
@@ -268,6 +271,11 @@ func (p *PodmanProvider) executePodmanCommand(podmanArgs []string) error {
 
 // Run runs a new container
 func (p *PodmanProvider) Run(spec *provider.RunSpec) error {
+	// A custom entrypoint can't apply the firewall
+	if err := provider.CheckCustomEntrypoint(p.config); err != nil {
+		return err
+	}
+
 	podmanLogger.Debugf("PodmanProvider.Run called with spec: Name=%s, ImageName=%s, Args=%v, Interactive=%v",
 		spec.Name, spec.ImageName, spec.Args, spec.Interactive)
 
@@ -281,20 +289,7 @@ func (p *PodmanProvider) Run(spec *provider.RunSpec) error {
 		ctx.useExistingContainer, ctx.homeDir, ctx.username)
 
 	// Prepare secrets if enabled (before building args so we can filter env)
-	var secretsJSON string
-	if p.config.Security.IsolateSecrets && !ctx.useExistingContainer {
-		podmanLogger.Debug("Preparing secrets for isolated secrets mode")
-		json, secretVarNames, err := p.prepareSecretsJSON(spec.ImageName, spec.Env)
-		if err == nil && json != "" {
-			secretsJSON = json
-			p.filterSecretEnvVars(spec.Env, secretVarNames)
-			// ADDT_CREDENTIAL_VARS is no longer needed — secrets are in the file
-			delete(spec.Env, "ADDT_CREDENTIAL_VARS")
-			podmanLogger.Debugf("Secrets prepared, %d secret variables filtered", len(secretVarNames))
-		} else if err != nil {
-			podmanLogger.Debugf("Failed to prepare secrets: %v", err)
-		}
-	}
+	secretsJSON := provider.IsolateSecrets(p.config, spec, ctx.useExistingContainer, p.GetExtensionEnvVars)
 
 	podmanLogger.Debug("Building base Podman arguments")
 	podmanLogger.Debugf("Spec.Interactive=%v, ctx.useExistingContainer=%v", spec.Interactive, ctx.useExistingContainer)
@@ -313,12 +308,19 @@ func (p *PodmanProvider) Run(spec *provider.RunSpec) error {
 	// Handle existing container
 	if ctx.useExistingContainer {
 		podmanLogger.Debugf("Using existing container: %s", spec.Name)
+		podmanArgs = append(podmanArgs, provider.TerminalSizeArgs(spec.Env)...)
+		podmanArgs = append(podmanArgs, provider.EntrypointEnvArgs(spec)...)
 		podmanArgs = append(podmanArgs, spec.Name)
 		// Call entrypoint with args for existing containers
-		podmanArgs = append(podmanArgs, "/usr/local/bin/podman-entrypoint.sh")
-		podmanArgs = append(podmanArgs, spec.Args...)
+		podmanArgs = append(podmanArgs, provider.EntrypointCommand(p.config, "/usr/local/bin/podman-entrypoint.sh"))
+		podmanArgs = append(podmanArgs, provider.CommandArgs(spec)...)
 		podmanLogger.Debugf("Executing podman exec with args: %v", podmanArgs)
-		return p.executePodmanCommand(podmanArgs)
+		return p.executePodmanCommand(podmanArgs, spec)
+	}
+
+	// Custom entrypoint: run it directly, without the keep-alive or secrets wrapping
+	if args := provider.CustomEntrypointArgs(p.config, spec); args != nil {
+		return p.executePodmanCommand(append(podmanArgs, args...), spec)
 	}
 
 	// New persistent container: detached keep-alive + exec entrypoint
@@ -326,10 +328,8 @@ func (p *PodmanProvider) Run(spec *provider.RunSpec) error {
 		return p.runPersistent(podmanArgs, spec, secretsJSON)
 	}
 
-	// New container with secrets: use two-step process
-	// 1. Start container detached with wait script
-	// 2. Copy secrets via podman cp
-	// 3. Signal container to continue and attach
+	// New container with secrets: start detached with sleep, copy secrets
+	// via podman cp, then exec the entrypoint
 	if secretsJSON != "" {
 		podmanLogger.Debug("Running with secrets (two-step process)")
 		return p.runWithSecrets(podmanArgs, spec, secretsJSON)
@@ -338,42 +338,25 @@ func (p *PodmanProvider) Run(spec *provider.RunSpec) error {
 	// Normal run without secrets
 	// Note: Image has default ENTRYPOINT ["/usr/local/bin/podman-entrypoint.sh"] set in Dockerfile.base
 	podmanLogger.Debugf("Normal run without secrets, appending image: %s and args: %v", spec.ImageName, spec.Args)
+	podmanArgs = append(podmanArgs, provider.EntrypointEnvArgs(spec)...)
 	podmanArgs = append(podmanArgs, spec.ImageName)
-	podmanArgs = append(podmanArgs, spec.Args...)
+	podmanArgs = append(podmanArgs, provider.CommandArgs(spec)...)
 	podmanLogger.Debugf("Executing podman run with final args (entrypoint will be called from image): %v", podmanArgs)
-	return p.executePodmanCommand(podmanArgs)
+	return p.executePodmanCommand(podmanArgs, spec)
 }
 
 // runPersistent creates a persistent container with sleep infinity as PID 1,
 // then execs the entrypoint. This ensures the container stays alive after
 // the agent exits, so subsequent runs can reuse it via podman exec.
 func (p *PodmanProvider) runPersistent(baseArgs []string, spec *provider.RunSpec, secretsJSON string) error {
-	// Strip interactive/init flags — not needed for detached sleep process
-	var runArgs []string
-	needsTTY := false
-	needsStdin := false
-	for _, arg := range baseArgs {
-		switch arg {
-		case "-it":
-			needsTTY = true
-			needsStdin = true
-		case "-i":
-			needsStdin = true
-		case "-t", "--init":
-			// not needed for detached sleep process
-		default:
-			runArgs = append(runArgs, arg)
-		}
-	}
-
-	// Start container detached with sleep as keep-alive PID 1
-	runArgs = append(runArgs, "-d", "--entrypoint", "sleep", spec.ImageName, "infinity")
+	// Start detached with sleep as keep-alive; -i/-t move to the entrypoint exec
+	runArgs, needsTTY, needsStdin := provider.KeepAliveRunArgs(baseArgs, spec.ImageName)
 	podmanLogger.Debugf("Starting persistent container: podman %v", runArgs)
 
 	cmd := exec.Command("podman", runArgs...)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("failed to start persistent container: %w\n%s", err, string(output))
+		return provider.Tag(provider.ErrContainerStartFailed, fmt.Errorf("failed to start persistent container: %w\n%s", err, string(output)))
 	}
 
 	// Copy secrets if needed
@@ -382,55 +365,32 @@ func (p *PodmanProvider) runPersistent(baseArgs []string, spec *provider.RunSpec
 		if err := p.copySecretsToContainer(spec.Name, secretsJSON); err != nil {
 			podmanLogger.Debugf("Failed to copy secrets, cleaning up container %s", spec.Name)
 			exec.Command("podman", "rm", "-f", spec.Name).Run()
-			return fmt.Errorf("failed to copy secrets: %w", err)
+			return provider.Tag(provider.ErrSecretsCopyFailed, fmt.Errorf("failed to copy secrets: %w", err))
 		}
 	}
 
 	// Exec entrypoint — output goes directly to terminal
-	execArgs := []string{"exec"}
-	if needsTTY {
-		execArgs = append(execArgs, "-it")
-	} else if needsStdin {
-		execArgs = append(execArgs, "-i")
-	}
+	execArgs := provider.EntrypointExecArgs(p.config, []string{"exec"}, needsTTY, needsStdin)
+	execArgs = append(execArgs, provider.EntrypointEnvArgs(spec)...)
 	execArgs = append(execArgs, spec.Name, "/usr/local/bin/podman-entrypoint.sh")
-	execArgs = append(execArgs, spec.Args...)
+	execArgs = append(execArgs, provider.CommandArgs(spec)...)
 
 	podmanLogger.Debugf("Executing entrypoint in persistent container: podman %v", execArgs)
-	return p.executePodmanCommand(execArgs)
+	return p.executePodmanCommand(execArgs, spec)
 }
 
 // runWithSecrets starts a container, copies secrets, then execs the entrypoint.
 // Uses a simple approach: start with sleep, copy secrets, exec entrypoint.
 // Entrypoint output goes directly to terminal via exec (no attach needed).
 func (p *PodmanProvider) runWithSecrets(baseArgs []string, spec *provider.RunSpec, secretsJSON string) error {
-	// Strip interactive flags from run args — they'll be added to exec instead.
-	// The detached sleep process doesn't need stdin or TTY.
-	var runArgs []string
-	needsTTY := false
-	needsStdin := false
-	for _, arg := range baseArgs {
-		switch arg {
-		case "-it":
-			needsTTY = true
-			needsStdin = true
-		case "-i":
-			needsStdin = true
-		case "-t", "--init":
-			// not needed for detached sleep process
-		default:
-			runArgs = append(runArgs, arg)
-		}
-	}
-
-	// Start container detached with sleep as keep-alive
-	runArgs = append(runArgs, "-d", "--entrypoint", "sleep", spec.ImageName, "infinity")
+	// Start detached with sleep as keep-alive; -i/-t move to the entrypoint exec
+	runArgs, needsTTY, needsStdin := provider.KeepAliveRunArgs(baseArgs, spec.ImageName)
 	podmanLogger.Debugf("Starting detached container: podman %v", runArgs)
 
 	cmd := exec.Command("podman", runArgs...)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("failed to start container: %w\n%s", err, string(output))
+		return provider.Tag(provider.ErrContainerStartFailed, fmt.Errorf("failed to start container: %w\n%s", err, string(output)))
 	}
 
 	// Copy secrets to container tmpfs
@@ -438,28 +398,24 @@ func (p *PodmanProvider) runWithSecrets(baseArgs []string, spec *provider.RunSpe
 	if err := p.copySecretsToContainer(spec.Name, secretsJSON); err != nil {
 		podmanLogger.Debugf("Failed to copy secrets, cleaning up container %s", spec.Name)
 		exec.Command("podman", "rm", "-f", spec.Name).Run()
-		return fmt.Errorf("failed to copy secrets: %w", err)
+		return provider.Tag(provider.ErrSecretsCopyFailed, fmt.Errorf("failed to copy secrets: %w", err))
 	}
 
 	// Exec entrypoint — output goes directly to terminal
 	// Note: secrets file ownership is fixed by root phase of entrypoint before dropping to addt
-	execArgs := []string{"exec"}
-	if needsTTY {
-		execArgs = append(execArgs, "-it")
-	} else if needsStdin {
-		execArgs = append(execArgs, "-i")
-	}
+	execArgs := provider.EntrypointExecArgs(p.config, []string{"exec"}, needsTTY, needsStdin)
+	execArgs = append(execArgs, provider.EntrypointEnvArgs(spec)...)
 	execArgs = append(execArgs, spec.Name, "/usr/local/bin/podman-entrypoint.sh")
-	execArgs = append(execArgs, spec.Args...)
+	execArgs = append(execArgs, provider.CommandArgs(spec)...)
 
 	podmanLogger.Debugf("Executing entrypoint: podman %v", execArgs)
-	execErr := p.executePodmanCommand(execArgs)
+	execErr := p.executePodmanCommand(execArgs, spec)
 
 	// On failure, dump container logs for debugging
 	if execErr != nil {
 		podmanLogger.Debugf("Entrypoint failed, fetching container logs for %s", spec.Name)
-		if logsOutput, err := exec.Command("podman", "logs", spec.Name).CombinedOutput(); err == nil && len(logsOutput) > 0 {
-			podmanLogger.Debugf("Container logs:\n%s", string(logsOutput))
+		if logs := provider.EntrypointLog(p.config, exec.Command("podman", "logs", spec.Name)); logs != "" {
+			podmanLogger.Debugf("Container logs:\n%s", logs)
 		}
 	}
 
@@ -493,6 +449,7 @@ func (p *PodmanProvider) Shell(spec *provider.RunSpec) error {
 	if ctx.useExistingContainer {
 		// Run through entrypoint so socat bridges and debug logging work
 		podmanArgs = append(podmanArgs, "-e", "ADDT_COMMAND=/bin/bash")
+		podmanArgs = append(podmanArgs, provider.TerminalSizeArgs(spec.Env)...)
 		podmanArgs = append(podmanArgs, spec.Name, "/usr/local/bin/podman-entrypoint.sh")
 		podmanArgs = append(podmanArgs, spec.Args...)
 	} else if spec.Persistent {
@@ -506,53 +463,35 @@ func (p *PodmanProvider) Shell(spec *provider.RunSpec) error {
 		podmanArgs = append(podmanArgs, spec.Args...)
 	}
 
-	return p.executePodmanCommand(podmanArgs)
+	return p.executePodmanCommand(podmanArgs, spec)
 }
 
 // shellPersistent creates a persistent container with sleep infinity as PID 1,
 // then execs the entrypoint with ADDT_COMMAND=/bin/bash for shell access.
 func (p *PodmanProvider) shellPersistent(baseArgs []string, spec *provider.RunSpec, ctx *containerContext) error {
-	// Strip interactive/init flags — not needed for detached sleep process
-	var runArgs []string
-	needsTTY := false
-	needsStdin := false
-	for _, arg := range baseArgs {
-		switch arg {
-		case "-it":
-			needsTTY = true
-			needsStdin = true
-		case "-i":
-			needsStdin = true
-		case "-t", "--init":
-			// not needed for detached sleep process
-		default:
-			runArgs = append(runArgs, arg)
-		}
-	}
-
-	// Start container detached with sleep as keep-alive PID 1
-	runArgs = append(runArgs, "-d", "--entrypoint", "sleep", spec.ImageName, "infinity")
+	// Start detached with sleep as keep-alive; -i/-t move to the entrypoint exec
+	runArgs, needsTTY, needsStdin := provider.KeepAliveRunArgs(baseArgs, spec.ImageName)
 	podmanLogger.Debugf("Starting persistent container for shell: podman %v", runArgs)
 
 	cmd := exec.Command("podman", runArgs...)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("failed to start persistent container: %w\n%s", err, string(output))
+		return provider.Tag(provider.ErrContainerStartFailed, fmt.Errorf("failed to start persistent container: %w\n%s", err, string(output)))
 	}
 
 	// Exec entrypoint with bash override
-	execArgs := []string{"exec"}
-	if needsTTY {
-		execArgs = append(execArgs, "-it")
-	} else if needsStdin {
-		execArgs = append(execArgs, "-i")
-	}
+	execArgs := provider.EntrypointExecArgs(p.config, []string{"exec"}, needsTTY, needsStdin)
 	execArgs = append(execArgs, "-e", "ADDT_COMMAND=/bin/bash")
 	execArgs = append(execArgs, spec.Name, "/usr/local/bin/podman-entrypoint.sh")
 	execArgs = append(execArgs, spec.Args...)
 
 	podmanLogger.Debugf("Executing shell in persistent container: podman %v", execArgs)
-	return p.executePodmanCommand(execArgs)
+	return p.executePodmanCommand(execArgs, spec)
+}
+
+// SecurityArgs returns the security flags a new container would be started with
+func (p *PodmanProvider) SecurityArgs() []string {
+	return p.addSecuritySettings(nil)
 }
 
 // addSecuritySettings adds container security hardening options
@@ -564,19 +503,17 @@ func (p *PodmanProvider) addSecuritySettings(podmanArgs []string) []string {
 		podmanArgs = append(podmanArgs, "--pids-limit", fmt.Sprintf("%d", sec.PidsLimit))
 	}
 
-	// Ulimits
-	if sec.UlimitNofile != "" {
-		podmanArgs = append(podmanArgs, "--ulimit", "nofile="+sec.UlimitNofile)
-	}
-	if sec.UlimitNproc != "" {
-		podmanArgs = append(podmanArgs, "--ulimit", "nproc="+sec.UlimitNproc)
-	}
+	// Ulimits (ulimit_nofile/ulimit_nproc plus the security.ulimits map)
+	podmanArgs = append(podmanArgs, security.UlimitArgs(sec)...)
 
 	// Privilege escalation prevention
 	if sec.NoNewPrivileges {
 		podmanArgs = append(podmanArgs, "--security-opt", "no-new-privileges")
 	}
 
+	// The firewall root phase drops to addt via gosu, which needs SETUID/SETGID
+	provider.WarnDroppedGosuCaps(p.config)
+
 	// Drop capabilities
 	for _, cap := range sec.CapDrop {
 		podmanArgs = append(podmanArgs, "--cap-drop", cap)
@@ -604,9 +541,9 @@ func (p *PodmanProvider) addSecuritySettings(podmanArgs []string) []string {
 		case "unconfined":
 			podmanArgs = append(podmanArgs, "--security-opt", "seccomp=unconfined")
 		case "restrictive":
-			// Write embedded restrictive profile to temp file with restrictive permissions
-			profilePath := filepath.Join(os.TempDir(), "addt-seccomp-restrictive.json")
-			if err := os.WriteFile(profilePath, assets.SeccompRestrictive, 0600); err == nil {
+			// Write embedded restrictive profile to a private file named by its
+			// content hash, so a profile from another addt version is never reused
+			if profilePath, err := provider.WriteSeccompProfile(assets.SeccompRestrictive); err == nil {
 				podmanArgs = append(podmanArgs, "--security-opt", "seccomp="+profilePath)
 			}
 		case "default":
@@ -617,11 +554,9 @@ func (p *PodmanProvider) addSecuritySettings(podmanArgs []string) []string {
 		}
 	}
 
-	// Network mode (none = completely isolated, no network access)
-	// Note: If firewall with pasta is enabled, skip network mode override
-	if sec.NetworkMode != "" && !p.config.FirewallEnabled {
-		podmanArgs = append(podmanArgs, "--network", sec.NetworkMode)
-	}
+	// Network mode (none = completely isolated, no network access).
+	// The firewall's pasta network wins unless firewall.allow_network_override
+	podmanArgs = append(podmanArgs, p.networkModeArgs()...)
 
 	// IPC namespace isolation
 	if sec.DisableIPC {
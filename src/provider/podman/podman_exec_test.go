@@ -1,14 +1,17 @@
 package podman
 
 import (
+	"os/exec"
+	"strings"
 	"testing"
 
+	"github.com/jedi4ever/addt/config/security"
 	"github.com/jedi4ever/addt/provider"
 )
 
 func TestBuildBasePodmanArgs_NewPersistent(t *testing.T) {
 	p := &PodmanProvider{
-		config: &provider.Config{},
+		config: &provider.Config{ContainerInit: true},
 	}
 	spec := &provider.RunSpec{
 		Name:        "test-container",
@@ -29,6 +32,21 @@ func TestBuildBasePodmanArgs_NewPersistent(t *testing.T) {
 	assertNotContains(t, args, "--rm")
 }
 
+func TestBuildBasePodmanArgs_InitDisabled(t *testing.T) {
+	p := &PodmanProvider{
+		config: &provider.Config{ContainerInit: false},
+	}
+	spec := &provider.RunSpec{
+		Name:        "test-container",
+		Interactive: true,
+	}
+
+	args := p.buildBasePodmanArgs(spec, &containerContext{})
+
+	assertContains(t, args, "-it")
+	assertNotContains(t, args, "--init")
+}
+
 func TestBuildBasePodmanArgs_ExistingPersistent(t *testing.T) {
 	p := &PodmanProvider{
 		config: &provider.Config{},
@@ -50,6 +68,24 @@ func TestBuildBasePodmanArgs_ExistingPersistent(t *testing.T) {
 	assertNotContains(t, args, "--rm")
 }
 
+func TestBuildBasePodmanArgs_DetachKeys(t *testing.T) {
+	p := &PodmanProvider{
+		config: &provider.Config{ContainerDetachKeys: "ctrl-x,x"},
+	}
+	spec := &provider.RunSpec{
+		Name:        "test-container",
+		Persistent:  true,
+		Interactive: true,
+	}
+	ctx := &containerContext{
+		useExistingContainer: true,
+	}
+
+	args := p.buildBasePodmanArgs(spec, ctx)
+
+	assertSliceEqual(t, args, []string{"exec", "-it", "--detach-keys", "ctrl-x,x"})
+}
+
 func TestBuildBasePodmanArgs_Ephemeral(t *testing.T) {
 	p := &PodmanProvider{
 		config: &provider.Config{},
@@ -106,20 +142,8 @@ func TestPodmanStripInteractiveFlags(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			var gotArgs []string
-			gotInteractive := false
-			for _, arg := range tt.input {
-				switch arg {
-				case "-it":
-					gotInteractive = true
-				case "-i":
-					gotInteractive = true
-				case "-t", "--init":
-					// stripped
-				default:
-					gotArgs = append(gotArgs, arg)
-				}
-			}
+			runArgs, _, gotInteractive := provider.KeepAliveRunArgs(tt.input, "img")
+			gotArgs := runArgs[:len(runArgs)-5] // drop -d --entrypoint sleep img infinity
 
 			if gotInteractive != tt.wantInteractive {
 				t.Errorf("interactive = %v, want %v", gotInteractive, tt.wantInteractive)
@@ -188,3 +212,64 @@ func assertSliceEqual(t *testing.T, got, want []string) {
 		}
 	}
 }
+
+func TestAddContainerVolumesAndEnv_DindModes(t *testing.T) {
+	for _, tc := range []struct {
+		mode     string
+		isolated bool
+	}{
+		{"isolated", true},
+		{"off", false},
+	} {
+		t.Run(tc.mode, func(t *testing.T) {
+			p := &PodmanProvider{config: &provider.Config{Security: security.DefaultConfig()}}
+			spec := &provider.RunSpec{Name: "test-container", DockerDindMode: tc.mode}
+			ctx := &containerContext{homeDir: t.TempDir(), username: "addt"}
+
+			args, cleanup := p.addContainerVolumesAndEnv(nil, spec, ctx)
+			defer cleanup()
+
+			if got := containsArg(args, "--privileged"); got != tc.isolated {
+				t.Errorf("--privileged = %v, want %v in %v", got, tc.isolated, args)
+			}
+			if got := containsEnv(args, "ADDT_DOCKER_DIND_ENABLE=true"); got != tc.isolated {
+				t.Errorf("ADDT_DOCKER_DIND_ENABLE = %v, want %v in %v", got, tc.isolated, args)
+			}
+			if got := containsArg(args, "root"); got != tc.isolated {
+				t.Errorf("--user root = %v, want %v in %v", got, tc.isolated, args)
+			}
+		})
+	}
+}
+
+func TestCheckFirewallNetwork_MissingPasta(t *testing.T) {
+	orig := lookPath
+	defer func() { lookPath = orig }()
+	lookPath = func(string) (string, error) { return "", exec.ErrNotFound }
+
+	p := &PodmanProvider{config: &provider.Config{FirewallEnabled: true}}
+	if err := p.checkFirewallNetwork(); err != nil {
+		t.Errorf("without require_pasta expected a warning only, got %v", err)
+	}
+
+	p.config.FirewallRequirePasta = true
+	if err := p.checkFirewallNetwork(); err == nil || !strings.Contains(err.Error(), "pasta") {
+		t.Errorf("with require_pasta expected an error naming pasta, got %v", err)
+	}
+
+	p.config.FirewallEnabled = false
+	if err := p.checkFirewallNetwork(); err != nil {
+		t.Errorf("firewall disabled should not need pasta, got %v", err)
+	}
+}
+
+func TestCheckFirewallNetwork_PastaAvailable(t *testing.T) {
+	orig := lookPath
+	defer func() { lookPath = orig }()
+	lookPath = func(string) (string, error) { return "/usr/bin/pasta", nil }
+
+	p := &PodmanProvider{config: &provider.Config{FirewallEnabled: true, FirewallRequirePasta: true}}
+	if err := p.checkFirewallNetwork(); err != nil {
+		t.Errorf("pasta available should pass, got %v", err)
+	}
+}
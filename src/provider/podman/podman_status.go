@@ -36,7 +36,9 @@ func (p *PodmanProvider) GetStatus(cfg *provider.Config, envName string) string
 		workdir, _ = os.Getwd()
 	}
 	if cfg.WorkdirAutomount {
-		if cfg.WorkdirReadonly {
+		if cfg.WorkdirOverlay {
+			parts = append(parts, fmt.Sprintf("%s [OVERLAY]", workdir))
+		} else if cfg.WorkdirReadonly {
 			parts = append(parts, fmt.Sprintf("%s [RO]", workdir))
 		} else {
 			parts = append(parts, fmt.Sprintf("%s [RW]", workdir))
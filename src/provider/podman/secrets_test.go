@@ -6,54 +6,6 @@ import (
 	"testing"
 )
 
-func TestFilterSecretEnvVars(t *testing.T) {
-	p := &PodmanProvider{}
-
-	env := map[string]string{
-		"ANTHROPIC_API_KEY": "secret123",
-		"GH_TOKEN":          "ghp_xxx",
-		"PATH":              "/usr/bin",
-		"OPENAI_API_KEY":    "sk-xxx",
-		"NON_SECRET_VAR":    "value",
-	}
-
-	secretVars := []string{"ANTHROPIC_API_KEY", "GH_TOKEN", "OPENAI_API_KEY"}
-
-	p.filterSecretEnvVars(env, secretVars)
-
-	// Secret vars should be removed
-	for _, secretVar := range secretVars {
-		if _, exists := env[secretVar]; exists {
-			t.Errorf("filterSecretEnvVars should have removed %s", secretVar)
-		}
-	}
-
-	// Non-secret vars should remain
-	if _, exists := env["PATH"]; !exists {
-		t.Error("filterSecretEnvVars removed PATH which should remain")
-	}
-	if _, exists := env["NON_SECRET_VAR"]; !exists {
-		t.Error("filterSecretEnvVars removed NON_SECRET_VAR which should remain")
-	}
-}
-
-func TestAddTmpfsSecretsMount(t *testing.T) {
-	p := &PodmanProvider{}
-
-	args := []string{"-it", "--rm"}
-	result := p.addTmpfsSecretsMount(args)
-
-	if len(result) != 4 {
-		t.Errorf("Expected 4 args, got %d: %v", len(result), result)
-	}
-	if result[2] != "--tmpfs" {
-		t.Errorf("Expected --tmpfs flag, got %s", result[2])
-	}
-	if result[3] != "/run/secrets:size=1m,mode=0777" {
-		t.Errorf("Expected tmpfs mount arg, got %s", result[3])
-	}
-}
-
 func TestPrepareSecrets(t *testing.T) {
 	// Test that secrets are properly encoded as base64 JSON
 
@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"strings"
+	"text/template"
+)
+
+// PortBindAddress is the host address container ports are published on
+const PortBindAddress = "127.0.0.1"
+
+// PortPromptData is the data available to ports.prompt_template
+type PortPromptData struct {
+	Ports       []PortMapping // allocated mappings: .Container and .Host
+	BindAddress string        // host address the ports are published on
+	Provider    string        // provider name, e.g. docker or orbstack
+}
+
+// ParsePortPromptTemplate parses a ports.prompt_template value
+func ParsePortPromptTemplate(text string) (*template.Template, error) {
+	return template.New("ports.prompt_template").Option("missingkey=error").Parse(text)
+}
+
+// RenderPortPrompt renders ports.prompt_template with the allocated ports
+func RenderPortPrompt(text string, data PortPromptData) (string, error) {
+	tmpl, err := ParsePortPromptTemplate(text)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
@@ -1,6 +1,10 @@
 package provider
 
 import (
+	"io"
+	"os"
+	"time"
+
 	"github.com/jedi4ever/addt/config/otel"
 	"github.com/jedi4ever/addt/config/security"
 )
@@ -18,6 +22,7 @@ type Provider interface {
 	IsRunning(name string) bool
 	Start(name string) error
 	Stop(name string) error
+	Restart(name string) error
 	Remove(name string) error
 	List() ([]Environment, error)
 
@@ -32,11 +37,32 @@ type Provider interface {
 	// Status information
 	GetStatus(cfg *Config, envName string) string
 	GetName() string // "docker" or "daytona"
+	Stats(name string) (Stats, error)
 
 	// Extension metadata
 	GetExtensionEnvVars(imageName string) []string
 }
 
+// SecurityArgsProvider is implemented by container providers that can report
+// the runtime security flags they would pass to a new container
+type SecurityArgsProvider interface {
+	SecurityArgs() []string
+}
+
+// SecretsProvider is implemented by providers that deliver isolated secrets
+// through a tmpfs file. A container restart clears the tmpfs, so the secrets
+// found in env are written into the named container again.
+type SecretsProvider interface {
+	RestoreSecrets(name, imageName string, env map[string]string) error
+}
+
+// ImageTransferProvider is implemented by providers that can export a local
+// image to a tarball and import one, to move images to offline hosts
+type ImageTransferProvider interface {
+	SaveImage(imageName, path string) error
+	LoadImage(path string) error
+}
+
 // Config holds provider configuration
 type Config struct {
 	AddtVersion               string
@@ -51,32 +77,58 @@ type Config struct {
 	Ports                     []string
 	PortRangeStart            int
 	PortsInjectSystemPrompt   bool
+	PortsPromptTemplate       string
 	SSHForwardKeys            bool
 	SSHForwardMode            string
 	SSHAllowedKeys            []string
 	SSHDir                    string
+	SSHDirs                   []string      // Extra SSH directories forwarded alongside SSHDir
+	ForwardFiles              []ForwardFile // Extra host files forwarded into the container
+	Volumes                   []string      // Extra host directories to mount (volumes), source:target[:ro|:rw]
 	TmuxForward               bool
 	HistoryPersist            bool
+	HistoryDir                string   // Where history files are kept (default: ~/.addt/history)
+	HomePersistSubdirs        []string // Home subdirs kept in per-workdir named volumes
 	GitDisableHooks           bool     // Neutralize git hooks inside container (default: true)
 	GitForwardConfig          bool     // Forward .gitconfig to container (default: true)
 	GitConfigPath             string   // Custom .gitconfig file path
+	GitConfigReadonly         bool     // Mount .gitconfig read-only (default: true)
+	GitConfigCopy             bool     // Deliver .gitconfig by value instead of a bind mount (default: false)
 	GPGForward                string   // "proxy", "agent", "keys", or "off"
 	GPGAllowedKeyIDs          []string // GPG key IDs (fingerprints) that are allowed
 	GPGDir                    string
 	TerminalOSC               bool // Forward terminal identification for OSC support (default: false)
 	DockerDindMode            string
+	DockerForwardConfig       bool   // Mount host ~/.docker/config.json (default: false)
+	DockerConfigPath          string // Custom Docker CLI config.json path
+	DockerBuildTimeout        string // Kill image builds running longer than this (default: 60m, 0 = no limit)
+	DockerPullPolicy          string // Base image pulls: "always", "missing" (default) or "never"
+	DockerCpusetCPUs          string // Pin the container to these CPUs (e.g. "0-3,8")
+	DockerCpusetMems          string // Pin the container to these NUMA memory nodes (e.g. "0")
 	EnvFileLoad               bool
 	EnvFile                   string
+	EnvFiles                  []string // Extra env files from --env-file, loaded after EnvFile in order
+	Mounts                    []string // Docker-style --mount values from addt run --mount, passed through as given
+	EntrypointArgs            []string // Args for the built-in entrypoint from addt run --entrypoint-arg
 	LogEnabled                bool
 	LogFile                   string
+	LogCaptureContainer       bool // Tee non-interactive container output into the addt log
 	ImageName                 string
 	Persistent                bool
 	WorkdirAutomount          bool
 	WorkdirReadonly           bool
+	WorkdirOverlay            bool // Throwaway writable layer over the workdir (podman :O; read-only elsewhere)
 	WorkdirAutotrust          bool
+	WorkdirTrusted            *bool // Explicit addt trust/untrust decision (nil: autotrust settings apply)
 	Workdir                   string
+	WorkdirTarget             string // Container path for the workdir mount from --mount-workdir-at ("" = /workspace)
+	NoConfigAutomount         bool   // Skip every extension config mount for this run (--no-automount-config)
 	FirewallEnabled           bool
 	FirewallMode              string
+	FirewallRequirePasta      bool
+	FirewallNetworkOverride   bool // Podman: use security.network_mode instead of pasta when the firewall is on
+	FirewallLogBlocked        bool // Log blocked destinations to ~/.addt/firewall/blocked/<container>.log
+	FirewallAsyncInit         bool // Start the agent while the firewall initializes in the background
 	Mode                      string
 	Provider                  string
 	Extensions                string
@@ -95,6 +147,13 @@ type Config struct {
 	NoCache                   bool                       // Disable Docker cache for builds
 	ContainerCPUs             string                     // Container CPU limit (e.g., "2", "0.5", "1.5")
 	ContainerMemory           string                     // Container memory limit (e.g., "512m", "2g", "4gb")
+	ContainerMaxAge           string                     // Recreate persistent containers older than this (empty = disabled)
+	ContainerDetachKeys       string                     // Detach sequence for interactive run/exec (empty = runtime default)
+	ContainerEntrypoint       string                     // Custom entrypoint replacing the image wrapper (empty = image entrypoint)
+	ContainerPlatform         string                     // Target platform for builds and runs (e.g., "linux/amd64"; empty = host)
+	ContainerInit             bool                       // Add --init to new interactive containers (default: true)
+	ContainerName             string                     // Persistent container name override (empty = generated)
+	ContainerEnvPrecedence    string                     // env-wins (default) or config-wins for forwarded env var values
 
 	// Security settings
 	Security security.Config
@@ -108,10 +167,12 @@ type RunSpec struct {
 	Name             string
 	ImageName        string
 	Args             []string
+	EntrypointArgs   []string // --entrypoint-arg values, passed to the entrypoint before Args
 	WorkDir          string
 	Interactive      bool
 	Persistent       bool
 	Volumes          []VolumeMount
+	Mounts           []string // --mount values passed to the runtime verbatim
 	Ports            []PortMapping
 	Env              map[string]string
 	SSHForwardKeys   bool
@@ -122,8 +183,37 @@ type RunSpec struct {
 	GPGForward       string   // "proxy", "agent", "keys", or "off"
 	GPGAllowedKeyIDs []string // GPG key IDs that are allowed
 	DockerDindMode   string
-	ContainerCPUs    string // Container CPU limit (e.g., "2", "0.5")
-	ContainerMemory  string // Container memory limit (e.g., "512m", "2g")
+	ContainerCPUs    string        // Container CPU limit (e.g., "2", "0.5")
+	ContainerMemory  string        // Container memory limit (e.g., "512m", "2g")
+	Stdout           io.Writer     // Container stdout destination (nil = terminal)
+	Stderr           io.Writer     // Container stderr destination (nil = terminal)
+	Timeout          time.Duration // Host-side deadline for the run (0 = none)
+	Record           io.Writer     // Session transcript from --record (nil = none)
+	LogStdout        io.Writer     // Copy of container stdout for the addt log (nil = none)
+	LogStderr        io.Writer     // Copy of container stderr for the addt log (nil = none)
+}
+
+// OutputWriters returns where container stdout and stderr go,
+// defaulting to the terminal. A --record transcript gets a copy of both,
+// and log.capture_container copies them into the addt log.
+func (s *RunSpec) OutputWriters() (stdout, stderr io.Writer) {
+	stdout, stderr = os.Stdout, os.Stderr
+	if s != nil && s.Stdout != nil {
+		stdout = s.Stdout
+	}
+	if s != nil && s.Stderr != nil {
+		stderr = s.Stderr
+	}
+	if s != nil && s.Record != nil {
+		stdout, stderr = io.MultiWriter(stdout, s.Record), io.MultiWriter(stderr, s.Record)
+	}
+	if s != nil && s.LogStdout != nil {
+		stdout = io.MultiWriter(stdout, s.LogStdout)
+	}
+	if s != nil && s.LogStderr != nil {
+		stderr = io.MultiWriter(stderr, s.LogStderr)
+	}
+	return stdout, stderr
 }
 
 // Environment represents a container or workspace
@@ -138,6 +228,7 @@ type VolumeMount struct {
 	Source   string
 	Target   string
 	ReadOnly bool
+	Overlay  bool // Writable, but writes are discarded with the container
 }
 
 // PortMapping represents a port mapping
@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Pull policies for docker.pull_policy
+const (
+	PullAlways  = "always"
+	PullMissing = "missing"
+	PullNever   = "never"
+)
+
+// PullPolicies lists the accepted docker.pull_policy values
+var PullPolicies = []string{PullAlways, PullMissing, PullNever}
+
+// PullPolicy returns docker.pull_policy, defaulting to missing. An invalid
+// value falls back to missing with a warning.
+func PullPolicy(cfg *Config) string {
+	if cfg == nil || cfg.DockerPullPolicy == "" {
+		return PullMissing
+	}
+	for _, policy := range PullPolicies {
+		if cfg.DockerPullPolicy == policy {
+			return policy
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Warning: invalid docker.pull_policy %q, using %s\n", cfg.DockerPullPolicy, PullMissing)
+	return PullMissing
+}
+
+// BasePullArgs returns the build flags applying docker.pull_policy to the
+// base image's FROM layer. Docker's build only knows --pull (always) and
+// pulls missing images by default; podman takes the policy itself. Only the
+// base build uses these: extension images build FROM the local base image,
+// which no registry has.
+func BasePullArgs(cfg *Config, podman bool) []string {
+	policy := PullPolicy(cfg)
+	switch {
+	case podman && policy != PullMissing:
+		return []string{"--pull=" + policy}
+	case !podman && policy == PullAlways:
+		return []string{"--pull"}
+	}
+	return nil
+}
+
+// RunPullArgs returns the run flags for docker.pull_policy. Run images are
+// built locally, so only never maps to a flag: it guarantees the runtime
+// doesn't fall back to a registry when the image is gone.
+func RunPullArgs(cfg *Config) []string {
+	if PullPolicy(cfg) == PullNever {
+		return []string{"--pull", "never"}
+	}
+	return nil
+}
+
+// CheckNeverPull enforces docker.pull_policy=never for runtimes whose build
+// has no flag to forbid pulling: every FROM image of dockerfile must already
+// exist locally. buildArgs expands ${VAR} references in FROM lines.
+func CheckNeverPull(cfg *Config, dockerfile []byte, buildArgs map[string]string, exists func(image string) bool) error {
+	if PullPolicy(cfg) != PullNever {
+		return nil
+	}
+	for _, image := range DockerfileFromImages(dockerfile, buildArgs) {
+		if !exists(image) {
+			return Tag(ErrImageBuildFailed, fmt.Errorf("docker.pull_policy is never but base image %s is not present locally; pull it first or use missing", image))
+		}
+	}
+	return nil
+}
+
+// DockerfileFromImages returns the images named in FROM lines, with ${VAR}
+// and $VAR expanded from buildArgs
+func DockerfileFromImages(dockerfile []byte, buildArgs map[string]string) []string {
+	var images []string
+	scanner := bufio.NewScanner(bytes.NewReader(dockerfile))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || !strings.EqualFold(fields[0], "FROM") {
+			continue
+		}
+		image := fields[1]
+		if strings.HasPrefix(image, "--") && len(fields) > 2 { // FROM --platform=... image
+			image = fields[2]
+		}
+		images = append(images, os.Expand(image, func(name string) string { return buildArgs[name] }))
+	}
+	return images
+}
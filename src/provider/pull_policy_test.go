@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPullPolicy(t *testing.T) {
+	tests := map[string]string{
+		"":          PullMissing,
+		"always":    PullAlways,
+		"never":     PullNever,
+		"missing":   PullMissing,
+		"sometimes": PullMissing,
+	}
+	for value, want := range tests {
+		if got := PullPolicy(&Config{DockerPullPolicy: value}); got != want {
+			t.Errorf("PullPolicy(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
+
+func TestBasePullArgs(t *testing.T) {
+	tests := []struct {
+		policy string
+		podman bool
+		want   []string
+	}{
+		{"always", false, []string{"--pull"}},
+		{"missing", false, nil},
+		{"never", false, nil},
+		{"always", true, []string{"--pull=always"}},
+		{"missing", true, nil},
+		{"never", true, []string{"--pull=never"}},
+	}
+	for _, tt := range tests {
+		got := BasePullArgs(&Config{DockerPullPolicy: tt.policy}, tt.podman)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("BasePullArgs(%s, podman=%v) = %v, want %v", tt.policy, tt.podman, got, tt.want)
+		}
+	}
+}
+
+func TestRunPullArgs(t *testing.T) {
+	if got := RunPullArgs(&Config{DockerPullPolicy: "never"}); !reflect.DeepEqual(got, []string{"--pull", "never"}) {
+		t.Errorf("RunPullArgs(never) = %v", got)
+	}
+	// Run images are built locally: always must not send the runtime to a registry
+	for _, policy := range []string{"always", "missing", ""} {
+		if got := RunPullArgs(&Config{DockerPullPolicy: policy}); got != nil {
+			t.Errorf("RunPullArgs(%q) = %v, want nil", policy, got)
+		}
+	}
+}
+
+func TestDockerfileFromImages(t *testing.T) {
+	dockerfile := []byte("ARG NODE_VERSION=22\nFROM node:${NODE_VERSION}-slim AS build\n# FROM ignored:comment\nfrom --platform=$TARGET alpine:3\nRUN echo FROM nothing\n")
+	got := DockerfileFromImages(dockerfile, map[string]string{"NODE_VERSION": "20"})
+	if want := []string{"node:20-slim", "alpine:3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DockerfileFromImages() = %v, want %v", got, want)
+	}
+}
+
+func TestCheckNeverPull(t *testing.T) {
+	dockerfile := []byte("FROM node:${NODE_VERSION}-slim\n")
+	args := map[string]string{"NODE_VERSION": "22"}
+	var checked []string
+	missing := func(image string) bool {
+		checked = append(checked, image)
+		return false
+	}
+
+	err := CheckNeverPull(&Config{DockerPullPolicy: "never"}, dockerfile, args, missing)
+	if !errors.Is(err, ErrImageBuildFailed) {
+		t.Errorf("CheckNeverPull(never, missing image) = %v, want ErrImageBuildFailed", err)
+	}
+	if !reflect.DeepEqual(checked, []string{"node:22-slim"}) {
+		t.Errorf("checked images = %v, want [node:22-slim]", checked)
+	}
+
+	present := func(string) bool { return true }
+	if err := CheckNeverPull(&Config{DockerPullPolicy: "never"}, dockerfile, args, present); err != nil {
+		t.Errorf("CheckNeverPull(never, present image) = %v", err)
+	}
+
+	checked = nil
+	if err := CheckNeverPull(&Config{DockerPullPolicy: "always"}, dockerfile, args, missing); err != nil || checked != nil {
+		t.Errorf("CheckNeverPull(always) = %v, checked %v; want no check", err, checked)
+	}
+}
@@ -0,0 +1,13 @@
+package provider
+
+import "os/exec"
+
+// RunCommand runs a runtime CLI command for spec. A recorded interactive
+// session (addt run --record) goes through a host pty so the transcript
+// can be captured without taking the terminal away from the CLI.
+func RunCommand(cmd *exec.Cmd, spec *RunSpec, tty bool) error {
+	if tty && spec != nil && spec.Record != nil {
+		return runRecordedTTY(cmd, spec.Record)
+	}
+	return cmd.Run()
+}
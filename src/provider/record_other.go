@@ -0,0 +1,17 @@
+//go:build !linux && !darwin
+
+package provider
+
+import (
+	"io"
+	"os"
+	"os/exec"
+)
+
+// runRecordedTTY tees the CLI's stdout into the transcript. Without a host
+// pty the runtime CLI no longer sees a terminal on stdout, so the container
+// TTY keeps its initial size.
+func runRecordedTTY(cmd *exec.Cmd, record io.Writer) error {
+	cmd.Stdout = io.MultiWriter(os.Stdout, record)
+	return cmd.Run()
+}
@@ -0,0 +1,53 @@
+//go:build linux || darwin
+
+package provider
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+
+	"github.com/creack/pty"
+	"github.com/jedi4ever/addt/util/terminal"
+)
+
+// runRecordedTTY runs cmd on a host pty and copies its output to both the
+// terminal and the transcript, like script(1). Piping the CLI's stdout
+// directly would stop it from sizing the container TTY, so the pty takes
+// the host terminal's size and follows every resize.
+func runRecordedTTY(cmd *exec.Cmd, record io.Writer) error {
+	if !terminal.IsTerminal() {
+		cmd.Stdout = io.MultiWriter(os.Stdout, record)
+		return cmd.Run()
+	}
+
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = nil, nil, nil
+	ptmx, err := pty.Start(cmd)
+	if err != nil {
+		return err
+	}
+	defer ptmx.Close()
+
+	pty.InheritSize(os.Stdin, ptmx)
+	resize := make(chan os.Signal, 1)
+	signal.Notify(resize, syscall.SIGWINCH)
+	defer func() {
+		signal.Stop(resize)
+		close(resize)
+	}()
+	go func() {
+		for range resize {
+			pty.InheritSize(os.Stdin, ptmx)
+		}
+	}()
+
+	if restore, err := terminal.MakeRaw(); err == nil {
+		defer restore()
+	}
+
+	go io.Copy(ptmx, os.Stdin)
+	io.Copy(io.MultiWriter(os.Stdout, record), ptmx)
+	return cmd.Wait()
+}
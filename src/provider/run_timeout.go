@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"time"
+)
+
+// RunContext returns the context a run's runtime CLI command executes under.
+// It expires after s.Timeout; without a timeout it never does.
+func (s *RunSpec) RunContext() (context.Context, context.CancelFunc) {
+	if s == nil || s.Timeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), s.Timeout)
+}
+
+// timeoutWaitDelay is how long the runtime CLI gets to exit once its
+// container is torn down before it is killed
+const timeoutWaitDelay = 5 * time.Second
+
+// CancelOnTimeout makes cmd tear down spec's container when its run context
+// expires. The default cancel kills the runtime CLI outright, which leaves
+// the terminal in raw mode; with its container gone, the CLI exits on its own
+// and restores the terminal. A CLI still running timeoutWaitDelay later is
+// killed.
+func CancelOnTimeout(cmd *exec.Cmd, p Provider, spec *RunSpec) {
+	cmd.Cancel = func() error {
+		fmt.Printf("Run exceeded --timeout %s, tearing down container %s\n", spec.Timeout, spec.Name)
+		// Killing the runtime CLI doesn't stop the container, so ephemeral
+		// containers are removed and persistent ones stopped (they are
+		// restarted on the next run)
+		if spec.Persistent {
+			p.Stop(spec.Name)
+		} else {
+			p.Remove(spec.Name)
+		}
+		return nil
+	}
+	cmd.WaitDelay = timeoutWaitDelay
+}
+
+// TimeoutError returns the ErrRunTimeout error for a run that hit its
+// host-side deadline; CancelOnTimeout has torn its container down
+func TimeoutError(spec *RunSpec) error {
+	return Tag(ErrRunTimeout, fmt.Errorf("run exceeded --timeout %s", spec.Timeout))
+}
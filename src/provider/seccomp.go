@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// SeccompProfileFile returns the temp file name for a seccomp profile,
+// derived from a hash of its content so each profile version gets its own file
+func SeccompProfileFile(profile []byte) string {
+	sum := sha256.Sum256(profile)
+	return filepath.Join(os.TempDir(), fmt.Sprintf("addt-seccomp-%x.json", sum[:8]))
+}
+
+// WriteSeccompProfile writes profile to its content-hashed temp file and
+// returns the path. An existing file is reused only when it is a private
+// regular file owned by the current user with the same content; otherwise it
+// is replaced, so a stale or tampered profile is never passed to the runtime.
+func WriteSeccompProfile(profile []byte) (string, error) {
+	path := SeccompProfileFile(profile)
+	if info, err := os.Lstat(path); err == nil && info.Mode().IsRegular() && info.Mode().Perm() == 0600 && ownedByCurrentUser(info) {
+		if existing, err := os.ReadFile(path); err == nil && bytes.Equal(existing, profile) {
+			return path, nil
+		}
+	}
+
+	// Write to a private temp file and rename it into place, so concurrent
+	// runs never read a partially written profile
+	tmp, err := os.CreateTemp(filepath.Dir(path), "addt-seccomp-*.tmp")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(profile); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
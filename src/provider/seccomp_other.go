@@ -0,0 +1,10 @@
+//go:build !linux && !darwin
+
+package provider
+
+import "os"
+
+// ownedByCurrentUser always reports true where file ownership isn't exposed
+func ownedByCurrentUser(info os.FileInfo) bool {
+	return true
+}
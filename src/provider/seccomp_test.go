@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSeccompProfileFile_ContentHash(t *testing.T) {
+	v1, v2 := []byte(`{"defaultAction":"SCMP_ACT_ERRNO"}`), []byte(`{"defaultAction":"SCMP_ACT_KILL"}`)
+	sum := sha256.Sum256(v1)
+	if got := filepath.Base(SeccompProfileFile(v1)); got != fmt.Sprintf("addt-seccomp-%x.json", sum[:8]) {
+		t.Errorf("SeccompProfileFile() = %q, want the content hash in the name", got)
+	}
+	if SeccompProfileFile(v1) == SeccompProfileFile(v2) {
+		t.Error("different profiles should get different files")
+	}
+}
+
+func TestWriteSeccompProfile(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir())
+	profile := []byte(`{"defaultAction":"SCMP_ACT_ERRNO"}`)
+
+	path, err := WriteSeccompProfile(profile)
+	if err != nil {
+		t.Fatalf("WriteSeccompProfile() error = %v", err)
+	}
+	if path != SeccompProfileFile(profile) {
+		t.Errorf("path = %q, want %q", path, SeccompProfileFile(profile))
+	}
+	info, err := os.Stat(path)
+	if err != nil || info.Mode().Perm() != 0600 {
+		t.Fatalf("profile file mode = %v, err = %v; want 0600", info.Mode().Perm(), err)
+	}
+
+	// A stale or loosened file under the same name is replaced
+	os.WriteFile(path, []byte("stale"), 0644)
+	os.Chmod(path, 0644)
+	if _, err := WriteSeccompProfile(profile); err != nil {
+		t.Fatalf("WriteSeccompProfile() error = %v", err)
+	}
+	got, _ := os.ReadFile(path)
+	info, _ = os.Stat(path)
+	if string(got) != string(profile) || info.Mode().Perm() != 0600 {
+		t.Errorf("stale profile not replaced: %q, mode %v", got, info.Mode().Perm())
+	}
+
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	for _, e := range entries {
+		if strings.HasSuffix(e.Name(), ".tmp") {
+			t.Errorf("temp file %s left behind", e.Name())
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jedi4ever/addt/util"
+)
+
+var secretsLogger = util.Log("secrets")
+
+// SecretVarNames returns the env vars security.isolate_secrets moves into
+// the secrets file: the extension env vars and the credential script vars
+// listed in env's ADDT_CREDENTIAL_VARS. Extension specs with a default
+// ("VAR=value") are settings rather than secrets and are skipped.
+func SecretVarNames(extensionEnvVars []string, env map[string]string) []string {
+	var names []string
+	for _, spec := range extensionEnvVars {
+		if !strings.Contains(spec, "=") {
+			names = append(names, spec)
+		}
+	}
+	if credVars := env["ADDT_CREDENTIAL_VARS"]; credVars != "" {
+		for _, v := range strings.Split(credVars, ",") {
+			names = append(names, strings.TrimSpace(v))
+		}
+	}
+	return names
+}
+
+// SecretsJSON collects the secret env vars that have a value and returns
+// them as JSON along with their names. Returns "" when there are none.
+func SecretsJSON(extensionEnvVars []string, env map[string]string) (string, []string, error) {
+	secrets := make(map[string]string)
+	var written []string
+	for _, name := range SecretVarNames(extensionEnvVars, env) {
+		if value := env[name]; value != "" {
+			secrets[name] = value
+			written = append(written, name)
+		}
+	}
+	if len(written) == 0 {
+		return "", nil, nil
+	}
+	data, err := json.Marshal(secrets)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to marshal secrets: %w", err)
+	}
+	return string(data), written, nil
+}
+
+// FilterSecretEnvVars removes the secret env vars from env so they aren't
+// passed as -e flags
+func FilterSecretEnvVars(env map[string]string, names []string) {
+	for _, name := range names {
+		delete(env, name)
+	}
+}
+
+// SecretsTmpfsArgs adds the tmpfs the secrets file is copied into. It is
+// world-writable so the entrypoint (running as addt) can read and delete
+// the file; the tmpfs is ephemeral and the file is deleted right after
+// parsing, so the broad permissions are acceptable.
+func SecretsTmpfsArgs(args []string) []string {
+	return append(args, "--tmpfs", "/run/secrets:size=1m,mode=0777")
+}
+
+// IsolateSecrets moves the secret env vars of a new container out of
+// spec.Env and returns them as JSON for the two-step secrets run flow.
+// Returns "" when isolate_secrets is off, the container already exists or no
+// secret has a value: the run then takes the normal single-step path.
+// extensionEnvVars is only called when the secrets are isolated.
+func IsolateSecrets(cfg *Config, spec *RunSpec, existing bool, extensionEnvVars func(imageName string) []string) string {
+	if !cfg.Security.IsolateSecrets || existing {
+		return ""
+	}
+	secretsJSON, names, err := SecretsJSON(extensionEnvVars(spec.ImageName), spec.Env)
+	if err != nil {
+		secretsLogger.Debugf("Failed to prepare secrets: %v", err)
+		return ""
+	}
+	if secretsJSON == "" {
+		secretsLogger.Debug("No secrets to isolate, using the normal run path")
+		return ""
+	}
+	// A custom entrypoint is started directly, without the secrets copy
+	// step, so the secrets stay plain env vars
+	if cfg.ContainerEntrypoint != "" {
+		fmt.Fprintln(os.Stderr, "Warning: container.entrypoint skips security.isolate_secrets; secrets are passed as plain environment variables")
+		return ""
+	}
+	FilterSecretEnvVars(spec.Env, names)
+	// ADDT_CREDENTIAL_VARS is no longer needed — secrets are in the file
+	delete(spec.Env, "ADDT_CREDENTIAL_VARS")
+	secretsLogger.Debugf("Secrets prepared, %d secret variables filtered", len(names))
+	return secretsJSON
+}
+
+// RestoreSecrets writes the secrets found in env into a restarted
+// container's tmpfs through copy. Does nothing when no secret has a value.
+func RestoreSecrets(extensionEnvVars []string, env map[string]string, copy func(secretsJSON string) error) error {
+	secretsJSON, _, err := SecretsJSON(extensionEnvVars, env)
+	if err != nil || secretsJSON == "" {
+		return err
+	}
+	if err := copy(secretsJSON); err != nil {
+		return Tag(ErrSecretsCopyFailed, err)
+	}
+	return nil
+}
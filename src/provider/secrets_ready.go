@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultSecretsReadyTimeout bounds the wait for a keep-alive container to
+// accept the secrets copy when ADDT_SECRETS_READY_TIMEOUT is unset
+const DefaultSecretsReadyTimeout = 30 * time.Second
+
+// readyPollInterval is how often WaitReady checks again; tests shorten it
+var readyPollInterval = 250 * time.Millisecond
+
+// SecretsReadyTimeout returns ADDT_SECRETS_READY_TIMEOUT, a duration ("45s",
+// "2m") or a number of seconds. An invalid value falls back to the default.
+func SecretsReadyTimeout() time.Duration {
+	v := os.Getenv("ADDT_SECRETS_READY_TIMEOUT")
+	if v == "" {
+		return DefaultSecretsReadyTimeout
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if d, err := time.ParseDuration(v); err == nil && d > 0 {
+		return d
+	}
+	fmt.Printf("Warning: invalid ADDT_SECRETS_READY_TIMEOUT %q, using %s\n", v, DefaultSecretsReadyTimeout)
+	return DefaultSecretsReadyTimeout
+}
+
+// WaitReady calls ready until it reports true or timeout passes. An error
+// from ready means waiting can't help (e.g. the container exited) and is
+// returned at once.
+func WaitReady(ready func() (bool, error), timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		ok, err := ready()
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("not ready after %s", timeout)
+		}
+		time.Sleep(readyPollInterval)
+	}
+}
+
+// KeepAliveState interprets a container's State.Status while waiting for
+// it: running is ready, created and restarting may still get there, and
+// anything else (exited, dead, paused) won't.
+func KeepAliveState(status string) (bool, error) {
+	switch status {
+	case "running":
+		return true, nil
+	case "created", "restarting", "":
+		return false, nil
+	}
+	return false, fmt.Errorf("container is %s", status)
+}
+
+// WaitSecretsReady waits, up to ADDT_SECRETS_READY_TIMEOUT, until the
+// keep-alive container name is running, so the secrets can be copied into
+// its tmpfs with exec. runtime builds a docker-compatible CLI command.
+//
+// The keep-alive runs "sleep infinity" instead of the entrypoint, so running
+// is the only readiness there is: nothing inside signals later, and the
+// entrypoint is exec'd only after the copy. "run -d" normally returns once
+// the container runs, so the first check passes; the wait catches a runtime
+// whose status lags and a keep-alive that exits at once, failing with the
+// container logs (subject to security.fetch_entrypoint_log) instead of a copy
+// error.
+func WaitSecretsReady(cfg *Config, runtime func(args ...string) *exec.Cmd, name string) error {
+	timeout := SecretsReadyTimeout()
+	err := WaitReady(func() (bool, error) {
+		out, err := runtime("inspect", "-f", "{{.State.Status}}", name).Output()
+		if err != nil {
+			return false, nil
+		}
+		return KeepAliveState(strings.TrimSpace(string(out)))
+	}, timeout)
+	if err == nil {
+		return nil
+	}
+	err = fmt.Errorf("container %s not ready for secrets: %w (ADDT_SECRETS_READY_TIMEOUT sets the wait)", name, err)
+	if logs := EntrypointLog(cfg, runtime("logs", name)); logs != "" {
+		err = fmt.Errorf("%w\nContainer logs:\n%s", err, logs)
+	}
+	return err
+}
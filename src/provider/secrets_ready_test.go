@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSecretsReadyTimeout(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", DefaultSecretsReadyTimeout},
+		{"45", 45 * time.Second},
+		{"2m", 2 * time.Minute},
+		{"soon", DefaultSecretsReadyTimeout},
+		{"0", DefaultSecretsReadyTimeout},
+	}
+	for _, tt := range tests {
+		t.Setenv("ADDT_SECRETS_READY_TIMEOUT", tt.value)
+		if got := SecretsReadyTimeout(); got != tt.want {
+			t.Errorf("SecretsReadyTimeout(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestWaitReady(t *testing.T) {
+	orig := readyPollInterval
+	defer func() { readyPollInterval = orig }()
+	readyPollInterval = time.Millisecond
+
+	calls := 0
+	err := WaitReady(func() (bool, error) {
+		calls++
+		return KeepAliveState([]string{"created", "created", "running"}[min(calls-1, 2)])
+	}, time.Second)
+	if err != nil || calls != 3 {
+		t.Errorf("WaitReady() = %v after %d calls, want nil after 3", err, calls)
+	}
+
+	// An exited container fails at once instead of waiting out the timeout
+	err = WaitReady(func() (bool, error) { return KeepAliveState("exited") }, time.Hour)
+	if err == nil || !strings.Contains(err.Error(), "exited") {
+		t.Errorf("WaitReady(exited) = %v, want an error naming the state", err)
+	}
+
+	err = WaitReady(func() (bool, error) { return false, nil }, 5*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "not ready after") {
+		t.Errorf("WaitReady(never) = %v, want a timeout error", err)
+	}
+}
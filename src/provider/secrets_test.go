@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/jedi4ever/addt/config/security"
+)
+
+func TestSecretsJSON(t *testing.T) {
+	env := map[string]string{
+		"ANTHROPIC_API_KEY":    "sk-test-key",
+		"GH_TOKEN":             "ghp_test",
+		"EMPTY_TOKEN":          "",
+		"TERM":                 "xterm",
+		"ADDT_CREDENTIAL_VARS": "GH_TOKEN, EMPTY_TOKEN",
+	}
+
+	jsonStr, names, err := SecretsJSON([]string{"ANTHROPIC_API_KEY", "CLAUDE_MODEL=opus"}, env)
+	if err != nil {
+		t.Fatalf("SecretsJSON() error = %v", err)
+	}
+	var parsed map[string]string
+	if err := json.Unmarshal([]byte(jsonStr), &parsed); err != nil {
+		t.Fatalf("invalid JSON %q: %v", jsonStr, err)
+	}
+	if len(parsed) != 2 || parsed["ANTHROPIC_API_KEY"] != "sk-test-key" || parsed["GH_TOKEN"] != "ghp_test" {
+		t.Errorf("secrets = %v, want ANTHROPIC_API_KEY and GH_TOKEN only", parsed)
+	}
+	if len(names) != 2 {
+		t.Errorf("names = %v, want the two secrets with a value", names)
+	}
+
+	if jsonStr, _, _ := SecretsJSON(nil, map[string]string{"TERM": "xterm"}); jsonStr != "" {
+		t.Errorf("SecretsJSON() without secrets = %q, want empty", jsonStr)
+	}
+}
+
+func TestFilterSecretEnvVars(t *testing.T) {
+	env := map[string]string{
+		"ANTHROPIC_API_KEY": "secret-key",
+		"GH_TOKEN":          "github-token",
+		"TERM":              "xterm-256color",
+	}
+
+	FilterSecretEnvVars(env, []string{"ANTHROPIC_API_KEY", "GH_TOKEN"})
+
+	if _, exists := env["ANTHROPIC_API_KEY"]; exists {
+		t.Error("ANTHROPIC_API_KEY should be removed")
+	}
+	if _, exists := env["GH_TOKEN"]; exists {
+		t.Error("GH_TOKEN should be removed")
+	}
+	if env["TERM"] != "xterm-256color" {
+		t.Errorf("TERM = %q, want \"xterm-256color\"", env["TERM"])
+	}
+}
+
+func TestSecretsTmpfsArgs(t *testing.T) {
+	result := SecretsTmpfsArgs([]string{"-it"})
+	if len(result) != 3 || result[1] != "--tmpfs" || result[2] != "/run/secrets:size=1m,mode=0777" {
+		t.Errorf("SecretsTmpfsArgs() = %v, want -it --tmpfs /run/secrets:size=1m,mode=0777", result)
+	}
+}
+
+func TestIsolateSecrets(t *testing.T) {
+	cfg := &Config{Security: security.Config{IsolateSecrets: true}}
+	noExtVars := func(string) []string { return nil }
+	newSpec := func() *RunSpec {
+		return &RunSpec{ImageName: "addt:test", Env: map[string]string{
+			"ADDT_CREDENTIAL_VARS": "MY_TOKEN",
+			"MY_TOKEN":             "s3cret",
+		}}
+	}
+
+	spec := newSpec()
+	if got := IsolateSecrets(cfg, spec, false, noExtVars); got != `{"MY_TOKEN":"s3cret"}` {
+		t.Errorf("IsolateSecrets() = %q, want the secret as JSON", got)
+	}
+	if _, ok := spec.Env["MY_TOKEN"]; ok {
+		t.Error("MY_TOKEN should be filtered from the env")
+	}
+	if _, ok := spec.Env["ADDT_CREDENTIAL_VARS"]; ok {
+		t.Error("ADDT_CREDENTIAL_VARS should be dropped once the secrets are in the file")
+	}
+
+	spec = newSpec()
+	called := false
+	if got := IsolateSecrets(cfg, spec, true, func(string) []string { called = true; return nil }); got != "" || called {
+		t.Errorf("IsolateSecrets() for an existing container = %q (extension vars read: %v), want a no-op", got, called)
+	}
+
+	spec = newSpec()
+	entrypointCfg := &Config{Security: security.Config{IsolateSecrets: true}, ContainerEntrypoint: "/opt/run.sh"}
+	if got := IsolateSecrets(entrypointCfg, spec, false, noExtVars); got != "" || spec.Env["MY_TOKEN"] != "s3cret" {
+		t.Errorf("IsolateSecrets() with a custom entrypoint = %q, env %v; want the secrets kept in the env", got, spec.Env)
+	}
+}
@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jedi4ever/addt/config/security"
+	"github.com/jedi4ever/addt/util"
+)
+
+// ExtraSSHDirs returns the ssh.dirs entries with ~ expanded. They are
+// forwarded in addition to ssh.dir.
+func ExtraSSHDirs(cfg *Config) []string {
+	var dirs []string
+	for _, dir := range cfg.SSHDirs {
+		if dir = strings.TrimSpace(dir); dir != "" {
+			dirs = append(dirs, util.ExpandTilde(dir))
+		}
+	}
+	return dirs
+}
+
+// SSHKeyAllowed reports whether a key file passes ssh.allowed_keys. Like the
+// proxy's comment match, a filter matches as a case-insensitive substring of
+// the file name. No filters allow every key.
+func SSHKeyAllowed(name string, allowedKeys []string) bool {
+	if len(allowedKeys) == 0 {
+		return true
+	}
+	name = strings.ToLower(name)
+	for _, filter := range allowedKeys {
+		if filter != "" && strings.Contains(name, strings.ToLower(filter)) {
+			return true
+		}
+	}
+	return false
+}
+
+// CopySSHFiles merges the SSH directories into dst: config from the first
+// directory that has one, known_hosts from all of them, and every public key.
+// With includePrivate, private keys (files with a matching .pub or an id_*
+// name) that pass allowedKeys are copied too. Earlier directories win when two
+// hold a file with the same name.
+func CopySSHFiles(dst string, dirs []string, allowedKeys []string, includePrivate bool) {
+	var knownHosts []byte
+	copied := make(map[string]bool)
+	copyOnce := func(src string) {
+		name := filepath.Base(src)
+		if copied[name] {
+			return
+		}
+		copied[name] = true
+		util.SafeCopyFile(src, filepath.Join(dst, name))
+	}
+
+	for _, dir := range dirs {
+		if _, err := os.Stat(filepath.Join(dir, "config")); err == nil {
+			copyOnce(filepath.Join(dir, "config"))
+		}
+		if data, err := os.ReadFile(filepath.Join(dir, "known_hosts")); err == nil {
+			knownHosts = append(knownHosts, data...)
+			if len(data) > 0 && data[len(data)-1] != '\n' {
+				knownHosts = append(knownHosts, '\n')
+			}
+		}
+
+		pubs, _ := filepath.Glob(filepath.Join(dir, "*.pub"))
+		for _, pub := range pubs {
+			copyOnce(pub)
+		}
+		if includePrivate {
+			for _, key := range privateSSHKeys(dir) {
+				if SSHKeyAllowed(filepath.Base(key), allowedKeys) {
+					copyOnce(key)
+				}
+			}
+		}
+	}
+
+	if knownHosts != nil {
+		os.WriteFile(filepath.Join(dst, "known_hosts"), knownHosts, 0600)
+	}
+}
+
+// MergedSSHDirArgs copies the SSH files of dirs into a new temp directory
+// (see CopySSHFiles) and returns the args mounting it read-only as the
+// user's ~/.ssh, along with the directory for the caller to clean up.
+// Returns no args when none of dirs exists or the directory can't be made.
+func MergedSSHDirArgs(dirs []string, username string, allowedKeys []string, includePrivate bool) ([]string, string) {
+	found := false
+	for _, dir := range dirs {
+		if _, err := os.Stat(dir); err == nil {
+			found = true
+		}
+	}
+	if !found {
+		return nil, ""
+	}
+
+	tmpDir, err := os.MkdirTemp("", "ssh-safe-*")
+	if err != nil {
+		return nil, ""
+	}
+
+	// Set restrictive permissions and write PID file
+	if err := os.Chmod(tmpDir, 0700); err != nil {
+		os.RemoveAll(tmpDir)
+		return nil, ""
+	}
+	if err := security.WritePIDFile(tmpDir); err != nil {
+		os.RemoveAll(tmpDir)
+		return nil, ""
+	}
+
+	CopySSHFiles(tmpDir, dirs, allowedKeys, includePrivate)
+
+	return []string{"-v", fmt.Sprintf("%s:/home/%s/.ssh:ro", tmpDir, username)}, tmpDir
+}
+
+// privateSSHKeys lists the private key files in dir
+func privateSSHKeys(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var keys []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasSuffix(name, ".pub") {
+			continue
+		}
+		_, err := os.Stat(filepath.Join(dir, name+".pub"))
+		if err == nil || strings.HasPrefix(name, "id_") {
+			keys = append(keys, filepath.Join(dir, name))
+		}
+	}
+	return keys
+}
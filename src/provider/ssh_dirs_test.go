@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func writeSSHDir(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func listDir(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestCopySSHFiles_MultipleDirs(t *testing.T) {
+	personal := writeSSHDir(t, map[string]string{
+		"config":         "Host personal",
+		"known_hosts":    "github.com ssh-ed25519 AAAA",
+		"id_ed25519":     "PRIVATE personal",
+		"id_ed25519.pub": "ssh-ed25519 personal",
+	})
+	work := writeSSHDir(t, map[string]string{
+		"config":          "Host work",
+		"known_hosts":     "gitlab.work ssh-ed25519 BBBB\n",
+		"id_ed25519":      "PRIVATE work (shadowed)",
+		"work_github":     "PRIVATE work",
+		"work_github.pub": "ssh-ed25519 work",
+		"notes.txt":       "not a key",
+	})
+
+	safe := t.TempDir()
+	CopySSHFiles(safe, []string{personal, work}, nil, false)
+	if got, want := listDir(t, safe), []string{"config", "id_ed25519.pub", "known_hosts", "work_github.pub"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("safe files = %v, want %v", got, want)
+	}
+	if data, _ := os.ReadFile(filepath.Join(safe, "config")); string(data) != "Host personal" {
+		t.Errorf("config = %q, want the first directory's", data)
+	}
+	if data, _ := os.ReadFile(filepath.Join(safe, "known_hosts")); string(data) != "github.com ssh-ed25519 AAAA\ngitlab.work ssh-ed25519 BBBB\n" {
+		t.Errorf("known_hosts = %q, want both directories merged", data)
+	}
+
+	keys := t.TempDir()
+	CopySSHFiles(keys, []string{personal, work}, nil, true)
+	if got, want := listDir(t, keys), []string{"config", "id_ed25519", "id_ed25519.pub", "known_hosts", "work_github", "work_github.pub"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("key files = %v, want %v", got, want)
+	}
+	if data, _ := os.ReadFile(filepath.Join(keys, "id_ed25519")); string(data) != "PRIVATE personal" {
+		t.Errorf("id_ed25519 = %q, want the first directory's key", data)
+	}
+}
+
+func TestCopySSHFiles_AllowedKeys(t *testing.T) {
+	personal := writeSSHDir(t, map[string]string{"id_ed25519": "PRIVATE", "id_ed25519.pub": "pub"})
+	work := writeSSHDir(t, map[string]string{"work_github": "PRIVATE", "work_github.pub": "pub"})
+
+	dst := t.TempDir()
+	CopySSHFiles(dst, []string{personal, work}, []string{"GitHub"}, true)
+
+	// Only the matching private key is copied; public keys are always safe
+	if got, want := listDir(t, dst), []string{"id_ed25519.pub", "work_github", "work_github.pub"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("files = %v, want %v", got, want)
+	}
+}
+
+func TestExtraSSHDirs(t *testing.T) {
+	home, _ := os.UserHomeDir()
+	cfg := &Config{SSHDirs: []string{"~/work/.ssh", " ", "/keys"}}
+	want := []string{filepath.Join(home, "work/.ssh"), "/keys"}
+	if got := ExtraSSHDirs(cfg); !reflect.DeepEqual(got, want) {
+		t.Errorf("ExtraSSHDirs() = %v, want %v", got, want)
+	}
+}
+
+func TestMergedSSHDirArgs(t *testing.T) {
+	dir := writeSSHDir(t, map[string]string{"id_ed25519.pub": "pub"})
+
+	args, tmpDir := MergedSSHDirArgs([]string{dir}, "addt", nil, false)
+	if tmpDir == "" {
+		t.Fatal("MergedSSHDirArgs() made no temp directory")
+	}
+	defer os.RemoveAll(tmpDir)
+	want := []string{"-v", tmpDir + ":/home/addt/.ssh:ro"}
+	if !reflect.DeepEqual(args, want) {
+		t.Errorf("args = %v, want %v", args, want)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "id_ed25519.pub")); err != nil {
+		t.Errorf("public key not copied: %v", err)
+	}
+
+	if args, tmpDir := MergedSSHDirArgs([]string{filepath.Join(dir, "missing")}, "addt", nil, false); args != nil || tmpDir != "" {
+		t.Errorf("MergedSSHDirArgs() for missing dirs = %v, %q; want nothing", args, tmpDir)
+	}
+}
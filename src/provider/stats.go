@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// Stats is a point-in-time resource usage sample of a container
+type Stats struct {
+	Name          string  `json:"name"`
+	CPUPercent    float64 `json:"cpu_percent"`
+	MemoryUsage   string  `json:"memory_usage"`
+	MemoryLimit   string  `json:"memory_limit"`
+	MemoryPercent float64 `json:"memory_percent"`
+	NetInput      string  `json:"net_input"`
+	NetOutput     string  `json:"net_output"`
+	PIDs          int     `json:"pids"`
+}
+
+// StatsFormat is the "stats --format" template ParseStats reads. Docker,
+// OrbStack and Podman all accept these fields.
+const StatsFormat = "{{.Name}}\t{{.CPUPerc}}\t{{.MemUsage}}\t{{.MemPerc}}\t{{.NetIO}}\t{{.PIDs}}"
+
+// RuntimeStats samples a container once with "<cmd> stats --no-stream".
+// cmd is the runtime CLI invocation, e.g. exec.Command("docker").
+func RuntimeStats(cmd *exec.Cmd, name string) (Stats, error) {
+	cmd.Args = append(cmd.Args, "stats", "--no-stream", "--format", StatsFormat, name)
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return Stats{}, fmt.Errorf("%s stats %s: %s", cmd.Args[0], name, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return Stats{}, fmt.Errorf("%s stats %s: %w", cmd.Args[0], name, err)
+	}
+	return ParseStats(string(output))
+}
+
+// ParseStats parses one line of "stats --format StatsFormat" output, e.g.
+// "addt-x\t0.52%\t120.5MiB / 7.6GiB\t1.55%\t1.2kB / 648B\t12"
+func ParseStats(line string) (Stats, error) {
+	fields := strings.Split(strings.TrimSpace(line), "\t")
+	if len(fields) != 6 {
+		return Stats{}, fmt.Errorf("unexpected stats output: %q", line)
+	}
+
+	cpu, err := parsePercent(fields[1])
+	if err != nil {
+		return Stats{}, fmt.Errorf("parsing CPU %%: %w", err)
+	}
+	mem, err := parsePercent(fields[3])
+	if err != nil {
+		return Stats{}, fmt.Errorf("parsing memory %%: %w", err)
+	}
+
+	stats := Stats{Name: fields[0], CPUPercent: cpu, MemoryPercent: mem}
+	stats.MemoryUsage, stats.MemoryLimit = splitPair(fields[2])
+	stats.NetInput, stats.NetOutput = splitPair(fields[4])
+	stats.PIDs, _ = strconv.Atoi(strings.TrimSpace(fields[5])) // "--" when unknown
+	return stats, nil
+}
+
+// parsePercent parses "12.34%" (or "--" for a stopped container, as 0)
+func parsePercent(s string) (float64, error) {
+	s = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(s), "%"))
+	if s == "--" || s == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+// splitPair splits "used / limit" style columns
+func splitPair(s string) (string, string) {
+	a, b, _ := strings.Cut(s, "/")
+	return strings.TrimSpace(a), strings.TrimSpace(b)
+}
@@ -0,0 +1,43 @@
+package provider
+
+import "testing"
+
+func TestParseStats_DockerLine(t *testing.T) {
+	line := "addt-persistent-app-1a2b3c4d\t0.52%\t120.5MiB / 7.656GiB\t1.54%\t1.21kB / 648B\t12\n"
+
+	got, err := ParseStats(line)
+	if err != nil {
+		t.Fatalf("ParseStats() error = %v", err)
+	}
+	want := Stats{
+		Name:          "addt-persistent-app-1a2b3c4d",
+		CPUPercent:    0.52,
+		MemoryUsage:   "120.5MiB",
+		MemoryLimit:   "7.656GiB",
+		MemoryPercent: 1.54,
+		NetInput:      "1.21kB",
+		NetOutput:     "648B",
+		PIDs:          12,
+	}
+	if got != want {
+		t.Errorf("ParseStats() = %+v, want %+v", got, want)
+	}
+}
+
+func TestParseStats_StoppedContainer(t *testing.T) {
+	got, err := ParseStats("addt-x\t--\t-- / --\t--\t--\t--")
+	if err != nil {
+		t.Fatalf("ParseStats() error = %v", err)
+	}
+	if got.CPUPercent != 0 || got.PIDs != 0 || got.Name != "addt-x" {
+		t.Errorf("ParseStats() = %+v, want zero usage", got)
+	}
+}
+
+func TestParseStats_Malformed(t *testing.T) {
+	for _, line := range []string{"", "addt-x\t0.5%", "addt-x\tlots\t1MiB / 2MiB\t1%\t0B / 0B\t1"} {
+		if _, err := ParseStats(line); err == nil {
+			t.Errorf("ParseStats(%q) expected an error", line)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package provider
+
+// TerminalSizeArgs returns -e flags carrying the current COLUMNS/LINES from
+// env for "exec" into an already running container. Without them the exec'd
+// process would inherit the size captured when the container was created.
+// Live resizes are handled by the runtime's TTY (-t), which forwards SIGWINCH
+// to the container pty.
+func TerminalSizeArgs(env map[string]string) []string {
+	var args []string
+	for _, name := range []string{"COLUMNS", "LINES"} {
+		if value := env[name]; value != "" {
+			args = append(args, "-e", name+"="+value)
+		}
+	}
+	return args
+}
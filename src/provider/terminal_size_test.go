@@ -0,0 +1,20 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTerminalSizeArgs(t *testing.T) {
+	got := TerminalSizeArgs(map[string]string{"COLUMNS": "120", "LINES": "40", "TERM": "xterm-256color"})
+	want := []string{"-e", "COLUMNS=120", "-e", "LINES=40"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TerminalSizeArgs() = %v, want %v", got, want)
+	}
+}
+
+func TestTerminalSizeArgs_Unset(t *testing.T) {
+	if got := TerminalSizeArgs(map[string]string{}); got != nil {
+		t.Errorf("TerminalSizeArgs() = %v, want nil", got)
+	}
+}
@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+)
+
+// VolumeArg formats vol as a -v value. An overlay mount uses podman's :O
+// option when the runtime supports it, so writes land in a throwaway layer
+// removed with the container. Other runtimes have no overlay bind mounts and
+// get a read-only mount instead, so the host directory is never written.
+func VolumeArg(vol VolumeMount, supportsOverlay bool) string {
+	mount := fmt.Sprintf("%s:%s", vol.Source, vol.Target)
+	switch {
+	case vol.Overlay && supportsOverlay:
+		return mount + ":O"
+	case vol.Overlay:
+		fmt.Printf("Warning: workdir.overlay needs the podman provider; mounting %s read-only instead\n", vol.Source)
+		return mount + ":ro"
+	case vol.ReadOnly:
+		return mount + ":ro"
+	}
+	return mount
+}
+
+// ReadOnlyMountArgs rewrites every host bind mount among the -v and --mount
+// flags in args to read-only, for security.mount_readonly. Named volumes (no
+// absolute source path) are left alone as they don't expose host files, as
+// are mounts that are already read-only or podman overlays (:O).
+func ReadOnlyMountArgs(args []string) []string {
+	out := make([]string, len(args))
+	copy(out, args)
+	for i := 0; i+1 < len(out); i++ {
+		switch out[i] {
+		case "-v":
+			out[i+1] = readOnlyMount(out[i+1])
+			i++
+		case "--mount":
+			out[i+1] = readOnlyMountSpec(out[i+1])
+			i++
+		}
+	}
+	return out
+}
+
+// readOnlyMount adds ro to a "source:target[:options]" bind mount value
+func readOnlyMount(mount string) string {
+	parts := strings.SplitN(mount, ":", 3)
+	if len(parts) < 2 || !strings.HasPrefix(parts[0], "/") {
+		return mount
+	}
+	if len(parts) == 2 || parts[2] == "" {
+		return parts[0] + ":" + parts[1] + ":ro"
+	}
+	opts := strings.Split(parts[2], ",")
+	for i, opt := range opts {
+		switch opt {
+		case "ro", "O":
+			return mount
+		case "rw":
+			opts[i] = "ro"
+			return parts[0] + ":" + parts[1] + ":" + strings.Join(opts, ",")
+		}
+	}
+	return mount + ",ro"
+}
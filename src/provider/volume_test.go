@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVolumeArg(t *testing.T) {
+	tests := []struct {
+		name            string
+		vol             VolumeMount
+		supportsOverlay bool
+		want            string
+	}{
+		{"read-write", VolumeMount{Source: "/src", Target: "/workspace"}, false, "/src:/workspace"},
+		{"read-only", VolumeMount{Source: "/src", Target: "/workspace", ReadOnly: true}, false, "/src:/workspace:ro"},
+		{"overlay", VolumeMount{Source: "/src", Target: "/workspace", Overlay: true}, true, "/src:/workspace:O"},
+		{"overlay wins over read-only", VolumeMount{Source: "/src", Target: "/workspace", ReadOnly: true, Overlay: true}, true, "/src:/workspace:O"},
+		{"overlay unsupported falls back to read-only", VolumeMount{Source: "/src", Target: "/workspace", Overlay: true}, false, "/src:/workspace:ro"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VolumeArg(tt.vol, tt.supportsOverlay); got != tt.want {
+				t.Errorf("VolumeArg() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadOnlyMountArgs(t *testing.T) {
+	args := []string{
+		"run", "--rm",
+		"-v", "/home/me/project:/workspace",
+		"-v", "/home/me/.gitconfig:/home/addt/.gitconfig:ro",
+		"-v", "/tmp/ssh-agent.sock:/ssh-agent",
+		"-v", "/home/me/.cache:/home/addt/.cache:rw",
+		"-v", "/home/me/data:/data:z",
+		"-v", "/home/me/src:/overlay:O",
+		"-v", "addt-history-abc:/home/addt/.history",
+		"-e", "FOO=/a:/b",
+		"image",
+	}
+	want := []string{
+		"run", "--rm",
+		"-v", "/home/me/project:/workspace:ro",
+		"-v", "/home/me/.gitconfig:/home/addt/.gitconfig:ro",
+		"-v", "/tmp/ssh-agent.sock:/ssh-agent:ro",
+		"-v", "/home/me/.cache:/home/addt/.cache:ro",
+		"-v", "/home/me/data:/data:z,ro",
+		"-v", "/home/me/src:/overlay:O",
+		"-v", "addt-history-abc:/home/addt/.history",
+		"-e", "FOO=/a:/b",
+		"image",
+	}
+
+	got := ReadOnlyMountArgs(args)
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("ReadOnlyMountArgs() =\n  %v\nwant\n  %v", got, want)
+	}
+	if args[3] != "/home/me/project:/workspace" {
+		t.Errorf("ReadOnlyMountArgs() modified its input: %v", args)
+	}
+}
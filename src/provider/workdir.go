@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"fmt"
+	"path"
+)
+
+// DefaultWorkdirTarget is where the working directory is mounted, and the
+// WORKDIR of the addt images
+const DefaultWorkdirTarget = "/workspace"
+
+// WorkdirTarget returns the container path the working directory is mounted
+// at: the --mount-workdir-at override, or /workspace
+func WorkdirTarget(cfg *Config) string {
+	if cfg.WorkdirTarget == "" {
+		return DefaultWorkdirTarget
+	}
+	return cfg.WorkdirTarget
+}
+
+// WorkdirArgs returns the -w flag that makes a moved workdir mount the
+// container's working directory, or nil when it stays at the image's
+// WORKDIR. Only container creation needs it: exec inherits it.
+func WorkdirArgs(cfg *Config) []string {
+	if target := WorkdirTarget(cfg); target != DefaultWorkdirTarget {
+		return []string{"-w", target}
+	}
+	return nil
+}
+
+// ValidateWorkdirTarget checks a workdir mount target: an absolute, clean
+// container path other than /
+func ValidateWorkdirTarget(target string) error {
+	if !path.IsAbs(target) {
+		return fmt.Errorf("expected an absolute container path, got %q", target)
+	}
+	if path.Clean(target) != target || target == "/" {
+		return fmt.Errorf("expected a clean container path other than /, got %q", target)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+//go:build addt
+
+package addt
+
+import (
+	"testing"
+)
+
+// --- Container tests (subprocess, both providers) ---
+
+func TestFirewall_Addt_NoNewPrivilegesGosu(t *testing.T) {
+	// Scenario: User enables the firewall together with no-new-privileges.
+	// The container starts as root for the firewall root phase and then
+	// drops to addt via gosu. gosu only lowers privileges, so it must keep
+	// working under no-new-privileges; if it breaks, the command never runs.
+	providers := requireProviders(t)
+
+	for _, prov := range providers {
+		t.Run(prov, func(t *testing.T) {
+			dir, cleanup := setupAddtDirWithExtensions(t, prov, `
+firewall:
+  enabled: true
+  mode: "strict"
+security:
+  no_new_privileges: true
+`)
+			defer cleanup()
+			ensureAddtImage(t, dir, "debug")
+
+			output, _ := runRunSubcommand(t, dir, "debug",
+				"-c", "echo WHOAMI:$(id -un); echo NNP:$(grep NoNewPrivs /proc/self/status | awk '{print $2}')")
+
+			user := extractMarker(output, "WHOAMI:")
+			nnp := extractMarker(output, "NNP:")
+			t.Logf("user=%q no_new_privs=%q", user, nnp)
+
+			if user == "" {
+				t.Fatalf("Command did not run — gosu likely failed to drop from root to addt under no-new-privileges. Output:\n%s", output)
+			}
+			if user != "addt" {
+				t.Errorf("Expected command to run as addt after gosu, got %q", user)
+			}
+			if nnp != "1" {
+				t.Errorf("Expected NoNewPrivs=1 inside the container, got %q", nnp)
+			}
+		})
+	}
+}
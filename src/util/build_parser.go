@@ -2,12 +2,15 @@ package util
 
 import (
 	"bufio"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -26,12 +29,77 @@ type BuildOutput struct {
 type BuildRunner struct {
 	Command     string // "docker" or "podman"
 	Args        []string
-	Env         []string // optional env override; if set, used as cmd.Env
+	Env         []string      // optional env override; if set, used as cmd.Env
+	Timeout     time.Duration // kill the build after this long (0 = no limit)
 	Verbose     bool
 	startTime   time.Time
 	currentStep int
 	totalSteps  int
 	spinner     *Spinner
+	tail        *lineTail
+}
+
+// buildTailLines is how many trailing output lines a timeout error reports
+const buildTailLines = 10
+
+// BuildTimeoutError is returned when a build exceeds its timeout
+type BuildTimeoutError struct {
+	Timeout   time.Duration
+	LastLines []string // trailing build output before the build was killed
+}
+
+func (e *BuildTimeoutError) Error() string {
+	msg := fmt.Sprintf("build timed out after %s (raise docker.build_timeout if installs are slow)", e.Timeout)
+	if len(e.LastLines) > 0 {
+		msg += "; last output:\n  " + strings.Join(e.LastLines, "\n  ")
+	}
+	return msg
+}
+
+// lineTail is an io.Writer that keeps the last n complete lines written to
+// it. It is safe for concurrent use, as verbose builds write stdout and
+// stderr to it from separate goroutines.
+type lineTail struct {
+	mu      sync.Mutex
+	n       int
+	lines   []string
+	partial string
+}
+
+func (t *lineTail) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	data := t.partial + string(p)
+	parts := strings.Split(data, "\n")
+	t.partial = parts[len(parts)-1]
+	for _, line := range parts[:len(parts)-1] {
+		t.add(line)
+	}
+	return len(p), nil
+}
+
+func (t *lineTail) add(line string) {
+	if strings.TrimSpace(line) == "" {
+		return
+	}
+	t.lines = append(t.lines, line)
+	if len(t.lines) > t.n {
+		t.lines = t.lines[len(t.lines)-t.n:]
+	}
+}
+
+// Lines returns the retained lines including any unterminated last line
+func (t *lineTail) Lines() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	lines := append([]string(nil), t.lines...)
+	if strings.TrimSpace(t.partial) != "" {
+		lines = append(lines, t.partial)
+		if len(lines) > t.n {
+			lines = lines[len(lines)-t.n:]
+		}
+	}
+	return lines
 }
 
 // NewBuildRunner creates a new build runner
@@ -46,34 +114,34 @@ func NewBuildRunner(command string, args []string) *BuildRunner {
 // Run executes the build with progress indication
 func (br *BuildRunner) Run() error {
 	br.startTime = time.Now()
+	br.tail = &lineTail{n: buildTailLines}
 
-	// If verbose mode, just run normally
-	if br.Verbose {
-		cmd := exec.Command(br.Command, br.Args...)
-		if len(br.Env) > 0 {
-			cmd.Env = br.Env
-		}
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-		return cmd.Run()
+	ctx := context.Background()
+	if br.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, br.Timeout)
+		defer cancel()
 	}
 
-	// Create command with piped output
-	cmd := exec.Command(br.Command, br.Args...)
+	cmd := exec.CommandContext(ctx, br.Command, br.Args...)
 	if len(br.Env) > 0 {
 		cmd.Env = br.Env
 	}
+	// Don't hang on output pipes held open by child processes after a kill
+	cmd.WaitDelay = 2 * time.Second
 
-	// Combine stdout and stderr
-	stdout, err := cmd.StdoutPipe()
-	if err != nil {
-		return fmt.Errorf("failed to create stdout pipe: %w", err)
-	}
-	stderr, err := cmd.StderrPipe()
-	if err != nil {
-		return fmt.Errorf("failed to create stderr pipe: %w", err)
+	// If verbose mode, just run normally
+	if br.Verbose {
+		cmd.Stdout = io.MultiWriter(os.Stdout, br.tail)
+		cmd.Stderr = io.MultiWriter(os.Stderr, br.tail)
+		return br.timeoutError(ctx, cmd.Run())
 	}
 
+	// Combine stdout and stderr into one stream for progress parsing
+	pr, pw := io.Pipe()
+	cmd.Stdout = io.MultiWriter(pw, br.tail)
+	cmd.Stderr = cmd.Stdout
+
 	// Start spinner
 	br.spinner = NewSpinner("Preparing build...")
 	br.spinner.Start()
@@ -84,20 +152,21 @@ func (br *BuildRunner) Run() error {
 		return fmt.Errorf("failed to start build: %w", err)
 	}
 
-	// Process output in goroutines
+	// Process output in a goroutine
 	done := make(chan struct{})
-	var buildErr error
-
 	go func() {
-		br.processOutput(io.MultiReader(stdout, stderr))
+		br.processOutput(pr)
+		io.Copy(io.Discard, pr)
 		close(done)
 	}()
 
-	// Wait for output processing to complete
+	// Wait for command to finish, then drain remaining output
+	err := cmd.Wait()
+	pw.Close()
 	<-done
 
-	// Wait for command to finish
-	if err := cmd.Wait(); err != nil {
+	if err != nil {
+		err = br.timeoutError(ctx, err)
 		br.spinner.StopWithError(fmt.Sprintf("Build failed: %v", err))
 		return err
 	}
@@ -106,7 +175,16 @@ func (br *BuildRunner) Run() error {
 	elapsed := time.Since(br.startTime).Round(time.Second)
 	br.spinner.StopWithSuccess(fmt.Sprintf("Build completed in %s", elapsed))
 
-	return buildErr
+	return nil
+}
+
+// timeoutError converts a build error into a BuildTimeoutError when the
+// build was killed because its timeout expired
+func (br *BuildRunner) timeoutError(ctx context.Context, err error) error {
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return &BuildTimeoutError{Timeout: br.Timeout, LastLines: br.tail.Lines()}
+	}
+	return err
 }
 
 func (br *BuildRunner) processOutput(reader io.Reader) {
@@ -203,6 +281,15 @@ func RunBuildCommandWithEnv(command string, args, env []string) error {
 	return runner.Run()
 }
 
+// RunBuildCommandWithTimeout runs a docker/podman build with a custom environment,
+// killing it if it runs longer than timeout (0 = no limit).
+func RunBuildCommandWithTimeout(command string, args, env []string, timeout time.Duration) error {
+	runner := NewBuildRunner(command, args)
+	runner.Env = env
+	runner.Timeout = timeout
+	return runner.Run()
+}
+
 // SimpleSpinnerRun runs a command with a simple spinner
 func SimpleSpinnerRun(message string, cmd *exec.Cmd) error {
 	spinner := NewSpinner(message)
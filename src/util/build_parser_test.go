@@ -0,0 +1,78 @@
+package util
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBuildRunner_TimeoutKillsBuild(t *testing.T) {
+	runner := NewBuildRunner("sh", []string{"-c", "echo installing extension; sleep 30"})
+	runner.Timeout = 300 * time.Millisecond
+
+	start := time.Now()
+	err := runner.Run()
+	elapsed := time.Since(start)
+
+	var timeoutErr *BuildTimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("Run() error = %v, want *BuildTimeoutError", err)
+	}
+	if elapsed > 10*time.Second {
+		t.Errorf("build was not terminated promptly, took %s", elapsed)
+	}
+	if len(timeoutErr.LastLines) == 0 || timeoutErr.LastLines[len(timeoutErr.LastLines)-1] != "installing extension" {
+		t.Errorf("LastLines = %v, want trailing build output", timeoutErr.LastLines)
+	}
+	if !strings.Contains(err.Error(), "timed out after 300ms") {
+		t.Errorf("error message = %q, want timeout duration", err.Error())
+	}
+}
+
+func TestBuildRunner_NoTimeoutOnFastBuild(t *testing.T) {
+	runner := NewBuildRunner("sh", []string{"-c", "echo done"})
+	runner.Timeout = 10 * time.Second
+	if err := runner.Run(); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+}
+
+func TestBuildRunner_FailureIsNotTimeout(t *testing.T) {
+	runner := NewBuildRunner("sh", []string{"-c", "exit 2"})
+	runner.Timeout = 10 * time.Second
+	err := runner.Run()
+	var timeoutErr *BuildTimeoutError
+	if err == nil || errors.As(err, &timeoutErr) {
+		t.Fatalf("Run() error = %v, want a plain build failure", err)
+	}
+}
+
+func TestLineTail_KeepsLastLines(t *testing.T) {
+	tail := &lineTail{n: 2}
+	tail.Write([]byte("one\ntwo\nthr"))
+	tail.Write([]byte("ee\nfour"))
+	got := tail.Lines()
+	if len(got) != 2 || got[0] != "three" || got[1] != "four" {
+		t.Errorf("Lines() = %v, want [three four]", got)
+	}
+}
+
+func TestLineTail_ConcurrentWriters(t *testing.T) {
+	tail := &lineTail{n: 1000}
+	var wg sync.WaitGroup
+	for w := 0; w < 2; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				tail.Write([]byte("line\n"))
+			}
+		}()
+	}
+	wg.Wait()
+	if got := len(tail.Lines()); got != 400 {
+		t.Errorf("len(Lines()) = %d, want 400", got)
+	}
+}
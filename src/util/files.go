@@ -1,9 +1,10 @@
 package util
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"io/fs"
 	"os"
-	"os/user"
 	"path/filepath"
 	"strings"
 )
@@ -14,11 +15,11 @@ func GetAddtHome() string {
 	if v := os.Getenv("ADDT_HOME"); v != "" {
 		return ExpandTilde(v)
 	}
-	currentUser, err := user.Current()
-	if err != nil {
+	homeDir := CurrentUser().HomeDir
+	if homeDir == "" {
 		return ""
 	}
-	return filepath.Join(currentUser.HomeDir, ".addt")
+	return filepath.Join(homeDir, ".addt")
 }
 
 // ExpandTilde expands a leading "~/" in a path to the user's home directory.
@@ -79,3 +80,14 @@ func SafeCopyDir(src, dst string) {
 		return nil
 	})
 }
+
+// WorkdirKey returns a short stable hash of dir's absolute path. Per-workdir
+// state (trust, shell history) is stored under this key, so a workdir maps
+// to the same key however it is spelled.
+func WorkdirKey(dir string) string {
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	sum := sha256.Sum256([]byte(dir))
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,44 @@
+package util
+
+import "bytes"
+
+// LogWriter is an io.Writer that logs every line written to it at INFO
+// through a module logger, so process output can be teed into the log
+type LogWriter struct {
+	logger *ModuleLogger
+	prefix string
+	buf    []byte
+}
+
+// NewLogWriter returns a LogWriter for module. Each logged line starts
+// with prefix (e.g. "stderr: "), which may be empty.
+func NewLogWriter(module, prefix string) *LogWriter {
+	return &LogWriter{logger: Log(module), prefix: prefix}
+}
+
+// Write logs each complete line in p and keeps a trailing partial line
+// until the next write or Flush
+func (w *LogWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.logLine(w.buf[:i])
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// Flush logs a trailing line that did not end in a newline
+func (w *LogWriter) Flush() {
+	if len(w.buf) > 0 {
+		w.logLine(w.buf)
+		w.buf = nil
+	}
+}
+
+func (w *LogWriter) logLine(line []byte) {
+	w.logger.Info("%s%s", w.prefix, bytes.TrimRight(line, "\r"))
+}
@@ -65,27 +65,43 @@ func parseLogLevel(levelStr string) LogLevel {
 }
 
 // parseMaxSize parses a human-readable size string (e.g., "10m", "1g", "500k")
+// and falls back to 10MB when it is empty or invalid
 func parseMaxSize(s string) int64 {
-	s = strings.TrimSpace(strings.ToLower(s))
-	if s == "" {
+	n, err := ParseLogSize(s)
+	if err != nil || strings.TrimSpace(s) == "" {
 		return 10 * 1024 * 1024 // default 10MB
 	}
+	return n
+}
+
+// ParseLogSize parses a log.max_size value: a positive number of bytes with
+// an optional k, m or g suffix (kb, mb, gb also accepted), e.g. "10m"
+func ParseLogSize(s string) (int64, error) {
+	num := strings.TrimSpace(strings.ToLower(s))
 	multiplier := int64(1)
-	if strings.HasSuffix(s, "g") || strings.HasSuffix(s, "gb") {
-		multiplier = 1024 * 1024 * 1024
-		s = strings.TrimRight(s, "gb")
-	} else if strings.HasSuffix(s, "m") || strings.HasSuffix(s, "mb") {
-		multiplier = 1024 * 1024
-		s = strings.TrimRight(s, "mb")
-	} else if strings.HasSuffix(s, "k") || strings.HasSuffix(s, "kb") {
-		multiplier = 1024
-		s = strings.TrimRight(s, "kb")
+	for _, unit := range []struct {
+		suffixes   []string
+		multiplier int64
+	}{
+		{[]string{"gb", "g"}, 1024 * 1024 * 1024},
+		{[]string{"mb", "m"}, 1024 * 1024},
+		{[]string{"kb", "k"}, 1024},
+	} {
+		for _, suffix := range unit.suffixes {
+			if strings.HasSuffix(num, suffix) {
+				num, multiplier = strings.TrimSuffix(num, suffix), unit.multiplier
+				break
+			}
+		}
+		if multiplier != 1 {
+			break
+		}
 	}
-	n, err := strconv.ParseInt(s, 10, 64)
+	n, err := strconv.ParseInt(num, 10, 64)
 	if err != nil || n <= 0 {
-		return 10 * 1024 * 1024 // default 10MB
+		return 0, fmt.Errorf("expected a positive size like 500k, 10m or 1g, got %q", s)
 	}
-	return n * multiplier
+	return n * multiplier, nil
 }
 
 // initLogLevel initializes the log level from environment variable.
@@ -124,11 +140,17 @@ func InitLogger(logFile string, enabled bool) {
 }
 
 // InitLoggerFull initializes the logger with all configuration options.
-// output can be "stderr", "stdout", or "file".
+// output can be "stderr", "stdout", or "file". A logFile of "-" sends logs
+// to stdout regardless of output.
 func InitLoggerFull(logFile, logDir, output string, enabled bool, level, modules string, rotate bool, maxSize string, maxFiles int) {
 	defaultLogger.mu.Lock()
 	defer defaultLogger.mu.Unlock()
 
+	if logFile == "-" {
+		logFile = ""
+		output = "stdout"
+	}
+
 	// Close existing file if open
 	if defaultLogger.file != nil {
 		defaultLogger.file.Close()
@@ -514,6 +514,17 @@ func TestParseMaxSize(t *testing.T) {
 	}
 }
 
+func TestParseLogSize(t *testing.T) {
+	if got, err := ParseLogSize("20MB"); err != nil || got != 20*1024*1024 {
+		t.Errorf("ParseLogSize(\"20MB\") = %d, %v", got, err)
+	}
+	for _, bad := range []string{"", "10x", "lots", "0", "-5m", "m"} {
+		if _, err := ParseLogSize(bad); err == nil {
+			t.Errorf("ParseLogSize(%q) expected error, got nil", bad)
+		}
+	}
+}
+
 func TestModuleLogger_LevelFromConfig(t *testing.T) {
 	tmpDir := t.TempDir()
 	logFile := filepath.Join(tmpDir, "test.log")
@@ -581,3 +592,38 @@ func TestModuleLogger_OutputStdout(t *testing.T) {
 		t.Errorf("Expected stdout to contain 'stdout test message', got: %s", output)
 	}
 }
+
+func TestModuleLogger_LogFileDashWritesToStdout(t *testing.T) {
+	oldEnv := os.Getenv("ADDT_LOG_LEVEL")
+	os.Unsetenv("ADDT_LOG_LEVEL")
+	defer func() {
+		if oldEnv != "" {
+			os.Setenv("ADDT_LOG_LEVEL", oldEnv)
+		}
+		defaultLogger.enabled = false
+		defaultLogger.output = "stderr"
+	}()
+
+	// "-" wins even when output is set to file
+	InitLoggerFull("-", t.TempDir(), "file", true, "INFO", "*", false, "10m", 5)
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	Log("test").Info("dash test message")
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	output := buf.String()
+
+	if !strings.Contains(output, "dash test message") {
+		t.Errorf("Expected stdout to contain 'dash test message', got: %s", output)
+	}
+	if defaultLogger.logFile != "" {
+		t.Errorf("logFile = %q, want empty", defaultLogger.logFile)
+	}
+}
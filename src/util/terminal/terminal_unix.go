@@ -21,3 +21,25 @@ func GetTerminalSize() (int, int) {
 	}
 	return int(ws.Col), int(ws.Row)
 }
+
+// MakeRaw puts stdin into raw mode so keystrokes pass through unprocessed
+// (no echo, line buffering or signal keys). Returns a func restoring the
+// previous mode.
+func MakeRaw() (restore func(), err error) {
+	old, err := unix.IoctlGetTermios(0, ioctlGetTermios)
+	if err != nil {
+		return nil, err
+	}
+	raw := *old
+	raw.Iflag &^= unix.IGNBRK | unix.BRKINT | unix.PARMRK | unix.ISTRIP | unix.INLCR | unix.IGNCR | unix.ICRNL | unix.IXON
+	raw.Oflag &^= unix.OPOST
+	raw.Lflag &^= unix.ECHO | unix.ECHONL | unix.ICANON | unix.ISIG | unix.IEXTEN
+	raw.Cflag &^= unix.CSIZE | unix.PARENB
+	raw.Cflag |= unix.CS8
+	raw.Cc[unix.VMIN] = 1
+	raw.Cc[unix.VTIME] = 0
+	if err := unix.IoctlSetTermios(0, ioctlSetTermios, &raw); err != nil {
+		return nil, err
+	}
+	return func() { unix.IoctlSetTermios(0, ioctlSetTermios, old) }, nil
+}
@@ -4,6 +4,7 @@
 package terminal
 
 import (
+	"errors"
 	"os"
 )
 
@@ -36,3 +37,8 @@ func GetTerminalSize() (int, int) {
 	// For now, return reasonable defaults
 	return 80, 24
 }
+
+// MakeRaw is not supported on Windows
+func MakeRaw() (restore func(), err error) {
+	return nil, errors.New("raw terminal mode is not supported on Windows")
+}
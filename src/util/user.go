@@ -0,0 +1,31 @@
+package util
+
+import (
+	"os"
+	"os/user"
+	"strconv"
+)
+
+// lookupUser resolves the current user (replaced in tests)
+var lookupUser = user.Current
+
+// CurrentUser returns the current user. In minimal CI images the running
+// UID often has no /etc/passwd entry and user.Current fails; the user is
+// then derived from the process UID/GID and $HOME instead of aborting.
+func CurrentUser() *user.User {
+	if u, err := lookupUser(); err == nil {
+		return u
+	}
+	uid := strconv.Itoa(os.Getuid())
+	u := &user.User{
+		Uid:      uid,
+		Gid:      strconv.Itoa(os.Getgid()),
+		Username: os.Getenv("USER"),
+	}
+	if u.Username == "" {
+		u.Username = uid
+	}
+	u.HomeDir, _ = os.UserHomeDir()
+	Log("util").Debugf("user.Current() failed, using uid=%s gid=%s home=%q", u.Uid, u.Gid, u.HomeDir)
+	return u
+}
@@ -0,0 +1,49 @@
+package util
+
+import (
+	"errors"
+	"os"
+	"os/user"
+	"strconv"
+	"testing"
+)
+
+func TestCurrentUser_FallbackWhenLookupFails(t *testing.T) {
+	orig := lookupUser
+	defer func() { lookupUser = orig }()
+	lookupUser = func() (*user.User, error) {
+		return nil, errors.New("user: unknown userid 4242")
+	}
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USER", "")
+
+	u := CurrentUser()
+
+	uid := strconv.Itoa(os.Getuid())
+	if u.Uid != uid || u.Gid != strconv.Itoa(os.Getgid()) {
+		t.Errorf("uid/gid = %s/%s, want the process IDs %s/%d", u.Uid, u.Gid, uid, os.Getgid())
+	}
+	if u.HomeDir != home {
+		t.Errorf("HomeDir = %q, want $HOME %q", u.HomeDir, home)
+	}
+	if u.Username != uid {
+		t.Errorf("Username = %q, want the uid when $USER is unset", u.Username)
+	}
+
+	t.Setenv("ADDT_HOME", "")
+	if got := GetAddtHome(); got != home+"/.addt" {
+		t.Errorf("GetAddtHome() = %q, want it under $HOME", got)
+	}
+}
+
+func TestCurrentUser_UsesLookup(t *testing.T) {
+	orig := lookupUser
+	defer func() { lookupUser = orig }()
+	want := &user.User{Uid: "1000", Gid: "1000", Username: "dev", HomeDir: "/home/dev"}
+	lookupUser = func() (*user.User, error) { return want, nil }
+
+	if got := CurrentUser(); got != want {
+		t.Errorf("CurrentUser() = %+v, want %+v", got, want)
+	}
+}